@@ -0,0 +1,58 @@
+// Command assign-sessions reports the sessions recorded before accounts
+// existed, which belong to no user and are listed for no one. With -user
+// it gives them to that user.
+//
+//	go run ./cmd/assign-sessions [-user <username>]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/pavittarx/lang-portal/backend/internal/config"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+func main() {
+	username := flag.String("user", "", "the user to give the sessions to")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: assign-sessions [-user <username>]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(context.Background(), *username); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, username string) error {
+	db, err := config.InitDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sessions := repository.NewSessionRepository(db)
+	if username == "" {
+		count, err := sessions.CountUnowned(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d sessions belong to no user, run with -user to assign them\n", count)
+		return nil
+	}
+
+	user, err := repository.NewUserRepository(db).GetByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("user %q: %w", username, err)
+	}
+	assigned, err := sessions.AssignUnowned(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d sessions assigned to %s\n", assigned, user.Username)
+	return nil
+}
@@ -0,0 +1,93 @@
+// Command content-report runs the content-quality report of
+// GET /api/admin/content-report against the database and prints a line for
+// every finding, or the whole report as JSON with -json. -check runs one
+// check only.
+//
+//	go run ./cmd/content-report [-check name] [-json]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/internal/config"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+func main() {
+	check := flag.String("check", "", "run one check only: "+strings.Join(models.ContentChecks, ", "))
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: content-report [-check name] [-json]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(context.Background(), *check, *asJSON); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, check string, asJSON bool) error {
+	db, err := config.InitDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	service := services.NewContentReportService(
+		repository.NewSQLiteWordRepository(db),
+		repository.NewSQLiteLanguageRepository(db),
+		repository.NewSQLiteContentReportRepository(db),
+	)
+	report, err := service.Report(ctx, check)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	for _, finding := range report.Findings {
+		subject := subjects("word", finding.WordIDs)
+		if len(finding.GroupIDs) > 0 {
+			subject = subjects("group", finding.GroupIDs)
+		}
+		fmt.Printf("%s %s: %s. %s", finding.Check, subject, finding.Problem, finding.Fix)
+		if finding.Suggested != "" {
+			fmt.Printf(", suggested %q", finding.Suggested)
+		}
+		fmt.Println()
+	}
+
+	var counts []string
+	for _, name := range models.ContentChecks {
+		if count, ok := report.Counts[name]; ok {
+			counts = append(counts, fmt.Sprintf("%d %s", count, name))
+		}
+	}
+	fmt.Printf("%d findings: %s\n", report.Total, strings.Join(counts, ", "))
+	return nil
+}
+
+// subjects names the rows of a finding, as in "word 12" or "words 12,40"
+func subjects(kind string, values []int64) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprint(value))
+	}
+	if len(parts) > 1 {
+		kind += "s"
+	}
+	return kind + " " + strings.Join(parts, ",")
+}
@@ -0,0 +1,80 @@
+// Command generate-ipa fills in the IPA pronunciation of words that have none
+// and regenerates those that are out of date with the converter. IPA entered
+// by editors is kept.
+//
+//	go run ./cmd/generate-ipa
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/pavittarx/lang-portal/backend/internal/config"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+const pageSize = 500
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: generate-ipa\n")
+	}
+	flag.Parse()
+
+	if err := run(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context) error {
+	db, err := config.InitDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := repository.NewSQLiteWordRepository(db)
+
+	var words []models.Word
+	for page := 1; ; page++ {
+		batch, total, err := repo.List(ctx, repository.ListWordsParams{Page: page, PageSize: pageSize})
+		if err != nil {
+			return err
+		}
+		words = append(words, batch...)
+		if len(batch) == 0 || len(words) >= total {
+			break
+		}
+	}
+
+	var generated, overridden, failed int
+	for _, word := range words {
+		if word.IPAOverride {
+			overridden++
+			continue
+		}
+
+		ipa := services.GenerateIPA(&word)
+		if ipa == word.IPA {
+			continue
+		}
+		if err := repo.SetIPA(ctx, word.ID, ipa, false); err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "failed to update word %d: %v\n", word.ID, err)
+			continue
+		}
+		generated++
+		fmt.Printf("word %d %s: /%s/\n", word.ID, word.Target, ipa)
+	}
+
+	fmt.Printf("%d words, %d generated, %d kept from editors, %d failed\n", len(words), generated, overridden, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d words could not be updated", failed)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Command import-listening imports the structured transcripts of listening-comp
+// as listening exercises. Transcripts already imported are skipped, so it can
+// be run again after listening-comp structures new ones.
+//
+//	go run ./cmd/import-listening [dir]
+//
+// dir defaults to ../../listening-comp/backend/structured_transcripts.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/pavittarx/lang-portal/backend/internal/config"
+	"github.com/pavittarx/lang-portal/backend/pkg/listening"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+const defaultDir = "../../listening-comp/backend/structured_transcripts"
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: import-listening [dir]\n")
+	}
+	flag.Parse()
+
+	dir := defaultDir
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if err := run(context.Background(), dir); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, dir string) error {
+	exercises, err := listening.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(exercises) == 0 {
+		return fmt.Errorf("no *%s files found in %s", listening.StructuredSuffix, dir)
+	}
+
+	db, err := config.InitDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	service := services.NewListeningService(
+		repository.NewSQLiteListeningRepository(db),
+		repository.NewSQLiteLanguageRepository(db),
+		repository.NewSQLiteAssetRepository(db),
+	)
+
+	var imported, skipped, failed int
+	for _, exercise := range exercises {
+		stored, created, err := service.ImportExercise(ctx, exercise)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Fprintf(os.Stderr, "failed %v\n", err)
+		case created:
+			imported++
+			fmt.Printf("imported %s as exercise %d\n", exercise.Source, stored.ID)
+		default:
+			skipped++
+			fmt.Printf("skipped %s, already exercise %d\n", exercise.Source, stored.ID)
+		}
+	}
+
+	fmt.Printf("%d imported, %d skipped, %d failed\n", imported, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d transcripts could not be imported", failed)
+	}
+	return nil
+}
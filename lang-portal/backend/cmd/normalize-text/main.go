@@ -0,0 +1,175 @@
+// Command normalize-text reports words and groups stored before text was
+// normalized on write, such as क़लम typed with the precomposed क़ or a word
+// with a stray joiner. With -fix it rewrites them in their canonical form.
+//
+// Rows that become equal once normalized are reported as collisions. Words
+// are still rewritten, so that duplicates can be found and merged, while a
+// group whose name collides with another is skipped, as names are unique.
+//
+//	go run ./cmd/normalize-text [-fix]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/pavittarx/lang-portal/backend/internal/config"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+const pageSize = 500
+
+func main() {
+	fix := flag.Bool("fix", false, "rewrite the rows in their canonical form")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: normalize-text [-fix]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(context.Background(), *fix); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, fix bool) error {
+	db, err := config.InitDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	wordsFailed, err := normalizeWords(ctx, repository.NewSQLiteWordRepository(db), fix)
+	if err != nil {
+		return err
+	}
+	groupsFailed, err := normalizeGroups(ctx, repository.NewSQLiteGroupRepository(db), fix)
+	if err != nil {
+		return err
+	}
+
+	if failed := wordsFailed + groupsFailed; failed > 0 {
+		return fmt.Errorf("%d rows could not be updated", failed)
+	}
+	return nil
+}
+
+// normalizeWords reports the words whose text is not canonical and the
+// words sharing a canonical target, rewriting them with fix. It returns
+// the number of words that could not be updated.
+func normalizeWords(ctx context.Context, repo repository.WordRepository, fix bool) (int, error) {
+	var words []models.Word
+	for page := 1; ; page++ {
+		batch, total, err := repo.List(ctx, repository.ListWordsParams{Page: page, PageSize: pageSize})
+		if err != nil {
+			return 0, err
+		}
+		words = append(words, batch...)
+		if len(batch) == 0 || len(words) >= total {
+			break
+		}
+	}
+
+	byTarget := map[string][]int64{}
+	var keys []string
+	var changed, failed int
+	for _, word := range words {
+		canonical := word
+		canonical.Sanitize()
+
+		key := canonical.Language + " " + canonical.Target
+		if _, ok := byTarget[key]; !ok {
+			keys = append(keys, key)
+		}
+		byTarget[key] = append(byTarget[key], word.ID)
+
+		if canonical == word {
+			continue
+		}
+		changed++
+		fmt.Printf("word %d: %q normalizes to %q\n", word.ID, word.Target, canonical.Target)
+
+		if fix {
+			if err := repo.Update(ctx, &canonical); err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "failed to update word %d: %v\n", word.ID, err)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	var collisions int
+	for _, key := range keys {
+		if ids := byTarget[key]; len(ids) > 1 {
+			collisions++
+			fmt.Printf("collision %s: words %v\n", key, ids)
+		}
+	}
+
+	if fix {
+		fmt.Printf("%d words, %d normalized, %d collisions, %d failed\n", len(words), changed-failed, collisions, failed)
+	} else {
+		fmt.Printf("%d words, %d to normalize, %d collisions, run with -fix to update\n", len(words), changed, collisions)
+	}
+	return failed, nil
+}
+
+// normalizeGroups reports the groups whose name or description is not
+// canonical, rewriting them with fix unless their name collides with the
+// name of another group. It returns the number of groups that could not
+// be updated.
+func normalizeGroups(ctx context.Context, repo *repository.SQLiteGroupRepository, fix bool) (int, error) {
+	var groups []models.Group
+	for page := 1; ; page++ {
+		batch, total, err := repo.List(ctx, page, pageSize, "")
+		if err != nil {
+			return 0, err
+		}
+		groups = append(groups, batch...)
+		if len(batch) == 0 || len(groups) >= total {
+			break
+		}
+	}
+
+	owners := map[string]int64{}
+	for _, group := range groups {
+		owners[group.Name] = group.ID
+	}
+
+	var changed, collisions, failed int
+	for _, group := range groups {
+		canonical := group
+		canonical.Sanitize()
+		if canonical == group {
+			continue
+		}
+
+		if owner, ok := owners[canonical.Name]; ok && owner != group.ID {
+			collisions++
+			fmt.Printf("collision group %d %q: normalizes to the name of group %d, skipped\n", group.ID, group.Name, owner)
+			continue
+		}
+		owners[canonical.Name] = group.ID
+		changed++
+		fmt.Printf("group %d: %q normalizes to %q\n", group.ID, group.Name, canonical.Name)
+
+		if fix {
+			if err := repo.Update(ctx, &canonical); err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "failed to update group %d: %v\n", group.ID, err)
+			}
+		}
+	}
+
+	if fix {
+		fmt.Printf("%d groups, %d normalized, %d collisions, %d failed\n", len(groups), changed-failed, collisions, failed)
+	} else {
+		fmt.Printf("%d groups, %d to normalize, %d collisions, run with -fix to update\n", len(groups), changed, collisions)
+	}
+	return failed, nil
+}
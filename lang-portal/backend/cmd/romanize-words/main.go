@@ -0,0 +1,97 @@
+// Command romanize-words reports Hindi words whose romanization is missing or
+// differs from the generated Hinglish spelling. With -fix it fills in the
+// missing ones and normalizes those that only differ in case or spacing, as
+// in "Din" for "din". Other differences are hand-entered spellings and are
+// only reported.
+//
+//	go run ./cmd/romanize-words [-fix]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/internal/config"
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+const pageSize = 500
+
+func main() {
+	fix := flag.Bool("fix", false, "fill in missing romanizations and normalize their case")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: romanize-words [-fix]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(context.Background(), *fix); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, fix bool) error {
+	db, err := config.InitDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	repo := repository.NewSQLiteWordRepository(db)
+
+	var words []models.Word
+	for page := 1; ; page++ {
+		batch, total, err := repo.List(ctx, repository.ListWordsParams{Page: page, PageSize: pageSize, Language: hindi.Code})
+		if err != nil {
+			return err
+		}
+		words = append(words, batch...)
+		if len(batch) == 0 || len(words) >= total {
+			break
+		}
+	}
+
+	var missing, normalized, differing, failed int
+	for _, word := range words {
+		generated := hindi.Romanize(word.Target, hindi.Hinglish)
+		current := strings.Join(strings.Fields(word.Romanized), " ")
+
+		switch {
+		case word.Romanized == generated:
+			continue
+		case current == "":
+			missing++
+			fmt.Printf("word %d %s: missing, generated %q\n", word.ID, word.Target, generated)
+		case strings.EqualFold(current, generated):
+			normalized++
+			fmt.Printf("word %d %s: %q normalizes to %q\n", word.ID, word.Target, word.Romanized, generated)
+		default:
+			differing++
+			fmt.Printf("word %d %s: %q differs from generated %q, kept\n", word.ID, word.Target, word.Romanized, generated)
+			continue
+		}
+
+		if fix {
+			if err := repo.SetRomanized(ctx, word.ID, generated); err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "failed to update word %d: %v\n", word.ID, err)
+			}
+		}
+	}
+
+	if fix {
+		fmt.Printf("%d words, %d filled in, %d normalized, %d differing, %d failed\n", len(words), missing, normalized, differing, failed)
+	} else {
+		fmt.Printf("%d words, %d missing, %d to normalize, %d differing, run with -fix to update\n", len(words), missing, normalized, differing)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d words could not be updated", failed)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+-- Adds the activity engine type, engine configuration and enabled flag
+-- to study activities. Existing rows are mapped to the built-in engines.
+
+ALTER TABLE study_activities ADD COLUMN type TEXT NOT NULL DEFAULT 'unscramble';
+ALTER TABLE study_activities ADD COLUMN config TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE study_activities ADD COLUMN enabled INTEGER NOT NULL DEFAULT 1 CHECK(enabled IN (0, 1));
+
+UPDATE study_activities SET type = 'unscramble' WHERE name = 'Unscramble Words';
+UPDATE study_activities SET type = 'group_words' WHERE name = 'Group Words';
+UPDATE study_activities SET type = 'complete_word' WHERE name = 'Complete the Word';
+
+CREATE INDEX IF NOT EXISTS idx_study_activities_type ON study_activities(type);
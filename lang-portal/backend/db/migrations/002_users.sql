@@ -0,0 +1,14 @@
+-- Adds learner accounts and scopes sessions to the learner who played them.
+-- Sessions recorded before accounts existed keep a NULL user_id and are no
+-- longer listed for anyone, until cmd/assign-sessions gives them to a user.
+
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    password_hash TEXT NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+ALTER TABLE sessions ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_id);
@@ -0,0 +1,18 @@
+-- Adds roles to accounts and API keys for scripts. Existing accounts become
+-- learners; promote the first admin with
+-- UPDATE users SET role = 'admin' WHERE username = '<name>';
+
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'learner' CHECK(role IN ('learner', 'editor', 'admin'));
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    last_used_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user ON api_keys(user_id);
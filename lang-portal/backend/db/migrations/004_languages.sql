@@ -0,0 +1,35 @@
+-- Makes the vocabulary language-agnostic. Words carry the language pair they
+-- belong to and generic target, romanized and native forms. The existing
+-- columns are renamed in place, so every Hindi word keeps its ID, group
+-- memberships and values and becomes a Hindi-English word.
+
+CREATE TABLE IF NOT EXISTS languages (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    script TEXT NOT NULL CHECK(script IN ('Deva', 'Guru', 'Latn')),
+    romanization TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+INSERT OR IGNORE INTO languages (code, name, script, romanization) VALUES
+    ('hi', 'Hindi', 'Deva', 'hinglish'),
+    ('mr', 'Marathi', 'Deva', 'iso15919'),
+    ('pa', 'Punjabi', 'Guru', 'iso15919'),
+    ('en', 'English', 'Latn', '');
+
+ALTER TABLE words RENAME COLUMN hindi TO target;
+ALTER TABLE words RENAME COLUMN hinglish TO romanized;
+ALTER TABLE words RENAME COLUMN english TO native;
+
+-- Added without a REFERENCES clause, SQLite cannot add one with a default
+-- while foreign keys are on. The word service checks codes against languages.
+ALTER TABLE words ADD COLUMN language TEXT NOT NULL DEFAULT 'hi';
+ALTER TABLE words ADD COLUMN native_language TEXT NOT NULL DEFAULT 'en';
+
+DROP INDEX IF EXISTS idx_words_hindi;
+DROP INDEX IF EXISTS idx_words_hinglish;
+DROP INDEX IF EXISTS idx_words_english;
+CREATE INDEX IF NOT EXISTS idx_words_target ON words(target);
+CREATE INDEX IF NOT EXISTS idx_words_romanized ON words(romanized);
+CREATE INDEX IF NOT EXISTS idx_words_native ON words(native);
+CREATE INDEX IF NOT EXISTS idx_words_language ON words(language, native_language);
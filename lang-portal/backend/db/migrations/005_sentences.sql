@@ -0,0 +1,23 @@
+-- Adds example sentences, linked many-to-many to the words they show in use.
+
+CREATE TABLE IF NOT EXISTS sentences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    language TEXT NOT NULL DEFAULT 'hi',
+    native_language TEXT NOT NULL DEFAULT 'en',
+    target TEXT NOT NULL,
+    romanized TEXT NOT NULL DEFAULT '',
+    native TEXT NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS word_sentences (
+    word_id INTEGER NOT NULL,
+    sentence_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    PRIMARY KEY (word_id, sentence_id),
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (sentence_id) REFERENCES sentences(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sentences_language ON sentences(language, native_language);
+CREATE INDEX IF NOT EXISTS idx_word_sentences_sentence ON word_sentences(sentence_id);
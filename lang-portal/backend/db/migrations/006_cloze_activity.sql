@@ -0,0 +1,4 @@
+-- Adds the fill-in-the-blank sentence activity, built on the example sentences.
+
+INSERT OR IGNORE INTO study_activities (name, description, image, score, type, config)
+VALUES ('Fill in the Blank', 'Complete example sentences with the missing word', 'cloze.png', 10, 'cloze', '{}');
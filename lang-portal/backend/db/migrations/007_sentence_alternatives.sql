@@ -0,0 +1,7 @@
+-- Adds the valid alternative word orders of a sentence, stored as a JSON array
+-- of sentences, for the sentence-ordering activity.
+
+ALTER TABLE sentences ADD COLUMN alternatives TEXT NOT NULL DEFAULT '[]';
+
+INSERT OR IGNORE INTO study_activities (name, description, image, score, type, config)
+VALUES ('Build the Sentence', 'Put the words of a sentence in order', 'sentence_order.png', 10, 'sentence_order', '{"attach_postpositions":true}');
@@ -0,0 +1,16 @@
+-- Adds uploaded audio and image assets, and the pronunciation audio of words.
+-- The files live in asset storage, this table keeps their metadata.
+
+CREATE TABLE IF NOT EXISTS assets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK(kind IN ('audio', 'image')),
+    mime_type TEXT NOT NULL,
+    filename TEXT NOT NULL DEFAULT '',
+    size INTEGER NOT NULL,
+    duration_ms INTEGER,
+    checksum TEXT NOT NULL UNIQUE,
+    storage_key TEXT NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+ALTER TABLE words ADD COLUMN audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL;
@@ -0,0 +1,22 @@
+-- Adds the background jobs table, used first to generate the pronunciation
+-- audio of words, and the cache of audio generated by text-to-speech.
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    subject_id INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'running', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    updated_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    UNIQUE (kind, subject_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(kind, status);
+
+CREATE TABLE IF NOT EXISTS speech_cache (
+    cache_key TEXT PRIMARY KEY,
+    asset_id INTEGER NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
@@ -0,0 +1,27 @@
+-- Adds drafts of content generated by a language model, which editors approve
+-- or reject, and the hints of words that approved hint drafts become.
+
+CREATE TABLE IF NOT EXISTS drafts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK(kind IN ('word', 'sentence', 'hint')),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'approved', 'rejected')),
+    content TEXT NOT NULL,
+    model TEXT NOT NULL DEFAULT '',
+    group_id INTEGER REFERENCES groups(id) ON DELETE SET NULL,
+    word_id INTEGER REFERENCES words(id) ON DELETE CASCADE,
+    result_id INTEGER,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    reviewed_at DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS word_hints (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_drafts_status ON drafts(status, kind);
+CREATE INDEX IF NOT EXISTS idx_word_hints_word ON word_hints(word_id);
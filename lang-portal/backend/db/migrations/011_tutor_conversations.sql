@@ -0,0 +1,35 @@
+-- Adds the sentence tutor, conversations in which a language model coaches a
+-- learner through translating a sentence, and its study activity.
+
+CREATE TABLE IF NOT EXISTS tutor_conversations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    sentence_id INTEGER REFERENCES sentences(id) ON DELETE SET NULL,
+    language TEXT NOT NULL,
+    native_language TEXT NOT NULL,
+    prompt TEXT NOT NULL,
+    answer TEXT NOT NULL DEFAULT '',
+    vocabulary TEXT NOT NULL DEFAULT '[]',
+    structure TEXT NOT NULL DEFAULT '',
+    solved BOOLEAN NOT NULL DEFAULT 0,
+    model TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    updated_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS tutor_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    conversation_id INTEGER NOT NULL REFERENCES tutor_conversations(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK(role IN ('learner', 'tutor')),
+    content TEXT NOT NULL,
+    clues TEXT NOT NULL DEFAULT '[]',
+    session_activity_id INTEGER REFERENCES session_activities(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_tutor_conversations_user ON tutor_conversations(user_id, session_id);
+CREATE INDEX IF NOT EXISTS idx_tutor_messages_conversation ON tutor_messages(conversation_id);
+
+INSERT OR IGNORE INTO study_activities (name, description, image, score, type, config)
+VALUES ('Sentence Tutor', 'Translate sentences with clues from a tutor', 'tutor.png', 10, 'tutor', '{}');
@@ -0,0 +1,37 @@
+-- Adds listening exercises, recorded dialogues with their transcript segments and
+-- multiple-choice questions, imported from listening-comp, and their study activity.
+
+CREATE TABLE IF NOT EXISTS listening_exercises (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    language TEXT NOT NULL DEFAULT 'hi',
+    source TEXT UNIQUE,
+    introduction TEXT NOT NULL DEFAULT '',
+    transcript TEXT NOT NULL,
+    audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL,
+    audio_url TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS listening_segments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    start_ms INTEGER,
+    end_ms INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS listening_questions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    question TEXT NOT NULL,
+    options TEXT NOT NULL DEFAULT '[]',
+    answer TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_listening_segments_exercise ON listening_segments(exercise_id, position);
+CREATE INDEX IF NOT EXISTS idx_listening_questions_exercise ON listening_questions(exercise_id, position);
+
+INSERT OR IGNORE INTO study_activities (name, description, image, score, type, config)
+VALUES ('Listening Comprehension', 'Listen to a dialogue and answer questions about it', 'listening.png', 10, 'listening', '{}');
@@ -0,0 +1,22 @@
+-- Adds the recordings of spoken answers to speaking challenges, kept with their
+-- transcripts for learners to review, and the speaking study activity.
+
+CREATE TABLE IF NOT EXISTS recordings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    session_activity_id INTEGER NOT NULL UNIQUE REFERENCES session_activities(id) ON DELETE CASCADE,
+    word_id INTEGER REFERENCES words(id) ON DELETE SET NULL,
+    mime_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    duration_ms INTEGER,
+    storage_key TEXT NOT NULL UNIQUE,
+    transcript TEXT NOT NULL DEFAULT '',
+    model TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_recordings_user ON recordings(user_id, session_id);
+
+INSERT OR IGNORE INTO study_activities (name, description, image, score, type, config)
+VALUES ('Speaking Practice', 'Say words aloud and hear how close you were', 'speaking.png', 10, 'speaking', '{}');
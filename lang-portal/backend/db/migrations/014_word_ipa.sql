@@ -0,0 +1,6 @@
+-- Adds the IPA pronunciation of words. It is generated from the target form
+-- unless an editor entered one, which ipa_override marks so that it is kept.
+-- Existing words get theirs from `go run ./cmd/generate-ipa`.
+
+ALTER TABLE words ADD COLUMN ipa TEXT NOT NULL DEFAULT '';
+ALTER TABLE words ADD COLUMN ipa_override BOOLEAN NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+-- Adds the minimal pairs study activity, drilling words that differ in one
+-- contrast such as aspiration or retroflexion. Pairs are found in the
+-- vocabulary when challenges are generated, so no table is needed.
+
+INSERT OR IGNORE INTO study_activities (name, description, image, score, type, config)
+VALUES ('Minimal Pairs', 'Hear which of two similar sounding words is spoken', 'minimal_pairs.png', 10, 'minimal_pairs', '{}');
@@ -0,0 +1,15 @@
+-- Adds the grammatical metadata of words: their part of speech, the gender of
+-- nouns and the transitivity of verbs. The regular plural and oblique forms of
+-- nouns and the root of verbs are generated unless an editor entered them,
+-- which forms_override marks so that they are kept.
+
+ALTER TABLE words ADD COLUMN part_of_speech TEXT NOT NULL DEFAULT '' CHECK(part_of_speech IN ('', 'noun', 'pronoun', 'verb', 'adjective', 'adverb', 'postposition', 'conjunction', 'interjection', 'numeral', 'particle'));
+ALTER TABLE words ADD COLUMN gender TEXT NOT NULL DEFAULT '' CHECK(gender IN ('', 'masculine', 'feminine'));
+ALTER TABLE words ADD COLUMN transitivity TEXT NOT NULL DEFAULT '' CHECK(transitivity IN ('', 'intransitive', 'transitive', 'ditransitive'));
+ALTER TABLE words ADD COLUMN root TEXT NOT NULL DEFAULT '';
+ALTER TABLE words ADD COLUMN plural TEXT NOT NULL DEFAULT '';
+ALTER TABLE words ADD COLUMN oblique TEXT NOT NULL DEFAULT '';
+ALTER TABLE words ADD COLUMN oblique_plural TEXT NOT NULL DEFAULT '';
+ALTER TABLE words ADD COLUMN forms_override BOOLEAN NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_words_grammar ON words(part_of_speech, gender);
@@ -0,0 +1,6 @@
+-- Adds the conjugation study activity, asking for the form of a verb for a
+-- tense and subject. Forms are generated from the root stored on verbs, so
+-- no table is needed.
+
+INSERT OR IGNORE INTO study_activities (name, description, image, score, type, config)
+VALUES ('Verb Conjugation', 'Write the form of a verb for a tense and subject', 'conjugation.png', 10, 'conjugation', '{}');
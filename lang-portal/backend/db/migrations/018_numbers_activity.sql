@@ -0,0 +1,6 @@
+-- Adds the numbers study activity, drilling the Hindi numbers up to 100 in
+-- words, Devanagari and Arabic digits and dictation. Numbers are generated
+-- when challenges are, so no table is needed.
+
+INSERT OR IGNORE INTO study_activities (name, description, image, score, type, config)
+VALUES ('Numbers', 'Read, write and hear the Hindi numbers up to 100', 'numbers.png', 10, 'numbers', '{}');
@@ -0,0 +1,14 @@
+-- Records the duplicate words merged into another word. The merged word is
+-- deleted, so a copy of it is kept with the groups it belonged to.
+
+CREATE TABLE IF NOT EXISTS word_merges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kept_word_id INTEGER NOT NULL,
+    merged_word_id INTEGER NOT NULL,
+    merged_word TEXT NOT NULL,
+    group_ids TEXT NOT NULL DEFAULT '[]',
+    merged_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_merges_kept ON word_merges(kept_word_id);
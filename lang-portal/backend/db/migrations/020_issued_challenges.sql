@@ -0,0 +1,15 @@
+-- Keeps the challenges handed to learners on the server. Learners answer a
+-- challenge by its ID, so they can neither forge a challenge nor read its
+-- answer, and each challenge is answered once.
+
+CREATE TABLE IF NOT EXISTS issued_challenges (
+    id TEXT PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    prompt TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '',
+    answered_at DATETIME,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_issued_challenges_session ON issued_challenges(session_id);
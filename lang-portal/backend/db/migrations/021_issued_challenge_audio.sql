@@ -0,0 +1,5 @@
+-- Keeps the recording played with a challenge on the server. Learners fetch
+-- it through the challenge, so the public URL of the played word's asset no
+-- longer gives the answer away.
+
+ALTER TABLE issued_challenges ADD COLUMN audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL;
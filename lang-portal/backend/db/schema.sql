@@ -6,22 +6,59 @@ PRAGMA encoding = 'UTF-8';
 PRAGMA foreign_keys = ON;
 PRAGMA recursive_triggers = ON;
 
--- Words Table
+-- Languages Table, scripts are ISO 15924 codes
+CREATE TABLE IF NOT EXISTS languages (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    script TEXT NOT NULL CHECK(script IN ('Deva', 'Guru', 'Latn')),
+    romanization TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Assets Table, metadata of uploaded audio and images kept in file storage
+CREATE TABLE IF NOT EXISTS assets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK(kind IN ('audio', 'image')),
+    mime_type TEXT NOT NULL,
+    filename TEXT NOT NULL DEFAULT '',
+    size INTEGER NOT NULL,
+    duration_ms INTEGER,
+    checksum TEXT NOT NULL UNIQUE,
+    storage_key TEXT NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Words Table, each word belongs to a target and native language pair
 CREATE TABLE IF NOT EXISTS words (
     id INTEGER PRIMARY KEY,
-    hindi TEXT NOT NULL,
+    language TEXT NOT NULL DEFAULT 'hi',
+    native_language TEXT NOT NULL DEFAULT 'en',
+    target TEXT NOT NULL,
     scrambled TEXT NOT NULL,
-    hinglish TEXT NOT NULL,
-    english TEXT NOT NULL,
+    romanized TEXT NOT NULL,
+    native TEXT NOT NULL,
+    ipa TEXT NOT NULL DEFAULT '',
+    ipa_override BOOLEAN NOT NULL DEFAULT 0,
+    part_of_speech TEXT NOT NULL DEFAULT '' CHECK(part_of_speech IN ('', 'noun', 'pronoun', 'verb', 'adjective', 'adverb', 'postposition', 'conjunction', 'interjection', 'numeral', 'particle')),
+    gender TEXT NOT NULL DEFAULT '' CHECK(gender IN ('', 'masculine', 'feminine')),
+    transitivity TEXT NOT NULL DEFAULT '' CHECK(transitivity IN ('', 'intransitive', 'transitive', 'ditransitive')),
+    root TEXT NOT NULL DEFAULT '',
+    plural TEXT NOT NULL DEFAULT '',
+    oblique TEXT NOT NULL DEFAULT '',
+    oblique_plural TEXT NOT NULL DEFAULT '',
+    forms_override BOOLEAN NOT NULL DEFAULT 0,
     difficulty TEXT CHECK(difficulty IN ('easy', 'medium', 'hard')),
-    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL
 );
 
 -- Create indexes for performance and text search
 CREATE INDEX IF NOT EXISTS idx_word_difficulty ON words(difficulty);
-CREATE INDEX IF NOT EXISTS idx_words_hindi ON words(hindi);
-CREATE INDEX IF NOT EXISTS idx_words_hinglish ON words(hinglish);
-CREATE INDEX IF NOT EXISTS idx_words_english ON words(english);
+CREATE INDEX IF NOT EXISTS idx_words_target ON words(target);
+CREATE INDEX IF NOT EXISTS idx_words_romanized ON words(romanized);
+CREATE INDEX IF NOT EXISTS idx_words_grammar ON words(part_of_speech, gender);
+CREATE INDEX IF NOT EXISTS idx_words_native ON words(native);
+CREATE INDEX IF NOT EXISTS idx_words_language ON words(language, native_language);
 
 -- Groups Table
 CREATE TABLE IF NOT EXISTS groups (
@@ -44,6 +81,31 @@ CREATE TABLE IF NOT EXISTS word_groups (
 -- Create index for performance
 CREATE INDEX IF NOT EXISTS idx_word_groups ON word_groups(word_id, group_id);
 
+-- Sentences Table, example sentences of a language pair
+CREATE TABLE IF NOT EXISTS sentences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    language TEXT NOT NULL DEFAULT 'hi',
+    native_language TEXT NOT NULL DEFAULT 'en',
+    target TEXT NOT NULL,
+    romanized TEXT NOT NULL DEFAULT '',
+    native TEXT NOT NULL,
+    alternatives TEXT NOT NULL DEFAULT '[]',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Word Sentences Table (Many-to-Many Relationship)
+CREATE TABLE IF NOT EXISTS word_sentences (
+    word_id INTEGER NOT NULL,
+    sentence_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    PRIMARY KEY (word_id, sentence_id),
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (sentence_id) REFERENCES sentences(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_sentences_language ON sentences(language, native_language);
+CREATE INDEX IF NOT EXISTS idx_word_sentences_sentence ON word_sentences(sentence_id);
+
 -- Study Activities Table
 CREATE TABLE IF NOT EXISTS study_activities (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -51,7 +113,31 @@ CREATE TABLE IF NOT EXISTS study_activities (
     description TEXT,
     image TEXT,
     score INTEGER DEFAULT 0,
-    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    type TEXT NOT NULL DEFAULT 'unscramble',
+    config TEXT NOT NULL DEFAULT '{}',
+    enabled INTEGER NOT NULL DEFAULT 1 CHECK(enabled IN (0, 1))
+);
+
+-- Users Table
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    password_hash TEXT NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    role TEXT NOT NULL DEFAULT 'learner' CHECK(role IN ('learner', 'editor', 'admin'))
+);
+
+-- API Keys Table
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    last_used_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
 -- Sessions Table
@@ -63,6 +149,7 @@ CREATE TABLE IF NOT EXISTS sessions (
     end_time DATETIME,
     score INTEGER DEFAULT 0,
     created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
     FOREIGN KEY (activity_id) REFERENCES study_activities(id) ON DELETE CASCADE,
     FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE SET NULL
 );
@@ -82,8 +169,168 @@ CREATE TABLE IF NOT EXISTS session_activities (
     FOREIGN KEY (activity_id) REFERENCES study_activities(id) ON DELETE CASCADE
 );
 
+-- Jobs Table, background work such as generating pronunciation audio.
+-- Each subject has one job of a kind, failed jobs are retried by requeueing them.
+CREATE TABLE IF NOT EXISTS jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    subject_id INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'running', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    updated_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    UNIQUE (kind, subject_id)
+);
+
+-- Speech Cache Table, the asset generated for each text-to-speech input
+CREATE TABLE IF NOT EXISTS speech_cache (
+    cache_key TEXT PRIMARY KEY,
+    asset_id INTEGER NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Drafts Table, generated content waiting for an editor's approval
+CREATE TABLE IF NOT EXISTS drafts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK(kind IN ('word', 'sentence', 'hint')),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'approved', 'rejected')),
+    content TEXT NOT NULL,
+    model TEXT NOT NULL DEFAULT '',
+    group_id INTEGER REFERENCES groups(id) ON DELETE SET NULL,
+    word_id INTEGER REFERENCES words(id) ON DELETE CASCADE,
+    result_id INTEGER,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    reviewed_at DATETIME
+);
+
+-- Word Hints Table, clues that help recall a word
+CREATE TABLE IF NOT EXISTS word_hints (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Tutor Conversations Table, a learner translating a sentence with clues from a language model
+CREATE TABLE IF NOT EXISTS tutor_conversations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    sentence_id INTEGER REFERENCES sentences(id) ON DELETE SET NULL,
+    language TEXT NOT NULL,
+    native_language TEXT NOT NULL,
+    prompt TEXT NOT NULL,
+    answer TEXT NOT NULL DEFAULT '',
+    vocabulary TEXT NOT NULL DEFAULT '[]',
+    structure TEXT NOT NULL DEFAULT '',
+    solved BOOLEAN NOT NULL DEFAULT 0,
+    model TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    updated_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Tutor Messages Table, the history of a tutor conversation
+CREATE TABLE IF NOT EXISTS tutor_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    conversation_id INTEGER NOT NULL REFERENCES tutor_conversations(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK(role IN ('learner', 'tutor')),
+    content TEXT NOT NULL,
+    clues TEXT NOT NULL DEFAULT '[]',
+    session_activity_id INTEGER REFERENCES session_activities(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Listening Exercises Table, a recorded dialogue with its transcript
+CREATE TABLE IF NOT EXISTS listening_exercises (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    language TEXT NOT NULL DEFAULT 'hi',
+    source TEXT UNIQUE,
+    introduction TEXT NOT NULL DEFAULT '',
+    transcript TEXT NOT NULL,
+    audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL,
+    audio_url TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Listening Segments Table, the lines of a transcript with their place in the audio
+CREATE TABLE IF NOT EXISTS listening_segments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    start_ms INTEGER,
+    end_ms INTEGER
+);
+
+-- Listening Questions Table, multiple-choice questions about a listening exercise
+CREATE TABLE IF NOT EXISTS listening_questions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    question TEXT NOT NULL,
+    options TEXT NOT NULL DEFAULT '[]',
+    answer TEXT NOT NULL
+);
+
+-- Recordings Table, spoken answers to speaking challenges with their transcripts
+CREATE TABLE IF NOT EXISTS recordings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    session_activity_id INTEGER NOT NULL UNIQUE REFERENCES session_activities(id) ON DELETE CASCADE,
+    word_id INTEGER REFERENCES words(id) ON DELETE SET NULL,
+    mime_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    duration_ms INTEGER,
+    storage_key TEXT NOT NULL UNIQUE,
+    transcript TEXT NOT NULL DEFAULT '',
+    model TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Word Merges Table, duplicate words merged into the word that was kept, with a
+-- copy of each merged word and the groups it belonged to
+CREATE TABLE IF NOT EXISTS word_merges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kept_word_id INTEGER NOT NULL,
+    merged_word_id INTEGER NOT NULL,
+    merged_word TEXT NOT NULL,
+    group_ids TEXT NOT NULL DEFAULT '[]',
+    merged_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+-- Issued Challenges Table, the challenges handed to learners, answered by ID
+-- once so their answers never leave the server
+CREATE TABLE IF NOT EXISTS issued_challenges (
+    id TEXT PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    prompt TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '',
+    audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL,
+    answered_at DATETIME,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
 -- Indexes for performance
 CREATE INDEX IF NOT EXISTS idx_groups_name ON groups(name);
+CREATE INDEX IF NOT EXISTS idx_study_activities_type ON study_activities(type);
 CREATE INDEX IF NOT EXISTS idx_sessions_activity ON sessions(activity_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_user ON api_keys(user_id);
 CREATE INDEX IF NOT EXISTS idx_session_activities_session ON session_activities(session_id);
 CREATE INDEX IF NOT EXISTS idx_session_activities_activity ON session_activities(activity_id);
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(kind, status);
+CREATE INDEX IF NOT EXISTS idx_drafts_status ON drafts(status, kind);
+CREATE INDEX IF NOT EXISTS idx_word_hints_word ON word_hints(word_id);
+CREATE INDEX IF NOT EXISTS idx_tutor_conversations_user ON tutor_conversations(user_id, session_id);
+CREATE INDEX IF NOT EXISTS idx_tutor_messages_conversation ON tutor_messages(conversation_id);
+CREATE INDEX IF NOT EXISTS idx_listening_segments_exercise ON listening_segments(exercise_id, position);
+CREATE INDEX IF NOT EXISTS idx_listening_questions_exercise ON listening_questions(exercise_id, position);
+CREATE INDEX IF NOT EXISTS idx_recordings_user ON recordings(user_id, session_id);
+CREATE INDEX IF NOT EXISTS idx_word_merges_kept ON word_merges(kept_word_id);
+CREATE INDEX IF NOT EXISTS idx_issued_challenges_session ON issued_challenges(session_id);
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// APIKeyPrefix starts every API key so leaked keys are easy to recognise
+const APIKeyPrefix = "lpk_"
+
+// HeaderAPIKey is the request header scripts send their API key in
+const HeaderAPIKey = "X-API-Key"
+
+// NewAPIKey generates an API key and the hash stored in its place
+func NewAPIKey() (key, hash string, err error) {
+	id, err := RandomID()
+	if err != nil {
+		return "", "", err
+	}
+	secret, err := RandomID()
+	if err != nil {
+		return "", "", err
+	}
+
+	key = APIKeyPrefix + id + secret
+	return key, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hex SHA-256 hash an API key is looked up by
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// principalKey is the echo.Context key holding the authenticated principal
+const principalKey = "auth.principal"
+
+// Ways a principal can authenticate
+const (
+	MethodToken  = "token"
+	MethodAPIKey = "api_key"
+)
+
+// Principal is the user a request acts as
+type Principal struct {
+	UserID   int64       `json:"user_id"`
+	Username string      `json:"username"`
+	Role     models.Role `json:"role"`
+	Method   string      `json:"method"`
+}
+
+// Authenticator resolves the principal behind a login token or API key
+type Authenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*Principal, error)
+	AuthenticateAPIKey(ctx context.Context, key string) (*Principal, error)
+}
+
+// MiddlewareConfig configures the authentication middleware
+type MiddlewareConfig struct {
+	// Skipper skips authentication for routes that verify their own tokens
+	Skipper middleware.Skipper
+	// Authenticator resolves login tokens and API keys
+	Authenticator Authenticator
+}
+
+// Middleware authenticates requests carrying an API key or a bearer login
+// token and stores the principal in the context. Requests without either
+// pass through anonymously, RequireRole decides whether that is enough.
+func Middleware(authenticator Authenticator) echo.MiddlewareFunc {
+	return MiddlewareWithConfig(MiddlewareConfig{Authenticator: authenticator})
+}
+
+// MiddlewareWithConfig returns the authentication middleware with a config
+func MiddlewareWithConfig(config MiddlewareConfig) echo.MiddlewareFunc {
+	if config.Skipper == nil {
+		config.Skipper = middleware.DefaultSkipper
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper(c) {
+				return next(c)
+			}
+
+			var principal *Principal
+			var err error
+
+			ctx := c.Request().Context()
+			if key := c.Request().Header.Get(HeaderAPIKey); key != "" {
+				principal, err = config.Authenticator.AuthenticateAPIKey(ctx, key)
+			} else if token := BearerToken(c.Request().Header.Get(echo.HeaderAuthorization)); token != "" {
+				principal, err = config.Authenticator.AuthenticateToken(ctx, token)
+			}
+
+			if err != nil {
+				if errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrTokenExpired) {
+					return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+				}
+				log.Printf("Failed to authenticate request: %v", err)
+				return c.JSON(http.StatusInternalServerError, map[string]string{
+					"error": "Failed to authenticate",
+				})
+			}
+
+			if principal != nil {
+				c.Set(principalKey, principal)
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireRole rejects requests whose principal lacks the role, answering
+// 401 for anonymous requests and 403 for insufficient roles
+func RequireRole(role models.Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := PrincipalFrom(c)
+			if principal == nil {
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"error": "Login token or API key is required",
+				})
+			}
+			if !principal.Role.Includes(role) {
+				return c.JSON(http.StatusForbidden, map[string]string{
+					"error": "This action requires the " + string(role) + " role",
+				})
+			}
+			return next(c)
+		}
+	}
+}
+
+// PrincipalFrom returns the authenticated principal, or nil for anonymous requests
+func PrincipalFrom(c echo.Context) *Principal {
+	principal, _ := c.Get(principalKey).(*Principal)
+	return principal
+}
@@ -0,0 +1,142 @@
+// Package auth signs and verifies the tokens issued by the backend
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MinKeyLength is the minimum accepted signing key length in bytes
+const MinKeyLength = 32
+
+// Audiences keep tokens issued for one purpose from being accepted for another
+const (
+	// AudienceLaunch marks tokens that let an external activity report results
+	AudienceLaunch = "activity-launch"
+	// AudienceUser marks login tokens that identify a learner
+	AudienceUser = "user"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token has expired")
+)
+
+// Claims are the registered JWT claims plus the backend's own claims
+type Claims struct {
+	Subject    string `json:"sub,omitempty"`
+	Audience   string `json:"aud"`
+	ID         string `json:"jti,omitempty"`
+	IssuedAt   int64  `json:"iat"`
+	ExpiresAt  int64  `json:"exp"`
+	SessionID  int64  `json:"sid,omitempty"`
+	ActivityID int64  `json:"aid,omitempty"`
+}
+
+// Signer issues and verifies HS256 signed JWTs
+type Signer struct {
+	key []byte
+	now func() time.Time
+}
+
+var encoding = base64.RawURLEncoding
+
+// header is the fixed JOSE header of every token the signer issues
+var header = encoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// NewSigner creates a new instance of Signer
+func NewSigner(key []byte) (*Signer, error) {
+	if len(key) < MinKeyLength {
+		return nil, fmt.Errorf("signing key must be at least %d bytes", MinKeyLength)
+	}
+	return &Signer{key: key, now: time.Now}, nil
+}
+
+// Issue signs claims for the given audience, valid for ttl
+func (s *Signer) Issue(claims Claims, audience string, ttl time.Duration) (string, time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(ttl)
+
+	claims.Audience = audience
+	claims.IssuedAt = now.Unix()
+	claims.ExpiresAt = expiresAt.Unix()
+	if claims.ID == "" {
+		id, err := RandomID()
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		claims.ID = id
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to encode claims: %w", err)
+	}
+
+	unsigned := header + "." + encoding.EncodeToString(payload)
+	return unsigned + "." + s.sign(unsigned), expiresAt, nil
+}
+
+// Verify checks the signature, audience and expiry of a token
+func (s *Signer) Verify(token, audience string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return nil, ErrInvalidToken
+	}
+
+	expected := s.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Audience != audience {
+		return nil, ErrInvalidToken
+	}
+	if s.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+
+	return &claims, nil
+}
+
+func (s *Signer) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(unsigned))
+	return encoding.EncodeToString(mac.Sum(nil))
+}
+
+// RandomID returns a random hex identifier for token IDs
+func RandomID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// BearerToken extracts the token from an Authorization header value
+func BearerToken(authorization string) string {
+	const prefix = "Bearer "
+	if len(authorization) > len(prefix) && strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return strings.TrimSpace(authorization[len(prefix):])
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+const (
+	defaultLaunchTokenTTL = 2 * time.Hour
+	defaultUserTokenTTL   = 24 * time.Hour
+)
+
+// LaunchConfig holds the settings for launching external activities
+type LaunchConfig struct {
+	Secret   []byte
+	TokenTTL time.Duration
+}
+
+// UserTokenConfig holds the settings for learner login tokens
+type UserTokenConfig struct {
+	Secret   []byte
+	TokenTTL time.Duration
+}
+
+// LoadLaunchConfig reads the launch token settings from the environment.
+// Without LAUNCH_TOKEN_SECRET a random key is used, which invalidates
+// outstanding launch tokens whenever the server restarts.
+func LoadLaunchConfig() (*LaunchConfig, error) {
+	secret, err := secretFromEnv("LAUNCH_TOKEN_SECRET")
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := durationFromEnv("LAUNCH_TOKEN_TTL", defaultLaunchTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LaunchConfig{Secret: secret, TokenTTL: ttl}, nil
+}
+
+// LoadUserTokenConfig reads the login token settings from the environment.
+// Without AUTH_TOKEN_SECRET a random key is used, which signs every learner
+// out whenever the server restarts.
+func LoadUserTokenConfig() (*UserTokenConfig, error) {
+	secret, err := secretFromEnv("AUTH_TOKEN_SECRET")
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := durationFromEnv("AUTH_TOKEN_TTL", defaultUserTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserTokenConfig{Secret: secret, TokenTTL: ttl}, nil
+}
+
+// secretFromEnv returns the named secret, or a random one if it is unset
+func secretFromEnv(name string) ([]byte, error) {
+	if value := os.Getenv(name); value != "" {
+		return []byte(value), nil
+	}
+
+	log.Printf("%s is not set, using a random key for this run", name)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate %s: %w", name, err)
+	}
+	return secret, nil
+}
+
+// durationFromEnv parses the named duration, falling back to a default
+func durationFromEnv(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	return duration, nil
+}
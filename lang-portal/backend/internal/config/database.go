@@ -7,11 +7,11 @@ import (
 	"os"
 	"path/filepath"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
 )
 
 var (
-	dbDriver = "sqlite3"
+	dbDriver = repository.DriverName
 	dbPath   = "./lang-portal.db"
 )
 
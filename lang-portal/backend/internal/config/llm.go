@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Language model services the backend can talk to
+const (
+	LLMOpenAI = "openai"
+	LLMOllama = "ollama"
+)
+
+const (
+	defaultOpenAIModel = "gpt-4o-mini"
+	defaultOllamaModel = "llama3.2:1b"
+	defaultLLMTimeout  = 60 * time.Second
+)
+
+// LLMConfig holds the settings for generating content with a language model
+type LLMConfig struct {
+	Provider string // empty when content generation is disabled
+	URL      string
+	Model    string
+	APIKey   string
+	Timeout  time.Duration
+}
+
+// LoadLLMConfig reads the language model settings from the environment.
+// LLM_PROVIDER picks "openai" for an OpenAI-compatible API or "ollama" for an
+// Ollama server, leaving it unset disables content generation.
+func LoadLLMConfig() (*LLMConfig, error) {
+	cfg := &LLMConfig{
+		Provider: os.Getenv("LLM_PROVIDER"),
+		URL:      os.Getenv("LLM_URL"),
+		Model:    os.Getenv("LLM_MODEL"),
+		APIKey:   os.Getenv("LLM_API_KEY"),
+	}
+
+	switch cfg.Provider {
+	case "":
+		return cfg, nil
+	case LLMOpenAI:
+		if cfg.Model == "" {
+			cfg.Model = defaultOpenAIModel
+		}
+	case LLMOllama:
+		if cfg.Model == "" {
+			cfg.Model = defaultOllamaModel
+		}
+	default:
+		return nil, fmt.Errorf("invalid LLM_PROVIDER: %q, use %q or %q", cfg.Provider, LLMOpenAI, LLMOllama)
+	}
+
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("LLM_URL is required when LLM_PROVIDER is %q", cfg.Provider)
+	}
+
+	timeout, err := durationFromEnv("LLM_TIMEOUT", defaultLLMTimeout)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Timeout = timeout
+
+	return cfg, nil
+}
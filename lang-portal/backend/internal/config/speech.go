@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Text-to-speech services the backend can talk to
+const (
+	TTSOpenAI = "openai"
+	TTSPiper  = "piper"
+)
+
+const (
+	defaultTTSModel   = "tts-1"
+	defaultTTSVoice   = "alloy"
+	defaultTTSTimeout = 30 * time.Second
+)
+
+// TTSConfig holds the settings for generating pronunciation audio
+type TTSConfig struct {
+	Provider string // empty when text-to-speech is disabled
+	URL      string
+	Model    string
+	Voice    string
+	APIKey   string
+	Timeout  time.Duration
+}
+
+// LoadTTSConfig reads the text-to-speech settings from the environment.
+// TTS_PROVIDER picks "openai" for an OpenAI-compatible API or "piper" for a
+// Piper HTTP server, leaving it unset disables audio generation.
+func LoadTTSConfig() (*TTSConfig, error) {
+	cfg := &TTSConfig{
+		Provider: os.Getenv("TTS_PROVIDER"),
+		URL:      os.Getenv("TTS_URL"),
+		Model:    os.Getenv("TTS_MODEL"),
+		Voice:    os.Getenv("TTS_VOICE"),
+		APIKey:   os.Getenv("TTS_API_KEY"),
+	}
+
+	switch cfg.Provider {
+	case "":
+		return cfg, nil
+	case TTSOpenAI:
+		if cfg.Model == "" {
+			cfg.Model = defaultTTSModel
+		}
+		if cfg.Voice == "" {
+			cfg.Voice = defaultTTSVoice
+		}
+	case TTSPiper:
+	default:
+		return nil, fmt.Errorf("invalid TTS_PROVIDER: %q, use %q or %q", cfg.Provider, TTSOpenAI, TTSPiper)
+	}
+
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("TTS_URL is required when TTS_PROVIDER is %q", cfg.Provider)
+	}
+
+	timeout, err := durationFromEnv("TTS_TIMEOUT", defaultTTSTimeout)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Timeout = timeout
+
+	return cfg, nil
+}
+
+// Speech recognition services the backend can talk to
+const (
+	ASRWhisper = "whisper"
+)
+
+const (
+	defaultASRModel   = "whisper-1"
+	defaultASRTimeout = 60 * time.Second
+)
+
+// ASRConfig holds the settings for transcribing spoken answers
+type ASRConfig struct {
+	Provider string // empty when speech recognition is disabled
+	URL      string
+	Model    string
+	APIKey   string
+	Timeout  time.Duration
+}
+
+// LoadASRConfig reads the speech recognition settings from the environment.
+// ASR_PROVIDER picks "whisper" for an OpenAI-compatible transcription API,
+// leaving it unset disables speaking practice.
+func LoadASRConfig() (*ASRConfig, error) {
+	cfg := &ASRConfig{
+		Provider: os.Getenv("ASR_PROVIDER"),
+		URL:      os.Getenv("ASR_URL"),
+		Model:    os.Getenv("ASR_MODEL"),
+		APIKey:   os.Getenv("ASR_API_KEY"),
+	}
+
+	switch cfg.Provider {
+	case "":
+		return cfg, nil
+	case ASRWhisper:
+		if cfg.Model == "" {
+			cfg.Model = defaultASRModel
+		}
+	default:
+		return nil, fmt.Errorf("invalid ASR_PROVIDER: %q, use %q", cfg.Provider, ASRWhisper)
+	}
+
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("ASR_URL is required when ASR_PROVIDER is %q", cfg.Provider)
+	}
+
+	timeout, err := durationFromEnv("ASR_TIMEOUT", defaultASRTimeout)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Timeout = timeout
+
+	return cfg, nil
+}
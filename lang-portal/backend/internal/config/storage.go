@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	defaultAssetDir       = "./data/assets"
+	defaultMaxUploadBytes = 10 << 20
+)
+
+// StorageConfig holds the settings for uploaded assets
+type StorageConfig struct {
+	Dir            string
+	MaxUploadBytes int64
+}
+
+// LoadStorageConfig reads the asset storage settings from the environment.
+// Files are kept below ASSET_DIR, uploads are limited to ASSET_MAX_UPLOAD_BYTES.
+func LoadStorageConfig() (*StorageConfig, error) {
+	dir := os.Getenv("ASSET_DIR")
+	if dir == "" {
+		dir = defaultAssetDir
+	}
+
+	maxUpload := int64(defaultMaxUploadBytes)
+	if value := os.Getenv("ASSET_MAX_UPLOAD_BYTES"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid ASSET_MAX_UPLOAD_BYTES: %q", value)
+		}
+		maxUpload = parsed
+	}
+
+	return &StorageConfig{Dir: dir, MaxUploadBytes: maxUpload}, nil
+}
@@ -14,11 +14,16 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 	"go.uber.org/zap"
 
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
 	"github.com/pavittarx/lang-portal/backend/internal/config"
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/ai"
 	"github.com/pavittarx/lang-portal/backend/pkg/handlers"
 	"github.com/pavittarx/lang-portal/backend/pkg/repository"
 	"github.com/pavittarx/lang-portal/backend/pkg/routes"
 	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/pkg/speech"
+	"github.com/pavittarx/lang-portal/backend/pkg/storage"
 )
 
 const (
@@ -39,7 +44,9 @@ func main() {
 
 	e := echo.New()
 	setupMiddleware(e)
-	setupRoutes(e, db, sugar)
+	if err := setupRoutes(e, db, sugar); err != nil {
+		sugar.Fatalf("Failed to initialize routes: %v", err)
+	}
 
 	server := createServer(e)
 	startServer(server, e, sugar)
@@ -64,20 +71,175 @@ func setupMiddleware(e *echo.Echo) {
 	e.Use(middleware.CORS())
 }
 
-func setupRoutes(e *echo.Echo, db *sql.DB, sugar *zap.SugaredLogger) {
+func setupRoutes(e *echo.Echo, db *sql.DB, sugar *zap.SugaredLogger) error {
 	// Initialize repositories
 	wordRepo := repository.NewSQLiteWordRepository(db)
+	languageRepo := repository.NewSQLiteLanguageRepository(db)
+	sentenceRepo := repository.NewSQLiteSentenceRepository(db)
 	groupRepo := repository.NewSQLiteGroupRepository(db)
 	sessionRepo := repository.NewSessionRepository(db)
 	studyActivityRepo := repository.NewStudyActivityRepository(db)
 	sessionActivityRepo := repository.NewSessionActivityRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	assetRepo := repository.NewSQLiteAssetRepository(db)
+	jobRepo := repository.NewSQLiteJobRepository(db)
+	speechCacheRepo := repository.NewSQLiteSpeechCacheRepository(db)
+	draftRepo := repository.NewSQLiteDraftRepository(db)
+	wordHintRepo := repository.NewSQLiteWordHintRepository(db)
+	tutorRepo := repository.NewSQLiteTutorRepository(db)
+	listeningRepo := repository.NewSQLiteListeningRepository(db)
+	recordingRepo := repository.NewSQLiteRecordingRepository(db)
+	wordMergeRepo := repository.NewSQLiteWordMergeRepository(db)
+	contentReportRepo := repository.NewSQLiteContentReportRepository(db)
+	issuedChallengeRepo := repository.NewSQLiteIssuedChallengeRepository(db)
+
+	// Launch tokens let externally hosted activities report their results
+	launchConfig, err := config.LoadLaunchConfig()
+	if err != nil {
+		return err
+	}
+	launchSigner, err := auth.NewSigner(launchConfig.Secret)
+	if err != nil {
+		return fmt.Errorf("invalid LAUNCH_TOKEN_SECRET: %w", err)
+	}
+
+	// Login tokens and API keys identify the user a request acts as
+	userTokenConfig, err := config.LoadUserTokenConfig()
+	if err != nil {
+		return err
+	}
+	userSigner, err := auth.NewSigner(userTokenConfig.Secret)
+	if err != nil {
+		return fmt.Errorf("invalid AUTH_TOKEN_SECRET: %w", err)
+	}
+
+	// Uploaded audio and images are kept on the local disk
+	storageConfig, err := config.LoadStorageConfig()
+	if err != nil {
+		return err
+	}
+	assetStorage, err := storage.NewLocalStorage(storageConfig.Dir)
+	if err != nil {
+		return err
+	}
+
+	// Pronunciation audio is generated by a text-to-speech service when one is configured
+	ttsConfig, err := config.LoadTTSConfig()
+	if err != nil {
+		return err
+	}
+	ttsProvider := newTTSProvider(ttsConfig)
+
+	// Spoken answers are transcribed by a speech recognizer when one is configured
+	asrConfig, err := config.LoadASRConfig()
+	if err != nil {
+		return err
+	}
+	asrProvider := newASRProvider(asrConfig)
+	if asrProvider == nil {
+		sugar.Info("ASR_PROVIDER is not set, speaking practice is disabled")
+	}
+
+	// Words, sentences and hints are drafted, and the sentence tutor answers,
+	// by a language model when one is configured
+	llmConfig, err := config.LoadLLMConfig()
+	if err != nil {
+		return err
+	}
+	llmProvider := newLLMProvider(llmConfig)
+	if llmProvider == nil {
+		sugar.Info("LLM_PROVIDER is not set, content generation and the sentence tutor are disabled")
+	}
+
+	// Uploaded and generated audio is stored as assets, numbers are dictated
+	// with generated audio when a text-to-speech service is configured
+	assetService := services.NewAssetService(assetRepo, assetStorage, storageConfig.MaxUploadBytes)
+	var pronunciationService *services.PronunciationService
+	var speaker activities.Speaker
+	if ttsProvider != nil {
+		pronunciationService = services.NewPronunciationService(ttsProvider, wordRepo, speechCacheRepo, assetService)
+		speaker = pronunciationService
+	}
+
+	// Minimal pairs are found in the vocabulary, for drills and editors alike
+	minimalPairService := services.NewMinimalPairService(wordRepo, groupRepo)
+
+	// Verbs are conjugated from their stored root, for drills and lookups alike
+	conjugationService := services.NewConjugationService(wordRepo)
+
+	// Register activity engines, new activity types only need an entry here
+	activityRegistry, err := activities.NewRegistry(
+		activities.NewUnscrambleEngine(wordRepo),
+		activities.NewCompleteWordEngine(wordRepo),
+		activities.NewGroupWordsEngine(wordRepo, groupRepo),
+		activities.NewClozeEngine(wordRepo, sentenceRepo),
+		activities.NewSentenceOrderEngine(wordRepo, sentenceRepo),
+		activities.NewExternalEngine(),
+		activities.NewClientGradedEngine(),
+		activities.NewTutorEngine(),
+		activities.NewListeningEngine(listeningRepo),
+		activities.NewSpeakingEngine(wordRepo),
+		activities.NewMinimalPairsEngine(wordRepo, minimalPairService),
+		activities.NewConjugationEngine(conjugationService),
+		activities.NewNumbersEngine(speaker),
+	)
+	if err != nil {
+		return err
+	}
 
 	// Initialize services
-	wordService := services.NewWordService(wordRepo)
+	wordService := services.NewWordService(wordRepo, languageRepo, sentenceRepo, assetRepo)
+	sentenceService := services.NewSentenceService(sentenceRepo, wordRepo, languageRepo)
 	groupService := services.NewGroupService(groupRepo)
 	sessionService := services.NewSessionService(sessionRepo)
-	studyActivityService := services.NewStudyActivityService(studyActivityRepo)
-	sessionActivityService := services.NewSessionActivityService(sessionActivityRepo, sessionRepo)
+	studyActivityService := services.NewStudyActivityService(studyActivityRepo, activityRegistry)
+	sessionActivityService := services.NewSessionActivityService(sessionActivityRepo, sessionRepo, studyActivityRepo)
+	challengeService := services.NewChallengeService(activityRegistry, studyActivityRepo, sessionRepo, sessionActivityRepo, issuedChallengeRepo)
+	launchService := services.NewLaunchService(
+		launchSigner,
+		launchConfig.TokenTTL,
+		studyActivityRepo,
+		sessionRepo,
+		sessionActivityRepo,
+	)
+	userService := services.NewUserService(userRepo, apiKeyRepo, userSigner, userTokenConfig.TokenTTL)
+
+	// Register job runners, kinds without a runner cannot be queued
+	var jobRunners []services.JobRunner
+	if pronunciationService != nil {
+		jobRunners = append(jobRunners, pronunciationService)
+	} else {
+		sugar.Info("TTS_PROVIDER is not set, pronunciation audio generation is disabled")
+	}
+	jobService := services.NewJobService(jobRepo, jobRunners...)
+	contentService := services.NewContentService(
+		llmProvider,
+		draftRepo,
+		wordHintRepo,
+		languageRepo,
+		wordService,
+		sentenceService,
+		groupService,
+	)
+	tutorService := services.NewTutorService(
+		llmProvider,
+		tutorRepo,
+		wordRepo,
+		sentenceRepo,
+		languageRepo,
+		studyActivityRepo,
+		sessionRepo,
+		sessionActivityRepo,
+	)
+	listeningService := services.NewListeningService(listeningRepo, languageRepo, assetRepo)
+	speakingService := services.NewSpeakingService(
+		asrProvider,
+		recordingRepo,
+		challengeService,
+		assetStorage,
+		storageConfig.MaxUploadBytes,
+	)
 
 	// Initialize handlers
 	wordHandler := handlers.NewWordHandler(wordService, wordRepo)
@@ -85,6 +247,28 @@ func setupRoutes(e *echo.Echo, db *sql.DB, sugar *zap.SugaredLogger) {
 	sessionHandler := handlers.NewSessionHandler(sessionService)
 	studyActivityHandler := handlers.NewStudyActivityHandler(studyActivityService)
 	sessionActivityHandler := handlers.NewSessionActivityHandler(sessionActivityService)
+	challengeHandler := handlers.NewChallengeHandler(challengeService, assetService)
+	launchHandler := handlers.NewLaunchHandler(launchService)
+	authHandler := handlers.NewAuthHandler(userService)
+	sentenceHandler := handlers.NewSentenceHandler(sentenceService)
+	assetHandler := handlers.NewAssetHandler(assetService)
+	jobHandler := handlers.NewJobHandler(jobService)
+	contentHandler := handlers.NewContentHandler(contentService)
+	tutorHandler := handlers.NewTutorHandler(tutorService)
+	listeningHandler := handlers.NewListeningHandler(listeningService)
+	speakingHandler := handlers.NewSpeakingHandler(speakingService)
+	minimalPairHandler := handlers.NewMinimalPairHandler(minimalPairService)
+	conjugationHandler := handlers.NewConjugationHandler(conjugationService)
+	numeralHandler := handlers.NewNumeralHandler(services.NewNumeralService())
+	inputHandler := handlers.NewInputHandler(services.NewInputService())
+	duplicateHandler := handlers.NewDuplicateHandler(services.NewDuplicateService(wordRepo, wordMergeRepo))
+	contentReportHandler := handlers.NewContentReportHandler(services.NewContentReportService(wordRepo, languageRepo, contentReportRepo))
+
+	// Authenticate every request, the routes decide which role they require
+	e.Use(auth.MiddlewareWithConfig(auth.MiddlewareConfig{
+		Skipper:       routes.IsLaunchCallback,
+		Authenticator: userService,
+	}))
 
 	// Register routes
 	routes.RegisterRoutes(e,
@@ -92,9 +276,85 @@ func setupRoutes(e *echo.Echo, db *sql.DB, sugar *zap.SugaredLogger) {
 		groupHandler,
 		studyActivityHandler,
 		sessionHandler,
-		sessionActivityHandler)
+		sessionActivityHandler,
+		challengeHandler,
+		launchHandler,
+		authHandler,
+		sentenceHandler,
+		assetHandler,
+		jobHandler,
+		contentHandler,
+		tutorHandler,
+		listeningHandler,
+		speakingHandler,
+		minimalPairHandler,
+		conjugationHandler,
+		numeralHandler,
+		inputHandler,
+		duplicateHandler,
+		contentReportHandler)
 
 	sugar.Info("Routes initialized successfully")
+	return nil
+}
+
+// newTTSProvider creates the configured text-to-speech provider, or nil when
+// none is configured
+func newTTSProvider(cfg *config.TTSConfig) speech.TTSProvider {
+	httpConfig := speech.HTTPConfig{
+		URL:     cfg.URL,
+		Model:   cfg.Model,
+		Voice:   cfg.Voice,
+		APIKey:  cfg.APIKey,
+		Timeout: cfg.Timeout,
+	}
+
+	switch cfg.Provider {
+	case config.TTSOpenAI:
+		return speech.NewOpenAITTS(httpConfig)
+	case config.TTSPiper:
+		return speech.NewPiperTTS(httpConfig)
+	default:
+		return nil
+	}
+}
+
+// newASRProvider creates the configured speech recognition provider, or nil
+// when none is configured
+func newASRProvider(cfg *config.ASRConfig) speech.ASRProvider {
+	httpConfig := speech.HTTPConfig{
+		URL:     cfg.URL,
+		Model:   cfg.Model,
+		APIKey:  cfg.APIKey,
+		Timeout: cfg.Timeout,
+	}
+
+	switch cfg.Provider {
+	case config.ASRWhisper:
+		return speech.NewWhisperASR(httpConfig)
+	default:
+		return nil
+	}
+}
+
+// newLLMProvider creates the configured language model provider, or nil when
+// none is configured
+func newLLMProvider(cfg *config.LLMConfig) ai.LLMProvider {
+	httpConfig := ai.HTTPConfig{
+		URL:     cfg.URL,
+		Model:   cfg.Model,
+		APIKey:  cfg.APIKey,
+		Timeout: cfg.Timeout,
+	}
+
+	switch cfg.Provider {
+	case config.LLMOpenAI:
+		return ai.NewOpenAIChat(httpConfig)
+	case config.LLMOllama:
+		return ai.NewOllama(httpConfig)
+	default:
+		return nil
+	}
 }
 
 func createServer(e *echo.Echo) *http.Server {
@@ -0,0 +1,45 @@
+package activities
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// ClientGradedType is the activity type of activities whose challenges are
+// made and graded by the client, such as the frontend's own games
+const ClientGradedType = "client_graded"
+
+// ErrClientGradedActivity is returned when a server-side challenge is
+// requested for an activity the client grades
+var ErrClientGradedActivity = errors.New("challenges for client graded activities are handled by the client")
+
+// ClientGradedEngine represents activities that generate and grade their
+// challenges in the client, which posts each result to the session
+type ClientGradedEngine struct{}
+
+// NewClientGradedEngine creates a new instance of ClientGradedEngine
+func NewClientGradedEngine() *ClientGradedEngine {
+	return &ClientGradedEngine{}
+}
+
+// Type returns the activity type of the engine
+func (e *ClientGradedEngine) Type() string {
+	return ClientGradedType
+}
+
+// GenerateChallenge is not supported, the client owns its challenges
+func (e *ClientGradedEngine) GenerateChallenge(_ context.Context, _ ChallengeRequest) (*Challenge, error) {
+	return nil, ErrClientGradedActivity
+}
+
+// GradeAnswer is not supported, the client grades its own challenges
+func (e *ClientGradedEngine) GradeAnswer(_ context.Context, _ *Challenge, _ string) (*Grade, error) {
+	return nil, ErrClientGradedActivity
+}
+
+// SummarizeSession aggregates the results posted by the client
+func (e *ClientGradedEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
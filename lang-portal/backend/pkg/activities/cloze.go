@@ -0,0 +1,236 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// ClozeType is the activity type of the fill-in-the-blank sentence engine
+const ClozeType = "cloze"
+
+// gap marks the blanked word in a cloze prompt
+const gap = "____"
+
+// inflectedScore is awarded for another form of the right word
+const inflectedScore = 50
+
+// ClozeEngine blanks a word out of one of its example sentences and asks the
+// learner to fill it in, in the form the sentence needs
+type ClozeEngine struct {
+	words     WordSource
+	sentences SentenceSource
+}
+
+type clozePayload struct {
+	SentenceID int64 `json:"sentence_id"`
+	WordID     int64 `json:"word_id"`
+	Token      int   `json:"token"`
+}
+
+// clozeToken is a whitespace separated token split from its punctuation
+type clozeToken struct {
+	lead, text, trail string
+}
+
+// clozeBlank locates the word in a tokenized sentence
+type clozeBlank struct {
+	token, length int
+	surface       string // the form of the word used in the sentence
+	postposition  string // a postposition joined to the word, left in the prompt
+}
+
+// NewClozeEngine creates a new instance of ClozeEngine
+func NewClozeEngine(words WordSource, sentences SentenceSource) *ClozeEngine {
+	return &ClozeEngine{words: words, sentences: sentences}
+}
+
+// Type returns the activity type of the engine
+func (e *ClozeEngine) Type() string {
+	return ClozeType
+}
+
+// GenerateChallenge picks a sentence linked to a word, restricted to the
+// session's group if set, and blanks the word out of it
+func (e *ClozeEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	wordIDs, err := groupWordIDs(ctx, e.words, req.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < maxSentenceAttempts; attempt++ {
+		sentence, err := e.sentences.GetRandomForWords(ctx, wordIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens := tokenize(sentence.Target)
+		for _, i := range rand.Perm(len(sentence.WordIDs)) {
+			id := sentence.WordIDs[i]
+			if wordIDs != nil && !containsID(wordIDs, id) {
+				continue
+			}
+
+			word, err := e.words.GetByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			blank, ok := findBlank(tokens, word)
+			if !ok {
+				continue
+			}
+
+			challenge, err := NewChallenge(ClozeType, clozePrompt(tokens, blank), clozePayload{
+				SentenceID: sentence.ID,
+				WordID:     word.ID,
+				Token:      blank.token,
+			})
+			if err != nil {
+				return nil, err
+			}
+			challenge.Hints = []string{devanagari.Aksharas(blank.surface)[0] + "…", word.Native}
+
+			return challenge, nil
+		}
+	}
+
+	return nil, errors.New("no sentence found with a word to blank out")
+}
+
+// GradeAnswer accepts the form used in the sentence in full, and other forms
+// of the same word as correct with a partial score
+func (e *ClozeEngine) GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload clozePayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	sentence, err := e.sentences.GetByID(ctx, payload.SentenceID)
+	if err != nil {
+		return nil, err
+	}
+	word, err := e.words.GetByID(ctx, payload.WordID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := tokenize(sentence.Target)
+	blank, ok := matchBlank(tokens, payload.Token, word)
+	if !ok {
+		return nil, fmt.Errorf("%w: word %d is no longer in sentence %d", ErrInvalidChallenge, word.ID, sentence.ID)
+	}
+
+	answer := normalizeAnswer(input)
+	if blank.postposition != "" && !sameForm(word.Language, answer, blank.surface) {
+		// The postposition is shown in the prompt, but may be typed along
+		answer = normalizeAnswer(strings.TrimSuffix(answer, blank.postposition))
+	}
+
+	if sameForm(word.Language, answer, blank.surface) {
+		return binaryGrade(true, blank.surface), nil
+	}
+	if word.Language == hindi.Code && hindi.IsInflectionOf(answer, word.Target) {
+		return &Grade{
+			Correct:  true,
+			Score:    inflectedScore,
+			Expected: blank.surface,
+			Feedback: fmt.Sprintf("right word, the sentence uses the form %s", blank.surface),
+		}, nil
+	}
+
+	return binaryGrade(false, blank.surface), nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *ClozeEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
+
+// tokenize splits a sentence on whitespace and separates the punctuation
+// around each token
+func tokenize(sentence string) []clozeToken {
+	fields := strings.Fields(sentence)
+	tokens := make([]clozeToken, len(fields))
+	for i, field := range fields {
+		text := strings.TrimLeftFunc(field, unicode.IsPunct)
+		lead := field[:len(field)-len(text)]
+		core := strings.TrimRightFunc(text, unicode.IsPunct)
+		tokens[i] = clozeToken{lead: lead, text: core, trail: text[len(core):]}
+	}
+	return tokens
+}
+
+// findBlank finds the first occurrence of the word in the tokens
+func findBlank(tokens []clozeToken, word *models.Word) (clozeBlank, bool) {
+	for i := range tokens {
+		if blank, ok := matchBlank(tokens, i, word); ok {
+			return blank, true
+		}
+	}
+	return clozeBlank{}, false
+}
+
+// matchBlank reports whether the word, which may span several tokens, starts
+// at the token
+func matchBlank(tokens []clozeToken, start int, word *models.Word) (clozeBlank, bool) {
+	length := len(strings.Fields(word.Target))
+	if length == 0 || start < 0 || start+length > len(tokens) {
+		return clozeBlank{}, false
+	}
+
+	parts := make([]string, length)
+	for i := range parts {
+		parts[i] = tokens[start+i].text
+	}
+	text := strings.Join(parts, " ")
+
+	blank := clozeBlank{token: start, length: length}
+	if word.Language == hindi.Code {
+		surface, postposition, ok := hindi.SplitPostposition(text, word.Target)
+		if !ok {
+			return clozeBlank{}, false
+		}
+		blank.surface, blank.postposition = surface, postposition
+		return blank, true
+	}
+
+	if text != word.Target {
+		return clozeBlank{}, false
+	}
+	blank.surface = text
+	return blank, true
+}
+
+// clozePrompt rebuilds the sentence with the blanked word replaced by a gap
+func clozePrompt(tokens []clozeToken, blank clozeBlank) string {
+	words := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if i != blank.token {
+			words = append(words, token.lead+token.text+token.trail)
+			continue
+		}
+
+		last := tokens[i+blank.length-1]
+		words = append(words, token.lead+gap+blank.postposition+last.trail)
+		i += blank.length - 1
+	}
+	return strings.Join(words, " ")
+}
+
+// sameForm compares an answer to the expected form, ignoring nasalization
+// spelling differences in Hindi
+func sameForm(language, answer, expected string) bool {
+	if language == hindi.Code {
+		return hindi.Normalize(answer) == hindi.Normalize(expected)
+	}
+	return answer == expected
+}
@@ -0,0 +1,132 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// CompleteWordType is the activity type of the complete-the-word engine
+const CompleteWordType = "complete_word"
+
+// blank marks a missing akshara in a challenge prompt
+const blank = "_"
+
+// CompleteWordConfig configures the complete-the-word engine
+type CompleteWordConfig struct {
+	// Missing is the number of aksharas blanked out of the word
+	Missing int `json:"missing"`
+}
+
+// CompleteWordEngine asks the learner to fill in missing aksharas of a word
+type CompleteWordEngine struct {
+	words WordSource
+}
+
+type completeWordPayload struct {
+	WordID  int64 `json:"word_id"`
+	Missing []int `json:"missing"`
+}
+
+// NewCompleteWordEngine creates a new instance of CompleteWordEngine
+func NewCompleteWordEngine(words WordSource) *CompleteWordEngine {
+	return &CompleteWordEngine{words: words}
+}
+
+// Type returns the activity type of the engine
+func (e *CompleteWordEngine) Type() string {
+	return CompleteWordType
+}
+
+// ValidateConfig checks the number of missing aksharas
+func (e *CompleteWordEngine) ValidateConfig(config json.RawMessage) error {
+	var cfg CompleteWordConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return err
+	}
+	if cfg.Missing < 0 || cfg.Missing > 3 {
+		return errors.New("missing must be between 0 and 3")
+	}
+	return nil
+}
+
+// GenerateChallenge picks a word and blanks out some of its aksharas
+func (e *CompleteWordEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	cfg := CompleteWordConfig{Missing: 1}
+	if err := DecodeConfig(req.Activity, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Missing < 1 {
+		cfg.Missing = 1
+	}
+
+	word, err := pickWord(ctx, e.words, req.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	aksharas := devanagari.Aksharas(word.Target)
+	missing := rand.Perm(len(aksharas))
+	if cfg.Missing < len(missing) {
+		missing = missing[:cfg.Missing]
+	}
+	// Always leave at least one akshara visible
+	if len(missing) == len(aksharas) && len(missing) > 1 {
+		missing = missing[:len(missing)-1]
+	}
+
+	prompt := make([]string, len(aksharas))
+	copy(prompt, aksharas)
+	for _, index := range missing {
+		prompt[index] = blank
+	}
+
+	challenge, err := NewChallenge(CompleteWordType, strings.Join(prompt, ""), completeWordPayload{
+		WordID:  word.ID,
+		Missing: missing,
+	})
+	if err != nil {
+		return nil, err
+	}
+	challenge.Hints = []string{word.Native}
+
+	return challenge, nil
+}
+
+// GradeAnswer accepts either the complete word or just the missing aksharas
+func (e *CompleteWordEngine) GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload completeWordPayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	word, err := e.words.GetByID(ctx, payload.WordID)
+	if err != nil {
+		return nil, err
+	}
+
+	aksharas := devanagari.Aksharas(word.Target)
+	var missing strings.Builder
+	for index := range aksharas {
+		for _, m := range payload.Missing {
+			if m == index {
+				missing.WriteString(aksharas[index])
+			}
+		}
+	}
+
+	answer := compactAnswer(input)
+	correct := answer == compactAnswer(word.Target) || (missing.Len() > 0 && answer == missing.String())
+
+	return binaryGrade(correct, word.Target), nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *CompleteWordEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
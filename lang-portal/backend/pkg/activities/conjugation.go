@@ -0,0 +1,202 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// ConjugationType is the activity type of the conjugation engine
+const ConjugationType = "conjugation"
+
+// ConjugationSource lists the verbs of a group, or all verbs when groupID is
+// nil, and conjugates them
+type ConjugationSource interface {
+	ListVerbs(ctx context.Context, groupID *int64) ([]models.Word, error)
+	Conjugate(ctx context.Context, id int64) (*models.WordConjugations, error)
+}
+
+// ConjugationConfig configures the conjugation engine
+type ConjugationConfig struct {
+	// Tenses limits the drill to some tenses, all are drilled when empty
+	Tenses []string `json:"tenses"`
+}
+
+// ConjugationEngine asks for the form of a verb for a tense and subject,
+// such as करना in the future with वह, करेगा
+type ConjugationEngine struct {
+	verbs ConjugationSource
+}
+
+type conjugationPayload struct {
+	WordID  int64  `json:"word_id"`
+	Tense   string `json:"tense"`
+	Subject string `json:"subject"`
+	Gender  string `json:"gender"`
+}
+
+// tenseNames are how prompts name the tenses
+var tenseNames = map[string]string{
+	string(hindi.PresentHabitual): "present habitual",
+	string(hindi.PastPerfective):  "past perfective",
+	string(hindi.Future):          "future",
+	string(hindi.Subjunctive):     "subjunctive",
+	string(hindi.Imperative):      "imperative",
+}
+
+// NewConjugationEngine creates a new instance of ConjugationEngine
+func NewConjugationEngine(verbs ConjugationSource) *ConjugationEngine {
+	return &ConjugationEngine{verbs: verbs}
+}
+
+// Type returns the activity type of the engine
+func (e *ConjugationEngine) Type() string {
+	return ConjugationType
+}
+
+// ValidateConfig checks that the config decodes and names known tenses
+func (e *ConjugationEngine) ValidateConfig(config json.RawMessage) error {
+	var cfg ConjugationConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return err
+	}
+	for _, tense := range cfg.Tenses {
+		if !hindi.IsTense(tense) {
+			return fmt.Errorf("unknown tense %q", tense)
+		}
+	}
+	return nil
+}
+
+// GenerateChallenge picks a verb of the session's group and one of its
+// conjugations in a drilled tense, and asks for the form with its subject.
+// The gender is only named when the form depends on it.
+func (e *ConjugationEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	var cfg ConjugationConfig
+	if err := DecodeConfig(req.Activity, &cfg); err != nil {
+		return nil, err
+	}
+
+	var groupID *int64
+	if req.Session != nil {
+		groupID = req.Session.GroupID
+	}
+	verbs, err := e.verbs.ListVerbs(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(verbs) == 0 {
+		return nil, fmt.Errorf("no verbs to conjugate: %w", models.ErrNotFound)
+	}
+
+	verb := verbs[rand.Intn(len(verbs))]
+	conjugated, err := e.verbs.Conjugate(ctx, verb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []models.Conjugation
+	for _, c := range conjugated.Conjugations {
+		if drillsTense(cfg, c.Tense) {
+			candidates = append(candidates, c)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no conjugations of %s to drill: %w", verb.Target, models.ErrNotFound)
+	}
+	target := candidates[rand.Intn(len(candidates))]
+
+	subject := target.Subject
+	if genderMatters(conjugated.Conjugations, target) {
+		subject = fmt.Sprintf("%s (%s)", subject, target.Gender)
+	}
+	prompt := fmt.Sprintf("%s, %s: %s ___", verb.Target, tenseNames[target.Tense], subject)
+
+	challenge, err := NewChallenge(ConjugationType, prompt, conjugationPayload{
+		WordID:  verb.ID,
+		Tense:   target.Tense,
+		Subject: target.Subject,
+		Gender:  target.Gender,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	challenge.Hints = []string{verb.Native}
+	if target.Ergative {
+		challenge.Hints = append(challenge.Hints, "With ने the verb agrees with its object, not the subject")
+	}
+
+	return challenge, nil
+}
+
+// GradeAnswer accepts the conjugated form, with or without its subject
+func (e *ConjugationEngine) GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload conjugationPayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	conjugated, err := e.verbs.Conjugate(ctx, payload.WordID)
+	if err != nil {
+		return nil, err
+	}
+
+	var expected *models.Conjugation
+	for i, c := range conjugated.Conjugations {
+		if c.Tense == payload.Tense && c.Subject == payload.Subject && c.Gender == payload.Gender {
+			expected = &conjugated.Conjugations[i]
+			break
+		}
+	}
+	if expected == nil {
+		return nil, fmt.Errorf("%s has no %s form for %s: %w",
+			conjugated.Word.Target, payload.Tense, payload.Subject, ErrInvalidChallenge)
+	}
+
+	answer := devanagari.Fold(normalizeAnswer(input))
+	form := devanagari.Fold(expected.Form)
+	correct := answer == form || strings.TrimPrefix(answer, devanagari.Fold(expected.Subject)+" ") == form
+
+	grade := binaryGrade(correct, expected.Form)
+	if !correct {
+		grade.Feedback = fmt.Sprintf("%s %s", expected.Subject, expected.Form)
+	}
+
+	return grade, nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *ConjugationEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
+
+// drillsTense reports whether the config drills a tense
+func drillsTense(cfg ConjugationConfig, tense string) bool {
+	if len(cfg.Tenses) == 0 {
+		return true
+	}
+	for _, drilled := range cfg.Tenses {
+		if drilled == tense {
+			return true
+		}
+	}
+	return false
+}
+
+// genderMatters reports whether the form of a conjugation differs for the
+// other gender of its subject
+func genderMatters(conjugations []models.Conjugation, target models.Conjugation) bool {
+	for _, c := range conjugations {
+		if c.Tense == target.Tense && c.Subject == target.Subject && c.Gender != target.Gender {
+			return c.Form != target.Form
+		}
+	}
+	return false
+}
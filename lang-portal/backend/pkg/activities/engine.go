@@ -0,0 +1,142 @@
+// Package activities defines the pluggable engines behind study activities
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// ErrInvalidChallenge is returned when a submitted challenge cannot be graded
+var ErrInvalidChallenge = errors.New("invalid challenge")
+
+// ActivityEngine generates and grades challenges for one type of study activity
+type ActivityEngine interface {
+	// Type returns the key the engine is registered under
+	Type() string
+
+	// GenerateChallenge builds the next challenge for a session
+	GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error)
+
+	// GradeAnswer scores the learner's input against a generated challenge
+	GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error)
+
+	// SummarizeSession aggregates the graded activities of a session
+	SummarizeSession(ctx context.Context, activities []models.SessionActivity) (*Summary, error)
+}
+
+// ConfigValidator is implemented by engines that accept activity configuration
+type ConfigValidator interface {
+	ValidateConfig(config json.RawMessage) error
+}
+
+// ChallengeRequest carries the context an engine needs to build a challenge
+type ChallengeRequest struct {
+	Activity *models.StudyActivity
+	Session  *models.Session
+	// Issued are the challenges issued for the session so far, oldest first
+	Issued []models.IssuedChallenge
+}
+
+// Challenge is a single prompt presented to the learner. Payload holds
+// engine-specific references used to grade the answer server-side, it is
+// kept with the challenge on the server and never sent to the learner, who
+// answers the challenge by its ID.
+type Challenge struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Prompt  string          `json:"prompt"`
+	Hints   []string        `json:"hints,omitempty"`
+	Options []string        `json:"options,omitempty"`
+	Audio   string          `json:"audio,omitempty"` // URL of a recording to play with the prompt
+	Payload json.RawMessage `json:"-"`
+
+	// AudioAssetID is a recording to play that would give the answer away
+	// by its URL. It is kept with the challenge and served through it.
+	AudioAssetID *int64 `json:"-"`
+}
+
+// Grade is the outcome of grading a single answer
+type Grade struct {
+	Correct  bool   `json:"correct"`
+	Score    int    `json:"score"`
+	Expected string `json:"expected"`
+	Feedback string `json:"feedback,omitempty"`
+}
+
+// Result returns the session activity result for the grade
+func (g *Grade) Result() string {
+	if g.Correct {
+		return models.ResultSuccess
+	}
+	return models.ResultFailure
+}
+
+// Summary aggregates the graded activities of a session
+type Summary struct {
+	Attempts int     `json:"attempts"`
+	Correct  int     `json:"correct"`
+	Accuracy float64 `json:"accuracy"`
+	Score    int     `json:"score"`
+}
+
+// SummarizeActivities computes the default summary shared by the built-in engines
+func SummarizeActivities(activities []models.SessionActivity) *Summary {
+	summary := &Summary{Attempts: len(activities)}
+	if len(activities) == 0 {
+		return summary
+	}
+
+	total := 0
+	for _, activity := range activities {
+		if activity.IsSuccessful() {
+			summary.Correct++
+		}
+		total += activity.Score
+	}
+
+	summary.Accuracy = float64(summary.Correct) / float64(summary.Attempts)
+	summary.Score = total / summary.Attempts
+
+	return summary
+}
+
+// DecodeConfig unmarshals an activity's config into target, leaving defaults
+// untouched when no config is set
+func DecodeConfig(activity *models.StudyActivity, target interface{}) error {
+	if activity == nil || len(activity.Config) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(activity.Config, target); err != nil {
+		return fmt.Errorf("invalid %s config: %w", activity.Type, err)
+	}
+	return nil
+}
+
+// NewChallenge builds a challenge with a JSON encoded payload
+func NewChallenge(activityType, prompt string, payload interface{}) (*Challenge, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode challenge payload: %w", err)
+	}
+
+	return &Challenge{
+		Type:    activityType,
+		Prompt:  prompt,
+		Payload: data,
+	}, nil
+}
+
+// DecodePayload unmarshals a challenge payload into target
+func DecodePayload(challenge *Challenge, target interface{}) error {
+	if challenge == nil || len(challenge.Payload) == 0 {
+		return fmt.Errorf("%w: payload is missing", ErrInvalidChallenge)
+	}
+	if err := json.Unmarshal(challenge.Payload, target); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidChallenge, err)
+	}
+	return nil
+}
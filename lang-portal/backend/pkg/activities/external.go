@@ -0,0 +1,80 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// ExternalType is the activity type of activities hosted as separate apps
+const ExternalType = "external"
+
+// ErrExternalActivity is returned when a server-side challenge is requested
+// for an activity that runs in its own app
+var ErrExternalActivity = errors.New("challenges for external activities are handled by the activity app")
+
+// LaunchConfig is the configuration shared by activities that can be launched
+type LaunchConfig struct {
+	// LaunchURL is where the activity app is hosted
+	LaunchURL string `json:"launch_url"`
+}
+
+// LaunchURL returns the configured launch URL of an activity, if any
+func LaunchURL(activity *models.StudyActivity) (string, error) {
+	var cfg LaunchConfig
+	if err := DecodeConfig(activity, &cfg); err != nil {
+		return "", err
+	}
+	return cfg.LaunchURL, nil
+}
+
+// ExternalEngine represents activities that generate and grade challenges in
+// their own app, reporting results back through launch tokens
+type ExternalEngine struct{}
+
+// NewExternalEngine creates a new instance of ExternalEngine
+func NewExternalEngine() *ExternalEngine {
+	return &ExternalEngine{}
+}
+
+// Type returns the activity type of the engine
+func (e *ExternalEngine) Type() string {
+	return ExternalType
+}
+
+// ValidateConfig requires an absolute http(s) launch URL
+func (e *ExternalEngine) ValidateConfig(config json.RawMessage) error {
+	var cfg LaunchConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return err
+	}
+
+	launchURL, err := url.Parse(cfg.LaunchURL)
+	if err != nil || cfg.LaunchURL == "" {
+		return errors.New("launch_url is required")
+	}
+	if (launchURL.Scheme != "http" && launchURL.Scheme != "https") || launchURL.Host == "" {
+		return fmt.Errorf("launch_url must be an absolute http(s) URL")
+	}
+
+	return nil
+}
+
+// GenerateChallenge is not supported, the activity app owns its challenges
+func (e *ExternalEngine) GenerateChallenge(_ context.Context, _ ChallengeRequest) (*Challenge, error) {
+	return nil, ErrExternalActivity
+}
+
+// GradeAnswer is not supported, the activity app grades its own challenges
+func (e *ExternalEngine) GradeAnswer(_ context.Context, _ *Challenge, _ string) (*Grade, error) {
+	return nil, ErrExternalActivity
+}
+
+// SummarizeSession aggregates the results reported by the activity app
+func (e *ExternalEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
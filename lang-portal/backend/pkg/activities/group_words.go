@@ -0,0 +1,161 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// GroupWordsType is the activity type of the group words engine
+const GroupWordsType = "group_words"
+
+// maxGroupsListed bounds how many groups are considered as options
+const maxGroupsListed = 100
+
+// GroupSource is the subset of group storage the group words engine reads from
+type GroupSource interface {
+	List(ctx context.Context, page, pageSize int, search string) ([]models.Group, int, error)
+	CountWords(ctx context.Context, groupIDs []int64) (map[int64]int, error)
+	GetGroupsByWordID(ctx context.Context, wordID int64) ([]models.Group, error)
+}
+
+// GroupWordsConfig configures the group words engine
+type GroupWordsConfig struct {
+	// Options is the number of groups offered to choose from
+	Options int `json:"options"`
+}
+
+// GroupWordsEngine asks the learner which group a word belongs to
+type GroupWordsEngine struct {
+	words  WordSource
+	groups GroupSource
+}
+
+// NewGroupWordsEngine creates a new instance of GroupWordsEngine
+func NewGroupWordsEngine(words WordSource, groups GroupSource) *GroupWordsEngine {
+	return &GroupWordsEngine{words: words, groups: groups}
+}
+
+// Type returns the activity type of the engine
+func (e *GroupWordsEngine) Type() string {
+	return GroupWordsType
+}
+
+// ValidateConfig checks the number of offered groups
+func (e *GroupWordsEngine) ValidateConfig(config json.RawMessage) error {
+	var cfg GroupWordsConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return err
+	}
+	if cfg.Options != 0 && (cfg.Options < 2 || cfg.Options > 10) {
+		return errors.New("options must be between 2 and 10")
+	}
+	return nil
+}
+
+// GenerateChallenge picks a group with words and offers it among other groups
+func (e *GroupWordsEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	cfg := GroupWordsConfig{Options: 4}
+	if err := DecodeConfig(req.Activity, &cfg); err != nil {
+		return nil, err
+	}
+
+	groups, _, err := e.groups.List(ctx, 1, maxGroupsListed, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) < 2 {
+		return nil, errors.New("at least two groups are needed to group words")
+	}
+
+	rand.Shuffle(len(groups), func(i, j int) { groups[i], groups[j] = groups[j], groups[i] })
+
+	groupIDs := make([]int64, len(groups))
+	for i, group := range groups {
+		groupIDs[i] = group.ID
+	}
+	counts, err := e.groups.CountWords(ctx, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var answer *models.Group
+	for i := range groups {
+		if req.Session != nil && req.Session.GroupID != nil && groups[i].ID != *req.Session.GroupID {
+			continue
+		}
+		if counts[groups[i].ID] > 0 {
+			answer = &groups[i]
+			break
+		}
+	}
+	if answer == nil {
+		return nil, errors.New("no group with words found")
+	}
+
+	groupWords, err := e.words.GetWordsByGroupID(ctx, answer.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupWords) == 0 {
+		return nil, errors.New("no group with words found")
+	}
+	word := &groupWords[rand.Intn(len(groupWords))]
+
+	options := []string{answer.Name}
+	for _, group := range groups {
+		if len(options) >= cfg.Options {
+			break
+		}
+		if group.ID != answer.ID {
+			options = append(options, group.Name)
+		}
+	}
+	rand.Shuffle(len(options), func(i, j int) { options[i], options[j] = options[j], options[i] })
+
+	challenge, err := NewChallenge(GroupWordsType, word.Target, wordPayload{WordID: word.ID})
+	if err != nil {
+		return nil, err
+	}
+	challenge.Options = options
+	challenge.Hints = []string{word.Native}
+
+	return challenge, nil
+}
+
+// GradeAnswer accepts any offered group that actually contains the word
+func (e *GroupWordsEngine) GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload wordPayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	groups, err := e.groups.GetGroupsByWordID(ctx, payload.WordID)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberOf []string
+	correct := false
+	for _, group := range groups {
+		memberOf = append(memberOf, group.Name)
+		if strings.EqualFold(normalizeAnswer(input), group.Name) {
+			correct = true
+		}
+	}
+	if len(memberOf) == 0 {
+		return nil, fmt.Errorf("word %d does not belong to any group", payload.WordID)
+	}
+
+	return binaryGrade(correct, strings.Join(memberOf, ", ")), nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *GroupWordsEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
@@ -0,0 +1,161 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// ListeningType is the activity type of the listening comprehension engine
+const ListeningType = "listening"
+
+// ListeningSource is the subset of listening exercise storage the listening engine reads from
+type ListeningSource interface {
+	GetByID(ctx context.Context, id int64) (*models.ListeningExercise, error)
+	GetQuestion(ctx context.Context, id int64) (*models.ListeningQuestion, error)
+	GetRandomQuestion(ctx context.Context) (*models.ListeningQuestion, error)
+}
+
+// ListeningConfig configures the listening comprehension engine
+type ListeningConfig struct {
+	// ShowTranscript offers the transcript of the dialogue as a hint
+	ShowTranscript bool `json:"show_transcript"`
+
+	// ExerciseID is the exercise asked about, or a random one per session
+	ExerciseID int64 `json:"exercise_id,omitempty"`
+}
+
+// ListeningEngine plays a recorded dialogue and asks a multiple-choice
+// question about it
+type ListeningEngine struct {
+	exercises ListeningSource
+}
+
+type listeningPayload struct {
+	ExerciseID int64 `json:"exercise_id"`
+	QuestionID int64 `json:"question_id"`
+}
+
+// NewListeningEngine creates a new instance of ListeningEngine
+func NewListeningEngine(exercises ListeningSource) *ListeningEngine {
+	return &ListeningEngine{exercises: exercises}
+}
+
+// Type returns the activity type of the engine
+func (e *ListeningEngine) Type() string {
+	return ListeningType
+}
+
+// ValidateConfig checks that the config decodes with a valid exercise ID
+func (e *ListeningEngine) ValidateConfig(config json.RawMessage) error {
+	var cfg ListeningConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return err
+	}
+	if cfg.ExerciseID < 0 {
+		return errors.New("exercise_id must not be negative")
+	}
+	return nil
+}
+
+// GenerateChallenge asks a question of one exercise per session: the
+// configured one, or the exercise of the session's first challenge, picked at
+// random. Questions answered in the session are not asked again. The options
+// are shuffled and the introduction of the dialogue is the first hint.
+func (e *ListeningEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	var cfg ListeningConfig
+	if err := DecodeConfig(req.Activity, &cfg); err != nil {
+		return nil, err
+	}
+
+	exerciseID := cfg.ExerciseID
+	answered := make(map[int64]bool)
+	for _, issued := range req.Issued {
+		if issued.Type != ListeningType {
+			continue
+		}
+		var payload listeningPayload
+		if err := DecodePayload(&Challenge{Payload: issued.Payload}, &payload); err != nil {
+			return nil, err
+		}
+		if exerciseID == 0 {
+			exerciseID = payload.ExerciseID
+		}
+		if issued.AnsweredAt != nil {
+			answered[payload.QuestionID] = true
+		}
+	}
+	if exerciseID == 0 {
+		question, err := e.exercises.GetRandomQuestion(ctx)
+		if err != nil {
+			return nil, err
+		}
+		exerciseID = question.ExerciseID
+	}
+
+	exercise, err := e.exercises.GetByID(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+	var questions []models.ListeningQuestion
+	for _, question := range exercise.Questions {
+		if !answered[question.ID] {
+			questions = append(questions, question)
+		}
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("no questions of listening exercise %d left to ask: %w", exercise.ID, models.ErrNotFound)
+	}
+	question := questions[rand.Intn(len(questions))]
+
+	challenge, err := NewChallenge(ListeningType, question.Question, listeningPayload{
+		ExerciseID: exercise.ID,
+		QuestionID: question.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	challenge.Options = append([]string(nil), question.Options...)
+	rand.Shuffle(len(challenge.Options), func(i, j int) {
+		challenge.Options[i], challenge.Options[j] = challenge.Options[j], challenge.Options[i]
+	})
+	challenge.Audio = exercise.AudioURL
+	if exercise.Introduction != "" {
+		challenge.Hints = append(challenge.Hints, exercise.Introduction)
+	}
+	if cfg.ShowTranscript || exercise.AudioURL == "" {
+		// Without a recording the dialogue can only be read
+		challenge.Hints = append(challenge.Hints, exercise.Transcript)
+	}
+
+	return challenge, nil
+}
+
+// GradeAnswer accepts the option that answers the question
+func (e *ListeningEngine) GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload listeningPayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	question, err := e.exercises.GetQuestion(ctx, payload.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.ExerciseID != payload.ExerciseID {
+		return nil, fmt.Errorf("%w: question %d is not about exercise %d", ErrInvalidChallenge, question.ID, payload.ExerciseID)
+	}
+
+	return binaryGrade(strings.TrimSpace(input) == question.Answer, question.Answer), nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *ListeningEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
@@ -0,0 +1,192 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// MinimalPairsType is the activity type of the minimal pairs engine
+const MinimalPairsType = "minimal_pairs"
+
+// PairSource finds the minimal pairs among the words of a group, or among
+// all words when groupID is nil
+type PairSource interface {
+	FindPairs(ctx context.Context, groupID *int64) ([]models.MinimalPair, error)
+}
+
+// MinimalPairsConfig configures the minimal pairs engine
+type MinimalPairsConfig struct {
+	// Contrasts limits the drill to some contrasts, all are drilled when empty
+	Contrasts []string `json:"contrasts"`
+}
+
+// MinimalPairsEngine plays one word of a minimal pair, such as कल and खल,
+// and asks which of the two was heard
+type MinimalPairsEngine struct {
+	words WordSource
+	pairs PairSource
+}
+
+type minimalPairsPayload struct {
+	WordID      int64  `json:"word_id"`
+	OtherWordID int64  `json:"other_word_id"`
+	Contrast    string `json:"contrast"`
+}
+
+// contrastHints tell the learner what to listen for
+var contrastHints = map[string]string{
+	string(hindi.Aspiration):   "Listen for the puff of air after an aspirated consonant",
+	string(hindi.Retroflex):    "Listen for the tongue curled back in a retroflex consonant",
+	string(hindi.VowelLength):  "Listen for how long the vowel is held",
+	string(hindi.Nasalization): "Listen for a vowel sounded through the nose",
+}
+
+// NewMinimalPairsEngine creates a new instance of MinimalPairsEngine
+func NewMinimalPairsEngine(words WordSource, pairs PairSource) *MinimalPairsEngine {
+	return &MinimalPairsEngine{words: words, pairs: pairs}
+}
+
+// Type returns the activity type of the engine
+func (e *MinimalPairsEngine) Type() string {
+	return MinimalPairsType
+}
+
+// ValidateConfig checks that the config decodes and names known contrasts
+func (e *MinimalPairsEngine) ValidateConfig(config json.RawMessage) error {
+	var cfg MinimalPairsConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return err
+	}
+	for _, contrast := range cfg.Contrasts {
+		if !hindi.IsContrast(contrast) {
+			return fmt.Errorf("unknown contrast %q", contrast)
+		}
+	}
+	return nil
+}
+
+// GenerateChallenge picks a minimal pair of the session's group and plays
+// one of its words, offering both as options. Pairs with a recording are
+// preferred; without one the word's IPA is the prompt.
+func (e *MinimalPairsEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	var cfg MinimalPairsConfig
+	if err := DecodeConfig(req.Activity, &cfg); err != nil {
+		return nil, err
+	}
+
+	var groupID *int64
+	if req.Session != nil {
+		groupID = req.Session.GroupID
+	}
+	pairs, err := e.pairs.FindPairs(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates, recorded []models.MinimalPair
+	for _, pair := range pairs {
+		if !drillsContrast(cfg, pair.Contrast) {
+			continue
+		}
+		candidates = append(candidates, pair)
+		if pair.First.AudioAssetID != nil || pair.Second.AudioAssetID != nil {
+			recorded = append(recorded, pair)
+		}
+	}
+	if len(recorded) > 0 {
+		candidates = recorded
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no minimal pairs to drill: %w", models.ErrNotFound)
+	}
+
+	pair := candidates[rand.Intn(len(candidates))]
+	word, other := pair.First, pair.Second
+	if rand.Intn(2) == 1 {
+		word, other = other, word
+	}
+	if word.AudioAssetID == nil && other.AudioAssetID != nil {
+		word, other = other, word
+	}
+
+	prompt := "Which word do you hear?"
+	if word.AudioAssetID == nil {
+		prompt = fmt.Sprintf("Which word is pronounced /%s/?", wordIPA(&word))
+	}
+	challenge, err := NewChallenge(MinimalPairsType, prompt, minimalPairsPayload{
+		WordID:      word.ID,
+		OtherWordID: other.ID,
+		Contrast:    pair.Contrast,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	challenge.Options = []string{word.Target, other.Target}
+	rand.Shuffle(len(challenge.Options), func(i, j int) {
+		challenge.Options[i], challenge.Options[j] = challenge.Options[j], challenge.Options[i]
+	})
+	challenge.AudioAssetID = word.AudioAssetID
+	if hint, ok := contrastHints[pair.Contrast]; ok {
+		challenge.Hints = append(challenge.Hints, hint)
+	}
+
+	return challenge, nil
+}
+
+// GradeAnswer accepts the word that was played. A wrong answer is told
+// how the two words sound different.
+func (e *MinimalPairsEngine) GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload minimalPairsPayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	word, err := e.words.GetByID(ctx, payload.WordID)
+	if err != nil {
+		return nil, err
+	}
+
+	grade := binaryGrade(normalizeAnswer(input) == word.Target, word.Target)
+	if !grade.Correct {
+		other, err := e.words.GetByID(ctx, payload.OtherWordID)
+		if err != nil {
+			return nil, err
+		}
+		grade.Feedback = fmt.Sprintf("It was %s /%s/, not %s /%s/",
+			word.Target, wordIPA(word), other.Target, wordIPA(other))
+	}
+
+	return grade, nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *MinimalPairsEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
+
+// drillsContrast reports whether the config drills a contrast
+func drillsContrast(cfg MinimalPairsConfig, contrast string) bool {
+	if len(cfg.Contrasts) == 0 {
+		return true
+	}
+	for _, drilled := range cfg.Contrasts {
+		if drilled == contrast {
+			return true
+		}
+	}
+	return false
+}
+
+// wordIPA is the stored pronunciation of a word, generated when it has none
+func wordIPA(word *models.Word) string {
+	if word.IPA != "" {
+		return word.IPA
+	}
+	return hindi.IPA(word.Target)
+}
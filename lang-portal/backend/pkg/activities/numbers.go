@@ -0,0 +1,227 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// NumbersType is the activity type of the numbers engine
+const NumbersType = "numbers"
+
+// Modes of the numbers engine
+const (
+	// DigitToWord shows a number in digits and asks for its Hindi word
+	DigitToWord = "digit_to_word"
+	// WordToDigit shows a Hindi number word and asks for its digits
+	WordToDigit = "word_to_digit"
+	// DigitScripts shows a number in Devanagari or Arabic digits and asks
+	// for it in the other
+	DigitScripts = "digits"
+	// NumberDictation speaks a number and asks for it in digits or words
+	NumberDictation = "dictation"
+)
+
+// NumberModes lists the modes of the numbers engine
+var NumberModes = []string{DigitToWord, WordToDigit, DigitScripts, NumberDictation}
+
+// Speaker speaks a text aloud, returning the audio asset
+type Speaker interface {
+	Synthesize(ctx context.Context, text, language string) (*models.Asset, error)
+}
+
+// NumbersConfig configures the numbers engine
+type NumbersConfig struct {
+	// Modes limits the drill to some modes, all are drilled when empty
+	Modes []string `json:"modes"`
+	// Min and Max are the range of numbers drilled, 1 to 100 by default
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// NumbersEngine drills the Hindi numbers from 0 to 100, generated by
+// hindi.Numerals rather than stored as words
+type NumbersEngine struct {
+	speaker Speaker
+}
+
+type numbersPayload struct {
+	Mode       string `json:"mode"`
+	Value      int    `json:"value"`
+	Devanagari bool   `json:"devanagari,omitempty"` // the digits mode asks for Devanagari digits
+}
+
+// NewNumbersEngine creates a new instance of NumbersEngine. Without a
+// speaker the dictation mode is left out.
+func NewNumbersEngine(speaker Speaker) *NumbersEngine {
+	return &NumbersEngine{speaker: speaker}
+}
+
+// Type returns the activity type of the engine
+func (e *NumbersEngine) Type() string {
+	return NumbersType
+}
+
+// ValidateConfig checks that the config decodes, names known modes and
+// has a range within 0 to 100
+func (e *NumbersEngine) ValidateConfig(config json.RawMessage) error {
+	var cfg NumbersConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return err
+	}
+	for _, mode := range cfg.Modes {
+		if !isNumberMode(mode) {
+			return fmt.Errorf("unknown mode %q", mode)
+		}
+	}
+	if cfg.Min < 0 || cfg.Max < 0 || cfg.Min > hindi.MaxNumber || cfg.Max > hindi.MaxNumber {
+		return fmt.Errorf("numbers must be within 0 and %d", hindi.MaxNumber)
+	}
+	if cfg.Max != 0 && cfg.Min > cfg.Max {
+		return fmt.Errorf("min %d is above max %d", cfg.Min, cfg.Max)
+	}
+	return nil
+}
+
+// GenerateChallenge picks a number of the configured range and one of the
+// configured modes. Dictations are spoken by the speaker, and left out when
+// there is none.
+func (e *NumbersEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	var cfg NumbersConfig
+	if err := DecodeConfig(req.Activity, &cfg); err != nil {
+		return nil, err
+	}
+
+	modes := cfg.Modes
+	if len(modes) == 0 {
+		modes = NumberModes
+	}
+	var available []string
+	for _, mode := range modes {
+		if mode != NumberDictation || e.speaker != nil {
+			available = append(available, mode)
+		}
+	}
+	if len(available) == 0 {
+		return nil, fmt.Errorf("number dictation: %w", models.ErrNotConfigured)
+	}
+
+	from, to := cfg.Min, cfg.Max
+	if from == 0 && to == 0 {
+		from = 1
+	}
+	if to == 0 {
+		to = hindi.MaxNumber
+	}
+	numerals := hindi.Numerals(from, to)
+	if len(numerals) == 0 {
+		return nil, fmt.Errorf("no numbers from %d to %d: %w", from, to, models.ErrInvalidInput)
+	}
+
+	numeral := numerals[rand.Intn(len(numerals))]
+	payload := numbersPayload{Mode: available[rand.Intn(len(available))], Value: numeral.Value}
+	arabic := strconv.Itoa(numeral.Value)
+
+	var prompt string
+	var hints []string
+	switch payload.Mode {
+	case DigitToWord:
+		prompt = fmt.Sprintf("Write %s in Hindi words", arabic)
+		hints = []string{numeral.Digits}
+	case WordToDigit:
+		prompt = fmt.Sprintf("Write %s in digits", numeral.Word)
+		hints = []string{hindi.Romanize(numeral.Word, hindi.Hinglish)}
+	case DigitScripts:
+		payload.Devanagari = rand.Intn(2) == 1
+		if payload.Devanagari {
+			prompt = fmt.Sprintf("Write %s in Devanagari digits", arabic)
+		} else {
+			prompt = fmt.Sprintf("Write %s in Arabic digits", numeral.Digits)
+		}
+		hints = []string{numeral.Word}
+	case NumberDictation:
+		prompt = "Write the number you hear"
+	}
+
+	challenge, err := NewChallenge(NumbersType, prompt, payload)
+	if err != nil {
+		return nil, err
+	}
+	challenge.Hints = hints
+
+	if payload.Mode == NumberDictation {
+		asset, err := e.speaker.Synthesize(ctx, numeral.Word, hindi.Code)
+		if err != nil {
+			return nil, err
+		}
+		challenge.Audio = models.AssetURL(asset.ID)
+	}
+
+	return challenge, nil
+}
+
+// GradeAnswer accepts the Hindi word of the number when words are asked
+// for, and its digits in either system when digits are asked for. The
+// digits mode only accepts the system it asks for, dictations accept
+// either digits or the word.
+func (e *NumbersEngine) GradeAnswer(_ context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload numbersPayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	word, ok := hindi.NumberWord(payload.Value)
+	if !ok {
+		return nil, fmt.Errorf("number %d is out of range: %w", payload.Value, ErrInvalidChallenge)
+	}
+	arabic := strconv.Itoa(payload.Value)
+	devanagari := hindi.DevanagariDigits(arabic)
+
+	var correct bool
+	var expected string
+	switch payload.Mode {
+	case DigitToWord:
+		n, ok := hindi.ParseNumberWord(normalizeAnswer(input))
+		correct, expected = ok && n == payload.Value, word
+	case WordToDigit:
+		n, ok := hindi.ParseDigits(compactAnswer(input))
+		correct, expected = ok && n == payload.Value, arabic
+	case DigitScripts:
+		expected = arabic
+		if payload.Devanagari {
+			expected = devanagari
+		}
+		correct = compactAnswer(input) == expected
+	case NumberDictation:
+		n, ok := hindi.ParseNumber(normalizeAnswer(input))
+		correct, expected = ok && n == payload.Value, arabic
+	default:
+		return nil, fmt.Errorf("unknown mode %q: %w", payload.Mode, ErrInvalidChallenge)
+	}
+
+	grade := binaryGrade(correct, expected)
+	if !correct {
+		grade.Feedback = fmt.Sprintf("%s is %s, %s", arabic, word, devanagari)
+	}
+	return grade, nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *NumbersEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
+
+// isNumberMode reports whether mode is one of NumberModes
+func isNumberMode(mode string) bool {
+	for _, known := range NumberModes {
+		if known == mode {
+			return true
+		}
+	}
+	return false
+}
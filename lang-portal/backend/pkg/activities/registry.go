@@ -0,0 +1,67 @@
+package activities
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Registry holds the activity engines keyed by activity type
+type Registry struct {
+	mu      sync.RWMutex
+	engines map[string]ActivityEngine
+}
+
+// NewRegistry creates a registry with the given engines registered
+func NewRegistry(engines ...ActivityEngine) (*Registry, error) {
+	r := &Registry{engines: make(map[string]ActivityEngine)}
+	for _, engine := range engines {
+		if err := r.Register(engine); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds an engine, rejecting duplicate activity types
+func (r *Registry) Register(engine ActivityEngine) error {
+	if engine == nil || engine.Type() == "" {
+		return fmt.Errorf("activity engine must have a type")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.engines[engine.Type()]; exists {
+		return fmt.Errorf("activity engine %q is already registered", engine.Type())
+	}
+	r.engines[engine.Type()] = engine
+
+	return nil
+}
+
+// Get returns the engine registered for an activity type
+func (r *Registry) Get(activityType string) (ActivityEngine, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	engine, ok := r.engines[activityType]
+	if !ok {
+		return nil, fmt.Errorf("no activity engine registered for type %q", activityType)
+	}
+	return engine, nil
+}
+
+// Types lists the registered activity types in alphabetical order
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]string, 0, len(r.engines))
+	for activityType := range r.engines {
+		types = append(types, activityType)
+	}
+	sort.Strings(types)
+
+	return types
+}
@@ -0,0 +1,136 @@
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// SentenceOrderType is the activity type of the sentence-ordering engine
+const SentenceOrderType = "sentence_order"
+
+// SentenceOrderConfig configures the sentence-ordering engine
+type SentenceOrderConfig struct {
+	// AttachPostpositions keeps Hindi postpositions with the word before them,
+	// so "नदी का" is one chunk instead of two
+	AttachPostpositions bool `json:"attach_postpositions"`
+}
+
+// SentenceOrderEngine shuffles the chunks of an example sentence and asks the
+// learner to put them back in order
+type SentenceOrderEngine struct {
+	words     WordSource
+	sentences SentenceSource
+}
+
+// NewSentenceOrderEngine creates a new instance of SentenceOrderEngine
+func NewSentenceOrderEngine(words WordSource, sentences SentenceSource) *SentenceOrderEngine {
+	return &SentenceOrderEngine{words: words, sentences: sentences}
+}
+
+// Type returns the activity type of the engine
+func (e *SentenceOrderEngine) Type() string {
+	return SentenceOrderType
+}
+
+// ValidateConfig checks that the config decodes
+func (e *SentenceOrderEngine) ValidateConfig(config json.RawMessage) error {
+	var cfg SentenceOrderConfig
+	return json.Unmarshal(config, &cfg)
+}
+
+// GenerateChallenge picks a sentence, restricted to the words of the session's
+// group if set, and offers its chunks shuffled. The prompt is the translation.
+func (e *SentenceOrderEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	var cfg SentenceOrderConfig
+	if err := DecodeConfig(req.Activity, &cfg); err != nil {
+		return nil, err
+	}
+
+	wordIDs, err := groupWordIDs(ctx, e.words, req.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < maxSentenceAttempts; attempt++ {
+		sentence, err := e.sentences.GetRandomForWords(ctx, wordIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		chunks := models.SentenceWords(sentence.Target)
+		if cfg.AttachPostpositions && sentence.Language == hindi.Code {
+			chunks = hindi.Chunk(chunks)
+		}
+		if len(chunks) < 2 {
+			continue
+		}
+
+		challenge, err := NewChallenge(SentenceOrderType, sentence.Native, sentencePayload{SentenceID: sentence.ID})
+		if err != nil {
+			return nil, err
+		}
+		challenge.Options = shuffleChunks(chunks, sentence)
+
+		return challenge, nil
+	}
+
+	return nil, errors.New("no sentence found with words to order")
+}
+
+// GradeAnswer accepts the sentence in its own word order or in any of its
+// alternative orders, ignoring punctuation and spacing
+func (e *SentenceOrderEngine) GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload sentencePayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	sentence, err := e.sentences.GetByID(ctx, payload.SentenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return binaryGrade(isValidOrder(input, sentence), sentence.Target), nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *SentenceOrderEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
+
+// shuffleChunks shuffles the chunks, avoiding an order that already solves
+// the sentence when another one is possible
+func shuffleChunks(chunks []string, sentence *models.Sentence) []string {
+	shuffled := make([]string, len(chunks))
+	for attempt := 0; attempt < maxSentenceAttempts; attempt++ {
+		for i, j := range rand.Perm(len(chunks)) {
+			shuffled[i] = chunks[j]
+		}
+		if !isValidOrder(strings.Join(shuffled, " "), sentence) {
+			break
+		}
+	}
+	return shuffled
+}
+
+// isValidOrder reports whether text is the sentence in one of its valid orders
+func isValidOrder(text string, sentence *models.Sentence) bool {
+	answer := orderKey(text)
+	for _, order := range append([]string{sentence.Target}, sentence.Alternatives...) {
+		if answer == orderKey(order) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderKey reduces a sentence to its words in order
+func orderKey(text string) string {
+	return hindi.Normalize(strings.Join(models.SentenceWords(text), " "))
+}
@@ -0,0 +1,53 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// maxSentenceAttempts bounds the sentences tried before giving up on a challenge
+const maxSentenceAttempts = 5
+
+// SentenceSource is the subset of sentence storage the sentence-based engines read from
+type SentenceSource interface {
+	GetByID(ctx context.Context, id int64) (*models.Sentence, error)
+	GetRandomForWords(ctx context.Context, wordIDs []int64) (*models.Sentence, error)
+}
+
+// sentencePayload references the sentence a challenge was built from
+type sentencePayload struct {
+	SentenceID int64 `json:"sentence_id"`
+}
+
+// groupWordIDs returns the IDs of the words in the session's group, or nil
+// when the session is not restricted to a group
+func groupWordIDs(ctx context.Context, words WordSource, session *models.Session) ([]int64, error) {
+	if session == nil || session.GroupID == nil {
+		return nil, nil
+	}
+
+	groupWords, err := words.GetWordsByGroupID(ctx, *session.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupWords) == 0 {
+		return nil, fmt.Errorf("no words found in group %d", *session.GroupID)
+	}
+
+	ids := make([]int64, len(groupWords))
+	for i, word := range groupWords {
+		ids[i] = word.ID
+	}
+	return ids, nil
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// SpeakingType is the activity type of the speaking practice engine
+const SpeakingType = "speaking"
+
+// SpeakingPassScore is the similarity a transcript needs to count as correct,
+// speech recognizers rarely spell a short word exactly as the portal does
+const SpeakingPassScore = 80
+
+// SpeakingEngine asks the learner to say a word aloud. The recording is
+// transcribed by a speech recognizer and the transcript is graded by how
+// close it is to the word.
+type SpeakingEngine struct {
+	words WordSource
+}
+
+// SpeakingPayload references the word of a speaking challenge and the
+// language its recording is transcribed in
+type SpeakingPayload struct {
+	WordID   int64  `json:"word_id"`
+	Language string `json:"language"`
+}
+
+// NewSpeakingEngine creates a new instance of SpeakingEngine
+func NewSpeakingEngine(words WordSource) *SpeakingEngine {
+	return &SpeakingEngine{words: words}
+}
+
+// Type returns the activity type of the engine
+func (e *SpeakingEngine) Type() string {
+	return SpeakingType
+}
+
+// GenerateChallenge picks a word to say, with its pronunciation to listen
+// to when it has one. Its romanization and translation are the hints.
+func (e *SpeakingEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	word, err := pickWord(ctx, e.words, req.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := NewChallenge(SpeakingType, word.Target, SpeakingPayload{
+		WordID:   word.ID,
+		Language: word.Language,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, hint := range []string{word.Romanized, word.Native} {
+		if hint != "" {
+			challenge.Hints = append(challenge.Hints, hint)
+		}
+	}
+	challenge.Audio = word.AudioURL
+
+	return challenge, nil
+}
+
+// GradeAnswer scores the transcript of the learner's recording by its
+// similarity to the word, ignoring spelling variants a recognizer produces
+func (e *SpeakingEngine) GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload SpeakingPayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	word, err := e.words.GetByID(ctx, payload.WordID)
+	if err != nil {
+		return nil, err
+	}
+
+	transcript := normalizeAnswer(input)
+	grade := &Grade{Expected: word.Target}
+	if transcript != "" {
+		grade.Score = devanagari.Similarity(transcript, word.Target)
+	}
+	grade.Correct = grade.Score >= SpeakingPassScore
+	if grade.Score < 100 {
+		grade.Feedback = heard(transcript)
+	}
+
+	return grade, nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *SpeakingEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
+
+// heard tells the learner what the recognizer understood
+func heard(transcript string) string {
+	if transcript == "" {
+		return "No speech was recognized in the recording"
+	}
+	return fmt.Sprintf("Heard %q", transcript)
+}
@@ -0,0 +1,45 @@
+package activities
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// TutorType is the activity type of conversations with the sentence tutor
+const TutorType = "tutor"
+
+// ErrTutorActivity is returned when a challenge is requested for a tutor
+// activity, which is practised in a tutor conversation instead
+var ErrTutorActivity = errors.New("tutor activities are practised in tutor conversations")
+
+// TutorEngine represents activities where a language model coaches the
+// learner through translating a sentence, attempts are graded by the tutor
+// conversation and recorded in the session
+type TutorEngine struct{}
+
+// NewTutorEngine creates a new instance of TutorEngine
+func NewTutorEngine() *TutorEngine {
+	return &TutorEngine{}
+}
+
+// Type returns the activity type of the engine
+func (e *TutorEngine) Type() string {
+	return TutorType
+}
+
+// GenerateChallenge is not supported, conversations present the sentences
+func (e *TutorEngine) GenerateChallenge(_ context.Context, _ ChallengeRequest) (*Challenge, error) {
+	return nil, ErrTutorActivity
+}
+
+// GradeAnswer is not supported, conversations grade the attempts
+func (e *TutorEngine) GradeAnswer(_ context.Context, _ *Challenge, _ string) (*Grade, error) {
+	return nil, ErrTutorActivity
+}
+
+// SummarizeSession aggregates the attempts made in the session's conversations
+func (e *TutorEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
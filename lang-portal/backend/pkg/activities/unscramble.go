@@ -0,0 +1,85 @@
+package activities
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// UnscrambleType is the activity type of the unscramble engine
+const UnscrambleType = "unscramble"
+
+// UnscrambleEngine asks the learner to rearrange a scrambled target word
+type UnscrambleEngine struct {
+	words WordSource
+}
+
+// NewUnscrambleEngine creates a new instance of UnscrambleEngine
+func NewUnscrambleEngine(words WordSource) *UnscrambleEngine {
+	return &UnscrambleEngine{words: words}
+}
+
+// Type returns the activity type of the engine
+func (e *UnscrambleEngine) Type() string {
+	return UnscrambleType
+}
+
+// GenerateChallenge picks a word and presents its aksharas shuffled
+func (e *UnscrambleEngine) GenerateChallenge(ctx context.Context, req ChallengeRequest) (*Challenge, error) {
+	word, err := pickWord(ctx, e.words, req.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := NewChallenge(UnscrambleType, scrambleAksharas(word.Target), wordPayload{WordID: word.ID})
+	if err != nil {
+		return nil, err
+	}
+	challenge.Hints = []string{word.Native}
+
+	return challenge, nil
+}
+
+// GradeAnswer checks the input against the original word
+func (e *UnscrambleEngine) GradeAnswer(ctx context.Context, challenge *Challenge, input string) (*Grade, error) {
+	var payload wordPayload
+	if err := DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	word, err := e.words.GetByID(ctx, payload.WordID)
+	if err != nil {
+		return nil, err
+	}
+
+	return binaryGrade(compactAnswer(input) == compactAnswer(word.Target), word.Target), nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (e *UnscrambleEngine) SummarizeSession(_ context.Context, activities []models.SessionActivity) (*Summary, error) {
+	return SummarizeActivities(activities), nil
+}
+
+// scrambleAksharas shuffles whole aksharas so matras stay on their consonants
+func scrambleAksharas(word string) string {
+	aksharas := devanagari.Aksharas(word)
+	if len(aksharas) < 2 {
+		return word
+	}
+
+	scrambled := make([]string, len(aksharas))
+	for attempt := 0; attempt < 5; attempt++ {
+		copy(scrambled, aksharas)
+		rand.Shuffle(len(scrambled), func(i, j int) {
+			scrambled[i], scrambled[j] = scrambled[j], scrambled[i]
+		})
+		if strings.Join(scrambled, "") != word {
+			break
+		}
+	}
+
+	return strings.Join(scrambled, " ")
+}
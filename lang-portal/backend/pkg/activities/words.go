@@ -0,0 +1,59 @@
+package activities
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// WordSource is the subset of word storage the word-based engines read from
+type WordSource interface {
+	GetByID(ctx context.Context, id int64) (*models.Word, error)
+	GetRandomWord(ctx context.Context) (*models.Word, error)
+	GetWordsByGroupID(ctx context.Context, groupID int64) ([]models.Word, error)
+}
+
+// wordPayload references the word a challenge was built from
+type wordPayload struct {
+	WordID int64 `json:"word_id"`
+}
+
+// pickWord selects a random word, restricted to the session's group if set
+func pickWord(ctx context.Context, words WordSource, session *models.Session) (*models.Word, error) {
+	if session == nil || session.GroupID == nil {
+		return words.GetRandomWord(ctx)
+	}
+
+	groupWords, err := words.GetWordsByGroupID(ctx, *session.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupWords) == 0 {
+		return nil, fmt.Errorf("no words found in group %d", *session.GroupID)
+	}
+
+	return &groupWords[rand.Intn(len(groupWords))], nil
+}
+
+// normalizeAnswer trims and collapses whitespace so that answers compare
+// independently of how the learner spaced them
+func normalizeAnswer(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// compactAnswer drops all whitespace, for single-word answers typed as tiles
+func compactAnswer(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+// binaryGrade builds a full-or-nothing grade
+func binaryGrade(correct bool, expected string) *Grade {
+	grade := &Grade{Correct: correct, Expected: expected}
+	if correct {
+		grade.Score = 100
+	}
+	return grade
+}
@@ -0,0 +1,147 @@
+// Package ai talks to large language models behind an interface, so a hosted
+// API can be swapped for a model served locally by Ollama
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrProvider is returned when the model service cannot be reached or fails
+var ErrProvider = errors.New("language model request failed")
+
+// ErrInvalidOutput is returned when the model answers with something other
+// than the requested structure
+var ErrInvalidOutput = errors.New("language model returned unusable output")
+
+// defaultTimeout bounds a request when the config does not set a timeout
+const defaultTimeout = 60 * time.Second
+
+// maxResponseBytes limits the response read from a model service
+const maxResponseBytes = 1 << 20
+
+// Roles of the messages in a conversation with a model
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message is one turn of a conversation with a model
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// CompletionRequest asks a model to continue a conversation
+type CompletionRequest struct {
+	Messages    []Message
+	JSON        bool    // constrains the answer to a JSON object where the service supports it
+	Temperature float64 // zero leaves the service default
+}
+
+// LLMProvider completes conversations with a large language model
+type LLMProvider interface {
+	// Model identifies the service and model, for reviewers of generated content
+	Model() string
+
+	// Complete returns the model's answer to the conversation
+	Complete(ctx context.Context, req CompletionRequest) (string, error)
+}
+
+// HTTPConfig configures a model service reached over HTTP
+type HTTPConfig struct {
+	URL     string
+	Model   string
+	APIKey  string // sent as a bearer token when set
+	Timeout time.Duration
+}
+
+// CompleteJSON completes the conversation and decodes the JSON object the
+// model answers with into out
+func CompleteJSON(ctx context.Context, provider LLMProvider, messages []Message, out interface{}) error {
+	answer, err := provider.Complete(ctx, CompletionRequest{Messages: messages, JSON: true})
+	if err != nil {
+		return err
+	}
+
+	object := extractObject(answer)
+	if object == "" {
+		return fmt.Errorf("%w: no JSON object in %q", ErrInvalidOutput, snippet(answer))
+	}
+	if err := json.Unmarshal([]byte(object), out); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOutput, err)
+	}
+	return nil
+}
+
+// extractObject returns the outermost JSON object in an answer, small models
+// like to wrap it in Markdown code fences or explanations
+func extractObject(answer string) string {
+	start := strings.Index(answer, "{")
+	end := strings.LastIndex(answer, "}")
+	if start < 0 || end < start {
+		return ""
+	}
+	return answer[start : end+1]
+}
+
+// httpClient returns a client with the configured timeout
+func httpClient(cfg HTTPConfig) *http.Client {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// postJSON posts body and decodes the JSON response into out
+func postJSON(ctx context.Context, client *http.Client, url, apiKey string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build model request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProvider, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("%w: failed to read response: %v", ErrProvider, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: service returned %s: %s", ErrProvider, resp.Status, snippet(string(data)))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: malformed response: %v", ErrProvider, err)
+	}
+	return nil
+}
+
+// snippet shortens text for an error message
+func snippet(text string) string {
+	text = strings.TrimSpace(text)
+	if len(text) > 200 {
+		text = text[:200] + "…"
+	}
+	return text
+}
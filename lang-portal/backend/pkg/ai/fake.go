@@ -0,0 +1,56 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// FakeLLM is an LLMProvider that answers with scripted responses, for tests
+// and for working on the portal without a model
+type FakeLLM struct {
+	mu        sync.Mutex
+	responses []string
+	requests  []CompletionRequest
+}
+
+// NewFakeLLM creates a FakeLLM answering with the responses in order
+func NewFakeLLM(responses ...string) *FakeLLM {
+	return &FakeLLM{responses: responses}
+}
+
+// Model identifies the fake
+func (f *FakeLLM) Model() string {
+	return "fake"
+}
+
+// Respond queues more responses
+func (f *FakeLLM) Respond(responses ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses = append(f.responses, responses...)
+}
+
+// Complete records the request and returns the next response
+func (f *FakeLLM) Complete(ctx context.Context, req CompletionRequest) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	if len(f.responses) == 0 {
+		return "", fmt.Errorf("%w: fake has no response left", ErrProvider)
+	}
+	response := f.responses[0]
+	f.responses = f.responses[1:]
+	return response, nil
+}
+
+// Requests returns the requests received so far
+func (f *FakeLLM) Requests() []CompletionRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]CompletionRequest(nil), f.requests...)
+}
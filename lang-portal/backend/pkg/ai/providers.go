@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OpenAIChat completes conversations with an OpenAI-compatible
+// /chat/completions endpoint, as served by OpenAI, vLLM and Ollama's /v1 API
+type OpenAIChat struct {
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+// NewOpenAIChat creates a new instance of OpenAIChat. The URL is the API
+// base, such as "http://localhost:8008/v1".
+func NewOpenAIChat(cfg HTTPConfig) *OpenAIChat {
+	return &OpenAIChat{cfg: cfg, client: httpClient(cfg)}
+}
+
+type openAIChatRequest struct {
+	Model          string            `json:"model"`
+	Messages       []Message         `json:"messages"`
+	Temperature    float64           `json:"temperature,omitempty"`
+	ResponseFormat map[string]string `json:"response_format,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message Message `json:"message"`
+	} `json:"choices"`
+}
+
+// Model identifies the model
+func (p *OpenAIChat) Model() string {
+	return "openai:" + p.cfg.Model
+}
+
+// Complete returns the first choice of the model
+func (p *OpenAIChat) Complete(ctx context.Context, req CompletionRequest) (string, error) {
+	body := openAIChatRequest{
+		Model:       p.cfg.Model,
+		Messages:    req.Messages,
+		Temperature: req.Temperature,
+	}
+	if req.JSON {
+		body.ResponseFormat = map[string]string{"type": "json_object"}
+	}
+
+	var resp openAIChatResponse
+	url := strings.TrimSuffix(p.cfg.URL, "/") + "/chat/completions"
+	if err := postJSON(ctx, p.client, url, p.cfg.APIKey, body, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("%w: response has no choices", ErrProvider)
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+// Ollama completes conversations with the native /api/chat endpoint of an
+// Ollama server, such as the one in opea-comps
+type Ollama struct {
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+// NewOllama creates a new instance of Ollama. The URL is the server address,
+// such as "http://localhost:8008".
+func NewOllama(cfg HTTPConfig) *Ollama {
+	return &Ollama{cfg: cfg, client: httpClient(cfg)}
+}
+
+type ollamaChatRequest struct {
+	Model    string             `json:"model"`
+	Messages []Message          `json:"messages"`
+	Stream   bool               `json:"stream"`
+	Format   string             `json:"format,omitempty"`
+	Options  map[string]float64 `json:"options,omitempty"`
+}
+
+type ollamaChatResponse struct {
+	Message Message `json:"message"`
+}
+
+// Model identifies the model
+func (p *Ollama) Model() string {
+	return "ollama:" + p.cfg.Model
+}
+
+// Complete returns the model's answer in one response
+func (p *Ollama) Complete(ctx context.Context, req CompletionRequest) (string, error) {
+	body := ollamaChatRequest{
+		Model:    p.cfg.Model,
+		Messages: req.Messages,
+	}
+	if req.JSON {
+		body.Format = "json"
+	}
+	if req.Temperature != 0 {
+		body.Options = map[string]float64{"temperature": req.Temperature}
+	}
+
+	var resp ollamaChatResponse
+	url := strings.TrimSuffix(p.cfg.URL, "/") + "/api/chat"
+	if err := postJSON(ctx, p.client, url, p.cfg.APIKey, body, &resp); err != nil {
+		return "", err
+	}
+	return resp.Message.Content, nil
+}
@@ -0,0 +1,60 @@
+// Package devanagari provides script-level helpers for Devanagari text
+package devanagari
+
+import "unicode"
+
+const (
+	// Virama (halant) suppresses the inherent vowel of a consonant
+	Virama = '्'
+	// Nukta modifies a consonant, as in क़ or ज़
+	Nukta = '़'
+	// ZWJ and ZWNJ control conjunct formation between consonants
+	ZWJ  = '\u200d'
+	ZWNJ = '\u200c'
+)
+
+// IsConsonant reports whether r is a Devanagari consonant
+func IsConsonant(r rune) bool {
+	return (r >= 'क' && r <= 'ह') || (r >= 'क़' && r <= 'य़')
+}
+
+// IsIndependentVowel reports whether r is a standalone Devanagari vowel
+func IsIndependentVowel(r rune) bool {
+	return (r >= 'ऄ' && r <= 'औ') || r == 'ॠ' || r == 'ॡ'
+}
+
+// IsMark reports whether r combines with the preceding character,
+// covering matras, nukta, virama and the nasal and visarga signs
+func IsMark(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Mc) || r == ZWJ || r == ZWNJ
+}
+
+// Aksharas splits text into orthographic syllables. Consonant clusters
+// joined by a virama stay together with their matras and nasal signs,
+// so "क्षमा" yields ["क्ष", "मा"] and "दिन" yields ["दि", "न"].
+func Aksharas(s string) []string {
+	var aksharas []string
+	var current []rune
+
+	for _, r := range s {
+		if len(current) > 0 && !joinsPrevious(r, current[len(current)-1]) {
+			aksharas = append(aksharas, string(current))
+			current = current[:0]
+		}
+		current = append(current, r)
+	}
+
+	if len(current) > 0 {
+		aksharas = append(aksharas, string(current))
+	}
+
+	return aksharas
+}
+
+func joinsPrevious(r, previous rune) bool {
+	if IsMark(r) {
+		return true
+	}
+	// A consonant following a virama forms a conjunct with it
+	return (previous == Virama || previous == ZWJ) && IsConsonant(r)
+}
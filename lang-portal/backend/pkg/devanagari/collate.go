@@ -0,0 +1,124 @@
+package devanagari
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Visarga is the breath after a vowel, as in दुःख
+const Visarga = 'ः'
+
+// Collation weights. Within a syllable the nasal signs come first, so
+// कंघा is listed before कई, then the inherent vowel and the matras in the
+// order of the varnamala, then the virama, so that conjuncts such as क्या
+// follow कौन. Vowels come before consonants.
+const (
+	weightSpace = iota
+	weightChandrabindu
+	weightAnusvara
+	weightVisarga
+	weightInherent
+	weightVirama = weightInherent + 18
+	weightNukta  = weightVirama + 1
+	weightVowel  = weightNukta + 1
+	// weightConsonant leaves room for the independent vowels
+	weightConsonant = weightVowel + 20
+	// weightOther orders characters that are not Devanagari letters after
+	// them, by code point
+	weightOther = weightConsonant + 64
+)
+
+// matraWeights orders the matras after the inherent vowel
+var matraWeights = map[rune]int{
+	'ा': 1, 'ि': 2, 'ी': 3, 'ु': 4, 'ू': 5, 'ृ': 6, 'ॄ': 7, 'ॢ': 8, 'ॣ': 9,
+	'ॆ': 10, 'े': 11, 'ै': 12, 'ॅ': 13, 'ॊ': 14, 'ो': 15, 'ौ': 16, 'ॉ': 17,
+}
+
+// vowelWeights orders the independent vowels as the varnamala does, with
+// the long vocalic ॠ and ॡ after their short vowels and the vowels of
+// borrowed words after the vowels of their matras
+var vowelWeights = map[rune]int{
+	'अ': 1, 'ऄ': 2, 'आ': 3, 'इ': 4, 'ई': 5, 'उ': 6, 'ऊ': 7, 'ऋ': 8, 'ॠ': 9, 'ऌ': 10, 'ॡ': 11,
+	'ऎ': 12, 'ए': 13, 'ऐ': 14, 'ऍ': 15, 'ऒ': 16, 'ओ': 17, 'औ': 18, 'ऑ': 19,
+}
+
+// Compare orders two texts as a Hindi dictionary does, returning -1, 0 or
+// +1. Letters follow the varnamala, a conjunct is listed under its first
+// consonant after the syllables of that consonant with a vowel, and a
+// consonant with a nukta is listed with the consonant, after it when the
+// texts are otherwise equal. Joiners are ignored.
+func Compare(a, b string) int {
+	if c := slices.Compare(collationKey(a, false), collationKey(b, false)); c != 0 {
+		return c
+	}
+	if c := slices.Compare(collationKey(a, true), collationKey(b, true)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// collationKey returns the weights text is ordered by, leaving out nuktas
+// unless they are asked for
+func collationKey(text string, nuktas bool) []int {
+	var runes []rune
+	for _, r := range text {
+		if forms, ok := nuktaForms[r]; ok {
+			runes = append(runes, forms...)
+			continue
+		}
+		if r != ZWJ && r != ZWNJ {
+			runes = append(runes, r)
+		}
+	}
+
+	key := make([]int, 0, len(runes)+len(runes)/2)
+	for i, r := range runes {
+		switch {
+		case r == Nukta:
+			if nuktas {
+				key = append(key, weightNukta)
+			}
+			if i > 0 && isBaseConsonant(runes[i-1]) && !takesVowelSign(runes[i+1:]) {
+				key = append(key, weightInherent)
+			}
+		case isBaseConsonant(r):
+			key = append(key, weightConsonant+int(r-'क'))
+			if !takesVowelSign(runes[i+1:]) {
+				key = append(key, weightInherent)
+			}
+		case matraWeights[r] != 0:
+			key = append(key, weightInherent+matraWeights[r])
+		case r == Virama:
+			key = append(key, weightVirama)
+		case IsIndependentVowel(r):
+			key = append(key, weightVowel+vowelWeights[r])
+		case r == Chandrabindu:
+			key = append(key, weightChandrabindu)
+		case r == Anusvara:
+			key = append(key, weightAnusvara)
+		case r == Visarga:
+			key = append(key, weightVisarga)
+		case unicode.IsSpace(r):
+			key = append(key, weightSpace)
+		default:
+			key = append(key, weightOther+int(r))
+		}
+	}
+	return key
+}
+
+// isBaseConsonant reports whether r is a consonant without a nukta
+func isBaseConsonant(r rune) bool {
+	return r >= 'क' && r <= 'ह'
+}
+
+// takesVowelSign reports whether the consonant before rest is followed by
+// a nukta, a matra or a virama rather than its inherent vowel. The inherent
+// vowel of a consonant with a nukta follows the nukta.
+func takesVowelSign(rest []rune) bool {
+	if len(rest) == 0 {
+		return false
+	}
+	return rest[0] == Nukta || matraWeights[rest[0]] != 0 || rest[0] == Virama
+}
@@ -0,0 +1,95 @@
+package devanagari
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Chandrabindu and Anusvara nasalize the vowel before them
+const (
+	Chandrabindu = 'ँ'
+	Anusvara     = 'ं'
+)
+
+// nuktaForms decomposes the precomposed consonants with a nukta, which
+// keyboards and speech recognizers produce interchangeably with the
+// consonant followed by the nukta sign
+var nuktaForms = map[rune][]rune{
+	'ऩ': {'न', Nukta},
+	'ऱ': {'र', Nukta},
+	'ऴ': {'ळ', Nukta},
+	'क़': {'क', Nukta},
+	'ख़': {'ख', Nukta},
+	'ग़': {'ग', Nukta},
+	'ज़': {'ज', Nukta},
+	'ड़': {'ड', Nukta},
+	'ढ़': {'ढ', Nukta},
+	'फ़': {'फ', Nukta},
+	'य़': {'य', Nukta},
+}
+
+// Fold normalizes text for comparing how it sounds rather than how it was
+// typed: nukta consonants are decomposed, chandrabindu is written as
+// anusvara, joiners and punctuation such as the danda are dropped, and
+// whitespace is collapsed
+func Fold(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == ZWJ || r == ZWNJ:
+			continue
+		case unicode.IsSpace(r) || unicode.IsPunct(r):
+			space = b.Len() > 0
+			continue
+		}
+
+		if space {
+			b.WriteRune(' ')
+			space = false
+		}
+		if forms, ok := nuktaForms[r]; ok {
+			b.WriteString(string(forms))
+			continue
+		}
+		if r == Chandrabindu {
+			r = Anusvara
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Distance returns the Levenshtein distance between the folded forms of a
+// and b, counting inserted, deleted and substituted characters
+func Distance(a, b string) int {
+	source, target := []rune(Fold(a)), []rune(Fold(b))
+
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
+
+// Similarity scores how close a is to b from 0 to 100, the share of the
+// longer folded text that does not need editing
+func Similarity(a, b string) int {
+	longest := max(len([]rune(Fold(a))), len([]rune(Fold(b))))
+	if longest == 0 {
+		return 100
+	}
+	return (longest - Distance(a, b)) * 100 / longest
+}
@@ -0,0 +1,277 @@
+package devanagari
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// InputMethod is a way of typing Devanagari on a Latin keyboard
+type InputMethod string
+
+const (
+	// ITRANS transliterates Roman keystrokes by the ITRANS scheme, where
+	// capitals mark long vowels and retroflexes, "kamalA" for कमला. A word
+	// ending in a consonant ends in a virama, as in Sanskrit.
+	ITRANS InputMethod = "itrans"
+	// Phonetic is ITRANS read the way Hindi is typed in chat: a word ending
+	// in a consonant keeps its inherent vowel, a final a, i or u is long,
+	// and ee and oo spell ई and ऊ, "kamara" for कमरा and "paani" for पानी.
+	// What chat typing leaves out, as in "kamra" and "main", is spelled out
+	// by Spellings.
+	Phonetic InputMethod = "phonetic"
+	// InScript maps the keys of a US keyboard to the Hindi InScript layout,
+	// "kf" for कि
+	InScript InputMethod = "inscript"
+)
+
+// InputMethods lists the input methods text can be converted from
+var InputMethods = []InputMethod{Phonetic, ITRANS, InScript}
+
+// IsInputMethod reports whether s names one of InputMethods
+func IsInputMethod(s string) bool {
+	for _, method := range InputMethods {
+		if string(method) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Convert converts the keystrokes typed with an input method to Devanagari.
+// Text that is already Devanagari, and keys the method does not map, are
+// kept as they are.
+func Convert(text string, method InputMethod) string {
+	switch method {
+	case ITRANS:
+		return transliterate(text, false)
+	case Phonetic:
+		return transliterate(text, true)
+	case InScript:
+		return strings.Map(func(r rune) rune {
+			if mapped, ok := inscriptKeys[r]; ok {
+				return mapped
+			}
+			return r
+		}, inscriptSequences.Replace(text))
+	}
+	return text
+}
+
+// maxSpellings bounds how many spellings Spellings returns
+const maxSpellings = 16
+
+// Spellings returns the ways text typed with an input method may be spelled
+// in Devanagari, Convert's first. Phonetic typing leaves out what Hindi
+// writes but does not say: the inherent vowel of a consonant before
+// another, कमरा typed "kamra" rather than कम्रा, and the nasalization of a
+// final vowel, मैं typed "main" rather than मैन. The spellings with them
+// follow, up to maxSpellings.
+func Spellings(text string, method InputMethod) []string {
+	converted := Convert(text, method)
+	if method != Phonetic {
+		return []string{converted}
+	}
+
+	runes := []rune(converted)
+	spellings := []string{""}
+	for i, r := range runes {
+		options := []string{string(r)}
+		switch {
+		case r == Virama && i+1 < len(runes) && IsConsonant(runes[i+1]) && followsConsonant(runes[:i]):
+			options = append(options, "")
+		case r == 'न' && (i+1 == len(runes) || !IsMark(runes[i+1]) && !unicode.IsLetter(runes[i+1])) && followsVowel(runes[:i]):
+			options = append(options, string(Anusvara), string(Chandrabindu))
+		}
+
+		next := make([]string, 0, len(spellings))
+		for j, option := range options {
+			for _, spelling := range spellings {
+				if j > 0 && len(next) >= maxSpellings {
+					break
+				}
+				next = append(next, spelling+option)
+			}
+		}
+		spellings = next
+	}
+	return spellings
+}
+
+// followsConsonant reports whether text ends in a consonant, with or
+// without a nukta
+func followsConsonant(text []rune) bool {
+	if len(text) > 0 && text[len(text)-1] == Nukta {
+		text = text[:len(text)-1]
+	}
+	return len(text) > 0 && IsConsonant(text[len(text)-1])
+}
+
+// followsVowel reports whether text ends in a vowel other than the
+// inherent one, a matra or an independent vowel
+func followsVowel(text []rune) bool {
+	if len(text) == 0 {
+		return false
+	}
+	last := text[len(text)-1]
+	return IsIndependentVowel(last) || IsMark(last) && last != Virama && last != Nukta &&
+		last != Anusvara && last != Chandrabindu && last != Visarga
+}
+
+// nukta spells the consonants with a nukta decomposed, as they are stored
+const nukta = "़"
+
+// itransConsonants are the ITRANS spellings of consonants and of the
+// conjuncts written with a letter of their own
+var itransConsonants = map[string]string{
+	"k": "क", "kh": "ख", "g": "ग", "gh": "घ", "~N": "ङ", "N^": "ङ",
+	"c": "च", "ch": "च", "Ch": "छ", "chh": "छ", "j": "ज", "jh": "झ", "~n": "ञ", "JN": "ञ",
+	"T": "ट", "Th": "ठ", "D": "ड", "Dh": "ढ", "N": "ण",
+	"t": "त", "th": "थ", "d": "द", "dh": "ध", "n": "न",
+	"p": "प", "ph": "फ", "b": "ब", "bh": "भ", "m": "म",
+	"y": "य", "r": "र", "l": "ल", "L": "ळ", "v": "व", "w": "व",
+	"sh": "श", "Sh": "ष", "shh": "ष", "s": "स", "h": "ह",
+	"x": "क्ष", "kSh": "क्ष", "GY": "ज्ञ", "j~n": "ज्ञ", "dny": "ज्ञ",
+	"q": "क" + nukta, "K": "ख" + nukta, "G": "ग" + nukta, "z": "ज" + nukta, "J": "ज" + nukta,
+	"f": "फ" + nukta, ".D": "ड" + nukta, ".Dh": "ढ" + nukta,
+}
+
+// itransVowels are the ITRANS spellings of vowels, independent and as a
+// matra. The inherent a has no matra.
+var itransVowels = map[string][2]string{
+	"a": {"अ", ""}, "aa": {"आ", "ा"}, "A": {"आ", "ा"},
+	"i": {"इ", "ि"}, "ii": {"ई", "ी"}, "I": {"ई", "ी"},
+	"u": {"उ", "ु"}, "uu": {"ऊ", "ू"}, "U": {"ऊ", "ू"},
+	"RRi": {"ऋ", "ृ"}, "R^i": {"ऋ", "ृ"},
+	"e": {"ए", "े"}, "ai": {"ऐ", "ै"}, "o": {"ओ", "ो"}, "au": {"औ", "ौ"},
+}
+
+// phoneticVowels are the spellings the phonetic method adds
+var phoneticVowels = map[string][2]string{
+	"ee": {"ई", "ी"}, "oo": {"ऊ", "ू"},
+}
+
+// phoneticFinals are the matras a word-final a, i and u stand for in the
+// phonetic method
+var phoneticFinals = map[string]string{"a": "ा", "i": "ी", "u": "ू"}
+
+// itransSigns are the ITRANS spellings of signs that follow a syllable,
+// and of punctuation
+var itransSigns = map[string]string{
+	"M": "ं", ".n": "ं", ".m": "ं", ".N": "ँ", "H": "ः", ".a": "ऽ",
+	"OM": "ॐ", "AUM": "ॐ", "|": "।", "||": "॥",
+}
+
+// itransVirama is the ITRANS spelling of an explicit virama
+const itransVirama = ".h"
+
+// itransMaxKey is the length of the longest ITRANS spelling
+const itransMaxKey = 3
+
+// transliterate converts ITRANS keystrokes, consonants typed one after the
+// other forming a conjunct. Digits become Devanagari digits.
+func transliterate(text string, phonetic bool) string {
+	var b strings.Builder
+	pending := false // the last letter written is a consonant without a vowel
+	endWord := func() {
+		if pending && !phonetic {
+			b.WriteRune(Virama)
+		}
+		pending = false
+	}
+
+	for i := 0; i < len(text); {
+		key := longestKey(text[i:], phonetic)
+		switch {
+		case key == "":
+			r, size := utf8.DecodeRuneInString(text[i:])
+			endWord()
+			if r >= '0' && r <= '9' {
+				r = r - '0' + '०'
+			}
+			b.WriteRune(r)
+			i += size
+			continue
+
+		case key == itransVirama:
+			b.WriteRune(Virama)
+			pending = false
+
+		case itransConsonants[key] != "":
+			if pending {
+				b.WriteRune(Virama)
+			}
+			b.WriteString(itransConsonants[key])
+			pending = true
+
+		case itransSigns[key] != "":
+			if key == "|" || key == "||" || key == "OM" || key == "AUM" {
+				endWord()
+			}
+			b.WriteString(itransSigns[key])
+			pending = false
+
+		default:
+			forms, ok := itransVowels[key]
+			if !ok {
+				forms = phoneticVowels[key]
+			}
+			if !pending {
+				b.WriteString(forms[0])
+				break
+			}
+			matra := forms[1]
+			if final, ok := phoneticFinals[key]; ok && phonetic && endsWord(text[i+len(key):]) {
+				matra = final
+			}
+			b.WriteString(matra)
+			pending = false
+		}
+		i += len(key)
+	}
+	endWord()
+
+	return b.String()
+}
+
+// longestKey returns the longest ITRANS spelling text starts with
+func longestKey(text string, phonetic bool) string {
+	for n := itransMaxKey; n > 0; n-- {
+		if len(text) < n {
+			continue
+		}
+		key := text[:n]
+		_, consonant := itransConsonants[key]
+		_, vowel := itransVowels[key]
+		_, sign := itransSigns[key]
+		_, phoneticVowel := phoneticVowels[key]
+		if consonant || vowel || sign || key == itransVirama || (phonetic && phoneticVowel) {
+			return key
+		}
+	}
+	return ""
+}
+
+// endsWord reports whether the rest of the text starts a new word
+func endsWord(rest string) bool {
+	r, _ := utf8.DecodeRuneInString(rest)
+	return rest == "" || !unicode.IsLetter(r)
+}
+
+// inscriptKeys are the characters of the InScript layout by the US key
+// in their place
+var inscriptKeys = map[rune]rune{
+	'q': 'ौ', 'w': 'ै', 'e': 'ा', 'r': 'ी', 't': 'ू', 'y': 'ब', 'u': 'ह', 'i': 'ग', 'o': 'द', 'p': 'ज', '[': 'ड', ']': '़',
+	'Q': 'औ', 'W': 'ऐ', 'E': 'आ', 'R': 'ई', 'T': 'ऊ', 'Y': 'भ', 'U': 'ङ', 'I': 'घ', 'O': 'ध', 'P': 'झ', '{': 'ढ', '}': 'ञ',
+	'a': 'ो', 's': 'े', 'd': '्', 'f': 'ि', 'g': 'ु', 'h': 'प', 'j': 'र', 'k': 'क', 'l': 'त', ';': 'च', '\'': 'ट',
+	'A': 'ओ', 'S': 'ए', 'D': 'अ', 'F': 'इ', 'G': 'उ', 'H': 'फ', 'J': 'ऱ', 'K': 'ख', 'L': 'थ', ':': 'छ', '"': 'ठ',
+	'x': 'ं', 'c': 'म', 'v': 'न', 'b': 'व', 'n': 'ल', 'm': 'स', '/': 'य',
+	'X': 'ँ', 'C': 'ण', 'N': 'ळ', 'M': 'श', '<': 'ष', '>': '।',
+	'\\': 'ॉ', '|': 'ऑ', '=': 'ृ', '+': 'ऋ', '_': 'ः', '@': 'ॅ', '!': 'ऍ',
+	'1': '१', '2': '२', '3': '३', '4': '४', '5': '५', '6': '६', '7': '७', '8': '८', '9': '९', '0': '०',
+}
+
+// inscriptSequences are the InScript keys that type more than one character
+var inscriptSequences = strings.NewReplacer(
+	"#", "्र", "$", "र्", "%", "ज्ञ", "^", "त्र", "&", "क्ष", "*", "श्र",
+)
@@ -0,0 +1,44 @@
+package devanagari
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// vowelSequences are the spellings of independent vowels as a vowel and a
+// matra, which Unicode lists as not to be used and NFC leaves alone
+var vowelSequences = strings.NewReplacer(
+	"अा", "आ", "अॆ", "ऄ", "अॉ", "ऑ", "अॊ", "ऒ", "अो", "ओ", "अौ", "औ",
+	"आॅ", "ऑ", "एॅ", "ऍ", "एॆ", "ऎ", "एे", "ऐ",
+)
+
+// Canonical returns the form text is stored in, so that words which look
+// the same compare equal. The text is NFC normalized, which writes क़, ख़,
+// ग़, ज़, ड़, ढ़, फ़ and य़ as the consonant followed by the nukta sign.
+// Independent vowels spelled as a vowel and a matra, as in अ followed by ा,
+// are written as the vowel, and joiners following Devanagari are dropped.
+// Other scripts are only NFC normalized.
+func Canonical(s string) string {
+	s = norm.NFC.String(s)
+	if !strings.ContainsFunc(s, isDevanagari) {
+		return s
+	}
+
+	var b strings.Builder
+	var previous rune
+	for _, r := range s {
+		if (r == ZWJ || r == ZWNJ) && isDevanagari(previous) {
+			continue
+		}
+		b.WriteRune(r)
+		previous = r
+	}
+	return vowelSequences.Replace(b.String())
+}
+
+// isDevanagari reports whether r belongs to the Devanagari block
+func isDevanagari(r rune) bool {
+	return unicode.Is(unicode.Devanagari, r)
+}
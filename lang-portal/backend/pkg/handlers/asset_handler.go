@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// assetCacheControl lets clients cache asset content for good, a file never
+// changes once stored because new content always gets a new asset
+const assetCacheControl = "public, max-age=31536000, immutable"
+
+// AssetHandler handles HTTP requests for uploaded audio and images
+type AssetHandler struct {
+	service *services.AssetService
+}
+
+// NewAssetHandler creates a new instance of AssetHandler
+func NewAssetHandler(service *services.AssetService) *AssetHandler {
+	return &AssetHandler{service: service}
+}
+
+// UploadAsset stores the multipart "file" field, with an optional
+// "duration_ms" field for audio whose duration cannot be read from the file
+func (h *AssetHandler) UploadAsset(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A file is required in the multipart field \"file\"",
+		})
+	}
+
+	upload := services.AssetUpload{
+		Filename:    fileHeader.Filename,
+		ContentType: fileHeader.Header.Get(echo.HeaderContentType),
+	}
+	if value := c.FormValue("duration_ms"); value != "" {
+		duration, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || duration <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid duration",
+			})
+		}
+		upload.DurationMS = &duration
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to read the uploaded file",
+		})
+	}
+	defer file.Close()
+	upload.Content = file
+
+	asset, err := h.service.Upload(c.Request().Context(), upload)
+	if err != nil {
+		return assetError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, asset)
+}
+
+// GetAsset retrieves the metadata of an asset
+func (h *AssetHandler) GetAsset(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid asset ID",
+		})
+	}
+
+	asset, err := h.service.GetAsset(c.Request().Context(), id)
+	if err != nil {
+		return assetError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, asset)
+}
+
+// ServeAsset streams the content of an asset, supporting range and
+// conditional requests so audio can be seeked and cached
+func (h *AssetHandler) ServeAsset(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid asset ID",
+		})
+	}
+
+	asset, content, err := h.service.OpenAsset(c.Request().Context(), id)
+	if err != nil {
+		return assetError(c, err)
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, asset.MimeType)
+	header.Set("Cache-Control", assetCacheControl)
+	header.Set("ETag", `"`+asset.Checksum+`"`)
+
+	http.ServeContent(c.Response(), c.Request(), asset.Filename, asset.CreatedAt, content)
+	return nil
+}
+
+// DeleteAsset removes an asset and its file
+func (h *AssetHandler) DeleteAsset(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid asset ID",
+		})
+	}
+
+	if err := h.service.DeleteAsset(c.Request().Context(), id); err != nil {
+		return assetError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Asset deleted successfully",
+	})
+}
+
+// assetError maps asset errors to responses, the rest are handled like word errors
+func assetError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, models.ErrFileTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrUnsupportedMedia):
+		return c.JSON(http.StatusUnsupportedMediaType, map[string]string{"error": err.Error()})
+	default:
+		return wordError(c, err)
+	}
+}
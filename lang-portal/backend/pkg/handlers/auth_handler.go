@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// AuthHandler handles accounts, API keys and role assignment
+type AuthHandler struct {
+	service *services.UserService
+}
+
+// NewAuthHandler creates a new instance of AuthHandler
+func NewAuthHandler(service *services.UserService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// CredentialsRequest defines the request payload for registering and logging in
+type CredentialsRequest struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Register creates a learner account
+func (h *AuthHandler) Register(c echo.Context) error {
+	var req CredentialsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	user, err := h.service.Register(c.Request().Context(), req.Username, req.Password)
+	if err != nil {
+		return authError(c, err, "Failed to register user")
+	}
+
+	return c.JSON(http.StatusCreated, user)
+}
+
+// Login checks a learner's credentials and returns a login token
+func (h *AuthHandler) Login(c echo.Context) error {
+	var req CredentialsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	login, err := h.service.Login(c.Request().Context(), req.Username, req.Password)
+	if err != nil {
+		return authError(c, err, "Failed to log in")
+	}
+
+	return c.JSON(http.StatusOK, login)
+}
+
+// Me returns the authenticated user
+func (h *AuthHandler) Me(c echo.Context) error {
+	user, err := h.service.GetUser(c.Request().Context(), userID(c))
+	if err != nil {
+		return authError(c, err, "Failed to retrieve user")
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+// CreateAPIKeyRequest defines the request payload for creating an API key
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// CreateAPIKey issues an API key acting as the authenticated user
+func (h *AuthHandler) CreateAPIKey(c echo.Context) error {
+	var req CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	apiKey, err := h.service.CreateAPIKey(c.Request().Context(), userID(c), req.Name)
+	if err != nil {
+		return authError(c, err, "Failed to create API key")
+	}
+
+	return c.JSON(http.StatusCreated, apiKey)
+}
+
+// ListAPIKeys lists the authenticated user's API keys
+func (h *AuthHandler) ListAPIKeys(c echo.Context) error {
+	apiKeys, err := h.service.ListAPIKeys(c.Request().Context(), userID(c))
+	if err != nil {
+		return authError(c, err, "Failed to retrieve API keys")
+	}
+
+	return c.JSON(http.StatusOK, apiKeys)
+}
+
+// DeleteAPIKey revokes one of the authenticated user's API keys
+func (h *AuthHandler) DeleteAPIKey(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid API key ID",
+		})
+	}
+
+	if err := h.service.DeleteAPIKey(c.Request().Context(), userID(c), id); err != nil {
+		return authError(c, err, "Failed to delete API key")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SetRoleRequest defines the request payload for changing a user's role
+type SetRoleRequest struct {
+	Role models.Role `json:"role" validate:"required"`
+}
+
+// SetUserRole changes the role of a user
+func (h *AuthHandler) SetUserRole(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var req SetRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	user, err := h.service.SetRole(c.Request().Context(), id, req.Role)
+	if err != nil {
+		return authError(c, err, "Failed to update role")
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+
+// userID returns the ID of the user the request is authenticated as
+func userID(c echo.Context) int64 {
+	if principal := auth.PrincipalFrom(c); principal != nil {
+		return principal.UserID
+	}
+	return 0
+}
+
+// authError maps account and credential errors to HTTP responses
+func authError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidCredentials),
+		errors.Is(err, auth.ErrInvalidToken),
+		errors.Is(err, auth.ErrTokenExpired):
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrUsernameTaken):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrInvalidUsername),
+		errors.Is(err, models.ErrInvalidPassword),
+		errors.Is(err, models.ErrInvalidRole),
+		errors.Is(err, models.ErrInvalidInput):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": message,
+		})
+	}
+}
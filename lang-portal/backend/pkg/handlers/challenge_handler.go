@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// ChallengeHandler handles HTTP requests for playing a session's study activity
+type ChallengeHandler struct {
+	service *services.ChallengeService
+	assets  *services.AssetService
+}
+
+// NewChallengeHandler creates a new instance of ChallengeHandler
+func NewChallengeHandler(service *services.ChallengeService, assets *services.AssetService) *ChallengeHandler {
+	return &ChallengeHandler{service: service, assets: assets}
+}
+
+// SubmitAnswerRequest defines the request payload for answering a challenge.
+// The challenge is named by its ID, clients sending the whole challenge back
+// are answered by the ID in it.
+type SubmitAnswerRequest struct {
+	ChallengeID string                `json:"challenge_id"`
+	Challenge   *activities.Challenge `json:"challenge"`
+	Input       string                `json:"input"`
+	InputMethod string                `json:"input_method"` // itrans, phonetic or inscript when typed on a Latin keyboard
+}
+
+// SubmitAnswerResponse pairs the recorded session activity with its grade
+type SubmitAnswerResponse struct {
+	SessionActivity *models.SessionActivity `json:"session_activity"`
+	Grade           *activities.Grade       `json:"grade"`
+}
+
+// GenerateChallenge returns the next challenge for a session
+func (h *ChallengeHandler) GenerateChallenge(c echo.Context) error {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || sessionID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid session ID",
+		})
+	}
+
+	challenge, err := h.service.GenerateChallenge(c.Request().Context(), userID(c), sessionID)
+	if err != nil {
+		return challengeError(c, err, "Failed to generate challenge")
+	}
+
+	return c.JSON(http.StatusOK, challenge)
+}
+
+// SubmitAnswer grades an answer and records it against the session
+func (h *ChallengeHandler) SubmitAnswer(c echo.Context) error {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || sessionID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid session ID",
+		})
+	}
+
+	var req SubmitAnswerRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+	if req.ChallengeID == "" && req.Challenge != nil {
+		req.ChallengeID = req.Challenge.ID
+	}
+	if req.ChallengeID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A challenge ID is required",
+		})
+	}
+
+	sessionActivity, grade, err := h.service.SubmitAnswer(c.Request().Context(), userID(c), sessionID, req.ChallengeID, req.Input, req.InputMethod)
+	if err != nil {
+		return challengeError(c, err, "Failed to grade answer")
+	}
+
+	return c.JSON(http.StatusCreated, SubmitAnswerResponse{
+		SessionActivity: sessionActivity,
+		Grade:           grade,
+	})
+}
+
+// ServeChallengeAudio streams the recording played with an issued challenge.
+// It is served without the asset's checksum or dates, which would tell which
+// word's recording it is.
+func (h *ChallengeHandler) ServeChallengeAudio(c echo.Context) error {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || sessionID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid session ID",
+		})
+	}
+
+	ctx := c.Request().Context()
+	assetID, err := h.service.ChallengeAudio(ctx, userID(c), sessionID, c.Param("cid"))
+	if err != nil {
+		return challengeError(c, err, "Failed to retrieve challenge audio")
+	}
+
+	asset, content, err := h.assets.OpenAsset(ctx, assetID)
+	if err != nil {
+		return challengeError(c, err, "Failed to retrieve challenge audio")
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, asset.MimeType)
+	header.Set("Cache-Control", "private, max-age=3600")
+	http.ServeContent(c.Response(), c.Request(), "", time.Time{}, content)
+	return nil
+}
+
+// GetSessionSummary returns the engine's summary of a session
+func (h *ChallengeHandler) GetSessionSummary(c echo.Context) error {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || sessionID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid session ID",
+		})
+	}
+
+	summary, err := h.service.SummarizeSession(c.Request().Context(), userID(c), sessionID)
+	if err != nil {
+		return challengeError(c, err, "Failed to summarize session")
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// challengeError maps activity engine errors to HTTP responses
+func challengeError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrActivityDisabled),
+		errors.Is(err, models.ErrSessionEnded),
+		errors.Is(err, models.ErrAnswered),
+		errors.Is(err, activities.ErrExternalActivity),
+		errors.Is(err, activities.ErrClientGradedActivity),
+		errors.Is(err, activities.ErrTutorActivity):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, activities.ErrInvalidChallenge), errors.Is(err, models.ErrInvalidInput):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrNotConfigured):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error":   message,
+			"details": err.Error(),
+		})
+	}
+}
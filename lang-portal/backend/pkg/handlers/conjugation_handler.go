@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// ConjugationHandler handles HTTP requests for verb conjugations
+type ConjugationHandler struct {
+	service *services.ConjugationService
+}
+
+// NewConjugationHandler creates a new instance of ConjugationHandler
+func NewConjugationHandler(service *services.ConjugationService) *ConjugationHandler {
+	return &ConjugationHandler{service: service}
+}
+
+// GetConjugations conjugates the verb with the ID in the URL
+func (h *ConjugationHandler) GetConjugations(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid word ID",
+		})
+	}
+
+	conjugations, err := h.service.Conjugate(c.Request().Context(), id)
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, conjugations)
+}
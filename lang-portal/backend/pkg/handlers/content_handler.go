@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/ai"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// ContentHandler handles HTTP requests for generated content drafts and hints
+type ContentHandler struct {
+	service *services.ContentService
+}
+
+// NewContentHandler creates a new instance of ContentHandler
+func NewContentHandler(service *services.ContentService) *ContentHandler {
+	return &ContentHandler{service: service}
+}
+
+// ProposeWords generates word drafts for a group
+func (h *ContentHandler) ProposeWords(c echo.Context) error {
+	var req services.ProposeWordsRequest
+	if err := c.Bind(&req); err != nil || req.GroupID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A group ID is required",
+		})
+	}
+
+	drafts, err := h.service.ProposeWords(c.Request().Context(), userID(c), req)
+	if err != nil {
+		return contentError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, drafts)
+}
+
+// ProposeSentences generates example sentence drafts for a word
+func (h *ContentHandler) ProposeSentences(c echo.Context) error {
+	var req services.ProposeRequest
+	if err := c.Bind(&req); err != nil || req.WordID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A word ID is required",
+		})
+	}
+
+	drafts, err := h.service.ProposeSentences(c.Request().Context(), userID(c), req)
+	if err != nil {
+		return contentError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, drafts)
+}
+
+// ProposeHints generates hint drafts for a word
+func (h *ContentHandler) ProposeHints(c echo.Context) error {
+	var req services.ProposeRequest
+	if err := c.Bind(&req); err != nil || req.WordID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A word ID is required",
+		})
+	}
+
+	drafts, err := h.service.ProposeHints(c.Request().Context(), userID(c), req)
+	if err != nil {
+		return contentError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, drafts)
+}
+
+// ListDrafts lists drafts, optionally filtered by the "kind", "status" and
+// "word_id" query parameters
+func (h *ContentHandler) ListDrafts(c echo.Context) error {
+	params := repository.ListDraftsParams{
+		Kind:   c.QueryParam("kind"),
+		Status: models.DraftStatus(c.QueryParam("status")),
+	}
+	if value := c.QueryParam("word_id"); value != "" {
+		wordID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || wordID <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid word ID",
+			})
+		}
+		params.WordID = wordID
+	}
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid limit",
+			})
+		}
+		params.Limit = limit
+	}
+
+	drafts, err := h.service.ListDrafts(c.Request().Context(), params)
+	if err != nil {
+		return contentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, drafts)
+}
+
+// GetDraft retrieves a draft
+func (h *ContentHandler) GetDraft(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid draft ID",
+		})
+	}
+
+	draft, err := h.service.GetDraft(c.Request().Context(), id)
+	if err != nil {
+		return contentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, draft)
+}
+
+// ApproveDraft adds a draft to the portal. The body may carry a "content"
+// object replacing the generated content with the editor's corrections.
+func (h *ContentHandler) ApproveDraft(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid draft ID",
+		})
+	}
+
+	var req struct {
+		Content json.RawMessage `json:"content"`
+	}
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	draft, err := h.service.ApproveDraft(c.Request().Context(), userID(c), id, req.Content)
+	if err != nil {
+		return contentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, draft)
+}
+
+// RejectDraft turns a draft down
+func (h *ContentHandler) RejectDraft(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid draft ID",
+		})
+	}
+
+	draft, err := h.service.RejectDraft(c.Request().Context(), userID(c), id)
+	if err != nil {
+		return contentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, draft)
+}
+
+// ListWordHints lists the approved hints of a word
+func (h *ContentHandler) ListWordHints(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid word ID",
+		})
+	}
+
+	hints, err := h.service.ListWordHints(c.Request().Context(), id)
+	if err != nil {
+		return contentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, hints)
+}
+
+// DeleteWordHint removes a hint from a word
+func (h *ContentHandler) DeleteWordHint(c echo.Context) error {
+	wordID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || wordID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid word ID",
+		})
+	}
+	id, err := strconv.ParseInt(c.Param("hint-id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid hint ID",
+		})
+	}
+
+	if err := h.service.DeleteWordHint(c.Request().Context(), wordID, id); err != nil {
+		return contentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Hint deleted successfully",
+	})
+}
+
+// contentError maps language model errors to responses, the rest are
+// handled like word errors
+func contentError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, models.ErrNotConfigured):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	case errors.Is(err, context.DeadlineExceeded):
+		return c.JSON(http.StatusGatewayTimeout, map[string]string{"error": "The language model did not answer in time"})
+	case errors.Is(err, ai.ErrInvalidOutput), errors.Is(err, ai.ErrProvider):
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": err.Error()})
+	}
+	return wordError(c, err)
+}
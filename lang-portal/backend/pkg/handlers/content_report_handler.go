@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// ContentReportHandler handles HTTP requests for the content-quality report
+type ContentReportHandler struct {
+	service *services.ContentReportService
+}
+
+// NewContentReportHandler creates a new instance of ContentReportHandler
+func NewContentReportHandler(service *services.ContentReportService) *ContentReportHandler {
+	return &ContentReportHandler{service: service}
+}
+
+// GetReport runs the content report, or only the check in the check query
+// parameter
+func (h *ContentReportHandler) GetReport(c echo.Context) error {
+	report, err := h.service.Report(c.Request().Context(), c.QueryParam("check"))
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// DuplicateHandler handles HTTP requests for finding and merging duplicate words
+type DuplicateHandler struct {
+	service *services.DuplicateService
+}
+
+// NewDuplicateHandler creates a new instance of DuplicateHandler
+func NewDuplicateHandler(service *services.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{service: service}
+}
+
+// ListDuplicates lists the clusters of likely duplicate words, of the
+// language in the language query parameter or of all languages
+func (h *DuplicateHandler) ListDuplicates(c echo.Context) error {
+	clusters, err := h.service.FindDuplicates(c.Request().Context(), c.QueryParam("language"))
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"clusters": clusters,
+		"total":    len(clusters),
+	})
+}
+
+// MergeWords merges the words of the request body into the word in the URL
+func (h *DuplicateHandler) MergeWords(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid word ID",
+		})
+	}
+
+	var req services.MergeWordsRequest
+	if err := c.Bind(&req); err != nil || len(req.WordIDs) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "The IDs of the words to merge are required",
+		})
+	}
+
+	merges, err := h.service.Merge(c.Request().Context(), userID(c), id, req)
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"merges": merges,
+	})
+}
+
+// ListMerges lists the recorded word merges, newest first
+func (h *DuplicateHandler) ListMerges(c echo.Context) error {
+	merges, err := h.service.ListMerges(c.Request().Context())
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"merges": merges,
+	})
+}
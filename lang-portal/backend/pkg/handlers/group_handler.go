@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -159,3 +160,54 @@ func (h *GroupHandler) GetGroups(c echo.Context) error {
 		"pageSize": pageSize,
 	})
 }
+
+// GroupWordRequest defines the request payload for adding a word to a group
+type GroupWordRequest struct {
+	WordID int64 `json:"word_id" validate:"required,min=1"`
+}
+
+// AddWordToGroup adds a word to a group
+func (h *GroupHandler) AddWordToGroup(c echo.Context) error {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || groupID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid group ID"})
+	}
+
+	var req GroupWordRequest
+	if err := c.Bind(&req); err != nil || req.WordID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	if err := h.groupService.AddWordToGroup(c.Request().Context(), groupID, req.WordID); err != nil {
+		return wordGroupError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RemoveWordFromGroup removes a word from a group
+func (h *GroupHandler) RemoveWordFromGroup(c echo.Context) error {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || groupID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid group ID"})
+	}
+
+	wordID, err := strconv.ParseInt(c.Param("word-id"), 10, 64)
+	if err != nil || wordID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid word ID"})
+	}
+
+	if err := h.groupService.RemoveWordFromGroup(c.Request().Context(), groupID, wordID); err != nil {
+		return wordGroupError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// wordGroupError maps word group errors to HTTP responses
+func wordGroupError(c echo.Context, err error) error {
+	if errors.Is(err, models.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// InputHandler handles HTTP requests for input method conversion
+type InputHandler struct {
+	service *services.InputService
+}
+
+// NewInputHandler creates a new instance of InputHandler
+func NewInputHandler(service *services.InputService) *InputHandler {
+	return &InputHandler{service: service}
+}
+
+// Convert converts the text query parameter typed with the input method in
+// the method query parameter, for previewing answers as they are typed
+func (h *InputHandler) Convert(c echo.Context) error {
+	text := c.QueryParam("text")
+	converted, err := h.service.Convert(text, c.QueryParam("method"))
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"text":      text,
+		"converted": converted,
+	})
+}
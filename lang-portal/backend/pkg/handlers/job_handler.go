@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// JobHandler handles HTTP requests for background jobs
+type JobHandler struct {
+	service *services.JobService
+}
+
+// NewJobHandler creates a new instance of JobHandler
+func NewJobHandler(service *services.JobService) *JobHandler {
+	return &JobHandler{service: service}
+}
+
+// EnqueueJobs queues a job of the requested kind for everything that needs
+// one, such as every word without pronunciation audio, and starts running them
+func (h *JobHandler) EnqueueJobs(c echo.Context) error {
+	var req struct {
+		Kind string `json:"kind"`
+	}
+	if err := c.Bind(&req); err != nil || req.Kind == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A job kind is required",
+		})
+	}
+
+	queued, err := h.service.EnqueueBacklog(c.Request().Context(), req.Kind)
+	if err != nil {
+		return jobError(c, err)
+	}
+	h.service.Start(req.Kind)
+
+	return c.JSON(http.StatusAccepted, map[string]interface{}{
+		"kind":   req.Kind,
+		"queued": queued,
+	})
+}
+
+// ListJobs lists jobs, optionally filtered by the "kind" and "status" query parameters
+func (h *JobHandler) ListJobs(c echo.Context) error {
+	params := repository.ListJobsParams{
+		Kind:   c.QueryParam("kind"),
+		Status: models.JobStatus(c.QueryParam("status")),
+	}
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid limit",
+			})
+		}
+		params.Limit = limit
+	}
+
+	jobs, err := h.service.ListJobs(c.Request().Context(), params)
+	if err != nil {
+		return jobError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, jobs)
+}
+
+// GetJob retrieves a job
+func (h *JobHandler) GetJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid job ID",
+		})
+	}
+
+	job, err := h.service.GetJob(c.Request().Context(), id)
+	if err != nil {
+		return jobError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// RetryJob queues a failed job again
+func (h *JobHandler) RetryJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid job ID",
+		})
+	}
+
+	job, err := h.service.Retry(c.Request().Context(), id)
+	if err != nil {
+		return jobError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// jobError maps job errors to responses, the rest are handled like word errors
+func jobError(c echo.Context, err error) error {
+	if errors.Is(err, models.ErrNotConfigured) {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	}
+	return wordError(c, err)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// LaunchHandler handles launching external activities and their result callbacks
+type LaunchHandler struct {
+	service *services.LaunchService
+}
+
+// NewLaunchHandler creates a new instance of LaunchHandler
+func NewLaunchHandler(service *services.LaunchService) *LaunchHandler {
+	return &LaunchHandler{service: service}
+}
+
+// LaunchActivityRequest defines the request payload for launching an activity
+type LaunchActivityRequest struct {
+	GroupID *int64 `json:"group_id,omitempty"`
+}
+
+// ExternalResultRequest defines a result reported by an activity app
+type ExternalResultRequest struct {
+	Challenge string `json:"challenge" validate:"required"`
+	Answer    string `json:"answer" validate:"required"`
+	Input     string `json:"input"`
+	Result    string `json:"result"`
+	Score     int    `json:"score" validate:"min=0,max=100"`
+}
+
+// CompleteExternalSessionRequest defines the final score reported by an activity app
+type CompleteExternalSessionRequest struct {
+	Score int `json:"score" validate:"min=0"`
+}
+
+// LaunchActivity creates a session for an external activity and returns its launch token and URL
+func (h *LaunchHandler) LaunchActivity(c echo.Context) error {
+	activityID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || activityID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid study activity ID",
+		})
+	}
+
+	var req LaunchActivityRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+	if req.GroupID != nil && *req.GroupID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid group ID",
+		})
+	}
+
+	launch, err := h.service.LaunchActivity(c.Request().Context(), userID(c), activityID, req.GroupID)
+	if err != nil {
+		return launchError(c, err, "Failed to launch activity")
+	}
+
+	return c.JSON(http.StatusCreated, launch)
+}
+
+// RecordResult stores a session activity reported by an activity app
+func (h *LaunchHandler) RecordResult(c echo.Context) error {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || sessionID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid session ID",
+		})
+	}
+
+	var req ExternalResultRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	sessionActivity := &models.SessionActivity{
+		Challenge: req.Challenge,
+		Answer:    req.Answer,
+		Input:     req.Input,
+		Result:    req.Result,
+		Score:     req.Score,
+	}
+	token := auth.BearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+	if err := h.service.RecordResult(c.Request().Context(), token, sessionID, sessionActivity); err != nil {
+		return launchError(c, err, "Failed to record result")
+	}
+
+	return c.JSON(http.StatusCreated, sessionActivity)
+}
+
+// CompleteSession ends a launched session with the score reported by the activity app
+func (h *LaunchHandler) CompleteSession(c echo.Context) error {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || sessionID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid session ID",
+		})
+	}
+
+	var req CompleteExternalSessionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	token := auth.BearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+	session, err := h.service.CompleteSession(c.Request().Context(), token, sessionID, req.Score)
+	if err != nil {
+		return launchError(c, err, "Failed to complete session")
+	}
+
+	return c.JSON(http.StatusOK, session)
+}
+
+// launchError maps launch protocol errors to HTTP responses
+func launchError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, auth.ErrInvalidToken), errors.Is(err, auth.ErrTokenExpired):
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrActivityDisabled), errors.Is(err, models.ErrSessionEnded):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, services.ErrNotLaunchable),
+		errors.Is(err, models.ErrInvalidInput),
+		errors.Is(err, models.ErrInvalidScore),
+		errors.Is(err, models.ErrInvalidID):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	default:
+		log.Printf("%s: %v", message, err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": message,
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// ListeningHandler handles HTTP requests for listening exercises
+type ListeningHandler struct {
+	service *services.ListeningService
+}
+
+// NewListeningHandler creates a new instance of ListeningHandler
+func NewListeningHandler(service *services.ListeningService) *ListeningHandler {
+	return &ListeningHandler{service: service}
+}
+
+// ListExercises lists the exercises, optionally filtered by the "language" query parameter
+func (h *ListeningHandler) ListExercises(c echo.Context) error {
+	exercises, err := h.service.ListExercises(c.Request().Context(), c.QueryParam("language"))
+	if err != nil {
+		return listeningError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, exercises)
+}
+
+// GetExercise retrieves an exercise with its segments and questions. The
+// answers are only shown to editors.
+func (h *ListeningHandler) GetExercise(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid exercise ID",
+		})
+	}
+
+	exercise, err := h.service.GetExercise(c.Request().Context(), id)
+	if err != nil {
+		return listeningError(c, err)
+	}
+
+	if principal := auth.PrincipalFrom(c); principal == nil || !principal.Role.Includes(models.RoleEditor) {
+		exercise.HideAnswers()
+	}
+
+	return c.JSON(http.StatusOK, exercise)
+}
+
+// CreateExercise adds an exercise with its segments and questions
+func (h *ListeningHandler) CreateExercise(c echo.Context) error {
+	var exercise models.ListeningExercise
+	if err := c.Bind(&exercise); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.service.CreateExercise(c.Request().Context(), &exercise); err != nil {
+		return listeningError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, exercise)
+}
+
+// ImportTranscript imports a structured listening-comp transcript, answering
+// with the existing exercise when its source was already imported
+func (h *ListeningHandler) ImportTranscript(c echo.Context) error {
+	var req services.ImportTranscriptRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	exercise, created, err := h.service.ImportTranscript(c.Request().Context(), req)
+	if err != nil {
+		return listeningError(c, err)
+	}
+
+	if !created {
+		return c.JSON(http.StatusOK, exercise)
+	}
+	return c.JSON(http.StatusCreated, exercise)
+}
+
+// SetAudio sets or removes the recording of an exercise
+func (h *ListeningHandler) SetAudio(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid exercise ID",
+		})
+	}
+
+	var req services.SetAudioRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	exercise, err := h.service.SetAudio(c.Request().Context(), id, req)
+	if err != nil {
+		return listeningError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, exercise)
+}
+
+// DeleteExercise removes an exercise
+func (h *ListeningHandler) DeleteExercise(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid exercise ID",
+		})
+	}
+
+	if err := h.service.DeleteExercise(c.Request().Context(), id); err != nil {
+		return listeningError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Listening exercise deleted successfully",
+	})
+}
+
+// listeningError maps segment timing errors to bad requests, the rest are
+// handled like word errors
+func listeningError(c echo.Context, err error) error {
+	if errors.Is(err, models.ErrInvalidTimeRange) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return wordError(c, err)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// MinimalPairHandler handles HTTP requests for minimal pairs
+type MinimalPairHandler struct {
+	service *services.MinimalPairService
+}
+
+// NewMinimalPairHandler creates a new instance of MinimalPairHandler
+func NewMinimalPairHandler(service *services.MinimalPairService) *MinimalPairHandler {
+	return &MinimalPairHandler{service: service}
+}
+
+// ListPairs lists the minimal pairs among all words, or those of the group
+// in the group_id query parameter, optionally of one contrast
+func (h *MinimalPairHandler) ListPairs(c echo.Context) error {
+	var groupID *int64
+	if value := c.QueryParam("group_id"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid group ID",
+			})
+		}
+		groupID = &parsed
+	}
+
+	pairs, err := h.service.ListPairs(c.Request().Context(), groupID, c.QueryParam("contrast"))
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"pairs": pairs,
+		"total": len(pairs),
+	})
+}
+
+// Coverage reports the minimal pairs each group has for every contrast
+func (h *MinimalPairHandler) Coverage(c echo.Context) error {
+	coverage, err := h.service.Coverage(c.Request().Context())
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"groups": coverage,
+	})
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// NumeralHandler handles HTTP requests for Hindi numbers
+type NumeralHandler struct {
+	service *services.NumeralService
+}
+
+// NewNumeralHandler creates a new instance of NumeralHandler
+func NewNumeralHandler(service *services.NumeralService) *NumeralHandler {
+	return &NumeralHandler{service: service}
+}
+
+// ListNumerals lists the numbers in the from and to query parameters, 0 to
+// 100 by default
+func (h *NumeralHandler) ListNumerals(c echo.Context) error {
+	from, to := 0, hindi.MaxNumber
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{{"from", &from}, {"to", &to}} {
+		value := c.QueryParam(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid " + param.name + " number",
+			})
+		}
+		*param.value = parsed
+	}
+
+	numerals, err := h.service.List(from, to)
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"numerals": numerals,
+		"total":    len(numerals),
+	})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// SentenceHandler handles HTTP requests for example sentences
+type SentenceHandler struct {
+	service *services.SentenceService
+}
+
+// NewSentenceHandler creates a new instance of SentenceHandler
+func NewSentenceHandler(service *services.SentenceService) *SentenceHandler {
+	return &SentenceHandler{service: service}
+}
+
+// ListSentences lists sentences, optionally only those of a word or language
+func (h *SentenceHandler) ListSentences(c echo.Context) error {
+	page, err := strconv.Atoi(c.QueryParam("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.QueryParam("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	params := repository.ListSentencesParams{
+		Page:     page,
+		PageSize: pageSize,
+		Language: c.QueryParam("language"),
+	}
+	if wordID := c.QueryParam("word_id"); wordID != "" {
+		params.WordID, err = strconv.ParseInt(wordID, 10, 64)
+		if err != nil || params.WordID <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid word ID",
+			})
+		}
+	}
+
+	sentences, total, err := h.service.ListSentences(c.Request().Context(), params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve sentences",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"sentences": sentences,
+		"total":     total,
+		"page":      page,
+		"pageSize":  pageSize,
+	})
+}
+
+// GetSentence retrieves a sentence by its ID
+func (h *SentenceHandler) GetSentence(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid sentence ID",
+		})
+	}
+
+	sentence, err := h.service.GetSentence(c.Request().Context(), id)
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, sentence)
+}
+
+// CreateSentence handles the creation of a new sentence
+func (h *SentenceHandler) CreateSentence(c echo.Context) error {
+	sentence := &models.Sentence{}
+	if err := c.Bind(sentence); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.service.CreateSentence(c.Request().Context(), sentence); err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, sentence)
+}
+
+// UpdateSentence replaces a sentence and its word links
+func (h *SentenceHandler) UpdateSentence(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid sentence ID",
+		})
+	}
+
+	sentence := &models.Sentence{}
+	if err := c.Bind(sentence); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+	sentence.ID = id
+
+	if err := h.service.UpdateSentence(c.Request().Context(), sentence); err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, sentence)
+}
+
+// DeleteSentence removes a sentence by its ID
+func (h *SentenceHandler) DeleteSentence(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid sentence ID",
+		})
+	}
+
+	if err := h.service.DeleteSentence(c.Request().Context(), id); err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Sentence deleted successfully",
+	})
+}
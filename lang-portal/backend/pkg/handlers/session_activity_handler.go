@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
 	"github.com/pavittarx/lang-portal/backend/pkg/services"
 )
 
@@ -48,6 +50,7 @@ func (h *SessionActivityHandler) AddSessionActivity(c echo.Context) error {
 	// Add session activity
 	sessionActivity, err := h.service.AddSessionActivity(
 		c.Request().Context(), 
+		userID(c),
 		req.SessionID, 
 		req.ActivityID, 
 		req.Challenge,
@@ -56,6 +59,15 @@ func (h *SessionActivityHandler) AddSessionActivity(c echo.Context) error {
 		req.Score,
 	)
 	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case errors.Is(err, models.ErrGradedActivity),
+			errors.Is(err, models.ErrSessionEnded):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		case errors.Is(err, models.ErrInvalidInput):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to add session activity",
 		})
@@ -78,6 +90,7 @@ func (h *SessionActivityHandler) GetSessionActivities(c echo.Context) error {
 	// Retrieve session activities
 	sessionActivities, err := h.service.GetSessionActivities(
 		c.Request().Context(), 
+		userID(c),
 		sessionID,
 	)
 	if err != nil {
@@ -103,6 +116,7 @@ func (h *SessionActivityHandler) DeleteSessionActivity(c echo.Context) error {
 	// Delete session activity
 	if err := h.service.DeleteSessionActivity(
 		c.Request().Context(), 
+		userID(c),
 		id,
 	); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
@@ -4,12 +4,12 @@ import (
 	"net/http"
 	"strconv"
 	"log"
-	"strings"
 	"database/sql"
 	"errors"
 	"context"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
 	"github.com/pavittarx/lang-portal/backend/pkg/services"
 )
 
@@ -25,7 +25,8 @@ func NewSessionHandler(service *services.SessionService) *SessionHandler {
 
 // CreateSessionRequest defines the request payload for creating a session
 type CreateSessionRequest struct {
-	ActivityID int64 `json:"activity_id" validate:"required"`
+	ActivityID int64  `json:"activity_id" validate:"required"`
+	GroupID    *int64 `json:"group_id,omitempty"`
 }
 
 // UpdateSessionRequest defines the request payload for updating a session
@@ -51,8 +52,15 @@ func (h *SessionHandler) CreateSession(c echo.Context) error {
 		})
 	}
 
+	// Validate optional group ID
+	if req.GroupID != nil && *req.GroupID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid group ID",
+		})
+	}
+
 	// Create session with automatic start_time
-	session, err := h.service.CreateSession(c.Request().Context(), req.ActivityID)
+	session, err := h.service.CreateSession(c.Request().Context(), userID(c), req.ActivityID, req.GroupID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to create session",
@@ -82,13 +90,13 @@ func (h *SessionHandler) GetSessionByID(c echo.Context) error {
 	}
 
 	// Retrieve session with activities
-	session, err := h.service.GetSessionByIDWithActivities(c.Request().Context(), id)
+	session, err := h.service.GetSessionByIDWithActivities(c.Request().Context(), userID(c), id)
 	if err != nil {
 		// Log the error for server-side tracking
 		log.Printf("Error retrieving session %d: %v", id, err)
 
 		// Check for specific error types
-		if strings.Contains(err.Error(), "no session available") {
+		if errors.Is(err, models.ErrNotFound) {
 			return c.JSON(http.StatusNotFound, map[string]string{
 				"error": err.Error(),
 			})
@@ -118,7 +126,7 @@ func (h *SessionHandler) GetSessions(c echo.Context) error {
 	}
 
 	// Retrieve sessions
-	sessions, err := h.service.ListSessions(c.Request().Context(), page, pageSize)
+	sessions, err := h.service.ListSessions(c.Request().Context(), userID(c), page, pageSize)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to retrieve sessions",
@@ -139,19 +147,38 @@ func (h *SessionHandler) UpdateSession(c echo.Context) error {
 	}
 
 	// End the session
-	if err := h.service.EndSession(c.Request().Context(), req.SessionID, req.Score); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to update session",
-		})
+	if err := h.service.EndSession(c.Request().Context(), userID(c), req.SessionID, req.Score); err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		case errors.Is(err, models.ErrSessionEnded):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		default:
+			log.Printf("Error ending session %d: %v", req.SessionID, err)
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to update session",
+			})
+		}
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
-// DeleteAllSessions handles deletion of all sessions
+// DeleteAllSessions handles deletion of every session, or of one learner's with user_id
 func (h *SessionHandler) DeleteAllSessions(c echo.Context) (int, error) {
-	// Delete all sessions and their associated session activities
-	sessionsDeleted, err := h.service.DeleteAllSessions(c.Request().Context())
+	var learnerID *int64
+	if idStr := c.QueryParam("user_id"); idStr != "" {
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil || id <= 0 {
+			return http.StatusBadRequest, c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid user ID",
+			})
+		}
+		learnerID = &id
+	}
+
+	// Delete the sessions and their associated session activities
+	sessionsDeleted, err := h.service.DeleteAllSessions(c.Request().Context(), learnerID)
 	if err != nil {
 		// Log the error for server-side tracking
 		log.Printf("Error deleting all sessions: %v", err)
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/pkg/speech"
+)
+
+// recordingCacheControl keeps recordings out of shared caches, they belong to one learner
+const recordingCacheControl = "private, max-age=3600"
+
+// SpeakingHandler handles HTTP requests for spoken answers and their recordings
+type SpeakingHandler struct {
+	service *services.SpeakingService
+}
+
+// NewSpeakingHandler creates a new instance of SpeakingHandler
+func NewSpeakingHandler(service *services.SpeakingService) *SpeakingHandler {
+	return &SpeakingHandler{service: service}
+}
+
+// SubmitRecording grades a recorded answer to a speaking challenge. The
+// multipart form carries the ID of the challenge in the "challenge_id" field,
+// the recording in the "audio" field and optionally its "duration_ms". A
+// challenge sent back as JSON in the "challenge" field is answered by its ID.
+func (h *SpeakingHandler) SubmitRecording(c echo.Context) error {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || sessionID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid session ID",
+		})
+	}
+
+	challengeID := c.FormValue("challenge_id")
+	if value := c.FormValue("challenge"); challengeID == "" && value != "" {
+		var challenge activities.Challenge
+		if err := json.Unmarshal([]byte(value), &challenge); err == nil {
+			challengeID = challenge.ID
+		}
+	}
+	if challengeID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A challenge ID is required in the multipart field \"challenge_id\"",
+		})
+	}
+
+	fileHeader, err := c.FormFile("audio")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A recording is required in the multipart field \"audio\"",
+		})
+	}
+
+	upload := services.RecordingUpload{
+		ContentType: fileHeader.Header.Get(echo.HeaderContentType),
+	}
+	if value := c.FormValue("duration_ms"); value != "" {
+		duration, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || duration <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid duration",
+			})
+		}
+		upload.DurationMS = &duration
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to read the uploaded recording",
+		})
+	}
+	defer file.Close()
+	upload.Content = file
+
+	answer, err := h.service.SubmitRecording(c.Request().Context(), userID(c), sessionID, challengeID, upload)
+	if err != nil {
+		return speakingError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, answer)
+}
+
+// ListRecordings lists the learner's recordings, optionally of the session
+// in the "session_id" query parameter
+func (h *SpeakingHandler) ListRecordings(c echo.Context) error {
+	var sessionID int64
+	if value := c.QueryParam("session_id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid session ID",
+			})
+		}
+		sessionID = id
+	}
+
+	recordings, err := h.service.ListRecordings(c.Request().Context(), userID(c), sessionID)
+	if err != nil {
+		return speakingError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, recordings)
+}
+
+// ServeRecording streams a recording of the learner, supporting range
+// requests so it can be seeked
+func (h *SpeakingHandler) ServeRecording(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid recording ID",
+		})
+	}
+
+	recording, content, err := h.service.OpenRecording(c.Request().Context(), userID(c), id)
+	if err != nil {
+		return speakingError(c, err)
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, recording.MimeType)
+	header.Set("Cache-Control", recordingCacheControl)
+
+	http.ServeContent(c.Response(), c.Request(), "", recording.CreatedAt, content)
+	return nil
+}
+
+// DeleteRecording removes a recording of the learner
+func (h *SpeakingHandler) DeleteRecording(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid recording ID",
+		})
+	}
+
+	if err := h.service.DeleteRecording(c.Request().Context(), userID(c), id); err != nil {
+		return speakingError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Recording deleted successfully",
+	})
+}
+
+// speakingError maps speech recognition and upload errors to responses, the
+// rest are handled like challenge errors
+func speakingError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, models.ErrNotConfigured):
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+	case errors.Is(err, context.DeadlineExceeded):
+		return c.JSON(http.StatusGatewayTimeout, map[string]string{"error": "The speech recognizer did not answer in time"})
+	case errors.Is(err, speech.ErrRecognition):
+		return c.JSON(http.StatusBadGateway, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrFileTooLarge), errors.Is(err, models.ErrUnsupportedMedia):
+		return assetError(c, err)
+	}
+	return challengeError(c, err, "Failed to grade recording")
+}
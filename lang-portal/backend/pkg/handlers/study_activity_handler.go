@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
 	"github.com/pavittarx/lang-portal/backend/pkg/services"
 )
 
@@ -19,14 +22,20 @@ func NewStudyActivityHandler(service *services.StudyActivityService) *StudyActiv
 	}
 }
 
-// GetStudyActivities retrieves all available study activities
-
-
-
-
-
+// StudyActivityRequest defines the request payload for creating or updating a study activity
+type StudyActivityRequest struct {
+	models.StudyActivity
+	Enabled *bool `json:"enabled"`
+}
 
+// toModel converts the request into a study activity, enabling it unless told otherwise
+func (r *StudyActivityRequest) toModel() *models.StudyActivity {
+	activity := r.StudyActivity
+	activity.Enabled = r.Enabled == nil || *r.Enabled
+	return &activity
+}
 
+// GetStudyActivities retrieves all available study activities
 func (h *StudyActivityHandler) GetStudyActivities(c echo.Context) error {
 	ctx := c.Request().Context()
 	activities, err := h.service.GetStudyActivities(ctx)
@@ -37,3 +46,100 @@ func (h *StudyActivityHandler) GetStudyActivities(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, activities)
 }
+
+// GetStudyActivityByID retrieves a study activity by its ID
+func (h *StudyActivityHandler) GetStudyActivityByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid study activity ID",
+		})
+	}
+
+	activity, err := h.service.GetStudyActivityByID(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve study activity",
+		})
+	}
+
+	return c.JSON(http.StatusOK, activity)
+}
+
+// GetActivityTypes lists the activity types that can be used for study activities
+func (h *StudyActivityHandler) GetActivityTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"types": h.service.GetActivityTypes(),
+	})
+}
+
+// CreateStudyActivity handles the creation of a new study activity
+func (h *StudyActivityHandler) CreateStudyActivity(c echo.Context) error {
+	var req StudyActivityRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	activity := req.toModel()
+	if err := h.service.CreateStudyActivity(c.Request().Context(), activity); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, activity)
+}
+
+// UpdateStudyActivity updates an existing study activity
+func (h *StudyActivityHandler) UpdateStudyActivity(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid study activity ID",
+		})
+	}
+
+	var req StudyActivityRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	activity := req.toModel()
+	activity.ID = id
+	if err := h.service.UpdateStudyActivity(c.Request().Context(), activity); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, activity)
+}
+
+// DeleteStudyActivity removes a study activity by its ID
+func (h *StudyActivityHandler) DeleteStudyActivity(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid study activity ID",
+		})
+	}
+
+	if err := h.service.DeleteStudyActivity(c.Request().Context(), id); err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete study activity",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Study activity deleted successfully",
+	})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+)
+
+// TutorHandler handles HTTP requests for sentence tutor conversations
+type TutorHandler struct {
+	service *services.TutorService
+}
+
+// NewTutorHandler creates a new instance of TutorHandler
+func NewTutorHandler(service *services.TutorService) *TutorHandler {
+	return &TutorHandler{service: service}
+}
+
+// StartConversation starts a conversation about a sentence in a tutor session
+func (h *TutorHandler) StartConversation(c echo.Context) error {
+	var req services.StartConversationRequest
+	if err := c.Bind(&req); err != nil || req.SessionID <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "A session ID is required",
+		})
+	}
+
+	conversation, err := h.service.StartConversation(c.Request().Context(), userID(c), req)
+	if err != nil {
+		return tutorError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, conversation)
+}
+
+// ListConversations lists the user's conversations, optionally filtered by
+// the "session_id" query parameter
+func (h *TutorHandler) ListConversations(c echo.Context) error {
+	var sessionID int64
+	if value := c.QueryParam("session_id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid session ID",
+			})
+		}
+		sessionID = id
+	}
+
+	conversations, err := h.service.ListConversations(c.Request().Context(), userID(c), sessionID)
+	if err != nil {
+		return tutorError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, conversations)
+}
+
+// GetConversation retrieves a conversation with its messages
+func (h *TutorHandler) GetConversation(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid conversation ID",
+		})
+	}
+
+	conversation, err := h.service.GetConversation(c.Request().Context(), userID(c), id)
+	if err != nil {
+		return tutorError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, conversation)
+}
+
+// SendMessage sends a question or an attempt at the sentence to the tutor
+func (h *TutorHandler) SendMessage(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid conversation ID",
+		})
+	}
+
+	var req services.SendMessageRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	turn, err := h.service.SendMessage(c.Request().Context(), userID(c), id, req)
+	if err != nil {
+		return tutorError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, turn)
+}
+
+// tutorError maps session state errors to conflicts, the rest are handled
+// like content errors
+func tutorError(c echo.Context, err error) error {
+	if errors.Is(err, models.ErrActivityDisabled) || errors.Is(err, models.ErrSessionEnded) {
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return contentError(c, err)
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"log"
 
@@ -44,16 +46,14 @@ func (h *WordHandler) CreateWord(c echo.Context) error {
 
 	// Create the word
 	if err := h.wordService.CreateWord(c.Request().Context(), word); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return wordError(c, err)
 	}
 
 	// Return the created word
 	return c.JSON(http.StatusCreated, word)
 }
 
-// GetWordByID retrieves a word by its ID
+// GetWordByID retrieves a word and its example sentences by the word's ID
 func (h *WordHandler) GetWordByID(c echo.Context) error {
 	// Parse the ID from the URL parameter
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -63,12 +63,10 @@ func (h *WordHandler) GetWordByID(c echo.Context) error {
 		})
 	}
 
-	// Retrieve the word
-	word, err := h.wordService.GetWordByID(c.Request().Context(), id)
+	// Retrieve the word with its example sentences
+	word, err := h.wordService.GetWordWithSentences(c.Request().Context(), id)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error": err.Error(),
-		})
+		return wordError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, word)
@@ -96,15 +94,47 @@ func (h *WordHandler) UpdateWord(c echo.Context) error {
 
 	// Update the word
 	if err := h.wordService.UpdateWord(c.Request().Context(), word); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
-		})
+		return wordError(c, err)
 	}
 
 	// Return the updated word
 	return c.JSON(http.StatusOK, word)
 }
 
+// ResetIPA drops the IPA an editor entered for a word and regenerates it
+func (h *WordHandler) ResetIPA(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid word ID",
+		})
+	}
+
+	word, err := h.wordService.ResetIPA(c.Request().Context(), id)
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, word)
+}
+
+// ResetForms drops the forms an editor entered for a word and regenerates them
+func (h *WordHandler) ResetForms(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid word ID",
+		})
+	}
+
+	word, err := h.wordService.ResetForms(c.Request().Context(), id)
+	if err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, word)
+}
+
 // DeleteWord removes a word by its ID
 func (h *WordHandler) DeleteWord(c echo.Context) error {
 	// Parse the ID from the URL parameter
@@ -145,8 +175,10 @@ func (h *WordHandler) ListWords(c echo.Context) error {
 		Page:     page,
 		PageSize: pageSize,
 		Search:   c.QueryParam("search"),
+		Field:    c.QueryParam("field"),
 		Language: c.QueryParam("language"),
 	}
+	legacyWordField(&params)
 
 	// Call service to list words
 	words, totalCount, err := h.wordService.ListWords(c.Request().Context(), params)
@@ -174,12 +206,12 @@ func (h *WordHandler) ListWords(c echo.Context) error {
 
 // SearchWords provides a search endpoint for words
 func (h *WordHandler) SearchWords(c echo.Context) error {
-	// Get search query and language
+	// Get search query and the field to search in
 	query := c.QueryParam("query")
-	language := c.QueryParam("language")
+	field := c.QueryParam("field")
 
 	// Perform search
-	words, totalCount, err := h.wordService.SearchWords(c.Request().Context(), query, language)
+	words, totalCount, err := h.wordService.SearchWords(c.Request().Context(), query, field)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
@@ -264,8 +296,32 @@ func (h *WordHandler) GetWords(c echo.Context) error {
 		pageSize = 10
 	}
 
+	params := repository.ListWordsParams{
+		Page:         page,
+		PageSize:     pageSize,
+		Language:     c.QueryParam("language"),
+		Search:       c.QueryParam("search"),
+		Field:        c.QueryParam("field"),
+		PartOfSpeech: c.QueryParam("part_of_speech"),
+		Gender:       c.QueryParam("gender"),
+		Transitivity: c.QueryParam("transitivity"),
+		Sort:         c.QueryParam("sort"),
+		Order:        c.QueryParam("order"),
+	}
+	legacyWordField(&params)
+	if err := models.ValidateGrammarValues(params.PartOfSpeech, params.Gender, params.Transitivity); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	if err := models.ValidateWordSort(params.Sort, params.Order); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	// Retrieve words with pagination
-	words, total, err := h.wordService.GetWords(c.Request().Context(), page, pageSize)
+	words, total, err := h.wordService.GetWords(c.Request().Context(), params)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to retrieve words",
@@ -348,3 +404,66 @@ func (h *WordHandler) GetWordsByGroup(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, words)
 }
+
+// ListLanguages retrieves the languages words can be written in
+func (h *WordHandler) ListLanguages(c echo.Context) error {
+	languages, err := h.wordService.ListLanguages(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to retrieve languages",
+		})
+	}
+
+	return c.JSON(http.StatusOK, languages)
+}
+
+// CreateLanguage adds a language words can be written in
+func (h *WordHandler) CreateLanguage(c echo.Context) error {
+	language := &models.Language{}
+	if err := c.Bind(language); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.wordService.CreateLanguage(c.Request().Context(), language); err != nil {
+		return wordError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, language)
+}
+
+// legacyWordFields are the fields words were searched in when language
+// named a field rather than the language of the words
+var legacyWordFields = map[string]string{
+	"hindi":    "target",
+	"hinglish": "romanized",
+	"english":  "native",
+}
+
+// legacyWordField reads a legacy language parameter as the field it named
+func legacyWordField(params *repository.ListWordsParams) {
+	field, ok := legacyWordFields[strings.ToLower(params.Language)]
+	if !ok {
+		return
+	}
+	if params.Field == "" {
+		params.Field = field
+	}
+	params.Language = ""
+}
+
+// wordError maps word, sentence and language errors to a status code
+func wordError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, models.ErrInvalidLanguage),
+		errors.Is(err, models.ErrInvalidScript),
+		errors.Is(err, models.ErrInvalidInput),
+		errors.Is(err, models.ErrInvalidID):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, models.ErrNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+}
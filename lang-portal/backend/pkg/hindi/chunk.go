@@ -0,0 +1,40 @@
+package hindi
+
+import "strings"
+
+// compoundPostpositions complete a compound postposition after के or की, or
+// a genitive pronoun such as उसके, as in "के लिए" or "उसकी तरह"
+var compoundPostpositions = map[string]bool{
+	"लिए": true, "साथ": true, "बाद": true, "पहले": true, "पास": true,
+	"ऊपर": true, "नीचे": true, "अंदर": true, "बाहर": true, "सामने": true,
+	"पीछे": true, "बारे": true, "तरह": true, "द्वारा": true,
+}
+
+// IsPostposition reports whether word is a simple postposition
+func IsPostposition(word string) bool {
+	for _, p := range Postpositions {
+		if word == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Chunk groups the words of a sentence into the units a learner reorders,
+// attaching postpositions to the noun phrase before them, as in "नदी का"
+// or "घर के पास"
+func Chunk(words []string) []string {
+	var chunks []string
+	previous := ""
+	for _, word := range words {
+		attached := IsPostposition(word) ||
+			(compoundPostpositions[word] && (strings.HasSuffix(previous, "के") || strings.HasSuffix(previous, "की")))
+		if attached && len(chunks) > 0 {
+			chunks[len(chunks)-1] += " " + word
+		} else {
+			chunks = append(chunks, word)
+		}
+		previous = word
+	}
+	return chunks
+}
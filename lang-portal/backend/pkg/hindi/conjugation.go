@@ -0,0 +1,274 @@
+package hindi
+
+import "strings"
+
+// Tense is a tense, aspect or mood a verb is conjugated in
+type Tense string
+
+const (
+	// PresentHabitual is what is done regularly, पढ़ता है
+	PresentHabitual Tense = "present_habitual"
+	// PastPerfective is what was done, पढ़ा or with ने उसने पढ़ा
+	PastPerfective Tense = "past_perfective"
+	// Future is what will be done, पढ़ेगा
+	Future Tense = "future"
+	// Subjunctive is what may or should be done, पढ़े
+	Subjunctive Tense = "subjunctive"
+	// Imperative is a request or order to the second person, पढ़ो
+	Imperative Tense = "imperative"
+)
+
+// Tenses lists the tenses verbs are conjugated in
+var Tenses = []Tense{PresentHabitual, PastPerfective, Future, Subjunctive, Imperative}
+
+// IsTense reports whether s names one of Tenses
+func IsTense(s string) bool {
+	for _, tense := range Tenses {
+		if string(tense) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Formality is how polite a second person subject is
+type Formality string
+
+const (
+	// Intimate is तू, for close family, children and prayer
+	Intimate Formality = "intimate"
+	// Familiar is तुम, for friends and peers
+	Familiar Formality = "familiar"
+	// Formal is आप, for elders and strangers
+	Formal Formality = "formal"
+)
+
+// Subject is a pronoun a verb agrees with
+type Subject struct {
+	Person    int
+	Plural    bool      // grammatically, तुम and आप are plural
+	Formality Formality // of the second person only
+	Pronoun   string    // मैं
+	Ergative  string    // मैंने, the subject of a transitive perfective
+}
+
+// Subjects lists the subjects verbs are conjugated for
+var Subjects = []Subject{
+	{Person: 1, Pronoun: "मैं", Ergative: "मैंने"},
+	{Person: 1, Plural: true, Pronoun: "हम", Ergative: "हमने"},
+	{Person: 2, Formality: Intimate, Pronoun: "तू", Ergative: "तूने"},
+	{Person: 2, Plural: true, Formality: Familiar, Pronoun: "तुम", Ergative: "तुमने"},
+	{Person: 2, Plural: true, Formality: Formal, Pronoun: "आप", Ergative: "आपने"},
+	{Person: 3, Pronoun: "वह", Ergative: "उसने"},
+	{Person: 3, Plural: true, Pronoun: "वे", Ergative: "उन्होंने"},
+}
+
+// agreement is the column of a subject in the subjunctive, future and
+// auxiliary tables: मैं, तू and वह, the plurals हम, आप and वे, and तुम
+type agreement int
+
+const (
+	firstSingular agreement = iota
+	singular
+	plural
+	familiar
+)
+
+func (s Subject) agreement() agreement {
+	switch {
+	case s.Person == 1 && !s.Plural:
+		return firstSingular
+	case s.Formality == Familiar:
+		return familiar
+	case s.Plural:
+		return plural
+	}
+	return singular
+}
+
+// Conjugation is one form of a verb
+type Conjugation struct {
+	Tense    Tense
+	Subject  Subject
+	Feminine bool
+	Ergative bool   // the subject takes ने and the verb does not agree with it
+	Form     string // the verb with its auxiliary, पढ़ती हूँ
+}
+
+// SubjectForm is the subject as it is written with the form, उसने in an
+// ergative perfective and वह otherwise
+func (c Conjugation) SubjectForm() string {
+	if c.Ergative {
+		return c.Subject.Ergative
+	}
+	return c.Subject.Pronoun
+}
+
+// presentAuxiliaries are the forms of होना in the present, by agreement
+var presentAuxiliaries = [4]string{"हूँ", "है", "हैं", "हो"}
+
+// irregularSubjunctives are roots whose subjunctive does not follow their
+// ending, by agreement
+var irregularSubjunctives = map[string][4]string{
+	"ले": {"लूँ", "ले", "लें", "लो"},
+	"दे": {"दूँ", "दे", "दें", "दो"},
+	"हो": {"होऊँ", "हो", "हों", "हो"},
+}
+
+// irregularPerfectives are roots with an irregular perfective participle,
+// masculine singular and plural, feminine singular and plural
+var irregularPerfectives = map[string][4]string{
+	"कर": {"किया", "किए", "की", "कीं"},
+	"जा": {"गया", "गए", "गई", "गईं"},
+	"दे": {"दिया", "दिए", "दी", "दीं"},
+	"ले": {"लिया", "लिए", "ली", "लीं"},
+	"हो": {"हुआ", "हुए", "हुई", "हुईं"},
+}
+
+// irregularPolite are roots with an irregular आप imperative
+var irregularPolite = map[string]string{
+	"कर": "कीजिए",
+	"ले": "लीजिए",
+	"दे": "दीजिए",
+}
+
+// nonErgative are transitive roots whose perfective does not take ने
+var nonErgative = map[string]bool{
+	"ला": true, // लाना is ले आना, to bring
+}
+
+// Conjugate returns the forms of a verb from its root for every tense,
+// subject and gender. The imperative is only conjugated for the second
+// person. Transitive verbs take the ergative in the perfective, with the
+// subject in ने and the verb in the masculine singular of an unstated object.
+// A compound root such as काम कर conjugates its last word.
+func Conjugate(root string, transitive bool) []Conjugation {
+	prefix, stem := "", root
+	if i := strings.LastIndex(root, " "); i >= 0 {
+		prefix, stem = root[:i+1], root[i+1:]
+	}
+
+	var conjugations []Conjugation
+	for _, tense := range Tenses {
+		for _, subject := range Subjects {
+			if tense == Imperative && subject.Person != 2 {
+				continue
+			}
+			for _, feminine := range []bool{false, true} {
+				c := Conjugation{Tense: tense, Subject: subject, Feminine: feminine}
+				c.Ergative = tense == PastPerfective && transitive && !nonErgative[stem]
+				c.Form = prefix + conjugate(stem, c)
+				conjugations = append(conjugations, c)
+			}
+		}
+	}
+	return conjugations
+}
+
+func conjugate(stem string, c Conjugation) string {
+	agreement := c.Subject.agreement()
+
+	switch c.Tense {
+	case PresentHabitual:
+		participle := stem + "ता"
+		switch {
+		case c.Feminine:
+			participle = stem + "ती"
+		case c.Subject.Plural:
+			participle = stem + "ते"
+		}
+		return participle + " " + presentAuxiliaries[agreement]
+
+	case PastPerfective:
+		if c.Ergative {
+			return perfective(stem, false, false)
+		}
+		return perfective(stem, c.Feminine, c.Subject.Plural)
+
+	case Future:
+		suffix := "गा"
+		switch {
+		case c.Feminine:
+			suffix = "गी"
+		case agreement == plural || agreement == familiar:
+			suffix = "गे"
+		}
+		return subjunctive(stem, agreement) + suffix
+
+	case Subjunctive:
+		return subjunctive(stem, agreement)
+
+	case Imperative:
+		switch c.Subject.Formality {
+		case Intimate:
+			return stem
+		case Familiar:
+			return subjunctive(stem, familiar)
+		}
+		return polite(stem)
+	}
+	return ""
+}
+
+// subjunctive adds the subjunctive ending to a root: पढ़ूँ, पढ़े, पढ़ें,
+// पढ़ो after a consonant and खाऊँ, खाए, खाएँ, खाओ after a vowel
+func subjunctive(stem string, agreement agreement) string {
+	if forms, ok := irregularSubjunctives[stem]; ok {
+		return forms[agreement]
+	}
+
+	switch {
+	case endsInConsonant(stem):
+		return stem + [4]string{"ूँ", "े", "ें", "ो"}[agreement]
+	case strings.HasSuffix(stem, "ी"):
+		// पियूँ, पिए, पिएँ, पियो
+		return trimLastRunes(stem, 1) + "ि" + [4]string{"यूँ", "ए", "एँ", "यो"}[agreement]
+	case strings.HasSuffix(stem, "ू"):
+		stem = trimLastRunes(stem, 1) + "ु"
+	}
+	return stem + [4]string{"ऊँ", "ए", "एँ", "ओ"}[agreement]
+}
+
+// perfective forms the perfective participle of a root: पढ़ा, पढ़े, पढ़ी,
+// पढ़ीं after a consonant and खाया, खाए, खाई, खाईं after a vowel
+func perfective(stem string, feminine, plural bool) string {
+	index := 0
+	if feminine {
+		index += 2
+	}
+	if plural {
+		index++
+	}
+
+	if forms, ok := irregularPerfectives[stem]; ok {
+		return forms[index]
+	}
+
+	switch {
+	case endsInConsonant(stem):
+		return stem + [4]string{"ा", "े", "ी", "ीं"}[index]
+	case strings.HasSuffix(stem, "ी"):
+		// पिया, पिए, पी, पीं
+		short := trimLastRunes(stem, 1) + "ि"
+		return [4]string{short + "या", short + "ए", stem, stem + "ं"}[index]
+	case strings.HasSuffix(stem, "ू"):
+		// छुआ, छुए, छुई, छुईं
+		return trimLastRunes(stem, 1) + "ु" + [4]string{"आ", "ए", "ई", "ईं"}[index]
+	}
+	return stem + [4]string{"या", "ए", "ई", "ईं"}[index]
+}
+
+// polite forms the आप imperative: पढ़िए, खाइए, पीजिए
+func polite(stem string) string {
+	if form, ok := irregularPolite[stem]; ok {
+		return form
+	}
+
+	switch {
+	case endsInConsonant(stem):
+		return stem + "िए"
+	case strings.HasSuffix(stem, "ी"):
+		return stem + "जिए"
+	}
+	return stem + "इए"
+}
@@ -0,0 +1,168 @@
+package hindi
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+)
+
+// Contrast is a phonetic feature two words of a minimal pair differ in
+type Contrast string
+
+const (
+	// Aspiration contrasts a plain and an aspirated consonant, as in कल and खल
+	Aspiration Contrast = "aspiration"
+	// Retroflex contrasts a retroflex and a dental consonant, as in टाल and ताल
+	Retroflex Contrast = "retroflex"
+	// VowelLength contrasts a short and a long vowel, as in दिन and दीन
+	VowelLength Contrast = "vowel_length"
+	// Nasalization contrasts an oral and a nasal vowel, as in है and हैं
+	Nasalization Contrast = "nasalization"
+)
+
+// Contrasts lists the contrasts minimal pairs are found for
+var Contrasts = []Contrast{Aspiration, Retroflex, VowelLength, Nasalization}
+
+// IsContrast reports whether s names one of Contrasts
+func IsContrast(s string) bool {
+	for _, contrast := range Contrasts {
+		if string(contrast) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// contrastingLetters holds the contrast between two letters, in both orders
+var contrastingLetters = bothOrders(map[[2]rune]Contrast{
+	{'क', 'ख'}: Aspiration, {'ग', 'घ'}: Aspiration, {'च', 'छ'}: Aspiration, {'ज', 'झ'}: Aspiration,
+	{'ट', 'ठ'}: Aspiration, {'ड', 'ढ'}: Aspiration, {'त', 'थ'}: Aspiration, {'द', 'ध'}: Aspiration,
+	{'प', 'फ'}: Aspiration, {'ब', 'भ'}: Aspiration,
+	{'ट', 'त'}: Retroflex, {'ठ', 'थ'}: Retroflex, {'ड', 'द'}: Retroflex, {'ढ', 'ध'}: Retroflex, {'ण', 'न'}: Retroflex,
+	{'ि', 'ी'}: VowelLength, {'ु', 'ू'}: VowelLength,
+	{'अ', 'आ'}: VowelLength, {'इ', 'ई'}: VowelLength, {'उ', 'ऊ'}: VowelLength,
+})
+
+func bothOrders(contrasts map[[2]rune]Contrast) map[[2]rune]Contrast {
+	both := make(map[[2]rune]Contrast, 2*len(contrasts))
+	for pair, contrast := range contrasts {
+		both[pair] = contrast
+		both[[2]rune{pair[1], pair[0]}] = contrast
+	}
+	return both
+}
+
+// FindContrast reports the contrast two words differ in when they are a
+// minimal pair: written with the same aksharas but one, which differs in a
+// single letter of the contrast or in an added ा or nasal sign, as in कम and
+// काम or है and हैं. Chandrabindu and anusvara are not told apart.
+func FindContrast(a, b string) (Contrast, bool) {
+	aksharasA := devanagari.Aksharas(Normalize(a))
+	aksharasB := devanagari.Aksharas(Normalize(b))
+	if len(aksharasA) != len(aksharasB) {
+		return "", false
+	}
+
+	differing := -1
+	for i := range aksharasA {
+		if aksharasA[i] != aksharasB[i] {
+			if differing >= 0 {
+				return "", false
+			}
+			differing = i
+		}
+	}
+	if differing < 0 {
+		return "", false
+	}
+	return aksharaContrast(aksharasA[differing], aksharasB[differing])
+}
+
+// aksharaContrast compares two differing aksharas letter by letter
+func aksharaContrast(a, b string) (Contrast, bool) {
+	runesA, runesB := []rune(a), []rune(b)
+	if len(runesA) > len(runesB) {
+		runesA, runesB = runesB, runesA
+	}
+
+	// One letter substituted for another
+	if len(runesA) == len(runesB) {
+		differing := -1
+		for i := range runesA {
+			if runesA[i] != runesB[i] {
+				if differing >= 0 {
+					return "", false
+				}
+				differing = i
+			}
+		}
+		contrast, ok := contrastingLetters[[2]rune{runesA[differing], runesB[differing]}]
+		return contrast, ok
+	}
+
+	// One sign added, the inherent vowel lengthened or the vowel nasalized
+	if len(runesB) != len(runesA)+1 {
+		return "", false
+	}
+	for i, r := range runesB {
+		if string(runesB[:i])+string(runesB[i+1:]) != string(runesA) {
+			continue
+		}
+		switch r {
+		case 'ा':
+			return VowelLength, true
+		case 'ं':
+			return Nasalization, true
+		}
+	}
+	return "", false
+}
+
+// Pair is a minimal pair found among words, by their indexes
+type Pair struct {
+	First    int
+	Second   int
+	Contrast Contrast
+}
+
+// MinimalPairs finds the minimal pairs among words. Words are bucketed by
+// all their aksharas but one, so only words that can differ in a single
+// akshara are compared.
+func MinimalPairs(words []string) []Pair {
+	buckets := make(map[string][]int)
+	for i, word := range words {
+		aksharas := devanagari.Aksharas(Normalize(word))
+		for position := range aksharas {
+			key := strconv.Itoa(position) + "|" + strings.Join(aksharas[:position], "|") +
+				"|_|" + strings.Join(aksharas[position+1:], "|")
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	var pairs []Pair
+	for _, bucket := range buckets {
+		for x := 0; x < len(bucket); x++ {
+			for y := x + 1; y < len(bucket); y++ {
+				first, second := bucket[x], bucket[y]
+				if seen[[2]int{first, second}] {
+					continue
+				}
+				seen[[2]int{first, second}] = true
+				if contrast, ok := FindContrast(words[first], words[second]); ok {
+					pairs = append(pairs, Pair{First: first, Second: second, Contrast: contrast})
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].First != pairs[j].First {
+			return pairs[i].First < pairs[j].First
+		}
+		return pairs[i].Second < pairs[j].Second
+	})
+	return pairs
+}
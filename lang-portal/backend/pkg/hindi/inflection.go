@@ -0,0 +1,80 @@
+// Package hindi implements Hindi-specific morphology on top of the script helpers
+package hindi
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+)
+
+// Code is the ISO 639 code of Hindi
+const Code = "hi"
+
+// Postpositions are the case markers that follow a noun, sometimes written
+// joined to it as in "घरमें"
+var Postpositions = []string{"ने", "को", "से", "में", "पर", "का", "की", "के", "तक"}
+
+// Normalize folds spellings that do not change the word, so far only
+// chandrabindu written as anusvara as in "हाँ" and "हां"
+func Normalize(s string) string {
+	return strings.ReplaceAll(s, "ँ", "ं")
+}
+
+// Inflections returns the lemma together with its regular direct and oblique,
+// singular and plural forms. Irregular forms are not generated.
+func Inflections(lemma string) []string {
+	forms := []string{lemma}
+	add := func(stem string, endings ...string) {
+		for _, ending := range endings {
+			forms = append(forms, stem+ending)
+		}
+	}
+
+	last, _ := utf8.DecodeLastRuneInString(lemma)
+	switch {
+	case strings.HasSuffix(lemma, "ा"):
+		// लड़का: लड़के, लड़कों; feminine माला: मालाएँ, मालाओं
+		stem := strings.TrimSuffix(lemma, "ा")
+		add(stem, "े", "ों", "ो")
+		add(lemma, "एँ", "ओं")
+	case strings.HasSuffix(lemma, "ी"):
+		// लड़की: लड़कियाँ, लड़कियों
+		stem := strings.TrimSuffix(lemma, "ी") + "ि"
+		add(stem, "याँ", "यों", "यो")
+	case strings.HasSuffix(lemma, "ू"), strings.HasSuffix(lemma, "ु"):
+		// वस्तु: वस्तुएँ, वस्तुओं
+		add(lemma, "एँ", "ओं", "ओ")
+	case devanagari.IsConsonant(last), last == devanagari.Nukta:
+		// घर: घरों; बात: बातें
+		add(lemma, "ों", "ें", "ो")
+	}
+
+	return forms
+}
+
+// IsInflectionOf reports whether form is the lemma or one of its inflections
+func IsInflectionOf(form, lemma string) bool {
+	form = Normalize(form)
+	for _, inflection := range Inflections(lemma) {
+		if form == Normalize(inflection) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitPostposition separates a postposition joined to an inflection of the
+// lemma, returning the word and the postposition. The postposition is empty
+// when none is attached, and ok is false when token is not a form of lemma.
+func SplitPostposition(token, lemma string) (word, postposition string, ok bool) {
+	if IsInflectionOf(token, lemma) {
+		return token, "", true
+	}
+	for _, p := range Postpositions {
+		if stem := strings.TrimSuffix(token, p); stem != token && IsInflectionOf(stem, lemma) {
+			return stem, p, true
+		}
+	}
+	return "", "", false
+}
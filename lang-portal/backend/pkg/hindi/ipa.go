@@ -0,0 +1,109 @@
+package hindi
+
+import "strings"
+
+// ipaConsonants holds the Hindi pronunciation of each consonant, keeping
+// the dental, retroflex and aspirated contrasts Hinglish spelling hides
+var ipaConsonants = map[rune]string{
+	'क': "k", 'ख': "kʰ", 'ग': "ɡ", 'घ': "ɡʱ", 'ङ': "ŋ",
+	'च': "t͡ʃ", 'छ': "t͡ʃʰ", 'ज': "d͡ʒ", 'झ': "d͡ʒʱ", 'ञ': "ɲ",
+	'ट': "ʈ", 'ठ': "ʈʰ", 'ड': "ɖ", 'ढ': "ɖʱ", 'ण': "ɳ",
+	'त': "t̪", 'थ': "t̪ʰ", 'द': "d̪", 'ध': "d̪ʱ", 'न': "n",
+	'प': "p", 'फ': "pʰ", 'ब': "b", 'भ': "bʱ", 'म': "m",
+	'य': "j", 'र': "r", 'ल': "l", 'ळ': "ɭ", 'व': "ʋ",
+	'श': "ʃ", 'ष': "ʃ", 'स': "s", 'ह': "ɦ",
+	'ऩ': "n", 'ऱ': "r", 'ऴ': "ɭ",
+	'\u0958': "q", '\u0959': "x", '\u095a': "ɣ", '\u095b': "z",
+	'\u095c': "ɽ", '\u095d': "ɽʱ", '\u095e': "f", '\u095f': "j",
+}
+
+// ipaVowels holds the Hindi pronunciation of each vowel
+var ipaVowels = map[rune]string{
+	'अ': "ə", 'आ': "aː", 'इ': "ɪ", 'ई': "iː", 'उ': "ʊ", 'ऊ': "uː",
+	'ऋ': "rɪ", 'ॠ': "riː", 'ऌ': "lɪ",
+	'ए': "eː", 'ऐ': "ɛː", 'ओ': "oː", 'औ': "ɔː",
+	'ऎ': "e", 'ऒ': "o", 'ऍ': "æ", 'ऑ': "ɔ",
+}
+
+// homorganicNasals is the nasal an anusvara is pronounced as before a stop
+// of the same place of articulation, as in "हिंदी" or "संबंध"
+var homorganicNasals = map[rune]string{
+	'क': "ŋ", 'ख': "ŋ", 'ग': "ŋ", 'घ': "ŋ",
+	'च': "ɲ", 'छ': "ɲ", 'ज': "ɲ", 'झ': "ɲ",
+	'ट': "ɳ", 'ठ': "ɳ", 'ड': "ɳ", 'ढ': "ɳ",
+	'त': "n", 'थ': "n", 'द': "n", 'ध': "n", 'न': "n",
+	'प': "m", 'फ': "m", 'ब': "m", 'भ': "m", 'म': "m",
+}
+
+// IPA transcribes the Hindi pronunciation of Devanagari text, leaving other
+// text as it is. Schwas are deleted as in Romanize, so "कमरा" is "kəmraː".
+// Chandrabindu nasalizes its vowel, as does anusvara unless it comes before
+// a stop, where it is the nasal consonant of the stop, as in "ɦɪnd̪iː".
+func IPA(text string) string {
+	sounds := parseSounds(text)
+	deleteSchwas(sounds)
+
+	var b strings.Builder
+	for i, s := range sounds {
+		switch s.kind {
+		case otherSound:
+			switch {
+			case s.r == 'ॐ':
+				b.WriteString("oːm")
+			case s.r == 0:
+				b.WriteString(s.text)
+			}
+		case visargaSound:
+			b.WriteString("ɦ")
+		case consonantSound:
+			// ज्ञ is pronounced ɡj in Hindi
+			switch {
+			case s.r == 'ज' && i+1 < len(sounds) && sounds[i+1].r == 'ञ':
+				b.WriteString("ɡ")
+			case s.r == 'ञ' && i > 0 && sounds[i-1].r == 'ज':
+				b.WriteString("j")
+			default:
+				b.WriteString(ipaConsonants[s.r])
+			}
+		case vowelSound:
+			if !s.silent {
+				b.WriteString(ipaVowel(sounds, i))
+			}
+		}
+	}
+	return b.String()
+}
+
+func ipaVowel(sounds []sound, i int) string {
+	vowel := ipaVowels[sounds[i].r]
+	switch sounds[i].nasal {
+	case anusvara:
+		if i+1 < len(sounds) && sounds[i+1].kind == consonantSound {
+			if nasal, ok := homorganicNasals[sounds[i+1].r]; ok {
+				return vowel + nasal
+			}
+		}
+		return nasalize(vowel)
+	case chandrabindu:
+		return nasalize(vowel)
+	}
+	return vowel
+}
+
+// precomposedNasals are the nasal vowels with a precomposed form
+var precomposedNasals = map[string]string{"a": "ã", "e": "ẽ", "i": "ĩ", "o": "õ", "u": "ũ"}
+
+// nasalize puts a tilde on the vowel, before its length mark
+func nasalize(vowel string) string {
+	base, long := strings.CutSuffix(vowel, "ː")
+	// Only the ASCII vowels have a precomposed form, so the last byte is enough
+	if nasal, ok := precomposedNasals[base[len(base)-1:]]; ok {
+		base = base[:len(base)-1] + nasal
+	} else {
+		base += "\u0303"
+	}
+	if long {
+		base += "ː"
+	}
+	return base
+}
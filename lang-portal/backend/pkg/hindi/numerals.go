@@ -0,0 +1,129 @@
+package hindi
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+)
+
+// MaxNumber is the largest number with a word of its own in numberWords
+const MaxNumber = 100
+
+// numberWords are the Hindi words for 0 to 100. Past twenty they only
+// loosely follow the tens and units, so each is learned as a word of its own.
+var numberWords = [MaxNumber + 1]string{
+	"शून्य", "एक", "दो", "तीन", "चार", "पाँच", "छह", "सात", "आठ", "नौ",
+	"दस", "ग्यारह", "बारह", "तेरह", "चौदह", "पंद्रह", "सोलह", "सत्रह", "अठारह", "उन्नीस",
+	"बीस", "इक्कीस", "बाईस", "तेईस", "चौबीस", "पच्चीस", "छब्बीस", "सत्ताईस", "अट्ठाईस", "उनतीस",
+	"तीस", "इकतीस", "बत्तीस", "तैंतीस", "चौंतीस", "पैंतीस", "छत्तीस", "सैंतीस", "अड़तीस", "उनतालीस",
+	"चालीस", "इकतालीस", "बयालीस", "तैंतालीस", "चवालीस", "पैंतालीस", "छियालीस", "सैंतालीस", "अड़तालीस", "उनचास",
+	"पचास", "इक्यावन", "बावन", "तिरपन", "चौवन", "पचपन", "छप्पन", "सत्तावन", "अट्ठावन", "उनसठ",
+	"साठ", "इकसठ", "बासठ", "तिरसठ", "चौंसठ", "पैंसठ", "छियासठ", "सड़सठ", "अड़सठ", "उनहत्तर",
+	"सत्तर", "इकहत्तर", "बहत्तर", "तिहत्तर", "चौहत्तर", "पचहत्तर", "छिहत्तर", "सतहत्तर", "अठहत्तर", "उनासी",
+	"अस्सी", "इक्यासी", "बयासी", "तिरासी", "चौरासी", "पचासी", "छियासी", "सत्तासी", "अट्ठासी", "नवासी",
+	"नब्बे", "इक्यानवे", "बानवे", "तिरानवे", "चौरानवे", "पंचानवे", "छियानवे", "सत्तानवे", "अट्ठानवे", "निन्यानवे",
+	"सौ",
+}
+
+// numberVariants are other common spellings of number words
+var numberVariants = map[string]int{
+	"छः": 6, "छे": 6, "पन्द्रह": 15, "उन्यासी": 79, "सरसठ": 67, "एक सौ": 100,
+	"इक्यानबे": 91, "बानबे": 92, "तिरानबे": 93, "चौरानबे": 94, "पंचानबे": 95,
+	"पचानवे": 95, "छियानबे": 96, "सत्तानबे": 97, "अट्ठानबे": 98, "निन्यानबे": 99,
+}
+
+// numberValues maps the folded number words and their variants to their value
+var numberValues = func() map[string]int {
+	values := make(map[string]int, len(numberWords)+len(numberVariants))
+	for n, word := range numberWords {
+		values[devanagari.Fold(word)] = n
+	}
+	for word, n := range numberVariants {
+		values[devanagari.Fold(word)] = n
+	}
+	return values
+}()
+
+// Numeral is a number with its Hindi word and Devanagari digits
+type Numeral struct {
+	Value  int
+	Word   string // सैंतालीस
+	Digits string // ४७
+}
+
+// NumberWord returns the Hindi word for a number from 0 to 100
+func NumberWord(n int) (string, bool) {
+	if n < 0 || n > MaxNumber {
+		return "", false
+	}
+	return numberWords[n], true
+}
+
+// Numerals generates the numerals from one number to another, both included
+// and clamped to 0 to 100
+func Numerals(from, to int) []Numeral {
+	if from < 0 {
+		from = 0
+	}
+	if to > MaxNumber {
+		to = MaxNumber
+	}
+
+	var numerals []Numeral
+	for n := from; n <= to; n++ {
+		numerals = append(numerals, Numeral{
+			Value:  n,
+			Word:   numberWords[n],
+			Digits: DevanagariDigits(strconv.Itoa(n)),
+		})
+	}
+	return numerals
+}
+
+// ParseNumberWord returns the number a Hindi number word stands for,
+// accepting common spellings and the spellings devanagari.Fold folds
+func ParseNumberWord(s string) (int, bool) {
+	n, ok := numberValues[devanagari.Fold(s)]
+	return n, ok
+}
+
+// ParseDigits returns the number written in Arabic digits, Devanagari
+// digits or a mix of them, as in 47 and ४७
+func ParseDigits(s string) (int, bool) {
+	s = ArabicDigits(strings.TrimSpace(s))
+	if s == "" || strings.Trim(s, "0123456789") != "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+// ParseNumber returns the number written in digits of either system or as
+// a Hindi number word
+func ParseNumber(s string) (int, bool) {
+	if n, ok := ParseDigits(s); ok {
+		return n, true
+	}
+	return ParseNumberWord(s)
+}
+
+// DevanagariDigits writes the Arabic digits of a text in Devanagari, 47 as ४७
+func DevanagariDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r - '0' + '०'
+		}
+		return r
+	}, s)
+}
+
+// ArabicDigits writes the Devanagari digits of a text in Arabic, ४७ as 47
+func ArabicDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '०' && r <= '९' {
+			return r - '०' + '0'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,114 @@
+package hindi
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+)
+
+// Declension is the regular paradigm of a noun besides its direct singular
+type Declension struct {
+	Plural        string // direct plural, लड़के of लड़का
+	Oblique       string // oblique singular, लड़के of लड़का
+	ObliquePlural string // oblique plural, लड़कों of लड़का
+}
+
+// unmarkedMasculines are ā-ending masculine nouns, mostly kinship terms and
+// loans, that do not inflect like लड़का
+var unmarkedMasculines = map[string]bool{
+	"पिता": true, "चाचा": true, "मामा": true, "दादा": true, "नाना": true,
+	"राजा": true, "नेता": true, "देवता": true, "कर्ता": true, "योद्धा": true,
+}
+
+// Decline returns the regular declension of a noun by its gender and ending:
+// marked masculines in ा (लड़का, लड़के, लड़कों), feminines in ी (लड़की,
+// लड़कियाँ, लड़कियों), feminines in a consonant (बात, बातें, बातों) and so on.
+// Irregular nouns are declined as their ending suggests.
+func Decline(noun string, feminine bool) Declension {
+	if feminine {
+		return declineFeminine(noun)
+	}
+	return declineMasculine(noun)
+}
+
+func declineMasculine(noun string) Declension {
+	switch {
+	case strings.HasSuffix(noun, "आँ"), strings.HasSuffix(noun, "आं"):
+		// कुआँ: कुएँ, कुओं
+		stem := trimLastRunes(noun, 2)
+		return Declension{Plural: stem + "एँ", Oblique: stem + "एँ", ObliquePlural: stem + "ओं"}
+	case strings.HasSuffix(noun, "ा") && !unmarkedMasculines[noun]:
+		// लड़का: लड़के, लड़कों
+		stem := strings.TrimSuffix(noun, "ा")
+		return Declension{Plural: stem + "े", Oblique: stem + "े", ObliquePlural: stem + "ों"}
+	}
+	// घर, आदमी, पिता: unchanged but for the oblique plural
+	return Declension{Plural: noun, Oblique: noun, ObliquePlural: obliquePlural(noun)}
+}
+
+func declineFeminine(noun string) Declension {
+	switch {
+	case strings.HasSuffix(noun, "िया"):
+		// चिड़िया: चिड़ियाँ, चिड़ियों
+		return Declension{Plural: noun + "ँ", Oblique: noun, ObliquePlural: strings.TrimSuffix(noun, "ा") + "ों"}
+	case strings.HasSuffix(noun, "ी"), strings.HasSuffix(noun, "ि"):
+		// लड़की: लड़कियाँ, लड़कियों
+		stem := trimLastRunes(noun, 1) + "ि"
+		return Declension{Plural: stem + "याँ", Oblique: noun, ObliquePlural: stem + "यों"}
+	case strings.HasSuffix(noun, "ई"), strings.HasSuffix(noun, "इ"):
+		// मिठाई: मिठाइयाँ, मिठाइयों
+		stem := trimLastRunes(noun, 1) + "इ"
+		return Declension{Plural: stem + "याँ", Oblique: noun, ObliquePlural: stem + "यों"}
+	case strings.HasSuffix(noun, "ू"), strings.HasSuffix(noun, "ु"):
+		// बहू: बहुएँ, बहुओं
+		stem := trimLastRunes(noun, 1) + "ु"
+		return Declension{Plural: stem + "एँ", Oblique: noun, ObliquePlural: stem + "ओं"}
+	case endsInConsonant(noun):
+		// बात: बातें, बातों
+		return Declension{Plural: noun + "ें", Oblique: noun, ObliquePlural: noun + "ों"}
+	}
+	// माला: मालाएँ, मालाओं
+	return Declension{Plural: noun + "एँ", Oblique: noun, ObliquePlural: noun + "ओं"}
+}
+
+// obliquePlural is the oblique plural of a masculine noun that does not
+// change in the direct case: घरों, आदमियों, आलुओं, पिताओं
+func obliquePlural(noun string) string {
+	switch {
+	case strings.HasSuffix(noun, "ी"), strings.HasSuffix(noun, "ि"):
+		return trimLastRunes(noun, 1) + "ियों"
+	case strings.HasSuffix(noun, "ई"), strings.HasSuffix(noun, "इ"):
+		return trimLastRunes(noun, 1) + "इयों"
+	case strings.HasSuffix(noun, "ू"), strings.HasSuffix(noun, "ु"):
+		return trimLastRunes(noun, 1) + "ुओं"
+	case endsInConsonant(noun):
+		return noun + "ों"
+	}
+	return noun + "ओं"
+}
+
+// VerbRoot returns the root of a verb from its infinitive, कर of करना and
+// काम कर of काम करना. ok is false when the word is not an infinitive.
+func VerbRoot(infinitive string) (root string, ok bool) {
+	root = strings.TrimSuffix(infinitive, "ना")
+	if root == infinitive || strings.TrimSpace(root) == "" {
+		return "", false
+	}
+	return root, true
+}
+
+// endsInConsonant reports whether a word ends in a consonant with its
+// inherent vowel, घर or पहाड़
+func endsInConsonant(word string) bool {
+	last, _ := utf8.DecodeLastRuneInString(word)
+	return devanagari.IsConsonant(last) || last == devanagari.Nukta
+}
+
+func trimLastRunes(s string, n int) string {
+	runes := []rune(s)
+	if n > len(runes) {
+		return ""
+	}
+	return string(runes[:len(runes)-n])
+}
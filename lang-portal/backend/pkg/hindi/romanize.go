@@ -0,0 +1,281 @@
+package hindi
+
+import (
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+)
+
+// Scheme is a romanization scheme for Devanagari
+type Scheme int
+
+const (
+	// ISO15919 is the ISO 15919 transliteration, as in "kamrā"
+	ISO15919 Scheme = iota
+	// IAST is the International Alphabet of Sanskrit Transliteration, as in "kamrā"
+	IAST
+	// Hinglish is the casual spelling learners type, as in "kamra" or "paani"
+	Hinglish
+)
+
+const (
+	anusvara     = 'ं'
+	chandrabindu = 'ँ'
+	visarga      = 'ः'
+)
+
+// consonants holds the ISO 15919, IAST and Hinglish spelling of each
+// consonant, without its inherent vowel
+var consonants = map[rune][3]string{
+	'क': {"k", "k", "k"}, 'ख': {"kh", "kh", "kh"}, 'ग': {"g", "g", "g"}, 'घ': {"gh", "gh", "gh"}, 'ङ': {"ṅ", "ṅ", "n"},
+	'च': {"c", "c", "ch"}, 'छ': {"ch", "ch", "chh"}, 'ज': {"j", "j", "j"}, 'झ': {"jh", "jh", "jh"}, 'ञ': {"ñ", "ñ", "n"},
+	'ट': {"ṭ", "ṭ", "t"}, 'ठ': {"ṭh", "ṭh", "th"}, 'ड': {"ḍ", "ḍ", "d"}, 'ढ': {"ḍh", "ḍh", "dh"}, 'ण': {"ṇ", "ṇ", "n"},
+	'त': {"t", "t", "t"}, 'थ': {"th", "th", "th"}, 'द': {"d", "d", "d"}, 'ध': {"dh", "dh", "dh"}, 'न': {"n", "n", "n"},
+	'प': {"p", "p", "p"}, 'फ': {"ph", "ph", "ph"}, 'ब': {"b", "b", "b"}, 'भ': {"bh", "bh", "bh"}, 'म': {"m", "m", "m"},
+	'य': {"y", "y", "y"}, 'र': {"r", "r", "r"}, 'ल': {"l", "l", "l"}, 'ळ': {"ḷ", "ḷ", "l"}, 'व': {"v", "v", "v"},
+	'श': {"ś", "ś", "sh"}, 'ष': {"ṣ", "ṣ", "sh"}, 'स': {"s", "s", "s"}, 'ह': {"h", "h", "h"},
+	'ऩ': {"ṉ", "ṉ", "n"}, 'ऱ': {"ṟ", "ṟ", "r"}, 'ऴ': {"ḻ", "ḻ", "l"},
+	// Precomposed nukta consonants, mostly from Persian and English loanwords
+	'\u0958': {"q", "q", "q"}, '\u0959': {"ḵh", "ḵh", "kh"}, '\u095a': {"ġ", "ġ", "g"}, '\u095b': {"z", "z", "z"},
+	'\u095c': {"ṛ", "ṛ", "d"}, '\u095d': {"ṛh", "ṛh", "dh"}, '\u095e': {"f", "f", "f"}, '\u095f': {"ẏ", "ẏ", "y"},
+}
+
+// nuktaConsonants composes a consonant followed by a combining nukta, as
+// in क़ (U+0958) or ज़ (U+095B)
+var nuktaConsonants = map[rune]rune{
+	'क': '\u0958', 'ख': '\u0959', 'ग': '\u095a', 'ज': '\u095b', 'ड': '\u095c', 'ढ': '\u095d', 'फ': '\u095e', 'य': '\u095f',
+	'न': 'ऩ', 'र': 'ऱ', 'ळ': 'ऴ',
+}
+
+// vowels holds the ISO 15919, IAST and Hinglish spelling of each vowel. A
+// fourth spelling is the doubled Hinglish long vowel, as in "paani".
+var vowels = map[rune][4]string{
+	'अ': {"a", "a", "a"}, 'आ': {"ā", "ā", "a", "aa"},
+	'इ': {"i", "i", "i"}, 'ई': {"ī", "ī", "i", "ee"},
+	'उ': {"u", "u", "u"}, 'ऊ': {"ū", "ū", "u", "oo"},
+	'ऋ': {"r̥", "ṛ", "ri"}, 'ॠ': {"r̥̄", "ṝ", "ri"}, 'ऌ': {"l̥", "ḷ", "li"},
+	'ए': {"ē", "e", "e"}, 'ऐ': {"ai", "ai", "ai"},
+	'ओ': {"ō", "o", "o"}, 'औ': {"au", "au", "au"},
+	'ऎ': {"e", "e", "e"}, 'ऒ': {"o", "o", "o"},
+	'ऍ': {"ê", "ê", "e"}, 'ऑ': {"ô", "ô", "o"},
+}
+
+// matras maps each vowel sign to its independent vowel
+var matras = map[rune]rune{
+	'ा': 'आ', 'ि': 'इ', 'ी': 'ई', 'ु': 'उ', 'ू': 'ऊ', 'ृ': 'ऋ', 'ॄ': 'ॠ', 'ॢ': 'ऌ',
+	'े': 'ए', 'ै': 'ऐ', 'ो': 'ओ', 'ौ': 'औ', 'ॆ': 'ऎ', 'ॊ': 'ऒ', 'ॅ': 'ऍ', 'ॉ': 'ऑ',
+}
+
+// symbols holds the ISO 15919, IAST and Hinglish spelling of signs that
+// stand on their own
+var symbols = map[rune][3]string{
+	'।': {".", ".", "."}, '॥': {".", ".", "."}, 'ऽ': {"’", "’", ""}, 'ॐ': {"ōṁ", "oṃ", "om"},
+}
+
+type soundKind int
+
+const (
+	otherSound soundKind = iota
+	consonantSound
+	vowelSound
+	visargaSound
+)
+
+// sound is a consonant or vowel of a word. Anything else, such as spaces,
+// punctuation or Latin letters, is an other sound that separates words.
+type sound struct {
+	kind     soundKind
+	r        rune
+	text     string // the spelling of an other sound
+	inherent bool   // the vowel is the inherent schwa of the consonant before it
+	silent   bool   // the inherent schwa is not pronounced
+	nasal    rune   // anusvara or chandrabindu on the vowel
+}
+
+// Romanize transliterates Devanagari text in scheme, leaving other text as
+// it is. The inherent schwa is dropped where Hindi does not pronounce it, at
+// the end of words and between two single consonants, so "कमरा" becomes
+// "kamra" rather than "kamara" and "समझना" becomes "samajhna".
+func Romanize(text string, scheme Scheme) string {
+	if scheme < ISO15919 || scheme > Hinglish {
+		scheme = Hinglish
+	}
+
+	sounds := parseSounds(text)
+	deleteSchwas(sounds)
+
+	var b strings.Builder
+	for i, s := range sounds {
+		switch s.kind {
+		case otherSound:
+			if s.r != 0 {
+				b.WriteString(symbols[s.r][scheme])
+			} else {
+				b.WriteString(s.text)
+			}
+		case visargaSound:
+			b.WriteString([3]string{"ḥ", "ḥ", "h"}[scheme])
+		case consonantSound:
+			spelling := consonants[s.r][scheme]
+			// ज्ञ is pronounced gy in Hindi, as in "gyaan"
+			if scheme == Hinglish && s.r == 'ज' && i+1 < len(sounds) && sounds[i+1].r == 'ञ' {
+				spelling = "g"
+			} else if scheme == Hinglish && s.r == 'ञ' && i > 0 && sounds[i-1].r == 'ज' {
+				spelling = "y"
+			}
+			b.WriteString(spelling)
+		case vowelSound:
+			if s.silent {
+				continue
+			}
+			b.WriteString(vowelSpelling(sounds, i, scheme))
+			b.WriteString(nasalSpelling(sounds, i, scheme))
+		}
+	}
+	return b.String()
+}
+
+// parseSounds splits text into consonants, each followed by its vowel, and
+// independent vowels
+func parseSounds(text string) []sound {
+	var sounds []sound
+	for _, r := range text {
+		last := len(sounds) - 1
+		switch {
+		case r == devanagari.ZWJ || r == devanagari.ZWNJ:
+		case devanagari.IsConsonant(r) && consonants[r] != [3]string{}:
+			sounds = append(sounds,
+				sound{kind: consonantSound, r: r},
+				sound{kind: vowelSound, r: 'अ', inherent: true})
+		case vowels[r] != [4]string{}:
+			sounds = append(sounds, sound{kind: vowelSound, r: r})
+		case matras[r] != 0:
+			if last >= 0 && sounds[last].inherent {
+				sounds[last].r = matras[r]
+				sounds[last].inherent = false
+			}
+		case r == devanagari.Nukta:
+			if last >= 1 && sounds[last].inherent && nuktaConsonants[sounds[last-1].r] != 0 {
+				sounds[last-1].r = nuktaConsonants[sounds[last-1].r]
+			}
+		case r == devanagari.Virama:
+			if last >= 0 && sounds[last].inherent {
+				sounds = sounds[:last]
+			}
+		case r == anusvara || r == chandrabindu:
+			if last >= 0 && sounds[last].kind == vowelSound {
+				sounds[last].nasal = r
+			}
+		case r == visarga:
+			sounds = append(sounds, sound{kind: visargaSound, r: r})
+		case r >= '०' && r <= '९':
+			sounds = append(sounds, sound{text: string('0' + r - '०')})
+		case symbols[r] != [3]string{}:
+			sounds = append(sounds, sound{r: r})
+		default:
+			sounds = append(sounds, sound{text: string(r)})
+		}
+	}
+	return sounds
+}
+
+// deleteSchwas marks the inherent vowels Hindi does not pronounce, word by word
+func deleteSchwas(sounds []sound) {
+	start := 0
+	for i := 0; i <= len(sounds); i++ {
+		if i == len(sounds) || sounds[i].kind == otherSound {
+			deleteWordSchwas(sounds[start:i])
+			start = i + 1
+		}
+	}
+}
+
+func deleteWordSchwas(word []sound) {
+	n := len(word)
+	if n < 3 {
+		return
+	}
+
+	pronounced := func(i int) bool {
+		return i >= 0 && i < n && word[i].kind == vowelSound && !word[i].silent
+	}
+	consonant := func(i int) bool {
+		return i >= 0 && i < n && word[i].kind == consonantSound
+	}
+
+	// The final schwa is silent, as in "घर", unless it carries a nasal sign
+	// or ends a conjunct with य, र or व, as in "मित्र", or follows इय, as in "प्रिय"
+	if last := word[n-1]; last.inherent && last.nasal == 0 {
+		final := word[n-2].r
+		cluster := consonant(n - 3)
+		keep := (cluster && (final == 'य' || final == 'र' || final == 'व')) ||
+			(final == 'य' && pronounced(n-3) && strings.ContainsRune("इईउऊए", word[n-3].r))
+		word[n-1].silent = !keep
+	}
+
+	// A medial schwa between two single consonants is silent, as in "कमरा".
+	// Going from the end keeps the first of two candidates, as in "समझना".
+	for i := n - 2; i >= 2; i-- {
+		if word[i].inherent && word[i].nasal == 0 &&
+			consonant(i-1) && pronounced(i-2) &&
+			consonant(i+1) && pronounced(i+2) {
+			word[i].silent = true
+		}
+	}
+}
+
+// vowelSpelling spells the vowel at i. Hinglish doubles ī and ū inside words,
+// as in "zameen", and ā only in the first syllable, as in "paani" but not
+// "hamara". Long vowels ending a longer word stay single, as in "kamra".
+func vowelSpelling(sounds []sound, i int, scheme Scheme) string {
+	spellings := vowels[sounds[i].r]
+	if scheme != Hinglish || spellings[3] == "" {
+		return spellings[scheme]
+	}
+
+	syllable, syllables := 0, 0
+	final := true
+	for j := i - 1; j >= 0 && sounds[j].kind != otherSound; j-- {
+		if sounds[j].kind == vowelSound && !sounds[j].silent {
+			syllable++
+		}
+	}
+	for j := i + 1; j < len(sounds) && sounds[j].kind != otherSound; j++ {
+		if sounds[j].kind == vowelSound && !sounds[j].silent {
+			syllables++
+		}
+		if sounds[j].kind == consonantSound {
+			final = false
+		}
+	}
+	syllables += syllable + 1
+
+	if syllables == 1 || (!final && (syllable == 0 || sounds[i].r != 'आ')) {
+		return spellings[3]
+	}
+	return spellings[2]
+}
+
+// nasalSpelling spells the anusvara or chandrabindu on the vowel at i. In
+// Hinglish both are n, or m before a labial as in "ambar".
+func nasalSpelling(sounds []sound, i int, scheme Scheme) string {
+	switch sounds[i].nasal {
+	case anusvara:
+		if scheme == Hinglish {
+			if i+1 < len(sounds) && sounds[i+1].kind == consonantSound && strings.ContainsRune("पफबभम", sounds[i+1].r) {
+				return "m"
+			}
+			return "n"
+		}
+		if scheme == IAST {
+			return "ṃ"
+		}
+		return "ṁ"
+	case chandrabindu:
+		if scheme == Hinglish {
+			return "n"
+		}
+		return "m̐"
+	}
+	return ""
+}
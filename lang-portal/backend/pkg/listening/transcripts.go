@@ -0,0 +1,168 @@
+// Package listening reads the transcripts produced by listening-comp into
+// listening exercises. listening-comp downloads timed YouTube transcripts to
+// backend/transcripts and has a language model structure each of them into an
+// introduction, a dialogue and a multiple-choice question, written to
+// backend/structured_transcripts as <name>_structured.json.
+package listening
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// StructuredSuffix ends the names of structured transcript files
+const StructuredSuffix = "_structured.json"
+
+// StructuredTranscript is a transcript structured by listening-comp
+type StructuredTranscript struct {
+	Introduction string   `json:"introduction"`
+	Dialogue     string   `json:"dialogue"`
+	Question     string   `json:"question"`
+	Options      []string `json:"options"`
+	Answer       string   `json:"answer"`
+}
+
+// TimedLine is a line of a YouTube transcript, timed in seconds
+type TimedLine struct {
+	Text     string  `json:"text"`
+	Start    float64 `json:"start"`
+	Duration float64 `json:"duration"`
+}
+
+// sentenceEnds are the marks a dialogue is split into segments after
+const sentenceEnds = "।?!.॥"
+
+// Exercise builds an exercise from a structured transcript. The segments are
+// the timed lines when there are any, otherwise the sentences of the dialogue.
+func Exercise(source string, transcript StructuredTranscript, lines []TimedLine) *models.ListeningExercise {
+	exercise := &models.ListeningExercise{
+		Language:     models.DefaultLanguage,
+		Source:       source,
+		Introduction: transcript.Introduction,
+		Transcript:   transcript.Dialogue,
+		Questions: []models.ListeningQuestion{{
+			Question: transcript.Question,
+			Options:  transcript.Options,
+			Answer:   transcript.Answer,
+		}},
+	}
+
+	if len(lines) > 0 {
+		exercise.Segments = TimedSegments(lines)
+	} else {
+		for _, sentence := range SplitSentences(transcript.Dialogue) {
+			exercise.Segments = append(exercise.Segments, models.ListeningSegment{Text: sentence})
+		}
+	}
+	return exercise
+}
+
+// TimedSegments converts the lines of a timed transcript, skipping empty ones
+func TimedSegments(lines []TimedLine) []models.ListeningSegment {
+	var segments []models.ListeningSegment
+	for _, line := range lines {
+		text := strings.Join(strings.Fields(line.Text), " ")
+		if text == "" {
+			continue
+		}
+		start := int64(math.Round(line.Start * 1000))
+		end := int64(math.Round((line.Start + line.Duration) * 1000))
+		segments = append(segments, models.ListeningSegment{Text: text, StartMS: &start, EndMS: &end})
+	}
+	return segments
+}
+
+// SplitSentences splits a dialogue after the marks ending its sentences,
+// keeping the marks
+func SplitSentences(dialogue string) []string {
+	var sentences []string
+	var current strings.Builder
+	flush := func() {
+		if sentence := strings.TrimSpace(current.String()); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		current.Reset()
+	}
+
+	for i, r := range dialogue {
+		current.WriteRune(r)
+		if !strings.ContainsRune(sentenceEnds, r) {
+			continue
+		}
+		// Keep runs of marks together, such as "?!"
+		next, _ := utf8.DecodeRuneInString(dialogue[i+utf8.RuneLen(r):])
+		if !strings.ContainsRune(sentenceEnds, next) {
+			flush()
+		}
+	}
+	flush()
+
+	return sentences
+}
+
+// ParseStructured decodes a structured transcript
+func ParseStructured(data []byte) (StructuredTranscript, error) {
+	var transcript StructuredTranscript
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return transcript, fmt.Errorf("invalid structured transcript: %w", err)
+	}
+	return transcript, nil
+}
+
+// ParseTimed decodes a timed transcript
+func ParseTimed(data []byte) ([]TimedLine, error) {
+	var lines []TimedLine
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return nil, fmt.Errorf("invalid timed transcript: %w", err)
+	}
+	return lines, nil
+}
+
+// ReadDir reads the structured transcripts in dir. Each exercise is named
+// after its file without the suffix, and is timed by the transcript of the
+// same name in the transcripts directory next to dir, when there is one.
+func ReadDir(dir string) ([]*models.ListeningExercise, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+StructuredSuffix))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	exercises := make([]*models.ListeningExercise, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		transcript, err := ParseStructured(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		source := strings.TrimSuffix(filepath.Base(path), StructuredSuffix)
+		timed := filepath.Join(filepath.Dir(filepath.Clean(dir)), "transcripts", source+".json")
+		var lines []TimedLine
+		data, err = os.ReadFile(timed)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			if lines, err = ParseTimed(data); err != nil {
+				return nil, fmt.Errorf("%s: %w", timed, err)
+			}
+		}
+
+		exercises = append(exercises, Exercise(source, transcript, lines))
+	}
+	return exercises, nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APIKey lets scripts act as the user who created it, only its hash is stored
+type APIKey struct {
+	ID         int64      `json:"id" db:"id"`
+	UserID     int64      `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	KeyHash    string     `json:"-" db:"key_hash"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+}
+
+// Validate performs validation checks on the APIKey struct
+func (k *APIKey) Validate() error {
+	k.Name = strings.TrimSpace(k.Name)
+
+	if k.UserID <= 0 {
+		return ErrInvalidID
+	}
+
+	if k.Name == "" || len(k.Name) > 64 {
+		return fmt.Errorf("api key name must be between 1 and 64 characters: %w", ErrInvalidInput)
+	}
+
+	if k.KeyHash == "" {
+		return errors.New("api key hash cannot be empty")
+	}
+
+	return nil
+}
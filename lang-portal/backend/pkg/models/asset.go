@@ -0,0 +1,115 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Kinds of uploaded assets
+const (
+	AssetAudio = "audio"
+	AssetImage = "image"
+)
+
+// assetExtensions maps each accepted media type to the extension its files are stored with
+var assetExtensions = map[string]string{
+	"audio/mpeg": ".mp3",
+	"audio/ogg":  ".ogg",
+	"audio/wav":  ".wav",
+	"audio/webm": ".weba",
+	"audio/mp4":  ".m4a",
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// mediaTypeAliases maps the names browsers and content sniffing use to the accepted media types
+var mediaTypeAliases = map[string]string{
+	"audio/mp3":       "audio/mpeg",
+	"audio/x-wav":     "audio/wav",
+	"audio/wave":      "audio/wav",
+	"audio/x-m4a":     "audio/mp4",
+	"application/ogg": "audio/ogg",
+	"video/webm":      "audio/webm",
+}
+
+var checksumPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Asset is an uploaded media file, such as the pronunciation of a word. The
+// file itself is kept in storage under StorageKey.
+type Asset struct {
+	ID         int64     `json:"id" db:"id"`
+	Kind       string    `json:"kind" db:"kind"`
+	MimeType   string    `json:"mime_type" db:"mime_type"`
+	Filename   string    `json:"filename" db:"filename"`
+	Size       int64     `json:"size" db:"size"`
+	DurationMS *int64    `json:"duration_ms,omitempty" db:"duration_ms"`
+	Checksum   string    `json:"checksum" db:"checksum"` // hex encoded SHA-256 of the content
+	StorageKey string    `json:"-" db:"storage_key"`
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// NormalizeMediaType returns the accepted media type for a Content-Type value,
+// or an empty string if the type is not accepted
+func NormalizeMediaType(contentType string) string {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	if alias, ok := mediaTypeAliases[mediaType]; ok {
+		mediaType = alias
+	}
+	if _, ok := assetExtensions[mediaType]; !ok {
+		return ""
+	}
+	return mediaType
+}
+
+// AssetURL returns the path the content of an asset is served from
+func AssetURL(id int64) string {
+	return fmt.Sprintf("/api/assets/%d/content", id)
+}
+
+// ChallengeAudioURL returns the path the recording played with an issued
+// challenge is served from
+func ChallengeAudioURL(sessionID int64, challengeID string) string {
+	return fmt.Sprintf("/api/sessions/%d/challenges/%s/audio", sessionID, challengeID)
+}
+
+// Validate performs validation checks on the Asset struct and derives its kind
+func (a *Asset) Validate() error {
+	a.Filename = path.Base(strings.ReplaceAll(strings.TrimSpace(a.Filename), `\`, "/"))
+	if a.Filename == "." || a.Filename == "/" {
+		a.Filename = ""
+	}
+
+	mediaType := NormalizeMediaType(a.MimeType)
+	if mediaType == "" {
+		return fmt.Errorf("%q is not an accepted audio or image type: %w", a.MimeType, ErrUnsupportedMedia)
+	}
+	a.MimeType = mediaType
+	a.Kind = strings.SplitN(mediaType, "/", 2)[0]
+
+	if a.Size <= 0 {
+		return fmt.Errorf("asset cannot be empty: %w", ErrInvalidInput)
+	}
+	if !checksumPattern.MatchString(a.Checksum) {
+		return fmt.Errorf("checksum must be a hex encoded SHA-256: %w", ErrInvalidInput)
+	}
+	if a.DurationMS != nil && (a.Kind != AssetAudio || *a.DurationMS <= 0) {
+		return fmt.Errorf("duration must be positive and only set for audio: %w", ErrInvalidInput)
+	}
+
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// Extension returns the file extension the asset is stored with
+func (a *Asset) Extension() string {
+	return assetExtensions[a.MimeType]
+}
@@ -0,0 +1,20 @@
+package models
+
+// Conjugation is one form of a verb, for a tense and a subject of a person,
+// number, gender and, in the second person, formality
+type Conjugation struct {
+	Tense     string `json:"tense"`
+	Person    int    `json:"person"`
+	Plural    bool   `json:"plural"`
+	Gender    string `json:"gender"`
+	Formality string `json:"formality,omitempty"`
+	Subject   string `json:"subject"`  // the pronoun, with ने when Ergative
+	Ergative  bool   `json:"ergative"` // the verb agrees with its object, not the subject
+	Form      string `json:"form"`
+}
+
+// WordConjugations is a verb with its conjugations
+type WordConjugations struct {
+	Word         Word          `json:"word"`
+	Conjugations []Conjugation `json:"conjugations"`
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Checks the content report runs
+const (
+	// CheckMissingRomanized is a Hindi word without its Hinglish spelling
+	CheckMissingRomanized = "missing_romanized"
+	// CheckScrambledUnchanged is a scrambled form equal to the target
+	CheckScrambledUnchanged = "scrambled_unchanged"
+	// CheckScrambledBroken is a scrambled form with a sign detached from its
+	// letter, or not made of the letters of the target
+	CheckScrambledBroken = "scrambled_broken"
+	// CheckUngroupedWord is a word in no group, which no activity shows
+	CheckUngroupedWord = "ungrouped_word"
+	// CheckEmptyGroup is a group without words
+	CheckEmptyGroup = "empty_group"
+	// CheckInvalidNative is a translation the script rule of its language
+	// rejects, such as "mother-in-law", so the word can't be saved as it is
+	CheckInvalidNative = "invalid_native"
+	// CheckDifficultyMismatch is a difficulty that disagrees with another
+	// entry of the word or with the length of the word
+	CheckDifficultyMismatch = "difficulty_mismatch"
+)
+
+// ContentChecks lists the checks of the content report in the order they run
+var ContentChecks = []string{
+	CheckMissingRomanized,
+	CheckScrambledUnchanged,
+	CheckScrambledBroken,
+	CheckUngroupedWord,
+	CheckEmptyGroup,
+	CheckInvalidNative,
+	CheckDifficultyMismatch,
+}
+
+// Difficulties of a word, from easy to hard
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// ContentFinding is a problem the content report found, with the words or
+// groups it was found in and how to fix it. Suggested is the value to set
+// when the fix is a new value.
+type ContentFinding struct {
+	Check     string  `json:"check"`
+	WordIDs   []int64 `json:"word_ids,omitempty"`
+	GroupIDs  []int64 `json:"group_ids,omitempty"`
+	Problem   string  `json:"problem"`
+	Fix       string  `json:"fix"`
+	Suggested string  `json:"suggested,omitempty"`
+}
+
+// ContentReport lists the problems found in words and groups, with the
+// number of findings of every check
+type ContentReport struct {
+	Findings    []ContentFinding `json:"findings"`
+	Counts      map[string]int   `json:"counts"`
+	Total       int              `json:"total"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
+
+// ValidateContentCheck checks that a content report check is known, it may
+// be empty
+func ValidateContentCheck(check string) error {
+	if check != "" && !contains(ContentChecks, check) {
+		return fmt.Errorf("unknown check %q: %w", check, ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Kinds of generated content
+const (
+	DraftWord     = "word"
+	DraftSentence = "sentence"
+	DraftHint     = "hint"
+)
+
+// DraftStatus is the review state of a draft
+type DraftStatus string
+
+const (
+	// DraftPending waits for an editor
+	DraftPending DraftStatus = "pending"
+	// DraftApproved was accepted and added to the content
+	DraftApproved DraftStatus = "approved"
+	// DraftRejected was turned down
+	DraftRejected DraftStatus = "rejected"
+)
+
+// Valid reports whether the status is one of the known statuses
+func (s DraftStatus) Valid() bool {
+	switch s {
+	case DraftPending, DraftApproved, DraftRejected:
+		return true
+	}
+	return false
+}
+
+// Draft is generated content waiting for an editor's approval. Content holds
+// a Word, a Sentence or a WordHint depending on the kind.
+type Draft struct {
+	ID         int64           `json:"id" db:"id"`
+	Kind       string          `json:"kind" db:"kind"`
+	Status     DraftStatus     `json:"status" db:"status"`
+	Content    json.RawMessage `json:"content" db:"content"`
+	Model      string          `json:"model" db:"model"`                       // the model that generated it
+	GroupID    *int64          `json:"group_id,omitempty" db:"group_id"`       // the group a word is proposed for
+	WordID     *int64          `json:"word_id,omitempty" db:"word_id"`         // the word a sentence or hint is for
+	ResultID   *int64          `json:"result_id,omitempty" db:"result_id"`     // the word, sentence or hint created on approval
+	CreatedBy  *int64          `json:"created_by,omitempty" db:"created_by"`   // the editor who asked for it
+	ReviewedBy *int64          `json:"reviewed_by,omitempty" db:"reviewed_by"` // the editor who approved or rejected it
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+	ReviewedAt *time.Time      `json:"reviewed_at,omitempty" db:"reviewed_at"`
+}
+
+// WordHint is a clue that helps recall a word without giving it away
+type WordHint struct {
+	ID        int64     `json:"id" db:"id"`
+	WordID    int64     `json:"word_id" db:"word_id"`
+	Text      string    `json:"text" db:"text"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
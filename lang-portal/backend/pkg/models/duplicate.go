@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Reasons two words are taken for duplicates
+const (
+	// DuplicateSameTarget is the same target form once normalized, such as
+	// कागज़ and कागज, whatever their translations
+	DuplicateSameTarget = "same_target"
+	// DuplicateSimilarTarget is a target form one letter apart with the
+	// same translation, such as a typo
+	DuplicateSimilarTarget = "similar_target"
+	// DuplicateSameRomanized is the same romanization and translation, such
+	// as two Devanagari spellings of one word
+	DuplicateSameRomanized = "same_romanized"
+)
+
+// DuplicateCluster is a set of words of one language that are likely the
+// same word, with the reasons they were matched
+type DuplicateCluster struct {
+	Language string   `json:"language"`
+	Reasons  []string `json:"reasons"`
+	Words    []Word   `json:"words"`
+}
+
+// WordMerge records a duplicate word merged into the word that was kept.
+// The merged word is deleted, MergedWord is its copy at the time and
+// GroupIDs the groups it belonged to.
+type WordMerge struct {
+	ID           int64     `json:"id" db:"id"`
+	KeptWordID   int64     `json:"kept_word_id" db:"kept_word_id"`
+	MergedWordID int64     `json:"merged_word_id" db:"merged_word_id"`
+	MergedWord   Word      `json:"merged_word" db:"merged_word"`
+	GroupIDs     []int64   `json:"group_ids" db:"group_ids"`
+	MergedBy     *int64    `json:"merged_by,omitempty" db:"merged_by"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
@@ -3,9 +3,23 @@ package models
 import "errors"
 
 var (
-	ErrInvalidID         = errors.New("invalid ID: must be a positive number")
-	ErrInvalidTime       = errors.New("invalid time: time cannot be zero")
-	ErrInvalidTimeRange  = errors.New("invalid time range: end time must be after start time")
-	ErrInvalidScore      = errors.New("invalid score: score cannot be negative")
-	ErrInvalidInput      = errors.New("invalid input: input cannot be empty")
+	ErrInvalidID        = errors.New("invalid ID: must be a positive number")
+	ErrInvalidTime      = errors.New("invalid time: time cannot be zero")
+	ErrInvalidTimeRange = errors.New("invalid time range: end time must be after start time")
+	ErrInvalidScore     = errors.New("invalid score: score cannot be negative")
+	ErrInvalidInput     = errors.New("invalid input: input cannot be empty")
+	ErrNotFound         = errors.New("record not found")
+	ErrActivityDisabled = errors.New("study activity is disabled")
+	ErrSessionEnded     = errors.New("session has already ended")
+	ErrAnswered         = errors.New("challenge has already been answered")
+	ErrGradedActivity   = errors.New("results of this study activity are graded by the server")
+	ErrUsernameTaken    = errors.New("username is already taken")
+	ErrInvalidUsername  = errors.New("invalid username: use 3 to 32 letters, digits, '.', '_' or '-'")
+	ErrInvalidPassword  = errors.New("invalid password: use 8 to 72 bytes")
+	ErrInvalidRole      = errors.New("invalid role: use learner, editor or admin")
+	ErrInvalidLanguage  = errors.New("invalid language")
+	ErrInvalidScript    = errors.New("text is not written in the language's script")
+	ErrUnsupportedMedia = errors.New("unsupported media type")
+	ErrFileTooLarge     = errors.New("file is too large")
+	ErrNotConfigured    = errors.New("feature is not configured on this server")
 )
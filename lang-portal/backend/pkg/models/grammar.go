@@ -0,0 +1,118 @@
+package models
+
+import "fmt"
+
+// Parts of speech a word can be tagged with
+const (
+	PartOfSpeechNoun         = "noun"
+	PartOfSpeechPronoun      = "pronoun"
+	PartOfSpeechVerb         = "verb"
+	PartOfSpeechAdjective    = "adjective"
+	PartOfSpeechAdverb       = "adverb"
+	PartOfSpeechPostposition = "postposition"
+	PartOfSpeechConjunction  = "conjunction"
+	PartOfSpeechInterjection = "interjection"
+	PartOfSpeechNumeral      = "numeral"
+	PartOfSpeechParticle     = "particle"
+)
+
+// PartsOfSpeech lists the parts of speech a word can be tagged with
+var PartsOfSpeech = []string{
+	PartOfSpeechNoun, PartOfSpeechPronoun, PartOfSpeechVerb, PartOfSpeechAdjective,
+	PartOfSpeechAdverb, PartOfSpeechPostposition, PartOfSpeechConjunction,
+	PartOfSpeechInterjection, PartOfSpeechNumeral, PartOfSpeechParticle,
+}
+
+// Grammatical genders of nouns
+const (
+	GenderMasculine = "masculine"
+	GenderFeminine  = "feminine"
+)
+
+// Genders lists the grammatical genders of nouns
+var Genders = []string{GenderMasculine, GenderFeminine}
+
+// Transitivities of verbs
+const (
+	TransitivityIntransitive = "intransitive"
+	TransitivityTransitive   = "transitive"
+	TransitivityDitransitive = "ditransitive"
+)
+
+// Transitivities lists the transitivities of verbs
+var Transitivities = []string{TransitivityIntransitive, TransitivityTransitive, TransitivityDitransitive}
+
+// WordForms are the inflected forms the grammar of a word needs, generated
+// for regular Hindi words unless an editor entered them
+type WordForms struct {
+	Root          string `json:"root" db:"root"`                     // stem of a verb, कर of करना
+	Plural        string `json:"plural" db:"plural"`                 // direct plural of a noun, लड़के of लड़का
+	Oblique       string `json:"oblique" db:"oblique"`               // oblique singular of a noun, लड़के of लड़का
+	ObliquePlural string `json:"oblique_plural" db:"oblique_plural"` // oblique plural of a noun, लड़कों of लड़का
+}
+
+// IsZero reports whether no form is set
+func (f WordForms) IsZero() bool {
+	return f == WordForms{}
+}
+
+// Canonical returns the forms trimmed and in their stored form
+func (f WordForms) Canonical() WordForms {
+	return WordForms{
+		Root:          CanonicalText(f.Root),
+		Plural:        CanonicalText(f.Plural),
+		Oblique:       CanonicalText(f.Oblique),
+		ObliquePlural: CanonicalText(f.ObliquePlural),
+	}
+}
+
+// ValidateGrammarValues checks that a part of speech, gender and
+// transitivity are known, each may be empty
+func ValidateGrammarValues(partOfSpeech, gender, transitivity string) error {
+	if partOfSpeech != "" && !contains(PartsOfSpeech, partOfSpeech) {
+		return fmt.Errorf("unknown part of speech %q: %w", partOfSpeech, ErrInvalidInput)
+	}
+	if gender != "" && !contains(Genders, gender) {
+		return fmt.Errorf("unknown gender %q: %w", gender, ErrInvalidInput)
+	}
+	if transitivity != "" && !contains(Transitivities, transitivity) {
+		return fmt.Errorf("unknown transitivity %q: %w", transitivity, ErrInvalidInput)
+	}
+	return nil
+}
+
+// ValidateGrammar checks the grammatical metadata of a word: known values,
+// gender and noun forms only on nouns, transitivity and root only on verbs
+func (w *Word) ValidateGrammar() error {
+	if err := ValidateGrammarValues(w.PartOfSpeech, w.Gender, w.Transitivity); err != nil {
+		return err
+	}
+
+	if w.PartOfSpeech != PartOfSpeechNoun {
+		if w.Gender != "" {
+			return fmt.Errorf("only nouns have a gender: %w", ErrInvalidInput)
+		}
+		if w.Plural != "" || w.Oblique != "" || w.ObliquePlural != "" {
+			return fmt.Errorf("only nouns have plural and oblique forms: %w", ErrInvalidInput)
+		}
+	}
+	if w.PartOfSpeech != PartOfSpeechVerb {
+		if w.Transitivity != "" {
+			return fmt.Errorf("only verbs have a transitivity: %w", ErrInvalidInput)
+		}
+		if w.Root != "" {
+			return fmt.Errorf("only verbs have a root: %w", ErrInvalidInput)
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -46,6 +46,6 @@ func (g *Group) Validate() error {
 
 // Sanitize removes any potentially harmful content and trims whitespace
 func (g *Group) Sanitize() {
-	g.Name = strings.TrimSpace(g.Name)
-	g.Description = strings.TrimSpace(g.Description)
+	g.Name = CanonicalText(g.Name)
+	g.Description = CanonicalText(g.Description)
 }
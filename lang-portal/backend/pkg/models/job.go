@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// JobStatus is the state of a background job
+type JobStatus string
+
+const (
+	// JobPending waits to be run
+	JobPending JobStatus = "pending"
+	// JobRunning is being run
+	JobRunning JobStatus = "running"
+	// JobSucceeded ran without errors
+	JobSucceeded JobStatus = "succeeded"
+	// JobFailed ran into an error and waits to be retried
+	JobFailed JobStatus = "failed"
+)
+
+// Valid reports whether the status is one of the known statuses
+func (s JobStatus) Valid() bool {
+	switch s {
+	case JobPending, JobRunning, JobSucceeded, JobFailed:
+		return true
+	}
+	return false
+}
+
+// JobPronunciation generates the pronunciation audio of the word SubjectID
+const JobPronunciation = "pronunciation"
+
+// Job is a unit of background work on a subject, such as a word. Each
+// subject has at most one job of a kind.
+type Job struct {
+	ID        int64     `json:"id" db:"id"`
+	Kind      string    `json:"kind" db:"kind"`
+	SubjectID int64     `json:"subject_id" db:"subject_id"`
+	Status    JobStatus `json:"status" db:"status"`
+	Attempts  int       `json:"attempts" db:"attempts"`
+	LastError string    `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Script is the ISO 15924 code of a writing system
+type Script string
+
+const (
+	// ScriptDevanagari is used by Hindi and Marathi
+	ScriptDevanagari Script = "Deva"
+	// ScriptGurmukhi is used by Punjabi
+	ScriptGurmukhi Script = "Guru"
+	// ScriptLatin is used by English and romanized text
+	ScriptLatin Script = "Latn"
+)
+
+// scripts maps each supported script to its name and the characters it allows
+var scripts = map[Script]struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	ScriptDevanagari: {"Devanagari", unicode.Devanagari},
+	ScriptGurmukhi:   {"Gurmukhi", unicode.Gurmukhi},
+	ScriptLatin:      {"Latin", unicode.Latin},
+}
+
+// Valid reports whether the script is one of the supported scripts
+func (s Script) Valid() bool {
+	_, ok := scripts[s]
+	return ok
+}
+
+// Name returns the English name of the script
+func (s Script) Name() string {
+	if script, ok := scripts[s]; ok {
+		return script.name
+	}
+	return string(s)
+}
+
+// Contains reports whether text is written only in the script. Spaces are
+// allowed between words, and joiners so conjuncts can be controlled.
+func (s Script) Contains(text string) bool {
+	script, ok := scripts[s]
+	if !ok {
+		return false
+	}
+
+	for _, r := range text {
+		if unicode.IsSpace(r) || r == '\u200c' || r == '\u200d' {
+			continue
+		}
+		if !unicode.Is(script.table, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsProse reports whether text is running text in the script, which
+// may also use punctuation and digits between its words
+func (s Script) ContainsProse(text string) bool {
+	var letters strings.Builder
+	for _, r := range text {
+		if unicode.IsPunct(r) || unicode.IsDigit(r) {
+			letters.WriteRune(' ')
+			continue
+		}
+		letters.WriteRune(r)
+	}
+	return s.Contains(letters.String())
+}
+
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// Language describes a language words can be written in
+type Language struct {
+	Code         string    `json:"code" db:"code"`
+	Name         string    `json:"name" db:"name"`
+	Script       Script    `json:"script" db:"script"`
+	Romanization string    `json:"romanization" db:"romanization"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validate performs validation checks on the Language struct
+func (l *Language) Validate() error {
+	l.Code = strings.ToLower(strings.TrimSpace(l.Code))
+	l.Name = strings.TrimSpace(l.Name)
+	l.Romanization = strings.TrimSpace(l.Romanization)
+
+	if !languageCodePattern.MatchString(l.Code) {
+		return fmt.Errorf("language code must be an ISO 639 code: %w", ErrInvalidLanguage)
+	}
+	if l.Name == "" {
+		return fmt.Errorf("language name cannot be empty: %w", ErrInvalidInput)
+	}
+	if !l.Script.Valid() {
+		return fmt.Errorf("unsupported script %q: %w", l.Script, ErrInvalidLanguage)
+	}
+
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+
+	return nil
+}
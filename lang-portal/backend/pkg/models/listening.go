@@ -0,0 +1,169 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTranscriptLength is the longest transcript, in characters, that can be stored
+	MaxTranscriptLength = 10000
+
+	// MaxListeningOptions is the largest number of choices a question can offer
+	MaxListeningOptions = 6
+)
+
+// ListeningExercise is a recorded dialogue with its transcript and the
+// multiple-choice questions asked about it
+type ListeningExercise struct {
+	ID           int64               `json:"id" db:"id"`
+	Language     string              `json:"language" db:"language"`
+	Source       string              `json:"source,omitempty" db:"source"` // where the exercise was imported from
+	Introduction string              `json:"introduction" db:"introduction"`
+	Transcript   string              `json:"transcript" db:"transcript"`
+	AudioAssetID *int64              `json:"audio_asset_id,omitempty" db:"audio_asset_id"`
+	AudioURL     string              `json:"audio_url,omitempty" db:"audio_url"`
+	CreatedAt    time.Time           `json:"created_at" db:"created_at"`
+	Segments     []ListeningSegment  `json:"segments,omitempty"`
+	Questions    []ListeningQuestion `json:"questions,omitempty"`
+}
+
+// ListeningSegment is a line of a transcript, with its place in the audio
+// when the transcript is timed
+type ListeningSegment struct {
+	ID         int64  `json:"id" db:"id"`
+	ExerciseID int64  `json:"exercise_id" db:"exercise_id"`
+	Position   int    `json:"position" db:"position"`
+	Text       string `json:"text" db:"text"`
+	StartMS    *int64 `json:"start_ms,omitempty" db:"start_ms"`
+	EndMS      *int64 `json:"end_ms,omitempty" db:"end_ms"`
+}
+
+// ListeningQuestion is a multiple-choice question about an exercise
+type ListeningQuestion struct {
+	ID         int64    `json:"id" db:"id"`
+	ExerciseID int64    `json:"exercise_id" db:"exercise_id"`
+	Position   int      `json:"position" db:"position"`
+	Question   string   `json:"question" db:"question"`
+	Options    []string `json:"options" db:"options"`
+	Answer     string   `json:"answer,omitempty" db:"answer"` // one of Options
+}
+
+// Validate performs validation checks on the ListeningExercise struct and
+// numbers its segments and questions
+func (e *ListeningExercise) Validate() error {
+	e.Language = strings.ToLower(strings.TrimSpace(e.Language))
+	e.Source = strings.TrimSpace(e.Source)
+	e.Introduction = strings.TrimSpace(e.Introduction)
+	e.Transcript = strings.TrimSpace(e.Transcript)
+	e.AudioURL = strings.TrimSpace(e.AudioURL)
+
+	if e.Language == "" {
+		e.Language = DefaultLanguage
+	}
+	if e.Transcript == "" {
+		return fmt.Errorf("transcript cannot be empty: %w", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(e.Transcript) > MaxTranscriptLength {
+		return fmt.Errorf("transcript cannot exceed %d characters: %w", MaxTranscriptLength, ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(e.Introduction) > MaxSentenceLength {
+		return fmt.Errorf("introduction cannot exceed %d characters: %w", MaxSentenceLength, ErrInvalidInput)
+	}
+	if e.AudioAssetID != nil && *e.AudioAssetID <= 0 {
+		return ErrInvalidID
+	}
+
+	var previous *ListeningSegment
+	for i := range e.Segments {
+		segment := &e.Segments[i]
+		segment.Position = i + 1
+		if err := segment.validate(previous); err != nil {
+			return err
+		}
+		previous = segment
+	}
+
+	if len(e.Questions) == 0 {
+		return fmt.Errorf("listening exercise needs a question: %w", ErrInvalidInput)
+	}
+	for i := range e.Questions {
+		e.Questions[i].Position = i + 1
+		if err := e.Questions[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// HideAnswers removes the answers of the questions, for learners
+func (e *ListeningExercise) HideAnswers() {
+	for i := range e.Questions {
+		e.Questions[i].Answer = ""
+	}
+}
+
+// validate checks a segment's text and that it starts after the previous segment
+func (s *ListeningSegment) validate(previous *ListeningSegment) error {
+	s.Text = strings.TrimSpace(s.Text)
+	if s.Text == "" {
+		return fmt.Errorf("segment %d cannot be empty: %w", s.Position, ErrInvalidInput)
+	}
+
+	if (s.StartMS == nil) != (s.EndMS == nil) {
+		return fmt.Errorf("segment %d needs both a start and an end: %w", s.Position, ErrInvalidInput)
+	}
+	if s.StartMS == nil {
+		return nil
+	}
+	if *s.StartMS < 0 || *s.EndMS < *s.StartMS {
+		return fmt.Errorf("segment %d: %w", s.Position, ErrInvalidTimeRange)
+	}
+	if previous != nil && previous.StartMS != nil && *s.StartMS < *previous.StartMS {
+		return fmt.Errorf("segment %d starts before the segment it follows: %w", s.Position, ErrInvalidTimeRange)
+	}
+	return nil
+}
+
+// Validate performs validation checks on the ListeningQuestion struct
+func (q *ListeningQuestion) Validate() error {
+	q.Question = strings.TrimSpace(q.Question)
+	q.Answer = strings.TrimSpace(q.Answer)
+
+	if q.Question == "" {
+		return fmt.Errorf("question cannot be empty: %w", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(q.Question) > MaxSentenceLength {
+		return fmt.Errorf("question cannot exceed %d characters: %w", MaxSentenceLength, ErrInvalidInput)
+	}
+
+	options := make([]string, 0, len(q.Options))
+	seen := make(map[string]bool)
+	for _, option := range q.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return fmt.Errorf("options of question %q cannot be empty: %w", q.Question, ErrInvalidInput)
+		}
+		if seen[option] {
+			return fmt.Errorf("question %q repeats the option %q: %w", q.Question, option, ErrInvalidInput)
+		}
+		seen[option] = true
+		options = append(options, option)
+	}
+	if len(options) < 2 || len(options) > MaxListeningOptions {
+		return fmt.Errorf("question %q needs 2 to %d options: %w", q.Question, MaxListeningOptions, ErrInvalidInput)
+	}
+	if !seen[q.Answer] {
+		return fmt.Errorf("answer of question %q must be one of its options: %w", q.Question, ErrInvalidInput)
+	}
+	q.Options = options
+
+	return nil
+}
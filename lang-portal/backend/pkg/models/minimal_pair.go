@@ -0,0 +1,19 @@
+package models
+
+// MinimalPair is two words that differ only in one phonetic contrast, such
+// as aspiration in कल and खल
+type MinimalPair struct {
+	Contrast string `json:"contrast"`
+	First    Word   `json:"first"`
+	Second   Word   `json:"second"`
+}
+
+// ContrastCoverage counts the minimal pairs a group's vocabulary has for each
+// contrast, and names the contrasts it has none for
+type ContrastCoverage struct {
+	GroupID   int64          `json:"group_id"`
+	GroupName string         `json:"group_name"`
+	Words     int            `json:"words"`
+	Pairs     map[string]int `json:"pairs"`
+	Missing   []string       `json:"missing"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// Numeral is a Hindi number with its word, Devanagari digits and
+// pronunciation, generated rather than stored
+type Numeral struct {
+	Value     int    `json:"value"`
+	Digits    string `json:"digits"`
+	Word      string `json:"word"`
+	Romanized string `json:"romanized"`
+	IPA       string `json:"ipa"`
+}
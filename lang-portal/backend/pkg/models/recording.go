@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Recording is a learner's spoken answer to a speaking challenge, kept with
+// its transcript so the learner can listen back to it. The file itself is
+// kept in storage under StorageKey.
+type Recording struct {
+	ID                int64     `json:"id" db:"id"`
+	UserID            int64     `json:"user_id" db:"user_id"`
+	SessionID         int64     `json:"session_id" db:"session_id"`
+	SessionActivityID int64     `json:"session_activity_id" db:"session_activity_id"` // the graded answer
+	WordID            *int64    `json:"word_id,omitempty" db:"word_id"`
+	MimeType          string    `json:"mime_type" db:"mime_type"`
+	Size              int64     `json:"size" db:"size"`
+	DurationMS        *int64    `json:"duration_ms,omitempty" db:"duration_ms"`
+	StorageKey        string    `json:"-" db:"storage_key"`
+	Transcript        string    `json:"transcript" db:"transcript"`
+	Model             string    `json:"model" db:"model"` // the speech recognizer that wrote the transcript
+	URL               string    `json:"url"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// RecordingURL returns the path the content of a recording is served from
+func RecordingURL(id int64) string {
+	return fmt.Sprintf("/api/recordings/%d/content", id)
+}
+
+// Validate performs validation checks on the Recording struct
+func (r *Recording) Validate() error {
+	if r.UserID <= 0 || r.SessionID <= 0 || r.SessionActivityID <= 0 {
+		return ErrInvalidID
+	}
+
+	mediaType := NormalizeMediaType(r.MimeType)
+	if !strings.HasPrefix(mediaType, AssetAudio+"/") {
+		return fmt.Errorf("%q is not an accepted audio type: %w", r.MimeType, ErrUnsupportedMedia)
+	}
+	r.MimeType = mediaType
+
+	if r.Size <= 0 || r.StorageKey == "" {
+		return fmt.Errorf("recording cannot be empty: %w", ErrInvalidInput)
+	}
+	if r.DurationMS != nil && *r.DurationMS <= 0 {
+		return fmt.Errorf("duration must be positive: %w", ErrInvalidInput)
+	}
+
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// Extension returns the file extension the recording is stored with
+func (r *Recording) Extension() string {
+	return assetExtensions[r.MimeType]
+}
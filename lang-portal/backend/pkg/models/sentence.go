@@ -0,0 +1,153 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxSentenceLength is the longest sentence, in characters, that can be stored
+const MaxSentenceLength = 500
+
+// Sentence is an example sentence of a language pair, linked to the words it shows in use
+type Sentence struct {
+	ID             int64     `json:"id" db:"id"`
+	Language       string    `json:"language" db:"language"`
+	NativeLanguage string    `json:"native_language" db:"native_language"`
+	Target         string    `json:"target" db:"target"`
+	Romanized      string    `json:"romanized" db:"romanized"`
+	Native         string    `json:"native" db:"native"`
+	WordIDs        []int64   `json:"word_ids"`
+	Alternatives   []string  `json:"alternatives" db:"alternatives"` // other valid word orders of Target
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// WordWithSentences represents a word with the example sentences that use it
+type WordWithSentences struct {
+	Word
+	Sentences []Sentence `json:"sentences"`
+}
+
+// MarshalJSON writes the word as Word does, followed by its sentences
+func (w WordWithSentences) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		wordFields
+		legacyWordKeys
+		Sentences []Sentence `json:"sentences"`
+	}{wordFields(w.Word), w.legacyKeys(), w.Sentences})
+}
+
+// Validate performs validation checks on the Sentence struct
+func (s *Sentence) Validate() error {
+	s.Language = strings.ToLower(strings.TrimSpace(s.Language))
+	s.NativeLanguage = strings.ToLower(strings.TrimSpace(s.NativeLanguage))
+	s.Target = strings.TrimSpace(s.Target)
+	s.Romanized = strings.TrimSpace(s.Romanized)
+	s.Native = strings.TrimSpace(s.Native)
+
+	if s.Language == "" {
+		s.Language = DefaultLanguage
+	}
+	if s.NativeLanguage == "" {
+		s.NativeLanguage = DefaultNativeLanguage
+	}
+
+	if s.Target == "" || s.Native == "" {
+		return fmt.Errorf("sentence and its translation cannot be empty: %w", ErrInvalidInput)
+	}
+	for _, text := range []string{s.Target, s.Romanized, s.Native} {
+		if utf8.RuneCountInString(text) > MaxSentenceLength {
+			return fmt.Errorf("sentence cannot exceed %d characters: %w", MaxSentenceLength, ErrInvalidInput)
+		}
+	}
+
+	alternatives := make([]string, 0, len(s.Alternatives))
+	seen := map[string]bool{s.Target: true}
+	for _, alternative := range s.Alternatives {
+		alternative = strings.TrimSpace(alternative)
+		if alternative == "" || seen[alternative] {
+			continue
+		}
+		seen[alternative] = true
+		if !sameWords(alternative, s.Target) {
+			return fmt.Errorf("alternative order %q must use the words of the sentence: %w", alternative, ErrInvalidInput)
+		}
+		alternatives = append(alternatives, alternative)
+	}
+	s.Alternatives = alternatives
+
+	for _, id := range s.WordIDs {
+		if id <= 0 {
+			return ErrInvalidID
+		}
+	}
+	s.WordIDs = uniqueIDs(s.WordIDs)
+
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// ValidateScripts checks that the sentence and its translation are written in
+// the scripts of their languages
+func (s *Sentence) ValidateScripts(target, native *Language) error {
+	if target.Code != s.Language || native.Code != s.NativeLanguage {
+		return fmt.Errorf("sentence is %s-%s, not %s-%s: %w", s.Language, s.NativeLanguage, target.Code, native.Code, ErrInvalidLanguage)
+	}
+
+	if !target.Script.ContainsProse(s.Target) {
+		return fmt.Errorf("%s sentence must be written in %s: %w", target.Name, target.Script.Name(), ErrInvalidScript)
+	}
+	if !native.Script.ContainsProse(s.Native) {
+		return fmt.Errorf("%s translation must be written in %s: %w", native.Name, native.Script.Name(), ErrInvalidScript)
+	}
+
+	return nil
+}
+
+// SentenceWords splits running text into its words, without the punctuation
+// around them
+func SentenceWords(text string) []string {
+	var words []string
+	for _, field := range strings.Fields(text) {
+		if word := strings.TrimFunc(field, unicode.IsPunct); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// sameWords reports whether a and b use the same words, in any order
+func sameWords(a, b string) bool {
+	counts := make(map[string]int)
+	for _, word := range SentenceWords(a) {
+		counts[word]++
+	}
+	for _, word := range SentenceWords(b) {
+		counts[word]--
+	}
+	for _, count := range counts {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueIDs drops repeated IDs, keeping the first occurrence of each
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -7,6 +7,7 @@ import (
 // Session represents a learning session in the language portal
 type Session struct {
 	ID         int64      `json:"id" db:"id"`
+	UserID     int64      `json:"user_id" db:"user_id"`
 	ActivityID int64      `json:"activity_id" db:"activity_id"`
 	GroupID    *int64     `json:"group_id,omitempty" db:"group_id"`
 	StartTime  time.Time  `json:"start_time" db:"start_time"`
@@ -4,6 +4,12 @@ import (
 	"time"
 )
 
+// Results recorded for a graded session activity
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
 // SessionActivity represents an individual activity within a learning session
 type SessionActivity struct {
 	ID         int64     `json:"id" db:"id"`
@@ -47,5 +53,18 @@ func (sa *SessionActivity) Validate() error {
 
 // IsSuccessful checks if the session activity was completed successfully
 func (sa *SessionActivity) IsSuccessful() bool {
-	return sa.Result == "success"
+	return sa.Result == ResultSuccess
+}
+
+// IssuedChallenge is a challenge handed to a learner, kept on the server with
+// the payload it is graded by. Learners answer it by its opaque ID, once.
+type IssuedChallenge struct {
+	ID           string     `json:"id" db:"id"`
+	SessionID    int64      `json:"session_id" db:"session_id"`
+	Type         string     `json:"type" db:"type"`
+	Prompt       string     `json:"prompt" db:"prompt"`
+	Payload      []byte     `json:"-" db:"payload"`
+	AudioAssetID *int64     `json:"-" db:"audio_asset_id"`
+	AnsweredAt   *time.Time `json:"answered_at,omitempty" db:"answered_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 }
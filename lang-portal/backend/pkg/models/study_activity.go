@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"strings"
 	"time"
@@ -8,12 +9,15 @@ import (
 
 // StudyActivity represents a learning activity in the language portal
 type StudyActivity struct {
-	ID          int64     `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	Description string    `json:"description" db:"description"`
-	Image       string    `json:"image" db:"image"`
-	Score       int       `json:"score" db:"score"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	ID          int64           `json:"id" db:"id"`
+	Name        string          `json:"name" db:"name"`
+	Description string          `json:"description" db:"description"`
+	Image       string          `json:"image" db:"image"`
+	Score       int             `json:"score" db:"score"`
+	Type        string          `json:"type" db:"type"`
+	Config      json.RawMessage `json:"config" db:"config"`
+	Enabled     bool            `json:"enabled" db:"enabled"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
 }
 
 // Validate performs validation checks on the StudyActivity struct
@@ -33,6 +37,19 @@ func (sa *StudyActivity) Validate() error {
 		return errors.New("score cannot be negative")
 	}
 
+	// Validate Type
+	if sa.Type == "" {
+		return errors.New("activity type cannot be empty")
+	}
+
+	// Config is optional, but must be a JSON object when present
+	if len(sa.Config) > 0 {
+		var config map[string]interface{}
+		if err := json.Unmarshal(sa.Config, &config); err != nil {
+			return errors.New("activity config must be a JSON object")
+		}
+	}
+
 	return nil
 }
 
@@ -41,6 +58,13 @@ func (sa *StudyActivity) Sanitize() {
 	// Trim whitespace from name and description
 	sa.Name = trimString(sa.Name)
 	sa.Description = trimString(sa.Description)
+	sa.Image = trimString(sa.Image)
+	sa.Type = strings.ToLower(trimString(sa.Type))
+
+	// Store an empty object rather than a null config
+	if len(sa.Config) == 0 || string(sa.Config) == "null" {
+		sa.Config = json.RawMessage("{}")
+	}
 }
 
 // Helper function to trim whitespace
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// Authors of the messages in a tutor conversation
+const (
+	TutorLearner = "learner"
+	TutorTutor   = "tutor"
+)
+
+// VocabularyEntry is a row of the vocabulary table given with a tutor
+// conversation, a word of the sentence taken from the words table
+type VocabularyEntry struct {
+	WordID    int64  `json:"word_id"`
+	Native    string `json:"native"`
+	Target    string `json:"target"`
+	Romanized string `json:"romanized"`
+}
+
+// TutorConversation is a learner working out the translation of one sentence
+// with the help of the sentence tutor
+type TutorConversation struct {
+	ID             int64             `json:"id" db:"id"`
+	UserID         int64             `json:"user_id" db:"user_id"`
+	SessionID      int64             `json:"session_id" db:"session_id"`
+	SentenceID     *int64            `json:"-" db:"sentence_id"` // the example sentence it was started from, hidden as it holds the answer
+	Language       string            `json:"language" db:"language"`
+	NativeLanguage string            `json:"native_language" db:"native_language"`
+	Prompt         string            `json:"prompt" db:"prompt"` // the sentence to translate, in the native language
+	Answer         string            `json:"-" db:"answer"`      // the expected translation, never shown to the learner
+	Vocabulary     []VocabularyEntry `json:"vocabulary" db:"vocabulary"`
+	Structure      string            `json:"structure" db:"structure"` // the sentence structure suggested by the tutor
+	Solved         bool              `json:"solved" db:"solved"`
+	Model          string            `json:"model" db:"model"`
+	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at" db:"updated_at"`
+	Messages       []TutorMessage    `json:"messages,omitempty"`
+}
+
+// TutorMessage is a message of a tutor conversation
+type TutorMessage struct {
+	ID                int64     `json:"id" db:"id"`
+	ConversationID    int64     `json:"conversation_id" db:"conversation_id"`
+	Role              string    `json:"role" db:"role"`
+	Content           string    `json:"content" db:"content"`
+	Clues             []string  `json:"clues,omitempty" db:"clues"`
+	SessionActivityID *int64    `json:"session_activity_id,omitempty" db:"session_activity_id"` // set on attempts at the translation
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
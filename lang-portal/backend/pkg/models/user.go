@@ -0,0 +1,85 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Password length limits, bcrypt ignores anything beyond 72 bytes
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// Role grants access to a set of endpoints, each role includes the ones below it
+type Role string
+
+const (
+	// RoleLearner reads content and plays their own sessions
+	RoleLearner Role = "learner"
+	// RoleEditor also manages words, groups and their word groups
+	RoleEditor Role = "editor"
+	// RoleAdmin also calls destructive endpoints and assigns roles
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleLearner: 1,
+	RoleEditor:  2,
+	RoleAdmin:   3,
+}
+
+// Valid reports whether the role is one of the known roles
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Includes reports whether the role grants everything the required role does
+func (r Role) Includes(required Role) bool {
+	return r.Valid() && roleRanks[r] >= roleRanks[required]
+}
+
+// User represents an account in the language portal
+type User struct {
+	ID           int64     `json:"id" db:"id"`
+	Username     string    `json:"username" db:"username"`
+	PasswordHash string    `json:"-" db:"password_hash"`
+	Role         Role      `json:"role" db:"role"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validate performs validation checks on the User struct
+func (u *User) Validate() error {
+	u.Username = strings.TrimSpace(u.Username)
+
+	if len(u.Username) < 3 || len(u.Username) > 32 {
+		return ErrInvalidUsername
+	}
+
+	for _, r := range u.Username {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_' || r == '-') {
+			return ErrInvalidUsername
+		}
+	}
+
+	if u.PasswordHash == "" {
+		return errors.New("password hash cannot be empty")
+	}
+
+	if !u.Role.Valid() {
+		return ErrInvalidRole
+	}
+
+	return nil
+}
+
+// ValidatePassword checks a plain text password before it is hashed
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return ErrInvalidPassword
+	}
+	return nil
+}
@@ -1,56 +1,120 @@
 package models
 
 import (
-	"errors"
+	"encoding/json"
+	"fmt"
 	"math/rand"
 	"strings"
 	"time"
-	"unicode"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+)
+
+// Default language pair for words that do not name one, the portal started as Hindi for English speakers
+const (
+	DefaultLanguage       = "hi"
+	DefaultNativeLanguage = "en"
 )
 
-// Word represents the structure of a word in the language portal
+// Word represents a vocabulary entry of a language pair: the target form being
+// learned, its romanization and its translation in the learner's native language
 type Word struct {
-	ID        int64     `json:"id" db:"id"`
-	Hindi     string    `json:"hindi" db:"hindi"`
-	Scrambled string    `json:"scrambled" db:"scrambled"`
-	Hinglish  string    `json:"hinglish" db:"hinglish"`
-	English   string    `json:"english" db:"english"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID             int64     `json:"id" db:"id"`
+	Language       string    `json:"language" db:"language"`
+	NativeLanguage string    `json:"native_language" db:"native_language"`
+	Target         string    `json:"target" db:"target"`
+	Scrambled      string    `json:"scrambled" db:"scrambled"`
+	Romanized      string    `json:"romanized" db:"romanized"`
+	IPA            string    `json:"ipa" db:"ipa"`
+	IPAOverride    bool      `json:"ipa_override" db:"ipa_override"` // IPA was entered by an editor rather than generated
+	Native         string    `json:"native" db:"native"`
+	PartOfSpeech   string    `json:"part_of_speech" db:"part_of_speech"`
+	Gender         string    `json:"gender" db:"gender"`                 // of nouns
+	Transitivity   string    `json:"transitivity" db:"transitivity"`     // of verbs
+	FormsOverride  bool      `json:"forms_override" db:"forms_override"` // forms were entered by an editor rather than generated
+	AudioAssetID   *int64    `json:"audio_asset_id,omitempty" db:"audio_asset_id"`
+	AudioURL       string    `json:"audio_url,omitempty"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+
+	// Inflected forms of nouns and verbs, flattened into the word's JSON
+	WordForms
+}
+
+// wordFields has the fields of Word without its JSON methods
+type wordFields Word
+
+// legacyWordKeys are the keys words were written with before they had a
+// language pair, kept for clients that still use them
+type legacyWordKeys struct {
+	Hindi    string `json:"hindi"`
+	Hinglish string `json:"hinglish"`
+	English  string `json:"english"`
+}
+
+// MarshalJSON writes the word with its target, romanized and native forms
+// also under their legacy keys
+func (w Word) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		wordFields
+		legacyWordKeys
+	}{wordFields(w), w.legacyKeys()})
+}
+
+// legacyKeys returns the word's forms under their legacy keys
+func (w *Word) legacyKeys() legacyWordKeys {
+	return legacyWordKeys{Hindi: w.Target, Hinglish: w.Romanized, English: w.Native}
+}
+
+// UnmarshalJSON reads a word, taking forms missing under the current keys
+// from the legacy ones
+func (w *Word) UnmarshalJSON(data []byte) error {
+	word := struct {
+		*wordFields
+		legacyWordKeys
+	}{wordFields: (*wordFields)(w)}
+	if err := json.Unmarshal(data, &word); err != nil {
+		return err
+	}
+
+	if w.Target == "" {
+		w.Target = word.Hindi
+	}
+	if w.Romanized == "" {
+		w.Romanized = word.Hinglish
+	}
+	if w.Native == "" {
+		w.Native = word.English
+	}
+	return nil
 }
 
 // Validate performs validation checks on the Word struct
 func (w *Word) Validate() error {
 	// Trim whitespace
-	w.Hindi = strings.TrimSpace(w.Hindi)
-	w.Scrambled = strings.TrimSpace(w.Scrambled)
-	w.Hinglish = strings.TrimSpace(w.Hinglish)
-	w.English = strings.TrimSpace(w.English)
+	w.Sanitize()
 
-	// Check for empty fields
-	if w.Hindi == "" {
-		return errors.New("hindi word cannot be empty")
+	if w.Language == "" {
+		w.Language = DefaultLanguage
 	}
-	if w.English == "" {
-		return errors.New("english word cannot be empty")
+	if w.NativeLanguage == "" {
+		w.NativeLanguage = DefaultNativeLanguage
 	}
 
-	// Validate Hindi characters
-	for _, r := range w.Hindi {
-		if !unicode.Is(unicode.Devanagari, r) && !unicode.IsSpace(r) {
-			return errors.New("hindi word must contain only Devanagari characters")
-		}
+	// Check for empty fields
+	if w.Target == "" {
+		return fmt.Errorf("target word cannot be empty: %w", ErrInvalidInput)
 	}
-
-	// Validate English characters
-	for _, r := range w.English {
-		if !unicode.IsLetter(r) && !unicode.IsSpace(r) {
-			return errors.New("english word must contain only alphabetic characters")
-		}
+	if w.Native == "" {
+		return fmt.Errorf("native word cannot be empty: %w", ErrInvalidInput)
 	}
 
 	// Ensure scrambled word is not longer than original
-	if len(w.Scrambled) > len(w.Hindi) {
-		return errors.New("scrambled word cannot be longer than original word")
+	if len(w.Scrambled) > len(w.Target) {
+		return fmt.Errorf("scrambled word cannot be longer than original word: %w", ErrInvalidInput)
+	}
+
+	if err := w.ValidateGrammar(); err != nil {
+		return err
 	}
 
 	// Set created_at if not already set
@@ -61,23 +125,50 @@ func (w *Word) Validate() error {
 	return nil
 }
 
+// ValidateScripts checks that the target and native forms are written in the
+// scripts of their languages
+func (w *Word) ValidateScripts(target, native *Language) error {
+	if target.Code != w.Language || native.Code != w.NativeLanguage {
+		return fmt.Errorf("word is %s-%s, not %s-%s: %w", w.Language, w.NativeLanguage, target.Code, native.Code, ErrInvalidLanguage)
+	}
+
+	if !target.Script.Contains(w.Target) {
+		return fmt.Errorf("%s word must contain only %s characters: %w", target.Name, target.Script.Name(), ErrInvalidScript)
+	}
+	if !native.Script.Contains(w.Native) {
+		return fmt.Errorf("%s word must contain only %s characters: %w", native.Name, native.Script.Name(), ErrInvalidScript)
+	}
+
+	return nil
+}
+
 // Sanitize removes any potentially harmful content
 func (w *Word) Sanitize() {
 	// Remove any leading/trailing whitespace
-	w.Hindi = strings.TrimSpace(w.Hindi)
-	w.Scrambled = strings.TrimSpace(w.Scrambled)
-	w.Hinglish = strings.TrimSpace(w.Hinglish)
-	w.English = strings.TrimSpace(w.English)
+	w.Language = strings.ToLower(strings.TrimSpace(w.Language))
+	w.NativeLanguage = strings.ToLower(strings.TrimSpace(w.NativeLanguage))
+	w.Target = CanonicalText(w.Target)
+	w.Scrambled = CanonicalText(w.Scrambled)
+	w.Romanized = CanonicalText(w.Romanized)
+	w.IPA = CanonicalText(w.IPA)
+	w.Native = CanonicalText(w.Native)
+	w.PartOfSpeech = strings.ToLower(strings.TrimSpace(w.PartOfSpeech))
+	w.Gender = strings.ToLower(strings.TrimSpace(w.Gender))
+	w.Transitivity = strings.ToLower(strings.TrimSpace(w.Transitivity))
+	w.WordForms = w.WordForms.Canonical()
+}
 
-	// Optionally, you could add more sanitization logic here
-	// For example, converting to lowercase, removing special characters, etc.
+// CanonicalText trims text and writes it in the form it is stored in, see
+// devanagari.Canonical
+func CanonicalText(s string) string {
+	return strings.TrimSpace(devanagari.Canonical(s))
 }
 
-// GenerateScrambledWord creates a scrambled version of the Hindi word if not provided
+// GenerateScrambledWord creates a scrambled version of the target word if not provided
 func (w *Word) GenerateScrambledWord() {
-	if w.Scrambled == "" && w.Hindi != "" {
+	if w.Scrambled == "" && w.Target != "" {
 		// Simple scrambling algorithm
-		runes := []rune(w.Hindi)
+		runes := []rune(w.Target)
 		for i := len(runes) - 1; i > 0; i-- {
 			j := rand.Intn(i + 1)
 			runes[i], runes[j] = runes[j], runes[i]
@@ -85,3 +176,35 @@ func (w *Word) GenerateScrambledWord() {
 		w.Scrambled = string(runes)
 	}
 }
+
+// Orders word lists can be sorted in. Hindi sorts the target form in
+// dictionary order, English and Hinglish sort the native and romanized
+// forms ignoring case, and difficulty sorts easy words first.
+const (
+	WordSortHindi      = "hindi"
+	WordSortEnglish    = "english"
+	WordSortHinglish   = "hinglish"
+	WordSortCreatedAt  = "created_at"
+	WordSortDifficulty = "difficulty"
+)
+
+// WordSorts lists the orders word lists can be sorted in
+var WordSorts = []string{WordSortHindi, WordSortEnglish, WordSortHinglish, WordSortCreatedAt, WordSortDifficulty}
+
+// Directions of a sort
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// ValidateWordSort checks that a word list order and its direction are
+// known, each may be empty
+func ValidateWordSort(sort, order string) error {
+	if sort != "" && !contains(WordSorts, sort) {
+		return fmt.Errorf("unknown sort %q: %w", sort, ErrInvalidInput)
+	}
+	if order != "" && order != SortAscending && order != SortDescending {
+		return fmt.Errorf("unknown order %q, expected %s or %s: %w", order, SortAscending, SortDescending, ErrInvalidInput)
+	}
+	return nil
+}
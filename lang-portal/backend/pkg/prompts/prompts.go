@@ -0,0 +1,38 @@
+// Package prompts renders the prompt templates used to generate content with
+// a language model. The templates follow the teaching-assistant prompts in
+// sentence-constructor and live in templates/ so they can be tuned without
+// touching the code.
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var files embed.FS
+
+var templates = template.Must(
+	template.New("").Funcs(template.FuncMap{"join": strings.Join}).ParseFS(files, "templates/*.tmpl"),
+)
+
+// Names of the prompt templates
+const (
+	System    = "system.tmpl"
+	Words     = "words.tmpl"
+	Sentences = "sentences.tmpl"
+	Hints     = "hints.tmpl"
+	Tutor     = "tutor.tmpl"
+)
+
+// Render executes the named template with data
+func Render(name string, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt %s: %w", name, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// APIKeyRepository handles database operations for API keys
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create stores a new API key
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	if key.CreatedAt.IsZero() {
+		key.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx, query, key.UserID, key.Name, key.Prefix, key.KeyHash, key.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	key.ID = id
+	return nil
+}
+
+// ListByUserID retrieves the API keys of a user
+func (r *APIKeyRepository) ListByUserID(ctx context.Context, userID int64) ([]models.APIKey, error) {
+	query := `
+		SELECT id, user_id, name, prefix, key_hash, created_at, last_used_at
+		FROM api_keys
+		WHERE user_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		var key models.APIKey
+		var lastUsedAt sql.NullTime
+		err := rows.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.KeyHash, &key.CreatedAt, &lastUsedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		if lastUsedAt.Valid {
+			key.LastUsedAt = &lastUsedAt.Time
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// GetUserByKeyHash retrieves the user owning the API key and records its use
+func (r *APIKeyRepository) GetUserByKeyHash(ctx context.Context, keyHash string) (*models.User, error) {
+	query := `
+		SELECT u.id, u.username, u.password_hash, u.role, u.created_at
+		FROM api_keys k
+		INNER JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = ?
+	`
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, keyHash))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("api key: %w", models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve api key: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `UPDATE api_keys SET last_used_at = ? WHERE key_hash = ?`, time.Now(), keyHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record api key use: %w", err)
+	}
+
+	return user, nil
+}
+
+// Delete removes an API key of the given user
+func (r *APIKeyRepository) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM api_keys WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("api key with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
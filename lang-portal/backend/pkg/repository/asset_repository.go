@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// AssetRepository defines the interface for uploaded asset metadata
+type AssetRepository interface {
+	// Create adds the metadata of a stored file
+	Create(ctx context.Context, asset *models.Asset) error
+
+	// GetByID retrieves an asset by its ID
+	GetByID(ctx context.Context, id int64) (*models.Asset, error)
+
+	// GetByChecksum retrieves the asset with the given content checksum
+	GetByChecksum(ctx context.Context, checksum string) (*models.Asset, error)
+
+	// Delete removes an asset by its ID, words using it lose their audio
+	Delete(ctx context.Context, id int64) error
+}
+
+// SQLiteAssetRepository implements AssetRepository for SQLite
+type SQLiteAssetRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAssetRepository creates a new instance of SQLiteAssetRepository
+func NewSQLiteAssetRepository(db *sql.DB) *SQLiteAssetRepository {
+	return &SQLiteAssetRepository{db: db}
+}
+
+const assetColumns = `id, kind, mime_type, filename, size, duration_ms, checksum, storage_key, created_at`
+
+// Create inserts the metadata of a stored file
+func (r *SQLiteAssetRepository) Create(ctx context.Context, asset *models.Asset) error {
+	if err := asset.Validate(); err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO assets (kind, mime_type, filename, size, duration_ms, checksum, storage_key, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		asset.Kind,
+		asset.MimeType,
+		asset.Filename,
+		asset.Size,
+		asset.DurationMS,
+		asset.Checksum,
+		asset.StorageKey,
+		asset.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create asset: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	asset.ID = id
+	asset.URL = models.AssetURL(id)
+	return nil
+}
+
+// GetByID retrieves an asset by its ID
+func (r *SQLiteAssetRepository) GetByID(ctx context.Context, id int64) (*models.Asset, error) {
+	query := `SELECT ` + assetColumns + ` FROM assets WHERE id = ?`
+
+	asset, err := scanAsset(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("asset with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve asset: %w", err)
+	}
+
+	return asset, nil
+}
+
+// GetByChecksum retrieves the asset with the given content checksum
+func (r *SQLiteAssetRepository) GetByChecksum(ctx context.Context, checksum string) (*models.Asset, error) {
+	query := `SELECT ` + assetColumns + ` FROM assets WHERE checksum = ?`
+
+	asset, err := scanAsset(r.db.QueryRowContext(ctx, query, checksum))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("asset with checksum %s: %w", checksum, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve asset: %w", err)
+	}
+
+	return asset, nil
+}
+
+// Delete removes an asset by its ID
+func (r *SQLiteAssetRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM assets WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete asset: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("asset with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// scanAsset reads an asset selected with assetColumns
+func scanAsset(row rowScanner) (*models.Asset, error) {
+	asset := &models.Asset{}
+	var duration sql.NullInt64
+	err := row.Scan(
+		&asset.ID,
+		&asset.Kind,
+		&asset.MimeType,
+		&asset.Filename,
+		&asset.Size,
+		&duration,
+		&asset.Checksum,
+		&asset.StorageKey,
+		&asset.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if duration.Valid {
+		asset.DurationMS = &duration.Int64
+	}
+	asset.URL = models.AssetURL(asset.ID)
+	return asset, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// ContentReportRepository defines the queries the content report runs
+// across words and groups
+type ContentReportRepository interface {
+	// ListUngroupedWordIDs retrieves the IDs of the words in no group
+	ListUngroupedWordIDs(ctx context.Context) ([]int64, error)
+
+	// ListEmptyGroups retrieves the groups without words
+	ListEmptyGroups(ctx context.Context) ([]models.Group, error)
+
+	// ListDifficulties retrieves the difficulty of every word that has one
+	ListDifficulties(ctx context.Context) (map[int64]string, error)
+}
+
+// SQLiteContentReportRepository implements ContentReportRepository for SQLite
+type SQLiteContentReportRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteContentReportRepository creates a new instance of SQLiteContentReportRepository
+func NewSQLiteContentReportRepository(db *sql.DB) *SQLiteContentReportRepository {
+	return &SQLiteContentReportRepository{db: db}
+}
+
+// ListUngroupedWordIDs retrieves the IDs of the words in no group
+func (r *SQLiteContentReportRepository) ListUngroupedWordIDs(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id FROM words w
+		WHERE NOT EXISTS (SELECT 1 FROM word_groups wg WHERE wg.word_id = w.id)
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ungrouped words: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan word ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ListEmptyGroups retrieves the groups without words
+func (r *SQLiteContentReportRepository) ListEmptyGroups(ctx context.Context) ([]models.Group, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, description, created_at FROM groups g
+		WHERE NOT EXISTS (SELECT 1 FROM word_groups wg WHERE wg.group_id = g.id)
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list empty groups: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []models.Group{}
+	for rows.Next() {
+		var group models.Group
+		if err := rows.Scan(&group.ID, &group.Name, &group.Description, &group.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// ListDifficulties retrieves the difficulty of every word that has one
+func (r *SQLiteContentReportRepository) ListDifficulties(ctx context.Context) (map[int64]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, difficulty FROM words WHERE difficulty IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list word difficulties: %w", err)
+	}
+	defer rows.Close()
+
+	difficulties := make(map[int64]string)
+	for rows.Next() {
+		var id int64
+		var difficulty string
+		if err := rows.Scan(&id, &difficulty); err != nil {
+			return nil, fmt.Errorf("failed to scan word difficulty: %w", err)
+		}
+		difficulties[id] = difficulty
+	}
+	return difficulties, rows.Err()
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// DraftRepository defines the interface for generated content drafts
+type DraftRepository interface {
+	// Create stores a pending draft
+	Create(ctx context.Context, draft *models.Draft) error
+
+	// GetByID retrieves a draft by its ID
+	GetByID(ctx context.Context, id int64) (*models.Draft, error)
+
+	// List retrieves drafts, newest first
+	List(ctx context.Context, params ListDraftsParams) ([]models.Draft, error)
+
+	// Review records the decision on a pending draft and the content it was approved with
+	Review(ctx context.Context, draft *models.Draft) error
+}
+
+// ListDraftsParams defines parameters for listing drafts
+type ListDraftsParams struct {
+	Kind   string             // lists all kinds when empty
+	Status models.DraftStatus // lists all statuses when empty
+	WordID int64              // lists drafts of all words when zero
+	Limit  int
+}
+
+// SQLiteDraftRepository implements DraftRepository for SQLite
+type SQLiteDraftRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteDraftRepository creates a new instance of SQLiteDraftRepository
+func NewSQLiteDraftRepository(db *sql.DB) *SQLiteDraftRepository {
+	return &SQLiteDraftRepository{db: db}
+}
+
+const draftColumns = `id, kind, status, content, model, group_id, word_id, result_id, created_by, reviewed_by, created_at, reviewed_at`
+
+// Create stores a pending draft
+func (r *SQLiteDraftRepository) Create(ctx context.Context, draft *models.Draft) error {
+	draft.Status = models.DraftPending
+	if draft.CreatedAt.IsZero() {
+		draft.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO drafts (kind, status, content, model, group_id, word_id, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		draft.Kind,
+		draft.Status,
+		string(draft.Content),
+		draft.Model,
+		draft.GroupID,
+		draft.WordID,
+		draft.CreatedBy,
+		draft.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create draft: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	draft.ID = id
+	return nil
+}
+
+// GetByID retrieves a draft by its ID
+func (r *SQLiteDraftRepository) GetByID(ctx context.Context, id int64) (*models.Draft, error) {
+	query := `SELECT ` + draftColumns + ` FROM drafts WHERE id = ?`
+
+	draft, err := scanDraft(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("draft with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve draft: %w", err)
+	}
+
+	return draft, nil
+}
+
+// List retrieves drafts matching the params, newest first
+func (r *SQLiteDraftRepository) List(ctx context.Context, params ListDraftsParams) ([]models.Draft, error) {
+	if params.Limit < 1 {
+		params.Limit = 100
+	}
+
+	query := `SELECT ` + draftColumns + ` FROM drafts WHERE 1=1`
+	args := []interface{}{}
+	if params.Kind != "" {
+		query += ` AND kind = ?`
+		args = append(args, params.Kind)
+	}
+	if params.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, params.Status)
+	}
+	if params.WordID != 0 {
+		query += ` AND word_id = ?`
+		args = append(args, params.WordID)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, params.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+	defer rows.Close()
+
+	drafts := []models.Draft{}
+	for rows.Next() {
+		draft, err := scanDraft(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan draft: %w", err)
+		}
+		drafts = append(drafts, *draft)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating drafts: %w", err)
+	}
+
+	return drafts, nil
+}
+
+// Review records the decision on a pending draft. Drafts that were reviewed
+// meanwhile are not changed again.
+func (r *SQLiteDraftRepository) Review(ctx context.Context, draft *models.Draft) error {
+	reviewedAt := time.Now()
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE drafts
+		SET status = ?, content = ?, result_id = ?, reviewed_by = ?, reviewed_at = ?
+		WHERE id = ? AND status = 'pending'`,
+		draft.Status,
+		string(draft.Content),
+		draft.ResultID,
+		draft.ReviewedBy,
+		reviewedAt,
+		draft.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to review draft: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("pending draft with ID %d: %w", draft.ID, models.ErrNotFound)
+	}
+
+	draft.ReviewedAt = &reviewedAt
+	return nil
+}
+
+// scanDraft reads a draft selected with draftColumns
+func scanDraft(row rowScanner) (*models.Draft, error) {
+	draft := &models.Draft{}
+	var content string
+	var groupID, wordID, resultID, createdBy, reviewedBy sql.NullInt64
+	var reviewedAt sql.NullTime
+	err := row.Scan(
+		&draft.ID,
+		&draft.Kind,
+		&draft.Status,
+		&content,
+		&draft.Model,
+		&groupID,
+		&wordID,
+		&resultID,
+		&createdBy,
+		&reviewedBy,
+		&draft.CreatedAt,
+		&reviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	draft.Content = []byte(content)
+	draft.GroupID = nullableID(groupID)
+	draft.WordID = nullableID(wordID)
+	draft.ResultID = nullableID(resultID)
+	draft.CreatedBy = nullableID(createdBy)
+	draft.ReviewedBy = nullableID(reviewedBy)
+	if reviewedAt.Valid {
+		draft.ReviewedAt = &reviewedAt.Time
+	}
+	return draft, nil
+}
+
+// nullableID converts a nullable ID column to a pointer
+func nullableID(id sql.NullInt64) *int64 {
+	if !id.Valid {
+		return nil
+	}
+	return &id.Int64
+}
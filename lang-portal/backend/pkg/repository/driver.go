@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+)
+
+// DriverName is the database/sql driver the repositories expect, SQLite with
+// the HINDI collation registered on every connection so that queries can
+// sort Devanagari in dictionary order with COLLATE HINDI
+const DriverName = "sqlite3_lang_portal"
+
+func init() {
+	sql.Register(DriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterCollation("HINDI", devanagari.Compare)
+		},
+	})
+}
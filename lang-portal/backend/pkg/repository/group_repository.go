@@ -21,6 +21,10 @@ func NewSQLiteGroupRepository(db *sql.DB) *SQLiteGroupRepository {
 
 // Create adds a new group to the database
 func (r *SQLiteGroupRepository) Create(ctx context.Context, group *models.Group) error {
+	// Sanitize the group name and description, so that names are validated
+	// in the form they are stored in
+	group.Sanitize()
+
 	// Validate the group
 	if err := group.Validate(); err != nil {
 		return err
@@ -72,14 +76,14 @@ func (r *SQLiteGroupRepository) GetByID(ctx context.Context, id int64) (*models.
 
 // Update modifies an existing group
 func (r *SQLiteGroupRepository) Update(ctx context.Context, group *models.Group) error {
+	// Sanitize the group name and description
+	group.Sanitize()
+
 	// Validate the group before updating
 	if err := group.Validate(); err != nil {
 		return err
 	}
 
-	// Sanitize the group name and description
-	group.Sanitize()
-
 	// Prepare the SQL statement
 	query := `UPDATE groups SET name = ?, description = ? WHERE id = ?`
 	result, err := r.db.ExecContext(ctx, query, group.Name, group.Description, group.ID)
@@ -135,7 +139,8 @@ func (r *SQLiteGroupRepository) List(ctx context.Context, page, pageSize int, se
 	if search != "" {
 		countQuery += ` AND (name LIKE ? OR description LIKE ?)`
 		listQuery += ` AND (name LIKE ? OR description LIKE ?)`
-		args = append(args, "%"+search+"%", "%"+search+"%")
+		search = "%" + models.CanonicalText(search) + "%"
+		args = append(args, search, search)
 	}
 
 	// Count total groups
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// IssuedChallengeRepository defines the interface for the challenges handed
+// to learners
+type IssuedChallengeRepository interface {
+	// Create stores an issued challenge
+	Create(ctx context.Context, challenge *models.IssuedChallenge) error
+
+	// GetByID retrieves a challenge issued for a session
+	GetByID(ctx context.Context, sessionID int64, id string) (*models.IssuedChallenge, error)
+
+	// ListBySession retrieves the challenges issued for a session, oldest first
+	ListBySession(ctx context.Context, sessionID int64) ([]models.IssuedChallenge, error)
+
+	// MarkAnswered marks a challenge of a session answered, failing with
+	// models.ErrAnswered when it already was
+	MarkAnswered(ctx context.Context, sessionID int64, id string) error
+}
+
+const issuedChallengeColumns = `id, session_id, type, prompt, payload, audio_asset_id, answered_at, created_at`
+
+// scanIssuedChallenge reads a challenge selected with issuedChallengeColumns
+func scanIssuedChallenge(row rowScanner) (*models.IssuedChallenge, error) {
+	var challenge models.IssuedChallenge
+	var payload string
+	var audioAssetID sql.NullInt64
+	var answeredAt sql.NullTime
+	if err := row.Scan(&challenge.ID, &challenge.SessionID, &challenge.Type, &challenge.Prompt, &payload, &audioAssetID, &answeredAt, &challenge.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	challenge.Payload = []byte(payload)
+	if audioAssetID.Valid {
+		challenge.AudioAssetID = &audioAssetID.Int64
+	}
+	if answeredAt.Valid {
+		challenge.AnsweredAt = &answeredAt.Time
+	}
+	return &challenge, nil
+}
+
+// SQLiteIssuedChallengeRepository implements IssuedChallengeRepository for SQLite
+type SQLiteIssuedChallengeRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteIssuedChallengeRepository creates a new instance of SQLiteIssuedChallengeRepository
+func NewSQLiteIssuedChallengeRepository(db *sql.DB) *SQLiteIssuedChallengeRepository {
+	return &SQLiteIssuedChallengeRepository{db: db}
+}
+
+// Create stores an issued challenge
+func (r *SQLiteIssuedChallengeRepository) Create(ctx context.Context, challenge *models.IssuedChallenge) error {
+	if challenge.CreatedAt.IsZero() {
+		challenge.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO issued_challenges (id, session_id, type, prompt, payload, audio_asset_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		challenge.ID, challenge.SessionID, challenge.Type, challenge.Prompt, string(challenge.Payload), challenge.AudioAssetID, challenge.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store issued challenge: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a challenge issued for a session
+func (r *SQLiteIssuedChallengeRepository) GetByID(ctx context.Context, sessionID int64, id string) (*models.IssuedChallenge, error) {
+	challenge, err := scanIssuedChallenge(r.db.QueryRowContext(ctx, `
+		SELECT `+issuedChallengeColumns+`
+		FROM issued_challenges WHERE id = ? AND session_id = ?`, id, sessionID,
+	))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("challenge %q of session %d: %w", id, sessionID, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve issued challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+// ListBySession retrieves the challenges issued for a session, oldest first
+func (r *SQLiteIssuedChallengeRepository) ListBySession(ctx context.Context, sessionID int64) ([]models.IssuedChallenge, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+issuedChallengeColumns+`
+		FROM issued_challenges WHERE session_id = ?
+		ORDER BY created_at, rowid`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issued challenges: %w", err)
+	}
+	defer rows.Close()
+
+	var challenges []models.IssuedChallenge
+	for rows.Next() {
+		challenge, err := scanIssuedChallenge(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issued challenge: %w", err)
+		}
+		challenges = append(challenges, *challenge)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list issued challenges: %w", err)
+	}
+	return challenges, nil
+}
+
+// MarkAnswered marks a challenge of a session answered, failing with
+// models.ErrAnswered when it already was, so concurrent answers are graded once
+func (r *SQLiteIssuedChallengeRepository) MarkAnswered(ctx context.Context, sessionID int64, id string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE issued_challenges SET answered_at = ?
+		WHERE id = ? AND session_id = ? AND answered_at IS NULL`, time.Now(), id, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to mark challenge answered: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		if _, err := r.GetByID(ctx, sessionID, id); err != nil {
+			return err
+		}
+		return fmt.Errorf("challenge %q: %w", id, models.ErrAnswered)
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// JobRepository defines the interface for background job operations
+type JobRepository interface {
+	// Enqueue queues a job of a kind for a subject, reporting whether it was
+	// queued. A subject with an open or failed job of the kind is skipped.
+	Enqueue(ctx context.Context, kind string, subjectID int64) (bool, error)
+
+	// Claim marks the oldest pending job of a kind as running and returns it
+	Claim(ctx context.Context, kind string) (*models.Job, error)
+
+	// Succeed marks a running job as succeeded
+	Succeed(ctx context.Context, id int64) error
+
+	// Fail marks a running job as failed with the error it ran into
+	Fail(ctx context.Context, id int64, message string) error
+
+	// Retry queues a failed job again
+	Retry(ctx context.Context, id int64) (*models.Job, error)
+
+	// ResetRunning queues the running jobs of a kind again, for jobs
+	// interrupted by a restart
+	ResetRunning(ctx context.Context, kind string) (int64, error)
+
+	// GetByID retrieves a job by its ID
+	GetByID(ctx context.Context, id int64) (*models.Job, error)
+
+	// List retrieves jobs, newest first
+	List(ctx context.Context, params ListJobsParams) ([]models.Job, error)
+}
+
+// ListJobsParams defines parameters for listing jobs
+type ListJobsParams struct {
+	Kind   string           // lists all kinds when empty
+	Status models.JobStatus // lists all statuses when empty
+	Limit  int
+}
+
+// SQLiteJobRepository implements JobRepository for SQLite
+type SQLiteJobRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteJobRepository creates a new instance of SQLiteJobRepository
+func NewSQLiteJobRepository(db *sql.DB) *SQLiteJobRepository {
+	return &SQLiteJobRepository{db: db}
+}
+
+const jobColumns = `id, kind, subject_id, status, attempts, last_error, created_at, updated_at`
+
+// Enqueue inserts a pending job, or queues a succeeded job of the subject
+// again since its work has to be redone
+func (r *SQLiteJobRepository) Enqueue(ctx context.Context, kind string, subjectID int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO jobs (kind, subject_id) VALUES (?, ?)
+		ON CONFLICT (kind, subject_id) DO UPDATE
+		SET status = 'pending', last_error = '', updated_at = datetime('now', 'localtime')
+		WHERE jobs.status = 'succeeded'`,
+		kind, subjectID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error checking rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// Claim marks the oldest pending job of a kind as running in a single
+// statement, so concurrent workers never claim the same job
+func (r *SQLiteJobRepository) Claim(ctx context.Context, kind string) (*models.Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'running', attempts = attempts + 1, updated_at = datetime('now', 'localtime')
+		WHERE id = (
+			SELECT id FROM jobs WHERE kind = ? AND status = 'pending' ORDER BY id LIMIT 1
+		)
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, kind))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("pending %s job: %w", kind, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	return job, nil
+}
+
+// Succeed marks a running job as succeeded
+func (r *SQLiteJobRepository) Succeed(ctx context.Context, id int64) error {
+	return r.finish(ctx, id, models.JobSucceeded, "")
+}
+
+// Fail marks a running job as failed with the error it ran into
+func (r *SQLiteJobRepository) Fail(ctx context.Context, id int64, message string) error {
+	return r.finish(ctx, id, models.JobFailed, message)
+}
+
+func (r *SQLiteJobRepository) finish(ctx context.Context, id int64, status models.JobStatus, message string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = ?, last_error = ?, updated_at = datetime('now', 'localtime')
+		WHERE id = ? AND status = 'running'`,
+		status, message, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("running job with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Retry queues a failed job again, keeping its last error until it runs
+func (r *SQLiteJobRepository) Retry(ctx context.Context, id int64) (*models.Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'pending', updated_at = datetime('now', 'localtime')
+		WHERE id = ? AND status = 'failed'
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		existing, err := r.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("job %d is %s, only failed jobs can be retried: %w", id, existing.Status, models.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ResetRunning queues the running jobs of a kind again
+func (r *SQLiteJobRepository) ResetRunning(ctx context.Context, kind string) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'pending', updated_at = datetime('now', 'localtime')
+		WHERE kind = ? AND status = 'running'`,
+		kind,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset running jobs: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// GetByID retrieves a job by its ID
+func (r *SQLiteJobRepository) GetByID(ctx context.Context, id int64) (*models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = ?`
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("job with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve job: %w", err)
+	}
+
+	return job, nil
+}
+
+// List retrieves jobs matching the params, newest first
+func (r *SQLiteJobRepository) List(ctx context.Context, params ListJobsParams) ([]models.Job, error) {
+	if params.Limit < 1 {
+		params.Limit = 100
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE 1=1`
+	args := []interface{}{}
+	if params.Kind != "" {
+		query += ` AND kind = ?`
+		args = append(args, params.Kind)
+	}
+	if params.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, params.Status)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, params.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []models.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, *job)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// scanJob reads a job selected with jobColumns
+func scanJob(row rowScanner) (*models.Job, error) {
+	job := &models.Job{}
+	err := row.Scan(
+		&job.ID,
+		&job.Kind,
+		&job.SubjectID,
+		&job.Status,
+		&job.Attempts,
+		&job.LastError,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// LanguageRepository defines the interface for language-related database operations
+type LanguageRepository interface {
+	// Create adds a new language
+	Create(ctx context.Context, language *models.Language) error
+
+	// GetByCode retrieves a language by its code
+	GetByCode(ctx context.Context, code string) (*models.Language, error)
+
+	// List retrieves all languages ordered by name
+	List(ctx context.Context) ([]models.Language, error)
+}
+
+// SQLiteLanguageRepository implements LanguageRepository for SQLite
+type SQLiteLanguageRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteLanguageRepository creates a new instance of SQLiteLanguageRepository
+func NewSQLiteLanguageRepository(db *sql.DB) *SQLiteLanguageRepository {
+	return &SQLiteLanguageRepository{db: db}
+}
+
+const languageColumns = `code, name, script, romanization, created_at`
+
+// Create inserts a new language into the database
+func (r *SQLiteLanguageRepository) Create(ctx context.Context, language *models.Language) error {
+	if err := language.Validate(); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO languages (` + languageColumns + `) VALUES (?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		language.Code,
+		language.Name,
+		language.Script,
+		language.Romanization,
+		language.CreatedAt,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+			return fmt.Errorf("language %q already exists: %w", language.Code, models.ErrInvalidLanguage)
+		}
+		return fmt.Errorf("failed to create language: %w", err)
+	}
+
+	return nil
+}
+
+// GetByCode retrieves a language by its code
+func (r *SQLiteLanguageRepository) GetByCode(ctx context.Context, code string) (*models.Language, error) {
+	query := `SELECT ` + languageColumns + ` FROM languages WHERE code = ?`
+
+	language, err := scanLanguage(r.db.QueryRowContext(ctx, query, code))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("language %q: %w", code, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve language: %w", err)
+	}
+
+	return language, nil
+}
+
+// List retrieves all languages ordered by name
+func (r *SQLiteLanguageRepository) List(ctx context.Context) ([]models.Language, error) {
+	query := `SELECT ` + languageColumns + ` FROM languages ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list languages: %w", err)
+	}
+	defer rows.Close()
+
+	languages := []models.Language{}
+	for rows.Next() {
+		language, err := scanLanguage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan language: %w", err)
+		}
+		languages = append(languages, *language)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating languages: %w", err)
+	}
+
+	return languages, nil
+}
+
+// scanLanguage reads a language selected with languageColumns
+func scanLanguage(row rowScanner) (*models.Language, error) {
+	language := &models.Language{}
+	err := row.Scan(
+		&language.Code,
+		&language.Name,
+		&language.Script,
+		&language.Romanization,
+		&language.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return language, nil
+}
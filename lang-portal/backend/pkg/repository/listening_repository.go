@@ -0,0 +1,411 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// ListeningRepository defines the interface for listening exercises with
+// their transcript segments and questions
+type ListeningRepository interface {
+	// Create stores an exercise with its segments and questions
+	Create(ctx context.Context, exercise *models.ListeningExercise) error
+
+	// GetByID retrieves an exercise with its segments and questions
+	GetByID(ctx context.Context, id int64) (*models.ListeningExercise, error)
+
+	// GetBySource retrieves an exercise by the source it was imported from,
+	// without its segments and questions
+	GetBySource(ctx context.Context, source string) (*models.ListeningExercise, error)
+
+	// List retrieves the exercises, without their segments and questions
+	List(ctx context.Context, language string) ([]models.ListeningExercise, error)
+
+	// UpdateAudio replaces the recording of an exercise
+	UpdateAudio(ctx context.Context, id int64, assetID *int64, url string) error
+
+	// Delete removes an exercise with its segments and questions
+	Delete(ctx context.Context, id int64) error
+
+	// GetQuestion retrieves a question by its ID
+	GetQuestion(ctx context.Context, id int64) (*models.ListeningQuestion, error)
+
+	// GetRandomQuestion retrieves a random question of any exercise
+	GetRandomQuestion(ctx context.Context) (*models.ListeningQuestion, error)
+}
+
+// SQLiteListeningRepository implements ListeningRepository for SQLite
+type SQLiteListeningRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteListeningRepository creates a new instance of SQLiteListeningRepository
+func NewSQLiteListeningRepository(db *sql.DB) *SQLiteListeningRepository {
+	return &SQLiteListeningRepository{db: db}
+}
+
+const (
+	listeningExerciseColumns = `id, language, source, introduction, transcript, audio_asset_id, audio_url, created_at`
+	listeningQuestionColumns = `id, exercise_id, position, question, options, answer`
+)
+
+// Create stores an exercise with its segments and questions in one transaction
+func (r *SQLiteListeningRepository) Create(ctx context.Context, exercise *models.ListeningExercise) error {
+	if err := exercise.Validate(); err != nil {
+		return err
+	}
+
+	var source sql.NullString
+	if exercise.Source != "" {
+		source = sql.NullString{String: exercise.Source, Valid: true}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO listening_exercises (language, source, introduction, transcript, audio_asset_id, audio_url, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		exercise.Language,
+		source,
+		exercise.Introduction,
+		exercise.Transcript,
+		exercise.AudioAssetID,
+		externalAudioURL(exercise),
+		exercise.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create listening exercise: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	for i := range exercise.Segments {
+		segment := &exercise.Segments[i]
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO listening_segments (exercise_id, position, text, start_ms, end_ms)
+			VALUES (?, ?, ?, ?, ?)`,
+			id, segment.Position, segment.Text, segment.StartMS, segment.EndMS,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create listening segment: %w", err)
+		}
+		if segment.ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("failed to get last insert ID: %w", err)
+		}
+		segment.ExerciseID = id
+	}
+
+	for i := range exercise.Questions {
+		question := &exercise.Questions[i]
+		options, err := json.Marshal(question.Options)
+		if err != nil {
+			return fmt.Errorf("failed to encode options: %w", err)
+		}
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO listening_questions (exercise_id, position, question, options, answer)
+			VALUES (?, ?, ?, ?, ?)`,
+			id, question.Position, question.Question, string(options), question.Answer,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create listening question: %w", err)
+		}
+		if question.ID, err = result.LastInsertId(); err != nil {
+			return fmt.Errorf("failed to get last insert ID: %w", err)
+		}
+		question.ExerciseID = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit listening exercise: %w", err)
+	}
+
+	exercise.ID = id
+	if exercise.AudioAssetID != nil {
+		exercise.AudioURL = models.AssetURL(*exercise.AudioAssetID)
+	}
+	return nil
+}
+
+// GetByID retrieves an exercise with its segments and questions
+func (r *SQLiteListeningRepository) GetByID(ctx context.Context, id int64) (*models.ListeningExercise, error) {
+	query := `SELECT ` + listeningExerciseColumns + ` FROM listening_exercises WHERE id = ?`
+
+	exercise, err := scanListeningExercise(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("listening exercise with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve listening exercise: %w", err)
+	}
+
+	if exercise.Segments, err = r.listSegments(ctx, id); err != nil {
+		return nil, err
+	}
+	if exercise.Questions, err = r.listQuestions(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return exercise, nil
+}
+
+// GetBySource retrieves an exercise by the source it was imported from
+func (r *SQLiteListeningRepository) GetBySource(ctx context.Context, source string) (*models.ListeningExercise, error) {
+	query := `SELECT ` + listeningExerciseColumns + ` FROM listening_exercises WHERE source = ?`
+
+	exercise, err := scanListeningExercise(r.db.QueryRowContext(ctx, query, source))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("listening exercise from %q: %w", source, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve listening exercise: %w", err)
+	}
+
+	return exercise, nil
+}
+
+// List retrieves the exercises, newest first, of one language when language is set
+func (r *SQLiteListeningRepository) List(ctx context.Context, language string) ([]models.ListeningExercise, error) {
+	query := `SELECT ` + listeningExerciseColumns + ` FROM listening_exercises`
+	var args []interface{}
+	if language != "" {
+		query += ` WHERE language = ?`
+		args = append(args, language)
+	}
+	query += ` ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list listening exercises: %w", err)
+	}
+	defer rows.Close()
+
+	exercises := []models.ListeningExercise{}
+	for rows.Next() {
+		exercise, err := scanListeningExercise(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan listening exercise: %w", err)
+		}
+		exercises = append(exercises, *exercise)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating listening exercises: %w", err)
+	}
+
+	return exercises, nil
+}
+
+// UpdateAudio replaces the recording of an exercise with an uploaded asset or
+// an external URL
+func (r *SQLiteListeningRepository) UpdateAudio(ctx context.Context, id int64, assetID *int64, url string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE listening_exercises SET audio_asset_id = ?, audio_url = ? WHERE id = ?`,
+		assetID, url, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update listening exercise: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("listening exercise with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete removes an exercise with its segments and questions in one transaction
+func (r *SQLiteListeningRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, query := range []string{
+		`DELETE FROM listening_segments WHERE exercise_id = ?`,
+		`DELETE FROM listening_questions WHERE exercise_id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return fmt.Errorf("failed to delete listening exercise: %w", err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM listening_exercises WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete listening exercise: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("listening exercise with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetQuestion retrieves a question by its ID
+func (r *SQLiteListeningRepository) GetQuestion(ctx context.Context, id int64) (*models.ListeningQuestion, error) {
+	query := `SELECT ` + listeningQuestionColumns + ` FROM listening_questions WHERE id = ?`
+
+	question, err := scanListeningQuestion(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("listening question with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve listening question: %w", err)
+	}
+
+	return question, nil
+}
+
+// GetRandomQuestion retrieves a random question of any exercise
+func (r *SQLiteListeningRepository) GetRandomQuestion(ctx context.Context) (*models.ListeningQuestion, error) {
+	query := `SELECT ` + listeningQuestionColumns + ` FROM listening_questions ORDER BY RANDOM() LIMIT 1`
+
+	question, err := scanListeningQuestion(r.db.QueryRowContext(ctx, query))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("listening question: %w", models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve random listening question: %w", err)
+	}
+
+	return question, nil
+}
+
+// listSegments retrieves the segments of an exercise in order
+func (r *SQLiteListeningRepository) listSegments(ctx context.Context, exerciseID int64) ([]models.ListeningSegment, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, exercise_id, position, text, start_ms, end_ms
+		FROM listening_segments WHERE exercise_id = ? ORDER BY position`,
+		exerciseID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list listening segments: %w", err)
+	}
+	defer rows.Close()
+
+	segments := []models.ListeningSegment{}
+	for rows.Next() {
+		var segment models.ListeningSegment
+		var start, end sql.NullInt64
+		err := rows.Scan(&segment.ID, &segment.ExerciseID, &segment.Position, &segment.Text, &start, &end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan listening segment: %w", err)
+		}
+		segment.StartMS = nullableID(start)
+		segment.EndMS = nullableID(end)
+		segments = append(segments, segment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating listening segments: %w", err)
+	}
+
+	return segments, nil
+}
+
+// listQuestions retrieves the questions of an exercise in order
+func (r *SQLiteListeningRepository) listQuestions(ctx context.Context, exerciseID int64) ([]models.ListeningQuestion, error) {
+	query := `SELECT ` + listeningQuestionColumns + ` FROM listening_questions WHERE exercise_id = ? ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, exerciseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list listening questions: %w", err)
+	}
+	defer rows.Close()
+
+	questions := []models.ListeningQuestion{}
+	for rows.Next() {
+		question, err := scanListeningQuestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan listening question: %w", err)
+		}
+		questions = append(questions, *question)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating listening questions: %w", err)
+	}
+
+	return questions, nil
+}
+
+// externalAudioURL returns the URL stored for an exercise, which only holds
+// recordings hosted elsewhere since uploaded assets have their own URL
+func externalAudioURL(exercise *models.ListeningExercise) string {
+	if exercise.AudioAssetID != nil {
+		return ""
+	}
+	return exercise.AudioURL
+}
+
+// scanListeningExercise reads an exercise selected with listeningExerciseColumns
+func scanListeningExercise(row rowScanner) (*models.ListeningExercise, error) {
+	exercise := &models.ListeningExercise{}
+	var source sql.NullString
+	var audioAssetID sql.NullInt64
+	err := row.Scan(
+		&exercise.ID,
+		&exercise.Language,
+		&source,
+		&exercise.Introduction,
+		&exercise.Transcript,
+		&audioAssetID,
+		&exercise.AudioURL,
+		&exercise.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	exercise.Source = source.String
+	exercise.AudioAssetID = nullableID(audioAssetID)
+	if exercise.AudioAssetID != nil {
+		exercise.AudioURL = models.AssetURL(*exercise.AudioAssetID)
+	}
+	return exercise, nil
+}
+
+// scanListeningQuestion reads a question selected with listeningQuestionColumns
+func scanListeningQuestion(row rowScanner) (*models.ListeningQuestion, error) {
+	question := &models.ListeningQuestion{}
+	var options string
+	err := row.Scan(
+		&question.ID,
+		&question.ExerciseID,
+		&question.Position,
+		&question.Question,
+		&options,
+		&question.Answer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(options), &question.Options); err != nil {
+		return nil, fmt.Errorf("invalid options of listening question %d: %w", question.ID, err)
+	}
+	return question, nil
+}
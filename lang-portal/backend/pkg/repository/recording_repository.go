@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// RecordingRepository defines the interface for the recordings of spoken answers
+type RecordingRepository interface {
+	// Create adds the metadata of a stored recording
+	Create(ctx context.Context, recording *models.Recording) error
+
+	// GetByID retrieves a recording of a user
+	GetByID(ctx context.Context, userID, id int64) (*models.Recording, error)
+
+	// List retrieves the recordings of a user, newest first, restricted to
+	// one session when sessionID is not zero
+	List(ctx context.Context, userID, sessionID int64) ([]models.Recording, error)
+
+	// Delete removes a recording of a user
+	Delete(ctx context.Context, userID, id int64) error
+}
+
+// SQLiteRecordingRepository implements RecordingRepository for SQLite
+type SQLiteRecordingRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRecordingRepository creates a new instance of SQLiteRecordingRepository
+func NewSQLiteRecordingRepository(db *sql.DB) *SQLiteRecordingRepository {
+	return &SQLiteRecordingRepository{db: db}
+}
+
+const recordingColumns = `id, user_id, session_id, session_activity_id, word_id, mime_type, size, duration_ms, storage_key, transcript, model, created_at`
+
+// Create inserts the metadata of a stored recording
+func (r *SQLiteRecordingRepository) Create(ctx context.Context, recording *models.Recording) error {
+	if err := recording.Validate(); err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO recordings
+			(user_id, session_id, session_activity_id, word_id, mime_type, size, duration_ms, storage_key, transcript, model, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		recording.UserID,
+		recording.SessionID,
+		recording.SessionActivityID,
+		recording.WordID,
+		recording.MimeType,
+		recording.Size,
+		recording.DurationMS,
+		recording.StorageKey,
+		recording.Transcript,
+		recording.Model,
+		recording.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recording: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	recording.ID = id
+	recording.URL = models.RecordingURL(id)
+	return nil
+}
+
+// GetByID retrieves a recording of a user
+func (r *SQLiteRecordingRepository) GetByID(ctx context.Context, userID, id int64) (*models.Recording, error) {
+	query := `SELECT ` + recordingColumns + ` FROM recordings WHERE id = ? AND user_id = ?`
+
+	recording, err := scanRecording(r.db.QueryRowContext(ctx, query, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("recording with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve recording: %w", err)
+	}
+
+	return recording, nil
+}
+
+// List retrieves the recordings of a user, newest first
+func (r *SQLiteRecordingRepository) List(ctx context.Context, userID, sessionID int64) ([]models.Recording, error) {
+	query := `SELECT ` + recordingColumns + ` FROM recordings WHERE user_id = ?`
+	args := []interface{}{userID}
+	if sessionID != 0 {
+		query += ` AND session_id = ?`
+		args = append(args, sessionID)
+	}
+	query += ` ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recordings: %w", err)
+	}
+	defer rows.Close()
+
+	recordings := []models.Recording{}
+	for rows.Next() {
+		recording, err := scanRecording(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recording: %w", err)
+		}
+		recordings = append(recordings, *recording)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recordings: %w", err)
+	}
+
+	return recordings, nil
+}
+
+// Delete removes a recording of a user
+func (r *SQLiteRecordingRepository) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM recordings WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete recording: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("recording with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// scanRecording reads a recording selected with recordingColumns
+func scanRecording(row rowScanner) (*models.Recording, error) {
+	recording := &models.Recording{}
+	var wordID, duration sql.NullInt64
+	err := row.Scan(
+		&recording.ID,
+		&recording.UserID,
+		&recording.SessionID,
+		&recording.SessionActivityID,
+		&wordID,
+		&recording.MimeType,
+		&recording.Size,
+		&duration,
+		&recording.StorageKey,
+		&recording.Transcript,
+		&recording.Model,
+		&recording.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	recording.WordID = nullableID(wordID)
+	if duration.Valid {
+		recording.DurationMS = &duration.Int64
+	}
+	recording.URL = models.RecordingURL(recording.ID)
+	return recording, nil
+}
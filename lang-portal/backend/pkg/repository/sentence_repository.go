@@ -0,0 +1,385 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// SentenceRepository defines the interface for example sentence database operations
+type SentenceRepository interface {
+	// Create adds a sentence and links it to its words
+	Create(ctx context.Context, sentence *models.Sentence) error
+
+	// GetByID retrieves a sentence by its ID
+	GetByID(ctx context.Context, id int64) (*models.Sentence, error)
+
+	// Update modifies a sentence and replaces its word links
+	Update(ctx context.Context, sentence *models.Sentence) error
+
+	// Delete removes a sentence by its ID
+	Delete(ctx context.Context, id int64) error
+
+	// List retrieves sentences with pagination and optional filtering
+	List(ctx context.Context, params ListSentencesParams) ([]models.Sentence, int, error)
+
+	// ListByWordID retrieves the sentences linked to a word
+	ListByWordID(ctx context.Context, wordID int64) ([]models.Sentence, error)
+
+	// GetRandomForWords retrieves a random sentence linked to any of the words,
+	// or to any word at all when wordIDs is empty
+	GetRandomForWords(ctx context.Context, wordIDs []int64) (*models.Sentence, error)
+}
+
+// ListSentencesParams defines parameters for listing sentences
+type ListSentencesParams struct {
+	Page     int
+	PageSize int
+	WordID   int64  // only sentences linked to this word when set
+	Language string // language code of the sentence, lists all when empty
+}
+
+// SQLiteSentenceRepository implements SentenceRepository for SQLite
+type SQLiteSentenceRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteSentenceRepository creates a new instance of SQLiteSentenceRepository
+func NewSQLiteSentenceRepository(db *sql.DB) *SQLiteSentenceRepository {
+	return &SQLiteSentenceRepository{db: db}
+}
+
+const sentenceColumns = `s.id, s.language, s.native_language, s.target, s.romanized, s.native, s.alternatives, s.created_at`
+
+// Create inserts a sentence and its word links in one transaction
+func (r *SQLiteSentenceRepository) Create(ctx context.Context, sentence *models.Sentence) error {
+	if err := sentence.Validate(); err != nil {
+		return err
+	}
+
+	alternatives, err := json.Marshal(sentence.Alternatives)
+	if err != nil {
+		return fmt.Errorf("failed to encode alternatives: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO sentences (language, native_language, target, romanized, native, alternatives, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		sentence.Language,
+		sentence.NativeLanguage,
+		sentence.Target,
+		sentence.Romanized,
+		sentence.Native,
+		string(alternatives),
+		sentence.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create sentence: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	if err := linkWords(ctx, tx, id, sentence.WordIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit sentence: %w", err)
+	}
+
+	sentence.ID = id
+	return nil
+}
+
+// GetByID retrieves a sentence by its ID
+func (r *SQLiteSentenceRepository) GetByID(ctx context.Context, id int64) (*models.Sentence, error) {
+	query := `SELECT ` + sentenceColumns + ` FROM sentences s WHERE s.id = ?`
+
+	sentence, err := scanSentence(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("sentence with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sentence: %w", err)
+	}
+
+	sentences := []models.Sentence{*sentence}
+	if err := r.attachWordIDs(ctx, sentences); err != nil {
+		return nil, err
+	}
+
+	return &sentences[0], nil
+}
+
+// Update modifies a sentence and replaces its word links in one transaction
+func (r *SQLiteSentenceRepository) Update(ctx context.Context, sentence *models.Sentence) error {
+	if err := sentence.Validate(); err != nil {
+		return err
+	}
+
+	alternatives, err := json.Marshal(sentence.Alternatives)
+	if err != nil {
+		return fmt.Errorf("failed to encode alternatives: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE sentences
+		SET language = ?, native_language = ?, target = ?, romanized = ?, native = ?, alternatives = ?
+		WHERE id = ?`,
+		sentence.Language,
+		sentence.NativeLanguage,
+		sentence.Target,
+		sentence.Romanized,
+		sentence.Native,
+		string(alternatives),
+		sentence.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update sentence: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("sentence with ID %d: %w", sentence.ID, models.ErrNotFound)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM word_sentences WHERE sentence_id = ?`, sentence.ID); err != nil {
+		return fmt.Errorf("failed to unlink sentence words: %w", err)
+	}
+	if err := linkWords(ctx, tx, sentence.ID, sentence.WordIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit sentence: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a sentence and its word links
+func (r *SQLiteSentenceRepository) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM word_sentences WHERE sentence_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to unlink sentence words: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM sentences WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete sentence: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("sentence with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return tx.Commit()
+}
+
+// List retrieves sentences with pagination and optional filtering
+func (r *SQLiteSentenceRepository) List(ctx context.Context, params ListSentencesParams) ([]models.Sentence, int, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+
+	baseQuery := `FROM sentences s WHERE 1=1`
+	args := []interface{}{}
+
+	if params.WordID > 0 {
+		baseQuery += ` AND s.id IN (SELECT sentence_id FROM word_sentences WHERE word_id = ?)`
+		args = append(args, params.WordID)
+	}
+	if params.Language != "" {
+		baseQuery += ` AND s.language = ?`
+		args = append(args, params.Language)
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) `+baseQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count sentences: %w", err)
+	}
+
+	query := `SELECT ` + sentenceColumns + ` ` + baseQuery + ` ORDER BY s.id LIMIT ? OFFSET ?`
+	args = append(args, params.PageSize, (params.Page-1)*params.PageSize)
+
+	sentences, err := r.query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return sentences, total, nil
+}
+
+// ListByWordID retrieves the sentences linked to a word
+func (r *SQLiteSentenceRepository) ListByWordID(ctx context.Context, wordID int64) ([]models.Sentence, error) {
+	query := `
+		SELECT ` + sentenceColumns + `
+		FROM sentences s
+		INNER JOIN word_sentences ws ON s.id = ws.sentence_id
+		WHERE ws.word_id = ?
+		ORDER BY s.id`
+
+	return r.query(ctx, query, wordID)
+}
+
+// GetRandomForWords retrieves a random sentence linked to any of the words
+func (r *SQLiteSentenceRepository) GetRandomForWords(ctx context.Context, wordIDs []int64) (*models.Sentence, error) {
+	query := `SELECT ` + sentenceColumns + ` FROM sentences s
+		WHERE s.id IN (SELECT sentence_id FROM word_sentences`
+	args := make([]interface{}, len(wordIDs))
+	if len(wordIDs) > 0 {
+		query += ` WHERE word_id IN (?` + strings.Repeat(", ?", len(wordIDs)-1) + `)`
+		for i, id := range wordIDs {
+			args[i] = id
+		}
+	}
+	query += `) ORDER BY RANDOM() LIMIT 1`
+
+	sentence, err := scanSentence(r.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("sentence linked to words: %w", models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve random sentence: %w", err)
+	}
+
+	sentences := []models.Sentence{*sentence}
+	if err := r.attachWordIDs(ctx, sentences); err != nil {
+		return nil, err
+	}
+
+	return &sentences[0], nil
+}
+
+// query runs a sentence query and attaches the word IDs of the results
+func (r *SQLiteSentenceRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.Sentence, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sentences: %w", err)
+	}
+	defer rows.Close()
+
+	sentences := []models.Sentence{}
+	for rows.Next() {
+		sentence, err := scanSentence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sentence: %w", err)
+		}
+		sentences = append(sentences, *sentence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sentences: %w", err)
+	}
+
+	if err := r.attachWordIDs(ctx, sentences); err != nil {
+		return nil, err
+	}
+
+	return sentences, nil
+}
+
+// attachWordIDs fills in the linked word IDs of the sentences with one query
+func (r *SQLiteSentenceRepository) attachWordIDs(ctx context.Context, sentences []models.Sentence) error {
+	if len(sentences) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]*models.Sentence, len(sentences))
+	args := make([]interface{}, len(sentences))
+	for i := range sentences {
+		sentences[i].WordIDs = []int64{}
+		index[sentences[i].ID] = &sentences[i]
+		args[i] = sentences[i].ID
+	}
+
+	query := `SELECT sentence_id, word_id FROM word_sentences
+		WHERE sentence_id IN (?` + strings.Repeat(", ?", len(sentences)-1) + `)
+		ORDER BY sentence_id, word_id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to list sentence words: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sentenceID, wordID int64
+		if err := rows.Scan(&sentenceID, &wordID); err != nil {
+			return fmt.Errorf("failed to scan sentence word: %w", err)
+		}
+		index[sentenceID].WordIDs = append(index[sentenceID].WordIDs, wordID)
+	}
+
+	return rows.Err()
+}
+
+// linkWords links a sentence to each of the words
+func linkWords(ctx context.Context, tx *sql.Tx, sentenceID int64, wordIDs []int64) error {
+	for _, wordID := range wordIDs {
+		_, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO word_sentences (word_id, sentence_id) VALUES (?, ?)`,
+			wordID, sentenceID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to link word %d to sentence: %w", wordID, err)
+		}
+	}
+	return nil
+}
+
+// scanSentence reads a sentence selected with sentenceColumns
+func scanSentence(row rowScanner) (*models.Sentence, error) {
+	sentence := &models.Sentence{}
+	var alternatives string
+	err := row.Scan(
+		&sentence.ID,
+		&sentence.Language,
+		&sentence.NativeLanguage,
+		&sentence.Target,
+		&sentence.Romanized,
+		&sentence.Native,
+		&alternatives,
+		&sentence.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(alternatives), &sentence.Alternatives); err != nil {
+		return nil, fmt.Errorf("invalid alternatives of sentence %d: %w", sentence.ID, err)
+	}
+	return sentence, nil
+}
@@ -22,11 +22,12 @@ func NewSessionRepository(db *sql.DB) *SessionRepository {
 // Create starts a new session
 func (r *SessionRepository) Create(ctx context.Context, session *models.Session) error {
 	query := `
-		INSERT INTO sessions (activity_id, group_id, start_time, score, created_at) 
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO sessions (user_id, activity_id, group_id, start_time, score, created_at) 
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
+		session.UserID,
 		session.ActivityID,
 		session.GroupID,
 		session.StartTime,
@@ -46,19 +47,20 @@ func (r *SessionRepository) Create(ctx context.Context, session *models.Session)
 	return nil
 }
 
-// GetByID retrieves a session by its ID
-func (r *SessionRepository) GetByID(ctx context.Context, id int64) (*models.Session, error) {
+// GetByID retrieves a session of the given user by its ID
+func (r *SessionRepository) GetByID(ctx context.Context, userID, id int64) (*models.Session, error) {
 	log.Printf("Retrieving session with ID: %d", id)
 
 	query := `
-		SELECT id, activity_id, group_id, start_time, end_time, score, created_at 
+		SELECT id, user_id, activity_id, group_id, start_time, end_time, score, created_at 
 		FROM sessions 
-		WHERE id = ?
+		WHERE id = ? AND user_id = ?
 	`
 
 	var session models.Session
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
 		&session.ID,
+		&session.UserID,
 		&session.ActivityID,
 		&session.GroupID,
 		&session.StartTime,
@@ -68,7 +70,7 @@ func (r *SessionRepository) GetByID(ctx context.Context, id int64) (*models.Sess
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("no session available with provided session id: %d", id)
+		return nil, fmt.Errorf("no session available with provided session id: %d: %w", id, models.ErrNotFound)
 	}
 
 	if err != nil {
@@ -79,12 +81,12 @@ func (r *SessionRepository) GetByID(ctx context.Context, id int64) (*models.Sess
 	return &session, nil
 }
 
-// GetByIDWithActivities retrieves a session with its associated activities
-func (r *SessionRepository) GetByIDWithActivities(ctx context.Context, id int64) (*models.SessionWithActivities, error) {
+// GetByIDWithActivities retrieves a session of the given user with its associated activities
+func (r *SessionRepository) GetByIDWithActivities(ctx context.Context, userID, id int64) (*models.SessionWithActivities, error) {
 	log.Printf("Retrieving session with activities for ID: %d", id)
 
 	// First, retrieve the session
-	session, err := r.GetByID(ctx, id)
+	session, err := r.GetByID(ctx, userID, id)
 	if err != nil {
 		log.Printf("Failed to retrieve session: %v", err)
 		return nil, err
@@ -148,7 +150,7 @@ func (r *SessionRepository) Update(ctx context.Context, session *models.Session)
 	query := `
 		UPDATE sessions 
 		SET activity_id = ?, group_id = ?, end_time = ?, score = ? 
-		WHERE id = ?
+		WHERE id = ? AND user_id = ?
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -157,6 +159,7 @@ func (r *SessionRepository) Update(ctx context.Context, session *models.Session)
 		session.EndTime,
 		session.Score,
 		session.ID,
+		session.UserID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update session: %w", err)
@@ -165,11 +168,17 @@ func (r *SessionRepository) Update(ctx context.Context, session *models.Session)
 	return nil
 }
 
-// DeleteAllSessionActivities removes all session activities from the database
-func (r *SessionRepository) DeleteAllSessionActivities(ctx context.Context) (int64, error) {
+// DeleteAllSessionActivities removes the session activities of the given user's
+// sessions, or of every session when userID is nil
+func (r *SessionRepository) DeleteAllSessionActivities(ctx context.Context, userID *int64) (int64, error) {
 	query := `DELETE FROM session_activities`
+	args := []interface{}{}
+	if userID != nil {
+		query += ` WHERE session_id IN (SELECT id FROM sessions WHERE user_id = ?)`
+		args = append(args, *userID)
+	}
 
-	result, err := r.db.ExecContext(ctx, query)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete all session activities: %w", err)
 	}
@@ -182,11 +191,16 @@ func (r *SessionRepository) DeleteAllSessionActivities(ctx context.Context) (int
 	return rowsAffected, nil
 }
 
-// DeleteAll removes all sessions from the database
-func (r *SessionRepository) DeleteAll(ctx context.Context) (int64, error) {
+// DeleteAll removes all sessions of the given user, or every session when userID is nil
+func (r *SessionRepository) DeleteAll(ctx context.Context, userID *int64) (int64, error) {
 	query := `DELETE FROM sessions`
+	args := []interface{}{}
+	if userID != nil {
+		query += ` WHERE user_id = ?`
+		args = append(args, *userID)
+	}
 
-	result, err := r.db.ExecContext(ctx, query)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete all sessions: %w", err)
 	}
@@ -199,16 +213,43 @@ func (r *SessionRepository) DeleteAll(ctx context.Context) (int64, error) {
 	return rowsAffected, nil
 }
 
-// List retrieves sessions with optional pagination
-func (r *SessionRepository) List(ctx context.Context, limit, offset int) ([]models.Session, error) {
+// CountUnowned counts the sessions recorded before accounts existed, which
+// belong to no user
+func (r *SessionRepository) CountUnowned(ctx context.Context) (int64, error) {
+	var count int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sessions WHERE user_id IS NULL`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unowned sessions: %w", err)
+	}
+	return count, nil
+}
+
+// AssignUnowned gives the sessions that belong to no user to the given
+// user, returning how many were assigned
+func (r *SessionRepository) AssignUnowned(ctx context.Context, userID int64) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE sessions SET user_id = ? WHERE user_id IS NULL`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to assign unowned sessions: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// List retrieves the given user's sessions with optional pagination
+func (r *SessionRepository) List(ctx context.Context, userID int64, limit, offset int) ([]models.Session, error) {
 	query := `
-		SELECT id, activity_id, group_id, start_time, end_time, score, created_at 
+		SELECT id, user_id, activity_id, group_id, start_time, end_time, score, created_at 
 		FROM sessions 
+		WHERE user_id = ?
 		ORDER BY created_at DESC 
 		LIMIT ? OFFSET ?
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sessions: %w", err)
 	}
@@ -222,6 +263,7 @@ func (r *SessionRepository) List(ctx context.Context, limit, offset int) ([]mode
 
 		err := rows.Scan(
 			&session.ID,
+			&session.UserID,
 			&session.ActivityID,
 			&groupID,
 			&session.StartTime,
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// SpeechCacheRepository remembers the asset generated for each text-to-speech
+// input, so the same text is never synthesized twice
+type SpeechCacheRepository interface {
+	// Get retrieves the ID of the asset cached under the key
+	Get(ctx context.Context, key string) (int64, error)
+
+	// Put caches an asset under the key, replacing any previous one
+	Put(ctx context.Context, key string, assetID int64) error
+}
+
+// SQLiteSpeechCacheRepository implements SpeechCacheRepository for SQLite
+type SQLiteSpeechCacheRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteSpeechCacheRepository creates a new instance of SQLiteSpeechCacheRepository
+func NewSQLiteSpeechCacheRepository(db *sql.DB) *SQLiteSpeechCacheRepository {
+	return &SQLiteSpeechCacheRepository{db: db}
+}
+
+// Get retrieves the ID of the asset cached under the key
+func (r *SQLiteSpeechCacheRepository) Get(ctx context.Context, key string) (int64, error) {
+	var assetID int64
+	err := r.db.QueryRowContext(ctx, `SELECT asset_id FROM speech_cache WHERE cache_key = ?`, key).Scan(&assetID)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("speech cache entry %s: %w", key, models.ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read speech cache: %w", err)
+	}
+
+	return assetID, nil
+}
+
+// Put caches an asset under the key, replacing any previous one
+func (r *SQLiteSpeechCacheRepository) Put(ctx context.Context, key string, assetID int64) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO speech_cache (cache_key, asset_id) VALUES (?, ?)
+		ON CONFLICT (cache_key) DO UPDATE SET asset_id = excluded.asset_id`,
+		key, assetID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write speech cache: %w", err)
+	}
+
+	return nil
+}
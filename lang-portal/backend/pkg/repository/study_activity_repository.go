@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pavittarx/lang-portal/backend/pkg/models"
 )
@@ -18,10 +20,38 @@ func NewStudyActivityRepository(db *sql.DB) *StudyActivityRepository {
 	return &StudyActivityRepository{db: db}
 }
 
+const studyActivityColumns = `id, name, description, image, score, type, config, enabled, created_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanStudyActivity(row rowScanner, activity *models.StudyActivity) error {
+	var config string
+	err := row.Scan(
+		&activity.ID,
+		&activity.Name,
+		&activity.Description,
+		&activity.Image,
+		&activity.Score,
+		&activity.Type,
+		&config,
+		&activity.Enabled,
+		&activity.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	activity.Config = json.RawMessage(config)
+	return nil
+}
+
 // GetAll retrieves all study activities
 func (r *StudyActivityRepository) GetAll(ctx context.Context) ([]models.StudyActivity, error) {
 	query := `
-		SELECT id, name, description, image, score, created_at 
+		SELECT ` + studyActivityColumns + `
 		FROM study_activities 
 		ORDER BY created_at DESC
 	`
@@ -35,15 +65,7 @@ func (r *StudyActivityRepository) GetAll(ctx context.Context) ([]models.StudyAct
 	var activities []models.StudyActivity
 	for rows.Next() {
 		var activity models.StudyActivity
-		err := rows.Scan(
-			&activity.ID,
-			&activity.Name,
-			&activity.Description,
-			&activity.Image,
-			&activity.Score,
-			&activity.CreatedAt,
-		)
-		if err != nil {
+		if err := scanStudyActivity(rows, &activity); err != nil {
 			return nil, fmt.Errorf("failed to scan study activity: %w", err)
 		}
 		activities = append(activities, activity)
@@ -55,3 +77,107 @@ func (r *StudyActivityRepository) GetAll(ctx context.Context) ([]models.StudyAct
 
 	return activities, nil
 }
+
+// GetByID retrieves a study activity by its ID
+func (r *StudyActivityRepository) GetByID(ctx context.Context, id int64) (*models.StudyActivity, error) {
+	query := `SELECT ` + studyActivityColumns + ` FROM study_activities WHERE id = ?`
+
+	activity := &models.StudyActivity{}
+	if err := scanStudyActivity(r.db.QueryRowContext(ctx, query, id), activity); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("study activity with ID %d: %w", id, models.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to retrieve study activity: %w", err)
+	}
+
+	return activity, nil
+}
+
+// Create adds a new study activity to the database
+func (r *StudyActivityRepository) Create(ctx context.Context, activity *models.StudyActivity) error {
+	query := `
+		INSERT INTO study_activities (name, description, image, score, type, config, enabled, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	if activity.CreatedAt.IsZero() {
+		activity.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		activity.Name,
+		activity.Description,
+		activity.Image,
+		activity.Score,
+		activity.Type,
+		string(activity.Config),
+		activity.Enabled,
+		activity.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create study activity: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	activity.ID = id
+	return nil
+}
+
+// Update modifies an existing study activity
+func (r *StudyActivityRepository) Update(ctx context.Context, activity *models.StudyActivity) error {
+	query := `
+		UPDATE study_activities
+		SET name = ?, description = ?, image = ?, score = ?, type = ?, config = ?, enabled = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		activity.Name,
+		activity.Description,
+		activity.Image,
+		activity.Score,
+		activity.Type,
+		string(activity.Config),
+		activity.Enabled,
+		activity.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update study activity: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("study activity with ID %d: %w", activity.ID, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// Delete removes a study activity along with its sessions
+func (r *StudyActivityRepository) Delete(ctx context.Context, id int64) error {
+	query := `DELETE FROM study_activities WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete study activity: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("study activity with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
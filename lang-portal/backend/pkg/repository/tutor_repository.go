@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// TutorRepository defines the interface for tutor conversations and their messages
+type TutorRepository interface {
+	// CreateConversation stores a new conversation
+	CreateConversation(ctx context.Context, conversation *models.TutorConversation) error
+
+	// GetConversation retrieves a conversation of a user, without its messages
+	GetConversation(ctx context.Context, userID, id int64) (*models.TutorConversation, error)
+
+	// ListConversations retrieves the conversations of a user, newest first,
+	// restricted to one session when sessionID is not zero
+	ListConversations(ctx context.Context, userID, sessionID int64) ([]models.TutorConversation, error)
+
+	// MarkSolved records that the learner translated the sentence
+	MarkSolved(ctx context.Context, id int64) error
+
+	// AddMessage appends a message to a conversation
+	AddMessage(ctx context.Context, message *models.TutorMessage) error
+
+	// ListMessages retrieves the messages of a conversation, oldest first
+	ListMessages(ctx context.Context, conversationID int64) ([]models.TutorMessage, error)
+}
+
+// SQLiteTutorRepository implements TutorRepository for SQLite
+type SQLiteTutorRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTutorRepository creates a new instance of SQLiteTutorRepository
+func NewSQLiteTutorRepository(db *sql.DB) *SQLiteTutorRepository {
+	return &SQLiteTutorRepository{db: db}
+}
+
+const tutorConversationColumns = `id, user_id, session_id, sentence_id, language, native_language, prompt, answer, vocabulary, structure, solved, model, created_at, updated_at`
+
+// CreateConversation stores a new conversation
+func (r *SQLiteTutorRepository) CreateConversation(ctx context.Context, conversation *models.TutorConversation) error {
+	vocabulary, err := json.Marshal(conversation.Vocabulary)
+	if err != nil {
+		return fmt.Errorf("failed to encode vocabulary: %w", err)
+	}
+
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO tutor_conversations
+			(user_id, session_id, sentence_id, language, native_language, prompt, answer, vocabulary, structure, solved, model, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		conversation.UserID,
+		conversation.SessionID,
+		conversation.SentenceID,
+		conversation.Language,
+		conversation.NativeLanguage,
+		conversation.Prompt,
+		conversation.Answer,
+		string(vocabulary),
+		conversation.Structure,
+		conversation.Solved,
+		conversation.Model,
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create tutor conversation: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	conversation.ID = id
+	conversation.CreatedAt = now
+	conversation.UpdatedAt = now
+	return nil
+}
+
+// GetConversation retrieves a conversation of a user, without its messages
+func (r *SQLiteTutorRepository) GetConversation(ctx context.Context, userID, id int64) (*models.TutorConversation, error) {
+	query := `SELECT ` + tutorConversationColumns + ` FROM tutor_conversations WHERE id = ? AND user_id = ?`
+
+	conversation, err := scanTutorConversation(r.db.QueryRowContext(ctx, query, id, userID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("tutor conversation with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tutor conversation: %w", err)
+	}
+
+	return conversation, nil
+}
+
+// ListConversations retrieves the conversations of a user, newest first
+func (r *SQLiteTutorRepository) ListConversations(ctx context.Context, userID, sessionID int64) ([]models.TutorConversation, error) {
+	query := `SELECT ` + tutorConversationColumns + ` FROM tutor_conversations WHERE user_id = ?`
+	args := []interface{}{userID}
+	if sessionID != 0 {
+		query += ` AND session_id = ?`
+		args = append(args, sessionID)
+	}
+	query += ` ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tutor conversations: %w", err)
+	}
+	defer rows.Close()
+
+	conversations := []models.TutorConversation{}
+	for rows.Next() {
+		conversation, err := scanTutorConversation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tutor conversation: %w", err)
+		}
+		conversations = append(conversations, *conversation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tutor conversations: %w", err)
+	}
+
+	return conversations, nil
+}
+
+// MarkSolved records that the learner translated the sentence
+func (r *SQLiteTutorRepository) MarkSolved(ctx context.Context, id int64) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE tutor_conversations SET solved = 1, updated_at = ? WHERE id = ?`,
+		time.Now(), id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update tutor conversation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("tutor conversation with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// AddMessage appends a message to a conversation and marks the conversation updated
+func (r *SQLiteTutorRepository) AddMessage(ctx context.Context, message *models.TutorMessage) error {
+	if message.Clues == nil {
+		message.Clues = []string{}
+	}
+	clues, err := json.Marshal(message.Clues)
+	if err != nil {
+		return fmt.Errorf("failed to encode clues: %w", err)
+	}
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO tutor_messages (conversation_id, role, content, clues, session_activity_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		message.ConversationID,
+		message.Role,
+		message.Content,
+		string(clues),
+		message.SessionActivityID,
+		message.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add tutor message: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE tutor_conversations SET updated_at = ? WHERE id = ?`,
+		message.CreatedAt, message.ConversationID,
+	); err != nil {
+		return fmt.Errorf("failed to update tutor conversation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	message.ID = id
+	return nil
+}
+
+// ListMessages retrieves the messages of a conversation, oldest first
+func (r *SQLiteTutorRepository) ListMessages(ctx context.Context, conversationID int64) ([]models.TutorMessage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, conversation_id, role, content, clues, session_activity_id, created_at
+		FROM tutor_messages WHERE conversation_id = ? ORDER BY id`,
+		conversationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tutor messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []models.TutorMessage{}
+	for rows.Next() {
+		var message models.TutorMessage
+		var clues string
+		var sessionActivityID sql.NullInt64
+		err := rows.Scan(
+			&message.ID,
+			&message.ConversationID,
+			&message.Role,
+			&message.Content,
+			&clues,
+			&sessionActivityID,
+			&message.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tutor message: %w", err)
+		}
+		if err := json.Unmarshal([]byte(clues), &message.Clues); err != nil {
+			return nil, fmt.Errorf("invalid clues of tutor message %d: %w", message.ID, err)
+		}
+		message.SessionActivityID = nullableID(sessionActivityID)
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tutor messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// scanTutorConversation reads a conversation selected with tutorConversationColumns
+func scanTutorConversation(row rowScanner) (*models.TutorConversation, error) {
+	conversation := &models.TutorConversation{}
+	var sentenceID sql.NullInt64
+	var vocabulary string
+	err := row.Scan(
+		&conversation.ID,
+		&conversation.UserID,
+		&conversation.SessionID,
+		&sentenceID,
+		&conversation.Language,
+		&conversation.NativeLanguage,
+		&conversation.Prompt,
+		&conversation.Answer,
+		&vocabulary,
+		&conversation.Structure,
+		&conversation.Solved,
+		&conversation.Model,
+		&conversation.CreatedAt,
+		&conversation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(vocabulary), &conversation.Vocabulary); err != nil {
+		return nil, fmt.Errorf("invalid vocabulary of tutor conversation %d: %w", conversation.ID, err)
+	}
+	conversation.SentenceID = nullableID(sentenceID)
+	return conversation, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// UserRepository handles database operations for users
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository creates a new instance of UserRepository
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+const userColumns = `id, username, password_hash, role, created_at`
+
+// Create adds a new user to the database
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	query := `INSERT INTO users (username, password_hash, role, created_at) VALUES (?, ?, ?, ?)`
+
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx, query, user.Username, user.PasswordHash, user.Role, user.CreatedAt)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return fmt.Errorf("user %q: %w", user.Username, models.ErrUsernameTaken)
+		}
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	user.ID = id
+	return nil
+}
+
+// GetByID retrieves a user by their ID
+func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ?`
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetByUsername retrieves a user by their username, ignoring case
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE username = ?`
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, username))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user %q: %w", username, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdateRole changes the role of a user
+func (r *UserRepository) UpdateRole(ctx context.Context, id int64, role models.Role) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET role = ? WHERE id = ?`, role, id)
+	if err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+func scanUser(row rowScanner) (*models.User, error) {
+	var user models.User
+	err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Role, &user.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
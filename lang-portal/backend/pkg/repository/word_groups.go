@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pavittarx/lang-portal/backend/pkg/models"
 )
@@ -10,7 +12,9 @@ import (
 // GetWordsByGroupID retrieves all words associated with a specific group
 func (r *SQLiteWordRepository) GetWordsByGroupID(ctx context.Context, groupID int64) ([]models.Word, error) {
 	query := `
-		SELECT w.id, w.hindi, w.english, w.hinglish, w.created_at
+		SELECT w.id, w.language, w.native_language, w.target, w.scrambled, w.romanized, w.native, w.ipa, w.ipa_override,
+			w.part_of_speech, w.gender, w.transitivity, w.root, w.plural, w.oblique, w.oblique_plural, w.forms_override,
+			w.audio_asset_id, w.created_at
 		FROM words w
 		INNER JOIN word_groups wg ON w.id = wg.word_id
 		WHERE wg.group_id = ?
@@ -25,18 +29,11 @@ func (r *SQLiteWordRepository) GetWordsByGroupID(ctx context.Context, groupID in
 
 	var words []models.Word
 	for rows.Next() {
-		var word models.Word
-		err := rows.Scan(
-			&word.ID,
-			&word.Hindi,
-			&word.English,
-			&word.Hinglish,
-			&word.CreatedAt,
-		)
+		word, err := scanWord(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan word: %w", err)
 		}
-		words = append(words, word)
+		words = append(words, *word)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -45,3 +42,119 @@ func (r *SQLiteWordRepository) GetWordsByGroupID(ctx context.Context, groupID in
 
 	return words, nil
 }
+
+// CountWords counts the words of each of the given groups in one query,
+// groups without words are left out
+func (r *SQLiteGroupRepository) CountWords(ctx context.Context, groupIDs []int64) (map[int64]int, error) {
+	counts := make(map[int64]int)
+	if len(groupIDs) == 0 {
+		return counts, nil
+	}
+
+	query := `
+		SELECT group_id, COUNT(*)
+		FROM word_groups
+		WHERE group_id IN (?` + strings.Repeat(", ?", len(groupIDs)-1) + `)
+		GROUP BY group_id
+	`
+	args := make([]interface{}, len(groupIDs))
+	for i, id := range groupIDs {
+		args[i] = id
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count group words: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupID int64
+		var count int
+		if err := rows.Scan(&groupID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan group word count: %w", err)
+		}
+		counts[groupID] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetGroupsByWordID retrieves all groups a word belongs to
+func (r *SQLiteGroupRepository) GetGroupsByWordID(ctx context.Context, wordID int64) ([]models.Group, error) {
+	query := `
+		SELECT g.id, g.name, g.description, g.created_at
+		FROM groups g
+		INNER JOIN word_groups wg ON g.id = wg.group_id
+		WHERE wg.word_id = ?
+		ORDER BY g.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query groups by word ID: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []models.Group{}
+	for rows.Next() {
+		var group models.Group
+		if err := rows.Scan(&group.ID, &group.Name, &group.Description, &group.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return groups, nil
+}
+
+// AddWord adds a word to a group, adding a word that is already a member is a no-op
+func (r *SQLiteGroupRepository) AddWord(ctx context.Context, groupID, wordID int64) error {
+	var groupExists, wordExists bool
+	err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM groups WHERE id = ?), EXISTS(SELECT 1 FROM words WHERE id = ?)`,
+		groupID, wordID,
+	).Scan(&groupExists, &wordExists)
+	if err != nil {
+		return fmt.Errorf("failed to check word group: %w", err)
+	}
+	if !groupExists {
+		return fmt.Errorf("group with ID %d: %w", groupID, models.ErrNotFound)
+	}
+	if !wordExists {
+		return fmt.Errorf("word with ID %d: %w", wordID, models.ErrNotFound)
+	}
+
+	query := `INSERT OR IGNORE INTO word_groups (word_id, group_id, created_at) VALUES (?, ?, ?)`
+	if _, err := r.db.ExecContext(ctx, query, wordID, groupID, time.Now()); err != nil {
+		return fmt.Errorf("failed to add word to group: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveWord removes a word from a group
+func (r *SQLiteGroupRepository) RemoveWord(ctx context.Context, groupID, wordID int64) error {
+	query := `DELETE FROM word_groups WHERE group_id = ? AND word_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, groupID, wordID)
+	if err != nil {
+		return fmt.Errorf("failed to remove word from group: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("word %d in group %d: %w", wordID, groupID, models.ErrNotFound)
+	}
+
+	return nil
+}
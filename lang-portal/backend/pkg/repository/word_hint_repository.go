@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// WordHintRepository defines the interface for the hints of words
+type WordHintRepository interface {
+	// Create adds a hint to a word
+	Create(ctx context.Context, hint *models.WordHint) error
+
+	// ListByWordID retrieves the hints of a word, oldest first
+	ListByWordID(ctx context.Context, wordID int64) ([]models.WordHint, error)
+
+	// Delete removes a hint of a word by its ID
+	Delete(ctx context.Context, wordID, id int64) error
+}
+
+// SQLiteWordHintRepository implements WordHintRepository for SQLite
+type SQLiteWordHintRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteWordHintRepository creates a new instance of SQLiteWordHintRepository
+func NewSQLiteWordHintRepository(db *sql.DB) *SQLiteWordHintRepository {
+	return &SQLiteWordHintRepository{db: db}
+}
+
+// Create adds a hint to a word
+func (r *SQLiteWordHintRepository) Create(ctx context.Context, hint *models.WordHint) error {
+	if hint.CreatedAt.IsZero() {
+		hint.CreatedAt = time.Now()
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`INSERT INTO word_hints (word_id, text, created_at) VALUES (?, ?, ?)`,
+		hint.WordID, hint.Text, hint.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create word hint: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	hint.ID = id
+	return nil
+}
+
+// ListByWordID retrieves the hints of a word, oldest first
+func (r *SQLiteWordHintRepository) ListByWordID(ctx context.Context, wordID int64) ([]models.WordHint, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, word_id, text, created_at FROM word_hints WHERE word_id = ? ORDER BY id`,
+		wordID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list word hints: %w", err)
+	}
+	defer rows.Close()
+
+	hints := []models.WordHint{}
+	for rows.Next() {
+		var hint models.WordHint
+		if err := rows.Scan(&hint.ID, &hint.WordID, &hint.Text, &hint.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan word hint: %w", err)
+		}
+		hints = append(hints, hint)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating word hints: %w", err)
+	}
+
+	return hints, nil
+}
+
+// Delete removes a hint of a word by its ID
+func (r *SQLiteWordHintRepository) Delete(ctx context.Context, wordID, id int64) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM word_hints WHERE id = ? AND word_id = ?`, id, wordID)
+	if err != nil {
+		return fmt.Errorf("failed to delete word hint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("hint with ID %d of word %d: %w", id, wordID, models.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// WordMergeRepository defines the interface for merging duplicate words
+type WordMergeRepository interface {
+	// Merge merges words into the word kept and records each merge, all in
+	// one transaction
+	Merge(ctx context.Context, keptID int64, mergedIDs []int64, mergedBy *int64) ([]models.WordMerge, error)
+
+	// List retrieves the recorded merges, newest first
+	List(ctx context.Context) ([]models.WordMerge, error)
+}
+
+// SQLiteWordMergeRepository implements WordMergeRepository for SQLite
+type SQLiteWordMergeRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteWordMergeRepository creates a new instance of SQLiteWordMergeRepository
+func NewSQLiteWordMergeRepository(db *sql.DB) *SQLiteWordMergeRepository {
+	return &SQLiteWordMergeRepository{db: db}
+}
+
+// wordReferences moves what refers to a merged word, the first ? being the
+// word kept and the second the merged word. Group and sentence links the
+// word kept already has are left as they are, words merged into the
+// merged word before are recorded as merged into the word kept, and
+// challenges not answered yet are graded against the word kept.
+var wordReferences = []string{
+	`INSERT OR IGNORE INTO word_groups (word_id, group_id, created_at)
+		SELECT ?, group_id, created_at FROM word_groups WHERE word_id = ?`,
+	`INSERT OR IGNORE INTO word_sentences (word_id, sentence_id, created_at)
+		SELECT ?, sentence_id, created_at FROM word_sentences WHERE word_id = ?`,
+	`UPDATE word_hints SET word_id = ? WHERE word_id = ?`,
+	`UPDATE drafts SET word_id = ? WHERE word_id = ?`,
+	`UPDATE recordings SET word_id = ? WHERE word_id = ?`,
+	`UPDATE word_merges SET kept_word_id = ? WHERE kept_word_id = ?`,
+	`UPDATE issued_challenges SET payload = json_set(payload, '$.word_id', ?)
+		WHERE answered_at IS NULL
+		AND CASE WHEN json_valid(payload) THEN json_extract(payload, '$.word_id') END = ?`,
+	`UPDATE issued_challenges SET payload = json_set(payload, '$.other_word_id', ?)
+		WHERE answered_at IS NULL
+		AND CASE WHEN json_valid(payload) THEN json_extract(payload, '$.other_word_id') END = ?`,
+}
+
+// Merge merges words into the word kept: the word kept joins their groups
+// and sentences, their hints, drafts, recordings and the challenges issued
+// with them are moved to it, it
+// takes their audio and the IPA and forms editors entered when it has none,
+// and they are deleted with their pending jobs. Words must share the
+// language pair of the word kept.
+func (r *SQLiteWordMergeRepository) Merge(ctx context.Context, keptID int64, mergedIDs []int64, mergedBy *int64) ([]models.WordMerge, error) {
+	if len(mergedIDs) == 0 {
+		return nil, fmt.Errorf("no words to merge: %w", models.ErrInvalidInput)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	kept, err := wordInTx(ctx, tx, keptID)
+	if err != nil {
+		return nil, err
+	}
+
+	var merges []models.WordMerge
+	for _, id := range mergedIDs {
+		if id == keptID {
+			return nil, fmt.Errorf("word %d cannot be merged into itself: %w", id, models.ErrInvalidInput)
+		}
+		merged, err := wordInTx(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if merged.Language != kept.Language || merged.NativeLanguage != kept.NativeLanguage {
+			return nil, fmt.Errorf("word %d is a %s-%s word, word %d a %s-%s word: %w",
+				id, merged.Language, merged.NativeLanguage, keptID, kept.Language, kept.NativeLanguage, models.ErrInvalidInput)
+		}
+
+		groupIDs, err := groupIDsInTx(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, query := range wordReferences {
+			if _, err := tx.ExecContext(ctx, query, keptID, id); err != nil {
+				return nil, fmt.Errorf("failed to move the references of word %d: %w", id, err)
+			}
+		}
+		if err := keepWordAttributes(ctx, tx, kept, merged); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM jobs WHERE kind = ? AND subject_id = ?`, models.JobPronunciation, id); err != nil {
+			return nil, fmt.Errorf("failed to delete the jobs of word %d: %w", id, err)
+		}
+		for _, query := range []string{
+			`DELETE FROM word_groups WHERE word_id = ?`,
+			`DELETE FROM word_sentences WHERE word_id = ?`,
+			`DELETE FROM words WHERE id = ?`,
+		} {
+			if _, err := tx.ExecContext(ctx, query, id); err != nil {
+				return nil, fmt.Errorf("failed to delete word %d: %w", id, err)
+			}
+		}
+
+		merge := models.WordMerge{
+			KeptWordID:   keptID,
+			MergedWordID: id,
+			MergedWord:   *merged,
+			GroupIDs:     groupIDs,
+			MergedBy:     mergedBy,
+			CreatedAt:    time.Now(),
+		}
+		if err := recordMerge(ctx, tx, &merge); err != nil {
+			return nil, err
+		}
+		merges = append(merges, merge)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit word merge: %w", err)
+	}
+
+	return merges, nil
+}
+
+// List retrieves the recorded merges, newest first
+func (r *SQLiteWordMergeRepository) List(ctx context.Context) ([]models.WordMerge, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, kept_word_id, merged_word_id, merged_word, group_ids, merged_by, created_at
+		FROM word_merges ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list word merges: %w", err)
+	}
+	defer rows.Close()
+
+	merges := []models.WordMerge{}
+	for rows.Next() {
+		var merge models.WordMerge
+		var word, groupIDs string
+		var mergedBy sql.NullInt64
+		if err := rows.Scan(&merge.ID, &merge.KeptWordID, &merge.MergedWordID, &word, &groupIDs, &mergedBy, &merge.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan word merge: %w", err)
+		}
+		if err := json.Unmarshal([]byte(word), &merge.MergedWord); err != nil {
+			return nil, fmt.Errorf("failed to decode merged word: %w", err)
+		}
+		if err := json.Unmarshal([]byte(groupIDs), &merge.GroupIDs); err != nil {
+			return nil, fmt.Errorf("failed to decode merged word groups: %w", err)
+		}
+		if mergedBy.Valid {
+			merge.MergedBy = &mergedBy.Int64
+		}
+		merges = append(merges, merge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating word merges: %w", err)
+	}
+
+	return merges, nil
+}
+
+// wordInTx retrieves a word within a transaction
+func wordInTx(ctx context.Context, tx *sql.Tx, id int64) (*models.Word, error) {
+	word, err := scanWord(tx.QueryRowContext(ctx, `SELECT `+wordColumns+` FROM words WHERE id = ?`, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("word with ID %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve word: %w", err)
+	}
+	return word, nil
+}
+
+// keepWordAttributes gives the word kept the audio of the merged word, and
+// the IPA and forms an editor entered for it, when the word kept has none
+// of its own. The word kept is updated in place, so that the first of the
+// merged words to have them is kept.
+func keepWordAttributes(ctx context.Context, tx *sql.Tx, kept, merged *models.Word) error {
+	if kept.AudioAssetID == nil && merged.AudioAssetID != nil {
+		if _, err := tx.ExecContext(ctx, `UPDATE words SET audio_asset_id = ? WHERE id = ?`, *merged.AudioAssetID, kept.ID); err != nil {
+			return fmt.Errorf("failed to keep the audio of word %d: %w", merged.ID, err)
+		}
+		kept.AudioAssetID = merged.AudioAssetID
+	}
+
+	if !kept.IPAOverride && merged.IPAOverride {
+		if _, err := tx.ExecContext(ctx, `UPDATE words SET ipa = ?, ipa_override = 1 WHERE id = ?`, merged.IPA, kept.ID); err != nil {
+			return fmt.Errorf("failed to keep the IPA of word %d: %w", merged.ID, err)
+		}
+		kept.IPA, kept.IPAOverride = merged.IPA, true
+	}
+
+	if !kept.FormsOverride && merged.FormsOverride {
+		forms := merged.WordForms
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE words SET root = ?, plural = ?, oblique = ?, oblique_plural = ?, forms_override = 1 WHERE id = ?`,
+			forms.Root, forms.Plural, forms.Oblique, forms.ObliquePlural, kept.ID,
+		); err != nil {
+			return fmt.Errorf("failed to keep the forms of word %d: %w", merged.ID, err)
+		}
+		kept.WordForms, kept.FormsOverride = forms, true
+	}
+
+	return nil
+}
+
+// groupIDsInTx retrieves the groups of a word within a transaction
+func groupIDsInTx(ctx context.Context, tx *sql.Tx, wordID int64) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT group_id FROM word_groups WHERE word_id = ? ORDER BY group_id`, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the groups of word %d: %w", wordID, err)
+	}
+	defer rows.Close()
+
+	groupIDs := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan group ID: %w", err)
+		}
+		groupIDs = append(groupIDs, id)
+	}
+	return groupIDs, rows.Err()
+}
+
+// recordMerge inserts a merge record within a transaction
+func recordMerge(ctx context.Context, tx *sql.Tx, merge *models.WordMerge) error {
+	word, err := json.Marshal(merge.MergedWord)
+	if err != nil {
+		return fmt.Errorf("failed to encode merged word: %w", err)
+	}
+	groupIDs, err := json.Marshal(merge.GroupIDs)
+	if err != nil {
+		return fmt.Errorf("failed to encode merged word groups: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO word_merges (kept_word_id, merged_word_id, merged_word, group_ids, merged_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		merge.KeptWordID, merge.MergedWordID, string(word), string(groupIDs), merge.MergedBy, merge.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record word merge: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	merge.ID = id
+	return nil
+}
@@ -35,6 +35,24 @@ type WordRepository interface {
 
 	// GetWordsByGroupID retrieves all words associated with a specific group
 	GetWordsByGroupID(ctx context.Context, groupID int64) ([]models.Word, error)
+
+	// ListWithoutAudio retrieves all words that have no pronunciation audio
+	ListWithoutAudio(ctx context.Context) ([]models.Word, error)
+
+	// SetAudio sets the pronunciation audio of a word
+	SetAudio(ctx context.Context, id, assetID int64) error
+
+	// SetRomanized sets the romanization of a word
+	SetRomanized(ctx context.Context, id int64, romanized string) error
+
+	// SetIPA sets the IPA pronunciation of a word and whether an editor entered it
+	SetIPA(ctx context.Context, id int64, ipa string, override bool) error
+
+	// SetForms sets the inflected forms of a word and whether an editor entered them
+	SetForms(ctx context.Context, id int64, forms models.WordForms, override bool) error
+
+	// ListByNative retrieves the words of a language whose native form is one of natives, ignoring case
+	ListByNative(ctx context.Context, language string, natives []string) ([]models.Word, error)
 }
 
 // ListWordsParams defines parameters for listing words
@@ -42,9 +60,45 @@ type ListWordsParams struct {
 	Page     int
 	PageSize int
 	Search   string
-	Language string // "hindi", "english", "hinglish"
+	Field    string // "target", "native", "romanized", "ipa", searches all when empty
+	Language string // language code of the target form, lists all when empty
+
+	// Grammar filters, each lists all when empty
+	PartOfSpeech string
+	Gender       string
+	Transitivity string
+
+	// Sort is one of models.WordSorts, words are listed by ID when empty.
+	// Order is models.SortAscending, the default, or models.SortDescending.
+	Sort  string
+	Order string
+}
+
+// wordSortColumns are the expressions words are ordered by for each of
+// models.WordSorts
+var wordSortColumns = map[string]string{
+	models.WordSortHindi:      `w.target COLLATE HINDI`,
+	models.WordSortEnglish:    `w.native COLLATE NOCASE`,
+	models.WordSortHinglish:   `w.romanized COLLATE NOCASE`,
+	models.WordSortCreatedAt:  `w.created_at`,
+	models.WordSortDifficulty: `CASE w.difficulty WHEN 'easy' THEN 1 WHEN 'medium' THEN 2 WHEN 'hard' THEN 3 ELSE 4 END`,
 }
 
+// wordOrder returns the ORDER BY clause of a word list, breaking ties by ID
+func wordOrder(params ListWordsParams) string {
+	if params.Sort == "" {
+		return ` ORDER BY w.id`
+	}
+	direction := ` ASC`
+	if params.Order == models.SortDescending {
+		direction = ` DESC`
+	}
+	return ` ORDER BY ` + wordSortColumns[params.Sort] + direction + `, w.id`
+}
+
+const wordColumns = `id, language, native_language, target, scrambled, romanized, native, ipa, ipa_override, ` +
+	`part_of_speech, gender, transitivity, root, plural, oblique, oblique_plural, forms_override, audio_asset_id, created_at`
+
 // SQLiteWordRepository implements WordRepository for SQLite
 type SQLiteWordRepository struct {
 	db *sql.DB
@@ -70,16 +124,30 @@ func (r *SQLiteWordRepository) Create(ctx context.Context, word *models.Word) er
 
 	// Prepare SQL statement
 	query := `
-		INSERT INTO words (hindi, scrambled, hinglish, english, created_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO words (language, native_language, target, scrambled, romanized, native, ipa, ipa_override,
+			part_of_speech, gender, transitivity, root, plural, oblique, oblique_plural, forms_override, audio_asset_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	// Execute the query
 	result, err := r.db.ExecContext(ctx, query,
-		word.Hindi,
+		word.Language,
+		word.NativeLanguage,
+		word.Target,
 		word.Scrambled,
-		word.Hinglish,
-		word.English,
+		word.Romanized,
+		word.Native,
+		word.IPA,
+		word.IPAOverride,
+		word.PartOfSpeech,
+		word.Gender,
+		word.Transitivity,
+		word.Root,
+		word.Plural,
+		word.Oblique,
+		word.ObliquePlural,
+		word.FormsOverride,
+		word.AudioAssetID,
 		word.CreatedAt,
 	)
 	if err != nil {
@@ -98,24 +166,12 @@ func (r *SQLiteWordRepository) Create(ctx context.Context, word *models.Word) er
 
 // GetByID retrieves a word by its ID
 func (r *SQLiteWordRepository) GetByID(ctx context.Context, id int64) (*models.Word, error) {
-	query := `
-		SELECT id, hindi, scrambled, hinglish, english, created_at
-		FROM words
-		WHERE id = ?
-	`
+	query := `SELECT ` + wordColumns + ` FROM words WHERE id = ?`
 
-	word := &models.Word{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&word.ID,
-		&word.Hindi,
-		&word.Scrambled,
-		&word.Hinglish,
-		&word.English,
-		&word.CreatedAt,
-	)
+	word, err := scanWord(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("word with ID %d not found", id)
+			return nil, fmt.Errorf("word with ID %d: %w", id, models.ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to retrieve word: %w", err)
 	}
@@ -136,16 +192,31 @@ func (r *SQLiteWordRepository) Update(ctx context.Context, word *models.Word) er
 	// Prepare SQL statement
 	query := `
 		UPDATE words
-		SET hindi = ?, scrambled = ?, hinglish = ?, english = ?
+		SET language = ?, native_language = ?, target = ?, scrambled = ?, romanized = ?, native = ?, ipa = ?, ipa_override = ?,
+			part_of_speech = ?, gender = ?, transitivity = ?, root = ?, plural = ?, oblique = ?, oblique_plural = ?, forms_override = ?,
+			audio_asset_id = ?
 		WHERE id = ?
 	`
 
 	// Execute the query
 	result, err := r.db.ExecContext(ctx, query,
-		word.Hindi,
+		word.Language,
+		word.NativeLanguage,
+		word.Target,
 		word.Scrambled,
-		word.Hinglish,
-		word.English,
+		word.Romanized,
+		word.Native,
+		word.IPA,
+		word.IPAOverride,
+		word.PartOfSpeech,
+		word.Gender,
+		word.Transitivity,
+		word.Root,
+		word.Plural,
+		word.Oblique,
+		word.ObliquePlural,
+		word.FormsOverride,
+		word.AudioAssetID,
 		word.ID,
 	)
 	if err != nil {
@@ -192,6 +263,10 @@ func (r *SQLiteWordRepository) List(ctx context.Context, params ListWordsParams)
 	// Log input parameters for debugging
 	log.Printf("SQLiteWordRepository.List called with params: %+v", params)
 
+	if err := models.ValidateWordSort(params.Sort, params.Order); err != nil {
+		return nil, 0, err
+	}
+
 	// Set default pagination
 	if params.Page < 1 {
 		params.Page = 1
@@ -205,6 +280,24 @@ func (r *SQLiteWordRepository) List(ctx context.Context, params ListWordsParams)
 	baseQuery := `FROM words w WHERE 1=1`
 	args := []interface{}{}
 
+	// Restrict to one target language if requested
+	if params.Language != "" {
+		baseQuery += ` AND w.language = ?`
+		args = append(args, params.Language)
+	}
+
+	// Restrict to words of the requested grammar
+	for _, filter := range []struct{ column, value string }{
+		{"part_of_speech", params.PartOfSpeech},
+		{"gender", params.Gender},
+		{"transitivity", params.Transitivity},
+	} {
+		if filter.value != "" {
+			baseQuery += ` AND w.` + filter.column + ` = ?`
+			args = append(args, filter.value)
+		}
+	}
+
 	// Add search filter if provided
 	if params.Search != "" {
 		// Check if search is a group filter
@@ -219,21 +312,24 @@ func (r *SQLiteWordRepository) List(ctx context.Context, params ListWordsParams)
 				args = append(args, groupID)
 			}
 		} else {
-			searchParam := "%" + params.Search + "%"
-			switch params.Language {
-			case "hindi":
-				baseQuery += ` AND w.hindi LIKE ?`
+			searchParam := "%" + models.CanonicalText(params.Search) + "%"
+			switch params.Field {
+			case "target":
+				baseQuery += ` AND w.target LIKE ?`
+				args = append(args, searchParam)
+			case "native":
+				baseQuery += ` AND w.native LIKE ?`
 				args = append(args, searchParam)
-			case "english":
-				baseQuery += ` AND w.english LIKE ?`
+			case "romanized":
+				baseQuery += ` AND w.romanized LIKE ?`
 				args = append(args, searchParam)
-			case "hinglish":
-				baseQuery += ` AND w.hinglish LIKE ?`
+			case "ipa":
+				baseQuery += ` AND w.ipa LIKE ?`
 				args = append(args, searchParam)
 			default:
-				// Search across all fields if no specific language is specified
-				baseQuery += ` AND (w.hindi LIKE ? OR w.english LIKE ? OR w.hinglish LIKE ?)`
-				args = append(args, searchParam, searchParam, searchParam)
+				// Search across all fields if no specific field is specified
+				baseQuery += ` AND (w.target LIKE ? OR w.native LIKE ? OR w.romanized LIKE ? OR w.ipa LIKE ?)`
+				args = append(args, searchParam, searchParam, searchParam, searchParam)
 			}
 		}
 	}
@@ -253,8 +349,8 @@ func (r *SQLiteWordRepository) List(ctx context.Context, params ListWordsParams)
 	log.Printf("Total count of words: %d", totalCount)
 
 	// Retrieve words with pagination
-	query := `SELECT w.id, w.hindi, w.scrambled, w.hinglish, w.english, w.created_at ` +
-		baseQuery + ` LIMIT ? OFFSET ?`
+	query := `SELECT ` + wordColumns + ` ` +
+		baseQuery + wordOrder(params) + ` LIMIT ? OFFSET ?`
 	args = append(args, params.PageSize, offset)
 
 	// Log the final query with arguments
@@ -271,19 +367,12 @@ func (r *SQLiteWordRepository) List(ctx context.Context, params ListWordsParams)
 	// Scan results
 	var words []models.Word
 	for rows.Next() {
-		var word models.Word
-		if err := rows.Scan(
-			&word.ID,
-			&word.Hindi,
-			&word.Scrambled,
-			&word.Hinglish,
-			&word.English,
-			&word.CreatedAt,
-		); err != nil {
+		word, err := scanWord(rows)
+		if err != nil {
 			log.Printf("Error scanning word: %v", err)
 			return nil, 0, fmt.Errorf("failed to scan word: %w", err)
 		}
-		words = append(words, word)
+		words = append(words, *word)
 	}
 
 	// Check for any errors during iteration
@@ -303,25 +392,17 @@ func (r *SQLiteWordRepository) GetRandomWord(ctx context.Context) (*models.Word,
 	// Prepare a query that selects a random word with more randomness
 	query := `
 		WITH RandomWords AS (
-			SELECT id, hindi, scrambled, hinglish, english, created_at,
+			SELECT ` + wordColumns + `,
 				   ABS(RANDOM()) as random_value
 			FROM words
 		)
-		SELECT id, hindi, scrambled, hinglish, english, created_at
+		SELECT ` + wordColumns + `
 		FROM RandomWords
 		ORDER BY random_value
 		LIMIT 1
 	`
 
-	word := &models.Word{}
-	err := r.db.QueryRowContext(ctx, query).Scan(
-		&word.ID,
-		&word.Hindi,
-		&word.Scrambled,
-		&word.Hinglish,
-		&word.English,
-		&word.CreatedAt,
-	)
+	word, err := scanWord(r.db.QueryRowContext(ctx, query))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("no words found in the database")
@@ -331,3 +412,177 @@ func (r *SQLiteWordRepository) GetRandomWord(ctx context.Context) (*models.Word,
 
 	return word, nil
 }
+
+// ListWithoutAudio retrieves all words that have no pronunciation audio
+func (r *SQLiteWordRepository) ListWithoutAudio(ctx context.Context) ([]models.Word, error) {
+	query := `SELECT ` + wordColumns + ` FROM words WHERE audio_asset_id IS NULL ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list words without audio: %w", err)
+	}
+	defer rows.Close()
+
+	var words []models.Word
+	for rows.Next() {
+		word, err := scanWord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan word: %w", err)
+		}
+		words = append(words, *word)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating words: %w", err)
+	}
+
+	return words, nil
+}
+
+// SetAudio sets the pronunciation audio of a word, leaving the rest of it as is
+func (r *SQLiteWordRepository) SetAudio(ctx context.Context, id, assetID int64) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE words SET audio_asset_id = ? WHERE id = ?`, assetID, id)
+	if err != nil {
+		return fmt.Errorf("failed to set word audio: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("word with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetRomanized sets the romanization of a word, leaving the rest of it as is
+func (r *SQLiteWordRepository) SetRomanized(ctx context.Context, id int64, romanized string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE words SET romanized = ? WHERE id = ?`, models.CanonicalText(romanized), id)
+	if err != nil {
+		return fmt.Errorf("failed to set word romanization: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("word with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetIPA sets the IPA pronunciation of a word, leaving the rest of it as is
+func (r *SQLiteWordRepository) SetIPA(ctx context.Context, id int64, ipa string, override bool) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE words SET ipa = ?, ipa_override = ? WHERE id = ?`, models.CanonicalText(ipa), override, id)
+	if err != nil {
+		return fmt.Errorf("failed to set word IPA: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("word with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetForms sets the inflected forms of a word, leaving the rest of it as is
+func (r *SQLiteWordRepository) SetForms(ctx context.Context, id int64, forms models.WordForms, override bool) error {
+	forms = forms.Canonical()
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE words SET root = ?, plural = ?, oblique = ?, oblique_plural = ?, forms_override = ?
+		WHERE id = ?
+	`, forms.Root, forms.Plural, forms.Oblique, forms.ObliquePlural, override, id)
+	if err != nil {
+		return fmt.Errorf("failed to set word forms: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error checking rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("word with ID %d: %w", id, models.ErrNotFound)
+	}
+
+	return nil
+}
+
+// ListByNative retrieves the words of a language whose native form is one of
+// natives, ignoring case
+func (r *SQLiteWordRepository) ListByNative(ctx context.Context, language string, natives []string) ([]models.Word, error) {
+	if len(natives) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT ` + wordColumns + ` FROM words
+		WHERE language = ? AND LOWER(native) IN (?` + strings.Repeat(", ?", len(natives)-1) + `)
+		ORDER BY id`
+	args := []interface{}{language}
+	for _, native := range natives {
+		args = append(args, strings.ToLower(models.CanonicalText(native)))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list words by native form: %w", err)
+	}
+	defer rows.Close()
+
+	var words []models.Word
+	for rows.Next() {
+		word, err := scanWord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan word: %w", err)
+		}
+		words = append(words, *word)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating words: %w", err)
+	}
+
+	return words, nil
+}
+
+// scanWord reads a word selected with wordColumns
+func scanWord(row rowScanner) (*models.Word, error) {
+	word := &models.Word{}
+	var audio sql.NullInt64
+	err := row.Scan(
+		&word.ID,
+		&word.Language,
+		&word.NativeLanguage,
+		&word.Target,
+		&word.Scrambled,
+		&word.Romanized,
+		&word.Native,
+		&word.IPA,
+		&word.IPAOverride,
+		&word.PartOfSpeech,
+		&word.Gender,
+		&word.Transitivity,
+		&word.Root,
+		&word.Plural,
+		&word.Oblique,
+		&word.ObliquePlural,
+		&word.FormsOverride,
+		&audio,
+		&word.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if audio.Valid {
+		word.AudioAssetID = &audio.Int64
+		word.AudioURL = models.AssetURL(audio.Int64)
+	}
+	return word, nil
+}
@@ -2,9 +2,19 @@ package routes
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
 	"github.com/pavittarx/lang-portal/backend/pkg/handlers"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// Role guards, each role includes the ones below it
+var (
+	learner = auth.RequireRole(models.RoleLearner)
+	editor  = auth.RequireRole(models.RoleEditor)
+	admin   = auth.RequireRole(models.RoleAdmin)
 )
 
 // RegisterRoutes sets up all routes for the application
@@ -13,7 +23,23 @@ func RegisterRoutes(e *echo.Echo,
 	groupHandler *handlers.GroupHandler, 
 	studyActivityHandler *handlers.StudyActivityHandler,
 	sessionHandler *handlers.SessionHandler,
-	sessionActivityHandler *handlers.SessionActivityHandler) {
+	sessionActivityHandler *handlers.SessionActivityHandler,
+	challengeHandler *handlers.ChallengeHandler,
+	launchHandler *handlers.LaunchHandler,
+	authHandler *handlers.AuthHandler,
+	sentenceHandler *handlers.SentenceHandler,
+	assetHandler *handlers.AssetHandler,
+	jobHandler *handlers.JobHandler,
+	contentHandler *handlers.ContentHandler,
+	tutorHandler *handlers.TutorHandler,
+	listeningHandler *handlers.ListeningHandler,
+	speakingHandler *handlers.SpeakingHandler,
+	minimalPairHandler *handlers.MinimalPairHandler,
+	conjugationHandler *handlers.ConjugationHandler,
+	numeralHandler *handlers.NumeralHandler,
+	inputHandler *handlers.InputHandler,
+	duplicateHandler *handlers.DuplicateHandler,
+	contentReportHandler *handlers.ContentReportHandler) {
 	// Health check endpoints
 	e.GET("/api", func(c echo.Context) error {
 		return c.JSON(http.StatusOK, map[string]string{
@@ -32,47 +58,154 @@ func RegisterRoutes(e *echo.Echo,
 	e.Static("/docs", "swagger-ui")
 	e.File("/swagger.json", "swagger.json")
 
+	// Account routes
+	e.POST("/api/auth/register", authHandler.Register)
+	e.POST("/api/auth/login", authHandler.Login)
+	e.GET("/api/auth/me", authHandler.Me, learner)
+	e.GET("/api/auth/api-keys", authHandler.ListAPIKeys, learner)
+	e.POST("/api/auth/api-keys", authHandler.CreateAPIKey, learner)
+	e.DELETE("/api/auth/api-keys/:id", authHandler.DeleteAPIKey, learner)
+	e.PUT("/api/users/:id/role", authHandler.SetUserRole, admin)
+
+	// Language routes
+	e.GET("/api/languages", wordHandler.ListLanguages, learner)
+	e.POST("/api/languages", wordHandler.CreateLanguage, admin)
+
 	// Words routes
-	e.GET("/api/words", wordHandler.GetWords)
-	e.GET("/api/words/random", wordHandler.GetRandomWordFiltered)
-	e.GET("/api/words/search", wordHandler.SearchWordsTerm)
-	e.GET("/api/words/groups/:group-id", wordHandler.GetWordsByGroup)
+	e.GET("/api/words", wordHandler.GetWords, learner)
+	e.GET("/api/words/random", wordHandler.GetRandomWordFiltered, learner)
+	e.GET("/api/words/search", wordHandler.SearchWordsTerm, learner)
+	e.GET("/api/words/groups/:group-id", wordHandler.GetWordsByGroup, learner)
+	e.GET("/api/words/:id", wordHandler.GetWordByID, learner)
+	e.POST("/api/words", wordHandler.CreateWord, editor)
+	e.PUT("/api/words/:id", wordHandler.UpdateWord, editor)
+	e.DELETE("/api/words/:id", wordHandler.DeleteWord, editor)
+	e.DELETE("/api/words/:id/ipa", wordHandler.ResetIPA, editor)
+	e.DELETE("/api/words/:id/forms", wordHandler.ResetForms, editor)
+
+	// Duplicate word routes, merges delete words so only admins run them
+	e.GET("/api/words/duplicates", duplicateHandler.ListDuplicates, admin)
+	e.GET("/api/words/merges", duplicateHandler.ListMerges, admin)
+	e.POST("/api/words/:id/merge", duplicateHandler.MergeWords, admin)
+
+	// Content report routes, the report reads every word and group
+	e.GET("/api/admin/content-report", contentReportHandler.GetReport, admin)
+
+	// Example sentence routes
+	e.GET("/api/sentences", sentenceHandler.ListSentences, learner)
+	e.GET("/api/sentences/:id", sentenceHandler.GetSentence, learner)
+	e.POST("/api/sentences", sentenceHandler.CreateSentence, editor)
+	e.PUT("/api/sentences/:id", sentenceHandler.UpdateSentence, editor)
+	e.DELETE("/api/sentences/:id", sentenceHandler.DeleteSentence, editor)
+
+	// Asset routes, content is public so audio and image elements can load it
+	e.POST("/api/assets", assetHandler.UploadAsset, editor)
+	e.GET("/api/assets/:id", assetHandler.GetAsset, learner)
+	e.GET("/api/assets/:id/content", assetHandler.ServeAsset)
+	e.DELETE("/api/assets/:id", assetHandler.DeleteAsset, editor)
+
+	// Background job routes, jobs call paid or slow services so only admins start them
+	e.GET("/api/jobs", jobHandler.ListJobs, admin)
+	e.POST("/api/jobs", jobHandler.EnqueueJobs, admin)
+	e.GET("/api/jobs/:id", jobHandler.GetJob, admin)
+	e.POST("/api/jobs/:id/retry", jobHandler.RetryJob, admin)
+
+	// Generated content routes, drafts only reach learners once an editor approves them
+	e.POST("/api/drafts/words", contentHandler.ProposeWords, editor)
+	e.POST("/api/drafts/sentences", contentHandler.ProposeSentences, editor)
+	e.POST("/api/drafts/hints", contentHandler.ProposeHints, editor)
+	e.GET("/api/drafts", contentHandler.ListDrafts, editor)
+	e.GET("/api/drafts/:id", contentHandler.GetDraft, editor)
+	e.POST("/api/drafts/:id/approve", contentHandler.ApproveDraft, editor)
+	e.POST("/api/drafts/:id/reject", contentHandler.RejectDraft, editor)
+	e.GET("/api/words/:id/hints", contentHandler.ListWordHints, learner)
+	e.DELETE("/api/words/:id/hints/:hint-id", contentHandler.DeleteWordHint, editor)
+
+	// Sentence tutor routes, conversations belong to the authenticated learner
+	e.POST("/api/tutor/conversations", tutorHandler.StartConversation, learner)
+	e.GET("/api/tutor/conversations", tutorHandler.ListConversations, learner)
+	e.GET("/api/tutor/conversations/:id", tutorHandler.GetConversation, learner)
+	e.POST("/api/tutor/conversations/:id/messages", tutorHandler.SendMessage, learner)
+
+	// Listening exercise routes, answers are only shown to editors
+	e.GET("/api/listening/exercises", listeningHandler.ListExercises, learner)
+	e.GET("/api/listening/exercises/:id", listeningHandler.GetExercise, learner)
+	e.POST("/api/listening/exercises", listeningHandler.CreateExercise, editor)
+	e.POST("/api/listening/exercises/import", listeningHandler.ImportTranscript, editor)
+	e.PUT("/api/listening/exercises/:id/audio", listeningHandler.SetAudio, editor)
+	e.DELETE("/api/listening/exercises/:id", listeningHandler.DeleteExercise, editor)
 
 	// Groups routes
-	e.GET("/api/groups", groupHandler.GetGroups)
+	e.GET("/api/groups", groupHandler.GetGroups, learner)
+	e.POST("/api/groups", groupHandler.CreateGroup, editor)
+	e.PUT("/api/groups/:id", groupHandler.UpdateGroup, editor)
+	e.DELETE("/api/groups/:id", groupHandler.DeleteGroup, editor)
+	e.POST("/api/groups/:id/words", groupHandler.AddWordToGroup, editor)
+	e.DELETE("/api/groups/:id/words/:word-id", groupHandler.RemoveWordFromGroup, editor)
 
 	// Study Activities routes
-	e.GET("/api/study-activities", studyActivityHandler.GetStudyActivities)
-
-	// Session routes
-	e.POST("/api/sessions", sessionHandler.CreateSession)
-	e.PUT("/api/sessions", sessionHandler.UpdateSession)
-	e.GET("/api/sessions", sessionHandler.GetSessions)
-	e.GET("/api/sessions/:id", sessionHandler.GetSessionByID)
-	e.DELETE("/api/sessions", func(c echo.Context) error {
-		status, err := sessionHandler.DeleteAllSessions(c)
-		if err != nil {
-			return err
-		}
-		return c.NoContent(status)
-	})
+	e.GET("/api/study-activities", studyActivityHandler.GetStudyActivities, learner)
+	e.GET("/api/study-activities/types", studyActivityHandler.GetActivityTypes, learner)
+	e.GET("/api/study-activities/:id", studyActivityHandler.GetStudyActivityByID, learner)
+	e.POST("/api/study-activities", studyActivityHandler.CreateStudyActivity, editor)
+	e.PUT("/api/study-activities/:id", studyActivityHandler.UpdateStudyActivity, editor)
+	e.DELETE("/api/study-activities/:id", studyActivityHandler.DeleteStudyActivity, admin)
+
+	// Session routes, scoped to the authenticated learner
+	SetupSessionRoutes(e, sessionHandler)
 
 	// Session Activity routes
-	e.POST("/api/session-activity", sessionActivityHandler.AddSessionActivity)
+	e.POST("/api/session-activity", sessionActivityHandler.AddSessionActivity, learner)
+
+	// Activity engine routes
+	e.POST("/api/sessions/:id/challenges", challengeHandler.GenerateChallenge, learner)
+	e.POST("/api/sessions/:id/answers", challengeHandler.SubmitAnswer, learner)
+	e.GET("/api/sessions/:id/challenges/:cid/audio", challengeHandler.ServeChallengeAudio, learner)
+	e.GET("/api/sessions/:id/summary", challengeHandler.GetSessionSummary, learner)
+
+	// Speaking practice routes, recordings belong to the authenticated learner
+	e.POST("/api/sessions/:id/recordings", speakingHandler.SubmitRecording, learner)
+	e.GET("/api/recordings", speakingHandler.ListRecordings, learner)
+	e.GET("/api/recordings/:id/content", speakingHandler.ServeRecording, learner)
+	e.DELETE("/api/recordings/:id", speakingHandler.DeleteRecording, learner)
+
+	// Minimal pair routes, coverage tells editors which contrasts groups lack
+	e.GET("/api/minimal-pairs", minimalPairHandler.ListPairs, learner)
+	e.GET("/api/minimal-pairs/coverage", minimalPairHandler.Coverage, editor)
+
+	// Conjugation routes
+	e.GET("/api/words/:id/conjugations", conjugationHandler.GetConjugations, learner)
+
+	// Numeral routes, the numbers are generated rather than stored
+	e.GET("/api/numbers", numeralHandler.ListNumerals, learner)
+
+	// Input method routes, previewing Devanagari typed on a Latin keyboard
+	e.GET("/api/input/convert", inputHandler.Convert, learner)
+
+	// External activity launch routes, callbacks authenticate with the launch token
+	e.POST("/api/study-activities/:id/launch", launchHandler.LaunchActivity, learner)
+	e.POST("/api/sessions/:id/external/activities", launchHandler.RecordResult)
+	e.POST("/api/sessions/:id/external/complete", launchHandler.CompleteSession)
 }
 
 // SetupSessionRoutes sets up routes for session-related endpoints
 func SetupSessionRoutes(e *echo.Echo, sessionHandler *handlers.SessionHandler) {
     // Sessions routes
-    e.POST("/api/sessions", sessionHandler.CreateSession)
-    e.GET("/api/sessions", sessionHandler.GetSessions)
-    e.PUT("/api/sessions", sessionHandler.UpdateSession)
-    e.GET("/api/sessions/:id", sessionHandler.GetSessionByID)
+    e.POST("/api/sessions", sessionHandler.CreateSession, learner)
+    e.GET("/api/sessions", sessionHandler.GetSessions, learner)
+    e.PUT("/api/sessions", sessionHandler.UpdateSession, learner)
+    e.GET("/api/sessions/:id", sessionHandler.GetSessionByID, learner)
     e.DELETE("/api/sessions", func(c echo.Context) error {
         status, err := sessionHandler.DeleteAllSessions(c)
         if err != nil {
             return err
         }
         return c.NoContent(status)
-    })
+    }, admin)
+}
+
+// IsLaunchCallback reports whether the request is an external activity
+// callback, which carries a launch token instead of a login token
+func IsLaunchCallback(c echo.Context) bool {
+	return strings.HasPrefix(c.Path(), "/api/sessions/:id/external/")
 }
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/storage"
+)
+
+// AssetService stores uploaded media files and their metadata
+type AssetService struct {
+	repo           repository.AssetRepository
+	storage        storage.Storage
+	maxUploadBytes int64
+}
+
+// NewAssetService creates a new instance of AssetService
+func NewAssetService(repo repository.AssetRepository, files storage.Storage, maxUploadBytes int64) *AssetService {
+	return &AssetService{repo: repo, storage: files, maxUploadBytes: maxUploadBytes}
+}
+
+// AssetUpload describes an uploaded file
+type AssetUpload struct {
+	Filename    string
+	ContentType string // as declared by the client, used when sniffing the content fails
+	DurationMS  *int64 // for audio formats whose duration cannot be read from the file
+	Content     io.Reader
+}
+
+// Upload stores a file and its metadata. Uploading content that is already
+// stored returns the existing asset instead of a copy.
+func (s *AssetService) Upload(ctx context.Context, upload AssetUpload) (*models.Asset, error) {
+	data, err := io.ReadAll(io.LimitReader(upload.Content, s.maxUploadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if int64(len(data)) > s.maxUploadBytes {
+		return nil, fmt.Errorf("uploads are limited to %d bytes: %w", s.maxUploadBytes, models.ErrFileTooLarge)
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	existing, err := s.repo.GetByChecksum(ctx, checksum)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, models.ErrNotFound) {
+		return nil, err
+	}
+
+	asset := &models.Asset{
+		MimeType:   sniffMediaType(data, upload.ContentType),
+		Filename:   upload.Filename,
+		Size:       int64(len(data)),
+		DurationMS: upload.DurationMS,
+		Checksum:   checksum,
+	}
+	if asset.MimeType == "audio/wav" {
+		if duration, ok := wavDuration(data); ok {
+			asset.DurationMS = &duration
+		}
+	}
+	if err := asset.Validate(); err != nil {
+		return nil, err
+	}
+
+	asset.StorageKey = checksum[:2] + "/" + checksum + asset.Extension()
+	if err := s.storage.Put(ctx, asset.StorageKey, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, asset); err != nil {
+		if deleteErr := s.storage.Delete(ctx, asset.StorageKey); deleteErr != nil {
+			log.Printf("Failed to remove orphaned asset file %s: %v", asset.StorageKey, deleteErr)
+		}
+		return nil, err
+	}
+
+	return asset, nil
+}
+
+// GetAsset retrieves the metadata of an asset
+func (s *AssetService) GetAsset(ctx context.Context, id int64) (*models.Asset, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// OpenAsset retrieves an asset together with its content, which the caller must close
+func (s *AssetService) OpenAsset(ctx context.Context, id int64) (*models.Asset, io.ReadSeekCloser, error) {
+	asset, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.storage.Open(ctx, asset.StorageKey)
+	if errors.Is(err, storage.ErrNotExist) {
+		return nil, nil, fmt.Errorf("content of asset %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return asset, content, nil
+}
+
+// DeleteAsset removes an asset and its file, words using it lose their audio
+func (s *AssetService) DeleteAsset(ctx context.Context, id int64) error {
+	asset, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.storage.Delete(ctx, asset.StorageKey)
+}
+
+// sniffMediaType detects the media type from the content, falling back to the
+// declared type only for content sniffing does not recognize, such as MP3
+// without an ID3 tag
+func sniffMediaType(data []byte, declared string) string {
+	mediaType := http.DetectContentType(data)
+	if mediaType == "application/octet-stream" && declared != "" {
+		mediaType = declared
+	}
+	if accepted := models.NormalizeMediaType(mediaType); accepted != "" {
+		return accepted
+	}
+	return mediaType
+}
+
+// wavDuration reads the duration of PCM WAV data from its fmt and data chunks
+func wavDuration(data []byte) (int64, bool) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return 0, false
+	}
+
+	var byteRate, dataSize uint32
+	for offset := 12; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := binary.LittleEndian.Uint32(data[offset+4 : offset+8])
+		body := offset + 8
+
+		switch id {
+		case "fmt ":
+			if body+12 > len(data) {
+				return 0, false
+			}
+			byteRate = binary.LittleEndian.Uint32(data[body+8 : body+12])
+		case "data":
+			// Streamed files may not know the final size when writing the header
+			dataSize = size
+			if available := uint32(len(data) - body); dataSize > available {
+				dataSize = available
+			}
+		}
+
+		// Chunks are padded to an even size
+		offset = body + int(size) + int(size%2)
+	}
+
+	if byteRate == 0 || dataSize == 0 {
+		return 0, false
+	}
+	return int64(dataSize) * 1000 / int64(byteRate), true
+}
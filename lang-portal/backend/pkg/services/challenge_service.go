@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// ChallengeService runs a session's study activity through its engine
+type ChallengeService struct {
+	registry            *activities.Registry
+	activityRepo        *repository.StudyActivityRepository
+	sessionRepo         *repository.SessionRepository
+	sessionActivityRepo *repository.SessionActivityRepository
+	issued              repository.IssuedChallengeRepository
+}
+
+// NewChallengeService creates a new instance of ChallengeService
+func NewChallengeService(
+	registry *activities.Registry,
+	activityRepo *repository.StudyActivityRepository,
+	sessionRepo *repository.SessionRepository,
+	sessionActivityRepo *repository.SessionActivityRepository,
+	issued repository.IssuedChallengeRepository,
+) *ChallengeService {
+	return &ChallengeService{
+		registry:            registry,
+		activityRepo:        activityRepo,
+		sessionRepo:         sessionRepo,
+		sessionActivityRepo: sessionActivityRepo,
+		issued:              issued,
+	}
+}
+
+// GenerateChallenge builds the next challenge for an open session and keeps
+// it on the server, where it is answered by its ID
+func (s *ChallengeService) GenerateChallenge(ctx context.Context, userID, sessionID int64) (*activities.Challenge, error) {
+	session, activity, engine, err := s.resolve(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsCompleted() {
+		return nil, fmt.Errorf("session %d: %w", sessionID, models.ErrSessionEnded)
+	}
+
+	issued, err := s.issued.ListBySession(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{
+		Activity: activity,
+		Session:  session,
+		Issued:   issued,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.ID, err = auth.RandomID(); err != nil {
+		return nil, err
+	}
+	if err := s.issued.Create(ctx, &models.IssuedChallenge{
+		ID:           challenge.ID,
+		SessionID:    session.ID,
+		Type:         challenge.Type,
+		Prompt:       challenge.Prompt,
+		Payload:      challenge.Payload,
+		AudioAssetID: challenge.AudioAssetID,
+	}); err != nil {
+		return nil, err
+	}
+	if challenge.AudioAssetID != nil {
+		challenge.Audio = models.ChallengeAudioURL(session.ID, challenge.ID)
+	}
+
+	return challenge, nil
+}
+
+// ChallengeAudio returns the ID of the asset played with a challenge issued
+// for a session of the user. It is served answered or not, so the learner can
+// replay it while reading the grade.
+func (s *ChallengeService) ChallengeAudio(ctx context.Context, userID, sessionID int64, challengeID string) (int64, error) {
+	session, _, _, err := s.resolve(ctx, userID, sessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	challenge, err := s.issued.GetByID(ctx, session.ID, challengeID)
+	if err != nil {
+		return 0, err
+	}
+	if challenge.AudioAssetID == nil {
+		return 0, fmt.Errorf("audio of challenge %q: %w", challengeID, models.ErrNotFound)
+	}
+	return *challenge.AudioAssetID, nil
+}
+
+// IssuedChallenge retrieves a challenge issued for an open session of the
+// user and not answered yet, with the payload it is graded by
+func (s *ChallengeService) IssuedChallenge(ctx context.Context, userID, sessionID int64, challengeID string) (*activities.Challenge, error) {
+	session, _, _, err := s.resolve(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsCompleted() {
+		return nil, fmt.Errorf("session %d: %w", sessionID, models.ErrSessionEnded)
+	}
+	return s.issuedChallenge(ctx, session.ID, challengeID)
+}
+
+// SubmitAnswer grades the learner's input to a challenge issued for the
+// session and records it as a session activity. Each challenge is answered
+// once. With an input method the input is also converted to Devanagari,
+// each of its spellings graded again, and the best grade with its input is
+// kept, so answers typed on a Latin keyboard are accepted as well as
+// Devanagari ones.
+func (s *ChallengeService) SubmitAnswer(
+	ctx context.Context,
+	userID, sessionID int64,
+	challengeID string,
+	input, inputMethod string,
+) (*models.SessionActivity, *activities.Grade, error) {
+	if inputMethod != "" && !devanagari.IsInputMethod(inputMethod) {
+		return nil, nil, fmt.Errorf("unknown input method %q: %w", inputMethod, models.ErrInvalidInput)
+	}
+
+	session, activity, engine, err := s.resolve(ctx, userID, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session.IsCompleted() {
+		return nil, nil, fmt.Errorf("session %d: %w", sessionID, models.ErrSessionEnded)
+	}
+	challenge, err := s.issuedChallenge(ctx, session.ID, challengeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge.Type != activity.Type {
+		return nil, nil, fmt.Errorf("%w: expected a %s challenge", activities.ErrInvalidChallenge, activity.Type)
+	}
+	if err := s.issued.MarkAnswered(ctx, session.ID, challenge.ID); err != nil {
+		return nil, nil, err
+	}
+
+	grade, err := engine.GradeAnswer(ctx, challenge, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inputMethod != "" {
+		typed := input
+		for _, spelling := range devanagari.Spellings(typed, devanagari.InputMethod(inputMethod)) {
+			if grade.Score >= 100 {
+				break
+			}
+			if spelling == typed {
+				continue
+			}
+			spellingGrade, err := engine.GradeAnswer(ctx, challenge, spelling)
+			if err != nil {
+				return nil, nil, err
+			}
+			if spellingGrade.Score > grade.Score {
+				grade, input = spellingGrade, spelling
+			}
+		}
+	}
+
+	sessionActivity := &models.SessionActivity{
+		SessionID:  session.ID,
+		ActivityID: session.ActivityID,
+		Challenge:  challenge.Prompt,
+		Answer:     grade.Expected,
+		Input:      input,
+		Result:     grade.Result(),
+		Score:      grade.Score,
+		CreatedAt:  time.Now(),
+	}
+	if err := sessionActivity.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.sessionActivityRepo.Create(ctx, sessionActivity); err != nil {
+		return nil, nil, err
+	}
+
+	return sessionActivity, grade, nil
+}
+
+// OpenSession loads an open session of the user with its study activity, for
+// answers that need work, such as transcribing a recording, before grading
+func (s *ChallengeService) OpenSession(ctx context.Context, userID, sessionID int64) (*models.Session, *models.StudyActivity, error) {
+	session, activity, _, err := s.resolve(ctx, userID, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session.IsCompleted() {
+		return nil, nil, fmt.Errorf("session %d: %w", sessionID, models.ErrSessionEnded)
+	}
+
+	return session, activity, nil
+}
+
+// SummarizeSession aggregates the graded activities of a session
+func (s *ChallengeService) SummarizeSession(ctx context.Context, userID, sessionID int64) (*activities.Summary, error) {
+	session, _, engine, err := s.resolve(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionActivities, err := s.sessionActivityRepo.ListBySessionID(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return engine.SummarizeSession(ctx, sessionActivities)
+}
+
+// issuedChallenge retrieves a challenge issued for a session that has not
+// been answered yet
+func (s *ChallengeService) issuedChallenge(ctx context.Context, sessionID int64, challengeID string) (*activities.Challenge, error) {
+	if challengeID == "" {
+		return nil, fmt.Errorf("%w: a challenge ID is required", activities.ErrInvalidChallenge)
+	}
+
+	issued, err := s.issued.GetByID(ctx, sessionID, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if issued.AnsweredAt != nil {
+		return nil, fmt.Errorf("challenge %q: %w", challengeID, models.ErrAnswered)
+	}
+
+	return &activities.Challenge{
+		ID:      issued.ID,
+		Type:    issued.Type,
+		Prompt:  issued.Prompt,
+		Payload: issued.Payload,
+	}, nil
+}
+
+// resolve loads a session of the user with its study activity and engine
+func (s *ChallengeService) resolve(
+	ctx context.Context,
+	userID, sessionID int64,
+) (*models.Session, *models.StudyActivity, activities.ActivityEngine, error) {
+	session, err := s.sessionRepo.GetByID(ctx, userID, sessionID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	activity, err := s.activityRepo.GetByID(ctx, session.ActivityID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !activity.Enabled {
+		return nil, nil, nil, fmt.Errorf("study activity %d: %w", activity.ID, models.ErrActivityDisabled)
+	}
+
+	engine, err := s.registry.Get(activity.Type)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return session, activity, engine, nil
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// conjugationPageSize is the page size verbs are read in
+const conjugationPageSize = 500
+
+// ConjugationService conjugates Hindi verbs from their stored root
+type ConjugationService struct {
+	words repository.WordRepository
+}
+
+// NewConjugationService creates a new instance of ConjugationService
+func NewConjugationService(words repository.WordRepository) *ConjugationService {
+	return &ConjugationService{words: words}
+}
+
+// Conjugate returns the conjugations of a Hindi verb in every tense. A verb
+// without a transitivity has no past perfective, as it can't tell whether
+// the subject takes ने.
+func (s *ConjugationService) Conjugate(ctx context.Context, id int64) (*models.WordConjugations, error) {
+	word, err := s.words.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok := verbRoot(word)
+	if !ok {
+		return nil, fmt.Errorf("word %d is not a Hindi verb with a root: %w", id, models.ErrInvalidInput)
+	}
+
+	transitive := word.Transitivity == models.TransitivityTransitive ||
+		word.Transitivity == models.TransitivityDitransitive
+
+	conjugations := []models.Conjugation{}
+	for _, c := range hindi.Conjugate(root, transitive) {
+		if c.Tense == hindi.PastPerfective && word.Transitivity == "" {
+			continue
+		}
+
+		gender := models.GenderMasculine
+		if c.Feminine {
+			gender = models.GenderFeminine
+		}
+		conjugations = append(conjugations, models.Conjugation{
+			Tense:     string(c.Tense),
+			Person:    c.Subject.Person,
+			Plural:    c.Subject.Plural,
+			Gender:    gender,
+			Formality: string(c.Subject.Formality),
+			Subject:   c.SubjectForm(),
+			Ergative:  c.Ergative,
+			Form:      c.Form,
+		})
+	}
+
+	return &models.WordConjugations{Word: *word, Conjugations: conjugations}, nil
+}
+
+// ListVerbs lists the Hindi verbs that can be conjugated among the words of
+// a group, or among all words when groupID is nil
+func (s *ConjugationService) ListVerbs(ctx context.Context, groupID *int64) ([]models.Word, error) {
+	var words []models.Word
+	if groupID != nil {
+		groupWords, err := s.words.GetWordsByGroupID(ctx, *groupID)
+		if err != nil {
+			return nil, err
+		}
+		words = groupWords
+	} else {
+		for page := 1; ; page++ {
+			batch, total, err := s.words.List(ctx, repository.ListWordsParams{
+				Page:         page,
+				PageSize:     conjugationPageSize,
+				Language:     hindi.Code,
+				PartOfSpeech: models.PartOfSpeechVerb,
+			})
+			if err != nil {
+				return nil, err
+			}
+			words = append(words, batch...)
+			if len(batch) == 0 || len(words) >= total {
+				break
+			}
+		}
+	}
+
+	verbs := []models.Word{}
+	for _, word := range words {
+		if _, ok := verbRoot(&word); ok {
+			verbs = append(verbs, word)
+		}
+	}
+	return verbs, nil
+}
+
+// verbRoot is the stored root of a Hindi verb, or the root of its infinitive
+// when none is stored
+func verbRoot(word *models.Word) (string, bool) {
+	if word.PartOfSpeech != models.PartOfSpeechVerb {
+		return "", false
+	}
+	if word.Language != "" && word.Language != hindi.Code {
+		return "", false
+	}
+	if word.Root != "" {
+		return word.Root, true
+	}
+	return hindi.VerbRoot(word.Target)
+}
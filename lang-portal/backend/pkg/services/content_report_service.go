@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// contentReportPageSize is the page size words are read in
+const contentReportPageSize = 500
+
+// Lengths of a word, in syllables with a conjunct counting twice, beyond
+// which an easy word is taken for hard and a hard word for easy
+const (
+	easyWordMaxLength = 5
+	hardWordMinLength = 3
+)
+
+// ContentReportService finds problems across words and groups that editors
+// would otherwise hear about from learners
+type ContentReportService struct {
+	words     repository.WordRepository
+	languages repository.LanguageRepository
+	report    repository.ContentReportRepository
+}
+
+// NewContentReportService creates a new instance of ContentReportService
+func NewContentReportService(words repository.WordRepository, languages repository.LanguageRepository, report repository.ContentReportRepository) *ContentReportService {
+	return &ContentReportService{words: words, languages: languages, report: report}
+}
+
+// Report runs every check of the content report, or only the given check
+// when it is not empty
+func (s *ContentReportService) Report(ctx context.Context, check string) (*models.ContentReport, error) {
+	if err := models.ValidateContentCheck(check); err != nil {
+		return nil, err
+	}
+
+	var words []models.Word
+	for page := 1; ; page++ {
+		batch, total, err := s.words.List(ctx, repository.ListWordsParams{Page: page, PageSize: contentReportPageSize})
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, batch...)
+		if len(batch) == 0 || len(words) >= total {
+			break
+		}
+	}
+
+	checks := map[string]func(context.Context, []models.Word) ([]models.ContentFinding, error){
+		models.CheckMissingRomanized:   s.missingRomanized,
+		models.CheckScrambledUnchanged: s.scrambledUnchanged,
+		models.CheckScrambledBroken:    s.scrambledBroken,
+		models.CheckUngroupedWord:      s.ungroupedWords,
+		models.CheckEmptyGroup:         s.emptyGroups,
+		models.CheckInvalidNative:      s.invalidNatives,
+		models.CheckDifficultyMismatch: s.difficultyMismatches,
+	}
+
+	report := &models.ContentReport{
+		Findings:    []models.ContentFinding{},
+		Counts:      make(map[string]int, len(models.ContentChecks)),
+		GeneratedAt: time.Now(),
+	}
+	for _, name := range models.ContentChecks {
+		if check != "" && name != check {
+			continue
+		}
+		findings, err := checks[name](ctx, words)
+		if err != nil {
+			return nil, err
+		}
+		report.Findings = append(report.Findings, findings...)
+		report.Counts[name] = len(findings)
+	}
+	report.Total = len(report.Findings)
+
+	return report, nil
+}
+
+// missingRomanized finds Hindi words without a Hinglish spelling and
+// suggests the generated one
+func (s *ContentReportService) missingRomanized(_ context.Context, words []models.Word) ([]models.ContentFinding, error) {
+	var findings []models.ContentFinding
+	for _, word := range words {
+		if word.Language != hindi.Code || word.Romanized != "" {
+			continue
+		}
+		findings = append(findings, models.ContentFinding{
+			Check:     models.CheckMissingRomanized,
+			WordIDs:   []int64{word.ID},
+			Problem:   fmt.Sprintf("%s has no Hinglish spelling", word.Target),
+			Fix:       "Set the generated romanization, or run make romanize-words ROMANIZE_FLAGS=-fix",
+			Suggested: hindi.Romanize(word.Target, hindi.Hinglish),
+		})
+	}
+	return findings, nil
+}
+
+// scrambledUnchanged finds words whose scrambled form is the word itself,
+// leaving words of one syllable, which can't be scrambled
+func (s *ContentReportService) scrambledUnchanged(_ context.Context, words []models.Word) ([]models.ContentFinding, error) {
+	var findings []models.ContentFinding
+	for _, word := range words {
+		if word.Scrambled != word.Target {
+			continue
+		}
+		suggested := scrambleSuggestion(word.Target)
+		if suggested == word.Target {
+			continue
+		}
+		findings = append(findings, models.ContentFinding{
+			Check:     models.CheckScrambledUnchanged,
+			WordIDs:   []int64{word.ID},
+			Problem:   fmt.Sprintf("the scrambled form of %s is the word itself", word.Target),
+			Fix:       "Rearrange the syllables of the word",
+			Suggested: suggested,
+		})
+	}
+	return findings, nil
+}
+
+// scrambledBroken finds scrambled forms with a matra or other sign that
+// does not follow a letter, as shuffling characters rather than syllables
+// leaves them, and scrambled forms not made of the letters of the word
+func (s *ContentReportService) scrambledBroken(_ context.Context, words []models.Word) ([]models.ContentFinding, error) {
+	var findings []models.ContentFinding
+	for _, word := range words {
+		if word.Scrambled == "" || word.Scrambled == word.Target {
+			continue
+		}
+
+		var problem string
+		switch {
+		case detachedSign(word.Scrambled):
+			problem = fmt.Sprintf("the scrambled form %s of %s has a sign detached from its letter", word.Scrambled, word.Target)
+		case !sameLetters(word.Scrambled, word.Target):
+			problem = fmt.Sprintf("the scrambled form %s is not made of the letters of %s", word.Scrambled, word.Target)
+		default:
+			continue
+		}
+		findings = append(findings, models.ContentFinding{
+			Check:     models.CheckScrambledBroken,
+			WordIDs:   []int64{word.ID},
+			Problem:   problem,
+			Fix:       "Rearrange whole syllables of the word, keeping matras and conjuncts with their letters",
+			Suggested: scrambleSuggestion(word.Target),
+		})
+	}
+	return findings, nil
+}
+
+// ungroupedWords finds the words in no group
+func (s *ContentReportService) ungroupedWords(ctx context.Context, words []models.Word) ([]models.ContentFinding, error) {
+	ids, err := s.report.ListUngroupedWordIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[int64]string, len(words))
+	for _, word := range words {
+		targets[word.ID] = word.Target
+	}
+
+	findings := make([]models.ContentFinding, 0, len(ids))
+	for _, id := range ids {
+		findings = append(findings, models.ContentFinding{
+			Check:   models.CheckUngroupedWord,
+			WordIDs: []int64{id},
+			Problem: fmt.Sprintf("%s is in no group, so no activity shows it", targets[id]),
+			Fix:     "Add the word to a group, or delete it",
+		})
+	}
+	return findings, nil
+}
+
+// emptyGroups finds the groups without words
+func (s *ContentReportService) emptyGroups(ctx context.Context, _ []models.Word) ([]models.ContentFinding, error) {
+	groups, err := s.report.ListEmptyGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]models.ContentFinding, 0, len(groups))
+	for _, group := range groups {
+		findings = append(findings, models.ContentFinding{
+			Check:    models.CheckEmptyGroup,
+			GroupIDs: []int64{group.ID},
+			Problem:  fmt.Sprintf("group %s has no words, so sessions of it have nothing to practice", group.Name),
+			Fix:      "Add words to the group, or delete it",
+		})
+	}
+	return findings, nil
+}
+
+// invalidNatives finds translations the script rule of their language
+// rejects and suggests them with the other characters written as spaces
+func (s *ContentReportService) invalidNatives(ctx context.Context, words []models.Word) ([]models.ContentFinding, error) {
+	languages, err := s.languages.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scripts := make(map[string]models.Script, len(languages))
+	for _, language := range languages {
+		scripts[language.Code] = language.Script
+	}
+
+	var findings []models.ContentFinding
+	for _, word := range words {
+		script, ok := scripts[word.NativeLanguage]
+		if !ok || script.Contains(word.Native) {
+			continue
+		}
+
+		suggested := strings.Join(strings.FieldsFunc(word.Native, func(r rune) bool {
+			return !script.Contains(string(r))
+		}), " ")
+		fix := fmt.Sprintf("Write the translation in %s letters and spaces, the word can't be updated until then", script.Name())
+		if suggested == "" {
+			fix = fmt.Sprintf("Translate the word in %s letters", script.Name())
+		}
+		findings = append(findings, models.ContentFinding{
+			Check:     models.CheckInvalidNative,
+			WordIDs:   []int64{word.ID},
+			Problem:   fmt.Sprintf("the translation %q of %s has characters other than %s letters", word.Native, word.Target, script.Name()),
+			Fix:       fix,
+			Suggested: suggested,
+		})
+	}
+	return findings, nil
+}
+
+// difficultyMismatches finds words entered more than once with different
+// difficulties, suggesting the most common one, and words whose difficulty
+// is far from their length: easy words longer than easyWordMaxLength and
+// hard words shorter than hardWordMinLength
+func (s *ContentReportService) difficultyMismatches(ctx context.Context, words []models.Word) ([]models.ContentFinding, error) {
+	difficulties, err := s.report.ListDifficulties(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []models.ContentFinding
+	entries := make(map[string][]models.Word)
+	var keys []string
+	for _, word := range words {
+		if difficulties[word.ID] == "" {
+			continue
+		}
+		key := word.Language + "|" + word.NativeLanguage + "|" + word.Target
+		if _, ok := entries[key]; !ok {
+			keys = append(keys, key)
+		}
+		entries[key] = append(entries[key], word)
+	}
+
+	for _, key := range keys {
+		entry := entries[key]
+		counts := make(map[string]int)
+		for _, word := range entry {
+			counts[difficulties[word.ID]]++
+		}
+		if len(counts) < 2 {
+			continue
+		}
+
+		var ids []int64
+		var levels []string
+		common := difficulties[entry[0].ID]
+		for _, word := range entry {
+			ids = append(ids, word.ID)
+			levels = append(levels, difficulties[word.ID])
+			if counts[difficulties[word.ID]] > counts[common] {
+				common = difficulties[word.ID]
+			}
+		}
+		findings = append(findings, models.ContentFinding{
+			Check:     models.CheckDifficultyMismatch,
+			WordIDs:   ids,
+			Problem:   fmt.Sprintf("%s is entered %d times as %s", entry[0].Target, len(entry), strings.Join(levels, ", ")),
+			Fix:       "Give every entry the same difficulty, or merge the duplicates",
+			Suggested: common,
+		})
+	}
+
+	for _, word := range words {
+		difficulty := difficulties[word.ID]
+		length := wordLength(word.Target)
+		var problem string
+		switch {
+		case difficulty == models.DifficultyEasy && length > easyWordMaxLength:
+			problem = fmt.Sprintf("%s is marked easy but is %d syllables long", word.Target, length)
+		case difficulty == models.DifficultyHard && length < hardWordMinLength:
+			problem = fmt.Sprintf("%s is marked hard but is %d syllables long", word.Target, length)
+		default:
+			continue
+		}
+		findings = append(findings, models.ContentFinding{
+			Check:     models.CheckDifficultyMismatch,
+			WordIDs:   []int64{word.ID},
+			Problem:   problem,
+			Fix:       "Check the difficulty of the word",
+			Suggested: models.DifficultyMedium,
+		})
+	}
+
+	return findings, nil
+}
+
+// wordLength measures the longest word of text in syllables, counting a
+// conjunct twice as it is harder to read
+func wordLength(text string) int {
+	longest := 0
+	for _, word := range strings.Fields(text) {
+		length := 0
+		for _, akshara := range devanagari.Aksharas(word) {
+			length++
+			if strings.ContainsRune(akshara, devanagari.Virama) {
+				length++
+			}
+		}
+		longest = max(longest, length)
+	}
+	return longest
+}
+
+// detachedSign reports whether a syllable of text starts with a combining
+// sign, a matra, virama, nukta or nasal sign with no letter to attach to
+func detachedSign(text string) bool {
+	for _, akshara := range devanagari.Aksharas(text) {
+		first := []rune(akshara)[0]
+		if devanagari.IsMark(first) && first != devanagari.ZWJ && first != devanagari.ZWNJ {
+			return true
+		}
+	}
+	return false
+}
+
+// sameLetters reports whether a and b are made of the same characters,
+// ignoring spaces
+func sameLetters(a, b string) bool {
+	letters := func(text string) []rune {
+		runes := []rune(strings.Join(strings.Fields(text), ""))
+		slices.Sort(runes)
+		return runes
+	}
+	return slices.Equal(letters(a), letters(b))
+}
+
+// scrambleSuggestion rearranges the syllables of a word by moving the
+// first to the end, which keeps every matra and conjunct whole. A word of
+// one syllable is returned as it is.
+func scrambleSuggestion(target string) string {
+	aksharas := devanagari.Aksharas(strings.Join(strings.Fields(target), ""))
+	if len(aksharas) < 2 {
+		return target
+	}
+	return strings.Join(append(aksharas[1:], aksharas[0]), "")
+}
@@ -0,0 +1,621 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/ai"
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/prompts"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// Limits on the number of items proposed per request
+const (
+	defaultProposals = 5
+	maxProposals     = 20
+)
+
+// maxHintLength limits a hint in characters
+const maxHintLength = 200
+
+// ContentService generates words, example sentences and hints with a
+// language model. Generated content is kept as drafts until an editor
+// approves it, only then it becomes part of the portal.
+type ContentService struct {
+	provider  ai.LLMProvider
+	drafts    repository.DraftRepository
+	hints     repository.WordHintRepository
+	languages repository.LanguageRepository
+	words     *WordService
+	sentences *SentenceService
+	groups    *GroupService
+}
+
+// NewContentService creates a new instance of ContentService, provider may be
+// nil when no language model is configured
+func NewContentService(
+	provider ai.LLMProvider,
+	drafts repository.DraftRepository,
+	hints repository.WordHintRepository,
+	languages repository.LanguageRepository,
+	words *WordService,
+	sentences *SentenceService,
+	groups *GroupService,
+) *ContentService {
+	return &ContentService{
+		provider:  provider,
+		drafts:    drafts,
+		hints:     hints,
+		languages: languages,
+		words:     words,
+		sentences: sentences,
+		groups:    groups,
+	}
+}
+
+// ProposeWordsRequest asks for words for a group
+type ProposeWordsRequest struct {
+	GroupID        int64  `json:"group_id"`
+	Theme          string `json:"theme"` // defaults to the group's name and description
+	Language       string `json:"language"`
+	NativeLanguage string `json:"native_language"`
+	Count          int    `json:"count"`
+}
+
+// ProposeRequest asks for sentences or hints for a word
+type ProposeRequest struct {
+	WordID int64 `json:"word_id"`
+	Count  int   `json:"count"`
+}
+
+// pairContent is the content of word and sentence drafts, the fields a
+// language model fills in
+type pairContent struct {
+	Language       string `json:"language"`
+	NativeLanguage string `json:"native_language"`
+	Target         string `json:"target"`
+	Romanized      string `json:"romanized"`
+	Native         string `json:"native"`
+}
+
+// hintContent is the content of hint drafts
+type hintContent struct {
+	Text string `json:"text"`
+}
+
+// promptData fills in the prompt templates
+type promptData struct {
+	Language       string
+	NativeLanguage string
+	Script         string
+	Count          int
+	Theme          string
+	Existing       []string
+	Word           *models.Word
+}
+
+// ProposeWords asks the model for new words fitting a group's theme and
+// stores the valid ones as drafts
+func (s *ContentService) ProposeWords(ctx context.Context, userID int64, req ProposeWordsRequest) ([]models.Draft, error) {
+	count, err := proposalCount(req.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.groups.GetGroupByID(ctx, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	theme := strings.TrimSpace(req.Theme)
+	if theme == "" {
+		theme = group.Name
+		if group.Description != "" {
+			theme += ": " + group.Description
+		}
+	}
+
+	language, native := req.Language, req.NativeLanguage
+	if language == "" {
+		language = models.DefaultLanguage
+	}
+	if native == "" {
+		native = models.DefaultNativeLanguage
+	}
+	data, err := s.promptData(ctx, language, native, count)
+	if err != nil {
+		return nil, err
+	}
+	data.Theme = theme
+
+	// Words already in the group are named in the prompt and never proposed
+	groupWords, err := s.words.GetWordsByGroupID(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, word := range groupWords {
+		if word.Language == language {
+			data.Existing = append(data.Existing, word.Target)
+			seen[word.Target] = true
+		}
+	}
+
+	var out struct {
+		Words []pairContent `json:"words"`
+	}
+	if err := s.complete(ctx, prompts.Words, data, &out); err != nil {
+		return nil, err
+	}
+
+	var proposals []models.Draft
+	for _, content := range out.Words {
+		content.Language, content.NativeLanguage = language, native
+		word := content.word()
+		if err := s.words.ValidateWord(ctx, word); err != nil {
+			log.Printf("Dropping proposed word %q: %v", content.Target, err)
+			continue
+		}
+		if seen[word.Target] {
+			continue
+		}
+		seen[word.Target] = true
+
+		exists, err := s.wordExists(ctx, word)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+
+		proposals = append(proposals, models.Draft{
+			Kind:    models.DraftWord,
+			Content: mustMarshal(pairFromWord(word)),
+			GroupID: &group.ID,
+		})
+	}
+
+	return s.store(ctx, userID, proposals, "words")
+}
+
+// ProposeSentences asks the model for example sentences using a word and
+// stores the ones that do as drafts
+func (s *ContentService) ProposeSentences(ctx context.Context, userID int64, req ProposeRequest) ([]models.Draft, error) {
+	count, err := proposalCount(req.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	word, err := s.words.GetWordByID(ctx, req.WordID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.promptData(ctx, word.Language, word.NativeLanguage, count)
+	if err != nil {
+		return nil, err
+	}
+	data.Word = word
+
+	var out struct {
+		Sentences []pairContent `json:"sentences"`
+	}
+	if err := s.complete(ctx, prompts.Sentences, data, &out); err != nil {
+		return nil, err
+	}
+
+	var proposals []models.Draft
+	for _, content := range out.Sentences {
+		content.Language, content.NativeLanguage = word.Language, word.NativeLanguage
+		sentence := content.sentence(word.ID)
+		if err := s.sentences.ValidateSentence(ctx, sentence); err != nil {
+			log.Printf("Dropping proposed sentence %q: %v", content.Target, err)
+			continue
+		}
+		if !usesWord(sentence.Target, word) {
+			log.Printf("Dropping proposed sentence %q: it does not use %q", sentence.Target, word.Target)
+			continue
+		}
+
+		proposals = append(proposals, models.Draft{
+			Kind:    models.DraftSentence,
+			Content: mustMarshal(pairFromSentence(sentence)),
+			WordID:  &word.ID,
+		})
+	}
+
+	return s.store(ctx, userID, proposals, "sentences")
+}
+
+// ProposeHints asks the model for hints to recall a word and stores the ones
+// that do not give the word away as drafts
+func (s *ContentService) ProposeHints(ctx context.Context, userID int64, req ProposeRequest) ([]models.Draft, error) {
+	count, err := proposalCount(req.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	word, err := s.words.GetWordByID(ctx, req.WordID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.promptData(ctx, word.Language, word.NativeLanguage, count)
+	if err != nil {
+		return nil, err
+	}
+	data.Word = word
+
+	var out struct {
+		Hints []string `json:"hints"`
+	}
+	if err := s.complete(ctx, prompts.Hints, data, &out); err != nil {
+		return nil, err
+	}
+
+	var proposals []models.Draft
+	for _, text := range out.Hints {
+		text = strings.TrimSpace(text)
+		if text == "" || utf8.RuneCountInString(text) > maxHintLength || revealsWord(text, word) {
+			log.Printf("Dropping proposed hint %q for word %d", text, word.ID)
+			continue
+		}
+
+		proposals = append(proposals, models.Draft{
+			Kind:    models.DraftHint,
+			Content: mustMarshal(hintContent{Text: text}),
+			WordID:  &word.ID,
+		})
+	}
+
+	return s.store(ctx, userID, proposals, "hints")
+}
+
+// GetDraft retrieves a draft by its ID
+func (s *ContentService) GetDraft(ctx context.Context, id int64) (*models.Draft, error) {
+	return s.drafts.GetByID(ctx, id)
+}
+
+// ListDrafts retrieves drafts, newest first
+func (s *ContentService) ListDrafts(ctx context.Context, params repository.ListDraftsParams) ([]models.Draft, error) {
+	if params.Status != "" && !params.Status.Valid() {
+		return nil, fmt.Errorf("unknown draft status %q: %w", params.Status, models.ErrInvalidInput)
+	}
+	return s.drafts.List(ctx, params)
+}
+
+// ApproveDraft adds a pending draft to the portal, with the content edited by
+// the editor if given. Words join the group they were proposed for,
+// sentences are linked to their word and hints are added to their word.
+func (s *ContentService) ApproveDraft(ctx context.Context, userID, id int64, edited json.RawMessage) (*models.Draft, error) {
+	draft, err := s.pendingDraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(edited) > 0 {
+		draft.Content = edited
+	}
+
+	var resultID int64
+	switch draft.Kind {
+	case models.DraftWord:
+		resultID, err = s.approveWord(ctx, draft)
+	case models.DraftSentence:
+		resultID, err = s.approveSentence(ctx, draft)
+	case models.DraftHint:
+		resultID, err = s.approveHint(ctx, draft)
+	default:
+		err = fmt.Errorf("unknown draft kind %q", draft.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	draft.Status = models.DraftApproved
+	draft.ResultID = &resultID
+	draft.ReviewedBy = &userID
+	if err := s.drafts.Review(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// RejectDraft turns down a pending draft
+func (s *ContentService) RejectDraft(ctx context.Context, userID, id int64) (*models.Draft, error) {
+	draft, err := s.pendingDraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	draft.Status = models.DraftRejected
+	draft.ReviewedBy = &userID
+	if err := s.drafts.Review(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// ListWordHints retrieves the hints of a word
+func (s *ContentService) ListWordHints(ctx context.Context, wordID int64) ([]models.WordHint, error) {
+	if _, err := s.words.GetWordByID(ctx, wordID); err != nil {
+		return nil, err
+	}
+	return s.hints.ListByWordID(ctx, wordID)
+}
+
+// DeleteWordHint removes a hint of a word
+func (s *ContentService) DeleteWordHint(ctx context.Context, wordID, id int64) error {
+	return s.hints.Delete(ctx, wordID, id)
+}
+
+func (s *ContentService) approveWord(ctx context.Context, draft *models.Draft) (int64, error) {
+	var content pairContent
+	if err := decodeContent(draft, &content); err != nil {
+		return 0, err
+	}
+
+	word := content.word()
+	if err := s.words.ValidateWord(ctx, word); err != nil {
+		return 0, invalidContent(err)
+	}
+	if err := s.words.CreateWord(ctx, word); err != nil {
+		return 0, err
+	}
+	draft.Content = mustMarshal(pairFromWord(word))
+
+	if draft.GroupID != nil {
+		if err := s.groups.AddWordToGroup(ctx, *draft.GroupID, word.ID); err != nil {
+			return 0, err
+		}
+	}
+	return word.ID, nil
+}
+
+func (s *ContentService) approveSentence(ctx context.Context, draft *models.Draft) (int64, error) {
+	if draft.WordID == nil {
+		return 0, fmt.Errorf("the word of draft %d was deleted: %w", draft.ID, models.ErrInvalidInput)
+	}
+	word, err := s.words.GetWordByID(ctx, *draft.WordID)
+	if err != nil {
+		return 0, err
+	}
+
+	var content pairContent
+	if err := decodeContent(draft, &content); err != nil {
+		return 0, err
+	}
+
+	sentence := content.sentence(word.ID)
+	if err := s.sentences.ValidateSentence(ctx, sentence); err != nil {
+		return 0, invalidContent(err)
+	}
+	if !usesWord(sentence.Target, word) {
+		return 0, fmt.Errorf("sentence does not use %q: %w", word.Target, models.ErrInvalidInput)
+	}
+	if err := s.sentences.CreateSentence(ctx, sentence); err != nil {
+		return 0, err
+	}
+	draft.Content = mustMarshal(pairFromSentence(sentence))
+
+	return sentence.ID, nil
+}
+
+func (s *ContentService) approveHint(ctx context.Context, draft *models.Draft) (int64, error) {
+	if draft.WordID == nil {
+		return 0, fmt.Errorf("the word of draft %d was deleted: %w", draft.ID, models.ErrInvalidInput)
+	}
+	if _, err := s.words.GetWordByID(ctx, *draft.WordID); err != nil {
+		return 0, err
+	}
+
+	var content hintContent
+	if err := decodeContent(draft, &content); err != nil {
+		return 0, err
+	}
+
+	hint := &models.WordHint{WordID: *draft.WordID, Text: strings.TrimSpace(content.Text)}
+	if hint.Text == "" || utf8.RuneCountInString(hint.Text) > maxHintLength {
+		return 0, fmt.Errorf("hints must have 1 to %d characters: %w", maxHintLength, models.ErrInvalidInput)
+	}
+	if err := s.hints.Create(ctx, hint); err != nil {
+		return 0, err
+	}
+	draft.Content = mustMarshal(hintContent{Text: hint.Text})
+
+	return hint.ID, nil
+}
+
+// pendingDraft retrieves a draft that has not been reviewed yet
+func (s *ContentService) pendingDraft(ctx context.Context, id int64) (*models.Draft, error) {
+	draft, err := s.drafts.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != models.DraftPending {
+		return nil, fmt.Errorf("draft %d is already %s: %w", id, draft.Status, models.ErrInvalidInput)
+	}
+	return draft, nil
+}
+
+// promptData looks up the names of a language pair for the prompts
+func (s *ContentService) promptData(ctx context.Context, language, native string, count int) (*promptData, error) {
+	target, err := lookupLanguage(ctx, s.languages, language)
+	if err != nil {
+		return nil, err
+	}
+	nativeLanguage, err := lookupLanguage(ctx, s.languages, native)
+	if err != nil {
+		return nil, err
+	}
+
+	return &promptData{
+		Language:       target.Name,
+		NativeLanguage: nativeLanguage.Name,
+		Script:         target.Script.Name(),
+		Count:          count,
+	}, nil
+}
+
+// complete renders the system prompt and the named prompt and decodes the
+// model's JSON answer into out
+func (s *ContentService) complete(ctx context.Context, prompt string, data *promptData, out interface{}) error {
+	if s.provider == nil {
+		return fmt.Errorf("content generation: %w", models.ErrNotConfigured)
+	}
+
+	system, err := prompts.Render(prompts.System, data)
+	if err != nil {
+		return err
+	}
+	user, err := prompts.Render(prompt, data)
+	if err != nil {
+		return err
+	}
+
+	return ai.CompleteJSON(ctx, s.provider, []ai.Message{
+		{Role: ai.RoleSystem, Content: system},
+		{Role: ai.RoleUser, Content: user},
+	}, out)
+}
+
+// store saves the proposals as pending drafts of the user
+func (s *ContentService) store(ctx context.Context, userID int64, proposals []models.Draft, what string) ([]models.Draft, error) {
+	if len(proposals) == 0 {
+		return nil, fmt.Errorf("%w: no usable %s in the answer", ai.ErrInvalidOutput, what)
+	}
+
+	for i := range proposals {
+		proposals[i].Model = s.provider.Model()
+		if userID > 0 {
+			proposals[i].CreatedBy = &userID
+		}
+		if err := s.drafts.Create(ctx, &proposals[i]); err != nil {
+			return nil, err
+		}
+	}
+	return proposals, nil
+}
+
+// wordExists reports whether the vocabulary already has the word
+func (s *ContentService) wordExists(ctx context.Context, word *models.Word) (bool, error) {
+	matches, _, err := s.words.SearchWords(ctx, word.Target, "target")
+	if err != nil {
+		return false, err
+	}
+	for _, match := range matches {
+		if match.Language == word.Language && match.Target == word.Target {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// proposalCount applies the default and the limit to a requested count
+func proposalCount(count int) (int, error) {
+	if count == 0 {
+		return defaultProposals, nil
+	}
+	if count < 0 || count > maxProposals {
+		return 0, fmt.Errorf("count must be between 1 and %d: %w", maxProposals, models.ErrInvalidInput)
+	}
+	return count, nil
+}
+
+// usesWord reports whether a sentence contains the word, in Hindi also in an
+// inflected form or with a joined postposition
+func usesWord(text string, word *models.Word) bool {
+	tokens := models.SentenceWords(strings.ToLower(text))
+	target := strings.ToLower(word.Target)
+	if strings.Contains(" "+strings.Join(tokens, " ")+" ", " "+target+" ") {
+		return true
+	}
+
+	if word.Language == hindi.Code {
+		for _, token := range tokens {
+			if _, _, ok := hindi.SplitPostposition(token, word.Target); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// revealsWord reports whether a hint gives the word or its romanization away
+func revealsWord(hint string, word *models.Word) bool {
+	hint = strings.ToLower(hint)
+	if strings.Contains(hint, strings.ToLower(word.Target)) {
+		return true
+	}
+	return word.Romanized != "" && strings.Contains(hint, strings.ToLower(word.Romanized))
+}
+
+// decodeContent decodes the content of a draft
+func decodeContent(draft *models.Draft, content interface{}) error {
+	if err := json.Unmarshal(draft.Content, content); err != nil {
+		return fmt.Errorf("invalid %s draft content: %w", draft.Kind, models.ErrInvalidInput)
+	}
+	return nil
+}
+
+// invalidContent marks a validation failure of draft content as invalid input
+func invalidContent(err error) error {
+	if errors.Is(err, models.ErrInvalidInput) || errors.Is(err, models.ErrInvalidLanguage) || errors.Is(err, models.ErrInvalidScript) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", models.ErrInvalidInput, err)
+}
+
+// mustMarshal encodes draft content, which only holds strings and cannot fail
+func mustMarshal(content interface{}) json.RawMessage {
+	data, err := json.Marshal(content)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func (c pairContent) word() *models.Word {
+	return &models.Word{
+		Language:       c.Language,
+		NativeLanguage: c.NativeLanguage,
+		Target:         c.Target,
+		Romanized:      c.Romanized,
+		Native:         c.Native,
+	}
+}
+
+func (c pairContent) sentence(wordID int64) *models.Sentence {
+	return &models.Sentence{
+		Language:       c.Language,
+		NativeLanguage: c.NativeLanguage,
+		Target:         c.Target,
+		Romanized:      c.Romanized,
+		Native:         c.Native,
+		WordIDs:        []int64{wordID},
+	}
+}
+
+func pairFromWord(word *models.Word) pairContent {
+	return pairContent{
+		Language:       word.Language,
+		NativeLanguage: word.NativeLanguage,
+		Target:         word.Target,
+		Romanized:      word.Romanized,
+		Native:         word.Native,
+	}
+}
+
+func pairFromSentence(sentence *models.Sentence) pairContent {
+	return pairContent{
+		Language:       sentence.Language,
+		NativeLanguage: sentence.NativeLanguage,
+		Target:         sentence.Target,
+		Romanized:      sentence.Romanized,
+		Native:         sentence.Native,
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// duplicatePageSize is the page size words are read in
+const duplicatePageSize = 500
+
+// translationPrefixes are dropped from the start of translations, so "a
+// book" and "book" or "to go" and "go" are the same translation
+var translationPrefixes = []string{"a ", "an ", "the ", "to "}
+
+// MergeWordsRequest names the words to merge into the word kept
+type MergeWordsRequest struct {
+	WordIDs []int64 `json:"word_ids"`
+}
+
+// DuplicateService finds words entered more than once and merges them
+type DuplicateService struct {
+	words  repository.WordRepository
+	merges repository.WordMergeRepository
+}
+
+// NewDuplicateService creates a new instance of DuplicateService
+func NewDuplicateService(words repository.WordRepository, merges repository.WordMergeRepository) *DuplicateService {
+	return &DuplicateService{words: words, merges: merges}
+}
+
+// FindDuplicates clusters the words that are likely the same word, among
+// the words of one language or of all languages when language is empty.
+// Only words of the same language pair are compared.
+func (s *DuplicateService) FindDuplicates(ctx context.Context, language string) ([]models.DuplicateCluster, error) {
+	var words []models.Word
+	for page := 1; ; page++ {
+		batch, total, err := s.words.List(ctx, repository.ListWordsParams{
+			Page:     page,
+			PageSize: duplicatePageSize,
+			Language: language,
+		})
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, batch...)
+		if len(batch) == 0 || len(words) >= total {
+			break
+		}
+	}
+	return duplicateClusters(words), nil
+}
+
+// Merge merges the words of the request into the word kept, recording who
+// merged them when userID is not 0
+func (s *DuplicateService) Merge(ctx context.Context, userID, keptID int64, req MergeWordsRequest) ([]models.WordMerge, error) {
+	var merged []int64
+	for _, id := range req.WordIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("invalid word ID %d: %w", id, models.ErrInvalidInput)
+		}
+		if !slices.Contains(merged, id) {
+			merged = append(merged, id)
+		}
+	}
+
+	var mergedBy *int64
+	if userID != 0 {
+		mergedBy = &userID
+	}
+	return s.merges.Merge(ctx, keptID, merged, mergedBy)
+}
+
+// ListMerges retrieves the recorded merges, newest first
+func (s *DuplicateService) ListMerges(ctx context.Context) ([]models.WordMerge, error) {
+	return s.merges.List(ctx)
+}
+
+// duplicateClusters groups words into clusters of likely duplicates. Two
+// words are matched when their target forms are the same once normalized,
+// or when they share a translation and their target forms are one letter
+// apart or romanized alike. Matches are transitive.
+func duplicateClusters(words []models.Word) []models.DuplicateCluster {
+	parent := make([]int, len(words))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	reasons := make(map[int][]string)
+	match := func(i, j int, reason string) {
+		ri, rj := find(i), find(j)
+		if ri != rj {
+			parent[rj] = ri
+			reasons[ri] = append(reasons[ri], reasons[rj]...)
+			delete(reasons, rj)
+		}
+		if !slices.Contains(reasons[ri], reason) {
+			reasons[ri] = append(reasons[ri], reason)
+		}
+	}
+
+	// Words are only compared within buckets of the same language pair and
+	// key, so large vocabularies are not compared pairwise
+	byTarget := make(map[string][]int)
+	byTranslation := make(map[string][]int)
+	for i, word := range words {
+		pair := word.Language + "|" + word.NativeLanguage + "|"
+		if key := targetKey(word.Target); key != "" {
+			byTarget[pair+key] = append(byTarget[pair+key], i)
+		}
+		if key := translationKey(word.Native); key != "" {
+			byTranslation[pair+key] = append(byTranslation[pair+key], i)
+		}
+	}
+
+	for _, bucket := range byTarget {
+		for _, i := range bucket[1:] {
+			match(bucket[0], i, models.DuplicateSameTarget)
+		}
+	}
+	for _, bucket := range byTranslation {
+		for a, i := range bucket {
+			for _, j := range bucket[a+1:] {
+				first, second := words[i], words[j]
+				if targetKey(first.Target) != targetKey(second.Target) &&
+					devanagari.Distance(first.Target, second.Target) <= 1 {
+					match(i, j, models.DuplicateSimilarTarget)
+				}
+				if key := romanizedKey(first.Romanized); key != "" && key == romanizedKey(second.Romanized) {
+					match(i, j, models.DuplicateSameRomanized)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]models.Word)
+	for i, word := range words {
+		root := find(i)
+		members[root] = append(members[root], word)
+	}
+
+	clusters := []models.DuplicateCluster{}
+	for root, cluster := range members {
+		if len(cluster) < 2 {
+			continue
+		}
+		slices.SortFunc(cluster, func(a, b models.Word) int { return cmp.Compare(a.ID, b.ID) })
+		clusterReasons := reasons[root]
+		slices.Sort(clusterReasons)
+		clusters = append(clusters, models.DuplicateCluster{
+			Language: cluster[0].Language,
+			Reasons:  clusterReasons,
+			Words:    cluster,
+		})
+	}
+	slices.SortFunc(clusters, func(a, b models.DuplicateCluster) int {
+		return cmp.Compare(a.Words[0].ID, b.Words[0].ID)
+	})
+	return clusters
+}
+
+// targetKey normalizes a target form for matching: folded as it sounds and
+// without nuktas, which are often left out when typing
+func targetKey(target string) string {
+	return strings.ReplaceAll(devanagari.Fold(models.CanonicalText(target)), string(devanagari.Nukta), "")
+}
+
+// translationKey normalizes a translation for matching: lowercased, without
+// punctuation or a leading translation prefix
+func translationKey(native string) string {
+	key := strings.Join(strings.FieldsFunc(strings.ToLower(native), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}), " ")
+	for _, prefix := range translationPrefixes {
+		if trimmed := strings.TrimPrefix(key, prefix); trimmed != key {
+			return trimmed
+		}
+	}
+	return key
+}
+
+// romanizedKey normalizes a romanization for matching: letters only,
+// lowercased, with doubled letters squeezed and w read as v, so kamra and
+// kamraa or pawan and pavan match
+func romanizedKey(romanized string) string {
+	var b strings.Builder
+	var previous rune
+	for _, r := range strings.ToLower(romanized) {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		if r == 'w' {
+			r = 'v'
+		}
+		if r != previous {
+			b.WriteRune(r)
+		}
+		previous = r
+	}
+	return b.String()
+}
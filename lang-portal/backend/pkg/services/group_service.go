@@ -103,3 +103,21 @@ func (s *GroupService) GetGroups(ctx context.Context, page, pageSize int) ([]*mo
 
 	return groupPtrs, int64(totalCount), nil
 }
+
+// AddWordToGroup adds a word to a group
+func (s *GroupService) AddWordToGroup(ctx context.Context, groupID, wordID int64) error {
+	if groupID <= 0 || wordID <= 0 {
+		return models.ErrInvalidID
+	}
+
+	return s.groupRepo.AddWord(ctx, groupID, wordID)
+}
+
+// RemoveWordFromGroup removes a word from a group
+func (s *GroupService) RemoveWordFromGroup(ctx context.Context, groupID, wordID int64) error {
+	if groupID <= 0 || wordID <= 0 {
+		return models.ErrInvalidID
+	}
+
+	return s.groupRepo.RemoveWord(ctx, groupID, wordID)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// InputService converts text typed with an input method to Devanagari, for
+// learners without a Devanagari keyboard
+type InputService struct{}
+
+// NewInputService creates a new instance of InputService
+func NewInputService() *InputService {
+	return &InputService{}
+}
+
+// Convert converts text typed with an input method, the phonetic one when
+// method is empty
+func (s *InputService) Convert(text, method string) (string, error) {
+	if method == "" {
+		method = string(devanagari.Phonetic)
+	}
+	if !devanagari.IsInputMethod(method) {
+		return "", fmt.Errorf("unknown input method %q: %w", method, models.ErrInvalidInput)
+	}
+	return devanagari.Convert(text, devanagari.InputMethod(method)), nil
+}
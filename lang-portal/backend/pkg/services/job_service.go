@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// JobRunner does the work of one kind of background job
+type JobRunner interface {
+	// Kind returns the kind of jobs the runner does
+	Kind() string
+
+	// Backlog lists the subjects that need a job
+	Backlog(ctx context.Context) ([]int64, error)
+
+	// Run does the work of a job on a subject
+	Run(ctx context.Context, subjectID int64) error
+}
+
+// JobRun counts the jobs processed by a run
+type JobRun struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// JobService queues background jobs and runs them one kind at a time. Jobs
+// are kept in the jobs table, so failed ones can be inspected and retried.
+type JobService struct {
+	repo    repository.JobRepository
+	runners map[string]JobRunner
+
+	mu      sync.Mutex
+	active  map[string]bool // kinds with a run in progress
+	rerun   map[string]bool // kinds queued to while their run was in progress
+	running sync.WaitGroup
+}
+
+// NewJobService creates a new instance of JobService with a runner for each
+// kind of job it can run
+func NewJobService(repo repository.JobRepository, runners ...JobRunner) *JobService {
+	s := &JobService{
+		repo:    repo,
+		runners: make(map[string]JobRunner, len(runners)),
+		active:  make(map[string]bool),
+		rerun:   make(map[string]bool),
+	}
+	for _, runner := range runners {
+		s.runners[runner.Kind()] = runner
+	}
+	return s
+}
+
+// EnqueueBacklog queues a job for every subject of the kind that needs one,
+// returning the number of jobs queued
+func (s *JobService) EnqueueBacklog(ctx context.Context, kind string) (int, error) {
+	runner, err := s.runner(kind)
+	if err != nil {
+		return 0, err
+	}
+
+	subjectIDs, err := runner.Backlog(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, id := range subjectIDs {
+		ok, err := s.repo.Enqueue(ctx, kind, id)
+		if err != nil {
+			return queued, err
+		}
+		if ok {
+			queued++
+		}
+	}
+
+	return queued, nil
+}
+
+// RunPending runs the pending jobs of a kind until none is left. Only one
+// run of a kind is active at a time, a second run returns at once and leaves
+// the jobs to the active one.
+func (s *JobService) RunPending(ctx context.Context, kind string) (*JobRun, error) {
+	runner, err := s.runner(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &JobRun{}
+	if !s.begin(kind) {
+		return run, nil
+	}
+
+	// Jobs left running belong to a run that was interrupted by a restart
+	if _, err := s.repo.ResetRunning(ctx, kind); err != nil {
+		s.stop(kind)
+		return nil, err
+	}
+
+	for {
+		job, err := s.repo.Claim(ctx, kind)
+		if errors.Is(err, models.ErrNotFound) {
+			if s.end(kind) {
+				return run, nil
+			}
+			continue
+		}
+		if err != nil {
+			s.stop(kind)
+			return run, err
+		}
+
+		if err := runner.Run(ctx, job.SubjectID); err != nil {
+			log.Printf("%s job %d failed: %v", kind, job.ID, err)
+			run.Failed++
+			err = s.repo.Fail(ctx, job.ID, err.Error())
+		} else {
+			run.Succeeded++
+			err = s.repo.Succeed(ctx, job.ID)
+		}
+		if err != nil {
+			s.stop(kind)
+			return run, err
+		}
+	}
+}
+
+// Start runs the pending jobs of a kind in the background
+func (s *JobService) Start(kind string) {
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		run, err := s.RunPending(context.Background(), kind)
+		if err != nil {
+			log.Printf("Failed to run %s jobs: %v", kind, err)
+			return
+		}
+		if run.Succeeded+run.Failed > 0 {
+			log.Printf("Ran %s jobs: %d succeeded, %d failed", kind, run.Succeeded, run.Failed)
+		}
+	}()
+}
+
+// Wait blocks until the runs started in the background have finished
+func (s *JobService) Wait() {
+	s.running.Wait()
+}
+
+// Retry queues a failed job again and starts running it
+func (s *JobService) Retry(ctx context.Context, id int64) (*models.Job, error) {
+	job, err := s.repo.Retry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.runners[job.Kind]; ok {
+		s.Start(job.Kind)
+	}
+	return job, nil
+}
+
+// GetJob retrieves a job by its ID
+func (s *JobService) GetJob(ctx context.Context, id int64) (*models.Job, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListJobs retrieves jobs, newest first
+func (s *JobService) ListJobs(ctx context.Context, params repository.ListJobsParams) ([]models.Job, error) {
+	if params.Status != "" && !params.Status.Valid() {
+		return nil, fmt.Errorf("unknown job status %q: %w", params.Status, models.ErrInvalidInput)
+	}
+	return s.repo.List(ctx, params)
+}
+
+// runner returns the runner of a kind of job
+func (s *JobService) runner(kind string) (JobRunner, error) {
+	runner, ok := s.runners[kind]
+	if !ok {
+		return nil, fmt.Errorf("%s jobs: %w", kind, models.ErrNotConfigured)
+	}
+	return runner, nil
+}
+
+// begin marks a run of the kind as active, reporting false if one already is
+func (s *JobService) begin(kind string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.active[kind] {
+		s.rerun[kind] = true
+		return false
+	}
+	s.active[kind] = true
+	return true
+}
+
+// end marks the run of the kind as finished, reporting false instead if
+// another run was asked for meanwhile and the jobs must be claimed again
+func (s *JobService) end(kind string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rerun[kind] {
+		s.rerun[kind] = false
+		return false
+	}
+	s.active[kind] = false
+	return true
+}
+
+// stop marks the run of the kind as finished after an error
+func (s *JobService) stop(kind string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.active[kind] = false
+	s.rerun[kind] = false
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// ErrNotLaunchable is returned for study activities without a launch URL
+var ErrNotLaunchable = errors.New("study activity has no launch_url configured")
+
+// Launch is the result of launching an external activity
+type Launch struct {
+	Session   *models.Session `json:"session"`
+	Token     string          `json:"token"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	LaunchURL string          `json:"launch_url"`
+}
+
+// LaunchService starts sessions for externally hosted activities and accepts
+// their results when they carry a valid launch token
+type LaunchService struct {
+	signer              *auth.Signer
+	tokenTTL            time.Duration
+	activityRepo        *repository.StudyActivityRepository
+	sessionRepo         *repository.SessionRepository
+	sessionActivityRepo *repository.SessionActivityRepository
+}
+
+// NewLaunchService creates a new instance of LaunchService
+func NewLaunchService(
+	signer *auth.Signer,
+	tokenTTL time.Duration,
+	activityRepo *repository.StudyActivityRepository,
+	sessionRepo *repository.SessionRepository,
+	sessionActivityRepo *repository.SessionActivityRepository,
+) *LaunchService {
+	return &LaunchService{
+		signer:              signer,
+		tokenTTL:            tokenTTL,
+		activityRepo:        activityRepo,
+		sessionRepo:         sessionRepo,
+		sessionActivityRepo: sessionActivityRepo,
+	}
+}
+
+// LaunchActivity creates a session of the user for the activity and a token
+// the activity app uses to report results for that session only
+func (s *LaunchService) LaunchActivity(ctx context.Context, userID, activityID int64, groupID *int64) (*Launch, error) {
+	activity, err := s.activityRepo.GetByID(ctx, activityID)
+	if err != nil {
+		return nil, err
+	}
+	if !activity.Enabled {
+		return nil, fmt.Errorf("study activity %d: %w", activity.ID, models.ErrActivityDisabled)
+	}
+
+	launchURL, err := activities.LaunchURL(activity)
+	if err != nil {
+		return nil, err
+	}
+	if launchURL == "" {
+		return nil, fmt.Errorf("study activity %d: %w", activity.ID, ErrNotLaunchable)
+	}
+
+	now := time.Now()
+	session := &models.Session{
+		UserID:     userID,
+		ActivityID: activity.ID,
+		GroupID:    groupID,
+		StartTime:  now,
+		CreatedAt:  now,
+	}
+	if err := session.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	token, expiresAt, err := s.signer.Issue(auth.Claims{
+		Subject:    strconv.FormatInt(userID, 10),
+		SessionID:  session.ID,
+		ActivityID: activity.ID,
+	}, auth.AudienceLaunch, s.tokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := buildLaunchURL(launchURL, session, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Launch{
+		Session:   session,
+		Token:     token,
+		ExpiresAt: expiresAt,
+		LaunchURL: target,
+	}, nil
+}
+
+// RecordResult stores a result reported by the activity app for its session
+func (s *LaunchService) RecordResult(
+	ctx context.Context,
+	token string,
+	sessionID int64,
+	sessionActivity *models.SessionActivity,
+) error {
+	session, err := s.authorize(ctx, token, sessionID)
+	if err != nil {
+		return err
+	}
+
+	sessionActivity.SessionID = session.ID
+	sessionActivity.ActivityID = session.ActivityID
+	sessionActivity.CreatedAt = time.Now()
+	if sessionActivity.Result != "" &&
+		sessionActivity.Result != models.ResultSuccess &&
+		sessionActivity.Result != models.ResultFailure {
+		return fmt.Errorf("result must be %q or %q: %w", models.ResultSuccess, models.ResultFailure, models.ErrInvalidInput)
+	}
+	if err := sessionActivity.Validate(); err != nil {
+		return err
+	}
+
+	return s.sessionActivityRepo.Create(ctx, sessionActivity)
+}
+
+// CompleteSession ends the session with the score reported by the activity app
+func (s *LaunchService) CompleteSession(ctx context.Context, token string, sessionID int64, score int) (*models.Session, error) {
+	session, err := s.authorize(ctx, token, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session.EndTime = &now
+	session.Score = score
+	if err := session.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessionRepo.Update(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// authorize verifies the launch token was issued for the open session
+func (s *LaunchService) authorize(ctx context.Context, token string, sessionID int64) (*models.Session, error) {
+	claims, err := s.signer.Verify(token, auth.AudienceLaunch)
+	if err != nil {
+		return nil, err
+	}
+	if claims.SessionID != sessionID {
+		return nil, fmt.Errorf("token was not issued for session %d: %w", sessionID, auth.ErrInvalidToken)
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, auth.ErrInvalidToken
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.ActivityID != claims.ActivityID {
+		return nil, fmt.Errorf("token was not issued for this activity: %w", auth.ErrInvalidToken)
+	}
+	if session.IsCompleted() {
+		return nil, fmt.Errorf("session %d: %w", sessionID, models.ErrSessionEnded)
+	}
+
+	return session, nil
+}
+
+// buildLaunchURL appends the session ID and token to the activity's launch URL
+func buildLaunchURL(launchURL string, session *models.Session, token string) (string, error) {
+	target, err := url.Parse(launchURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid launch_url: %w", err)
+	}
+
+	query := target.Query()
+	query.Set("session_id", strconv.FormatInt(session.ID, 10))
+	query.Set("token", token)
+	target.RawQuery = query.Encode()
+
+	return target.String(), nil
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/listening"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// ListeningService handles listening exercises and their import from listening-comp
+type ListeningService struct {
+	exercises repository.ListeningRepository
+	languages repository.LanguageRepository
+	assets    repository.AssetRepository
+}
+
+// NewListeningService creates a new instance of ListeningService
+func NewListeningService(
+	exercises repository.ListeningRepository,
+	languages repository.LanguageRepository,
+	assets repository.AssetRepository,
+) *ListeningService {
+	return &ListeningService{exercises: exercises, languages: languages, assets: assets}
+}
+
+// ImportTranscriptRequest is a structured listening-comp transcript, with the
+// timed transcript it was made from when available
+type ImportTranscriptRequest struct {
+	Source     string                         `json:"source"`
+	Transcript listening.StructuredTranscript `json:"transcript"`
+	Lines      []listening.TimedLine          `json:"lines"`
+	AudioURL   string                         `json:"audio_url"`
+}
+
+// SetAudioRequest sets the recording of an exercise, either an uploaded
+// audio asset or a URL; neither removes the recording
+type SetAudioRequest struct {
+	AssetID *int64 `json:"asset_id"`
+	URL     string `json:"url"`
+}
+
+// CreateExercise validates and stores an exercise
+func (s *ListeningService) CreateExercise(ctx context.Context, exercise *models.ListeningExercise) error {
+	if err := s.validate(ctx, exercise); err != nil {
+		return err
+	}
+
+	if exercise.Source != "" {
+		_, err := s.exercises.GetBySource(ctx, exercise.Source)
+		if err == nil {
+			return fmt.Errorf("%q was already imported: %w", exercise.Source, models.ErrInvalidInput)
+		}
+		if !errors.Is(err, models.ErrNotFound) {
+			return err
+		}
+	}
+
+	return s.exercises.Create(ctx, exercise)
+}
+
+// ImportExercise stores an exercise unless one was already imported from
+// the same source, which is returned instead. It reports whether the
+// exercise was created.
+func (s *ListeningService) ImportExercise(ctx context.Context, exercise *models.ListeningExercise) (*models.ListeningExercise, bool, error) {
+	if exercise.Source == "" {
+		return nil, false, fmt.Errorf("imported exercises need a source: %w", models.ErrInvalidInput)
+	}
+
+	existing, err := s.exercises.GetBySource(ctx, exercise.Source)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !errors.Is(err, models.ErrNotFound) {
+		return nil, false, err
+	}
+
+	if err := s.CreateExercise(ctx, exercise); err != nil {
+		return nil, false, fmt.Errorf("%s: %w", exercise.Source, err)
+	}
+	return exercise, true, nil
+}
+
+// ImportTranscript imports a structured listening-comp transcript
+func (s *ListeningService) ImportTranscript(ctx context.Context, req ImportTranscriptRequest) (*models.ListeningExercise, bool, error) {
+	exercise := listening.Exercise(req.Source, req.Transcript, req.Lines)
+	exercise.AudioURL = req.AudioURL
+	return s.ImportExercise(ctx, exercise)
+}
+
+// GetExercise retrieves an exercise with its segments and questions
+func (s *ListeningService) GetExercise(ctx context.Context, id int64) (*models.ListeningExercise, error) {
+	return s.exercises.GetByID(ctx, id)
+}
+
+// ListExercises retrieves the exercises, of one language when language is set
+func (s *ListeningService) ListExercises(ctx context.Context, language string) ([]models.ListeningExercise, error) {
+	return s.exercises.List(ctx, language)
+}
+
+// SetAudio sets or removes the recording of an exercise
+func (s *ListeningService) SetAudio(ctx context.Context, id int64, req SetAudioRequest) (*models.ListeningExercise, error) {
+	req.URL = strings.TrimSpace(req.URL)
+	if req.AssetID != nil && req.URL != "" {
+		return nil, fmt.Errorf("set either an audio asset or a URL: %w", models.ErrInvalidInput)
+	}
+
+	exercise := &models.ListeningExercise{AudioAssetID: req.AssetID, AudioURL: req.URL}
+	if err := s.validateAudio(ctx, exercise); err != nil {
+		return nil, err
+	}
+	if err := s.exercises.UpdateAudio(ctx, id, req.AssetID, req.URL); err != nil {
+		return nil, err
+	}
+
+	return s.exercises.GetByID(ctx, id)
+}
+
+// DeleteExercise removes an exercise with its segments and questions
+func (s *ListeningService) DeleteExercise(ctx context.Context, id int64) error {
+	return s.exercises.Delete(ctx, id)
+}
+
+// validate checks an exercise, that its texts are written in the script of
+// its language and that its recording exists
+func (s *ListeningService) validate(ctx context.Context, exercise *models.ListeningExercise) error {
+	if err := exercise.Validate(); err != nil {
+		return err
+	}
+
+	language, err := lookupLanguage(ctx, s.languages, exercise.Language)
+	if err != nil {
+		return err
+	}
+
+	texts := []string{exercise.Transcript}
+	if exercise.Introduction != "" {
+		texts = append(texts, exercise.Introduction)
+	}
+	for _, segment := range exercise.Segments {
+		texts = append(texts, segment.Text)
+	}
+	for _, question := range exercise.Questions {
+		texts = append(texts, question.Question)
+		texts = append(texts, question.Options...)
+	}
+	for _, text := range texts {
+		if !language.Script.ContainsProse(text) {
+			return fmt.Errorf("%q must be written in %s: %w", text, language.Script.Name(), models.ErrInvalidScript)
+		}
+	}
+
+	return s.validateAudio(ctx, exercise)
+}
+
+// validateAudio checks that the recording is an uploaded audio asset or an
+// absolute http(s) URL
+func (s *ListeningService) validateAudio(ctx context.Context, exercise *models.ListeningExercise) error {
+	if exercise.AudioAssetID != nil {
+		asset, err := s.assets.GetByID(ctx, *exercise.AudioAssetID)
+		if errors.Is(err, models.ErrNotFound) {
+			return fmt.Errorf("audio asset %d does not exist: %w", *exercise.AudioAssetID, models.ErrInvalidInput)
+		}
+		if err != nil {
+			return err
+		}
+		if asset.Kind != models.AssetAudio {
+			return fmt.Errorf("asset %d is not audio: %w", asset.ID, models.ErrInvalidInput)
+		}
+		exercise.AudioURL = asset.URL
+		return nil
+	}
+
+	if exercise.AudioURL != "" {
+		u, err := url.Parse(exercise.AudioURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("audio URL must be an http or https URL: %w", models.ErrInvalidInput)
+		}
+	}
+	return nil
+}
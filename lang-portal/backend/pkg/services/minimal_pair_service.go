@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// minimalPairPageSize is the page size words and groups are read in
+const minimalPairPageSize = 500
+
+// MinimalPairService finds minimal pairs among Hindi words, words that sound
+// alike but for one contrast learners find hard to hear
+type MinimalPairService struct {
+	words  repository.WordRepository
+	groups *repository.SQLiteGroupRepository
+}
+
+// NewMinimalPairService creates a new instance of MinimalPairService
+func NewMinimalPairService(words repository.WordRepository, groups *repository.SQLiteGroupRepository) *MinimalPairService {
+	return &MinimalPairService{words: words, groups: groups}
+}
+
+// FindPairs finds the minimal pairs among the Hindi words of a group, or
+// among all Hindi words when groupID is nil
+func (s *MinimalPairService) FindPairs(ctx context.Context, groupID *int64) ([]models.MinimalPair, error) {
+	var words []models.Word
+	var err error
+	if groupID != nil {
+		words, err = s.words.GetWordsByGroupID(ctx, *groupID)
+	} else {
+		words, err = s.allWords(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return minimalPairs(hindiWords(words)), nil
+}
+
+// ListPairs finds the minimal pairs of a group like FindPairs, keeping those
+// of one contrast when contrast is not empty
+func (s *MinimalPairService) ListPairs(ctx context.Context, groupID *int64, contrast string) ([]models.MinimalPair, error) {
+	if contrast != "" && !hindi.IsContrast(contrast) {
+		return nil, fmt.Errorf("unknown contrast %q: %w", contrast, models.ErrInvalidInput)
+	}
+
+	pairs, err := s.FindPairs(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if contrast == "" {
+		return pairs, nil
+	}
+
+	filtered := []models.MinimalPair{}
+	for _, pair := range pairs {
+		if pair.Contrast == contrast {
+			filtered = append(filtered, pair)
+		}
+	}
+	return filtered, nil
+}
+
+// Coverage reports for every group how many minimal pairs of each contrast
+// its vocabulary has, so editors can see which contrasts a group can't drill
+func (s *MinimalPairService) Coverage(ctx context.Context) ([]models.ContrastCoverage, error) {
+	var groups []models.Group
+	for page := 1; ; page++ {
+		batch, total, err := s.groups.List(ctx, page, minimalPairPageSize, "")
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, batch...)
+		if len(batch) == 0 || len(groups) >= total {
+			break
+		}
+	}
+
+	coverage := make([]models.ContrastCoverage, 0, len(groups))
+	for _, group := range groups {
+		words, err := s.words.GetWordsByGroupID(ctx, group.ID)
+		if err != nil {
+			return nil, err
+		}
+		words = hindiWords(words)
+
+		report := models.ContrastCoverage{
+			GroupID:   group.ID,
+			GroupName: group.Name,
+			Words:     len(words),
+			Pairs:     make(map[string]int, len(hindi.Contrasts)),
+			Missing:   []string{},
+		}
+		for _, contrast := range hindi.Contrasts {
+			report.Pairs[string(contrast)] = 0
+		}
+		for _, pair := range minimalPairs(words) {
+			report.Pairs[pair.Contrast]++
+		}
+		for _, contrast := range hindi.Contrasts {
+			if report.Pairs[string(contrast)] == 0 {
+				report.Missing = append(report.Missing, string(contrast))
+			}
+		}
+		coverage = append(coverage, report)
+	}
+
+	return coverage, nil
+}
+
+// allWords reads every Hindi word page by page
+func (s *MinimalPairService) allWords(ctx context.Context) ([]models.Word, error) {
+	var words []models.Word
+	for page := 1; ; page++ {
+		batch, total, err := s.words.List(ctx, repository.ListWordsParams{
+			Page:     page,
+			PageSize: minimalPairPageSize,
+			Language: hindi.Code,
+		})
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, batch...)
+		if len(batch) == 0 || len(words) >= total {
+			break
+		}
+	}
+	return words, nil
+}
+
+// hindiWords keeps the words whose target form is Hindi
+func hindiWords(words []models.Word) []models.Word {
+	kept := make([]models.Word, 0, len(words))
+	for _, word := range words {
+		if word.Language == "" || word.Language == hindi.Code {
+			kept = append(kept, word)
+		}
+	}
+	return kept
+}
+
+// minimalPairs finds the minimal pairs among words by their target forms
+func minimalPairs(words []models.Word) []models.MinimalPair {
+	targets := make([]string, len(words))
+	for i, word := range words {
+		targets[i] = word.Target
+	}
+
+	pairs := []models.MinimalPair{}
+	for _, pair := range hindi.MinimalPairs(targets) {
+		pairs = append(pairs, models.MinimalPair{
+			Contrast: string(pair.Contrast),
+			First:    words[pair.First],
+			Second:   words[pair.Second],
+		})
+	}
+	return pairs
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+// NumeralService generates the Hindi numbers as vocabulary
+type NumeralService struct{}
+
+// NewNumeralService creates a new instance of NumeralService
+func NewNumeralService() *NumeralService {
+	return &NumeralService{}
+}
+
+// List generates the numbers from one number to another, both included,
+// with their Hindi word, Devanagari digits, Hinglish spelling and IPA
+func (s *NumeralService) List(from, to int) ([]models.Numeral, error) {
+	if from < 0 || to > hindi.MaxNumber || from > to {
+		return nil, fmt.Errorf("numbers must be a range within 0 and %d: %w", hindi.MaxNumber, models.ErrInvalidInput)
+	}
+
+	numerals := []models.Numeral{}
+	for _, numeral := range hindi.Numerals(from, to) {
+		numerals = append(numerals, models.Numeral{
+			Value:     numeral.Value,
+			Digits:    numeral.Digits,
+			Word:      numeral.Word,
+			Romanized: hindi.Romanize(numeral.Word, hindi.Hinglish),
+			IPA:       hindi.IPA(numeral.Word),
+		})
+	}
+	return numerals, nil
+}
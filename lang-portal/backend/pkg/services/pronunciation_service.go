@@ -0,0 +1,117 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/speech"
+)
+
+// PronunciationService generates the pronunciation audio of words with a
+// text-to-speech provider and stores it as their audio asset
+type PronunciationService struct {
+	provider speech.TTSProvider
+	words    repository.WordRepository
+	cache    repository.SpeechCacheRepository
+	assets   *AssetService
+}
+
+// NewPronunciationService creates a new instance of PronunciationService
+func NewPronunciationService(
+	provider speech.TTSProvider,
+	words repository.WordRepository,
+	cache repository.SpeechCacheRepository,
+	assets *AssetService,
+) *PronunciationService {
+	return &PronunciationService{provider: provider, words: words, cache: cache, assets: assets}
+}
+
+// Kind returns the kind of jobs the service runs
+func (s *PronunciationService) Kind() string {
+	return models.JobPronunciation
+}
+
+// Backlog lists the words that have no pronunciation audio
+func (s *PronunciationService) Backlog(ctx context.Context) ([]int64, error) {
+	words, err := s.words.ListWithoutAudio(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(words))
+	for i, word := range words {
+		ids[i] = word.ID
+	}
+	return ids, nil
+}
+
+// Run generates the pronunciation of a word. Words deleted or given audio
+// since the job was queued are left as they are.
+func (s *PronunciationService) Run(ctx context.Context, wordID int64) error {
+	word, err := s.words.GetByID(ctx, wordID)
+	if errors.Is(err, models.ErrNotFound) {
+		log.Printf("Skipping pronunciation of deleted word %d", wordID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if word.AudioAssetID != nil {
+		return nil
+	}
+
+	asset, err := s.Synthesize(ctx, word.Target, word.Language)
+	if err != nil {
+		return err
+	}
+
+	return s.words.SetAudio(ctx, word.ID, asset.ID)
+}
+
+// Synthesize returns the audio asset speaking the text. Audio is cached
+// under the content address of the provider's voice and the text, so text
+// spoken before is never sent to the provider again.
+func (s *PronunciationService) Synthesize(ctx context.Context, text, language string) (*models.Asset, error) {
+	key := speech.CacheKey(s.provider, text, language)
+
+	assetID, err := s.cache.Get(ctx, key)
+	if err == nil {
+		asset, err := s.assets.GetAsset(ctx, assetID)
+		if err == nil {
+			return asset, nil
+		}
+		// The cached asset was deleted, generate it again
+		if !errors.Is(err, models.ErrNotFound) {
+			return nil, err
+		}
+	} else if !errors.Is(err, models.ErrNotFound) {
+		return nil, err
+	}
+
+	audio, err := s.provider.Synthesize(ctx, text, language)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := s.assets.Upload(ctx, AssetUpload{
+		Filename:    "pronunciation",
+		ContentType: audio.ContentType,
+		Content:     bytes.NewReader(audio.Data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store generated audio: %w", err)
+	}
+	if asset.Kind != models.AssetAudio {
+		return nil, fmt.Errorf("speech provider returned %s instead of audio: %w", asset.MimeType, models.ErrUnsupportedMedia)
+	}
+
+	if err := s.cache.Put(ctx, key, asset.ID); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// SentenceService handles example sentences and the words they are linked to
+type SentenceService struct {
+	repo      repository.SentenceRepository
+	words     repository.WordRepository
+	languages repository.LanguageRepository
+}
+
+// NewSentenceService creates a new instance of SentenceService
+func NewSentenceService(
+	repo repository.SentenceRepository,
+	words repository.WordRepository,
+	languages repository.LanguageRepository,
+) *SentenceService {
+	return &SentenceService{repo: repo, words: words, languages: languages}
+}
+
+// CreateSentence validates and stores a new sentence
+func (s *SentenceService) CreateSentence(ctx context.Context, sentence *models.Sentence) error {
+	if err := s.validate(ctx, sentence); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, sentence)
+}
+
+// ValidateSentence checks a sentence and its linked words without storing it
+func (s *SentenceService) ValidateSentence(ctx context.Context, sentence *models.Sentence) error {
+	return s.validate(ctx, sentence)
+}
+
+// GetSentence retrieves a sentence by its ID
+func (s *SentenceService) GetSentence(ctx context.Context, id int64) (*models.Sentence, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// UpdateSentence replaces a sentence and its word links
+func (s *SentenceService) UpdateSentence(ctx context.Context, sentence *models.Sentence) error {
+	if sentence.ID <= 0 {
+		return models.ErrInvalidID
+	}
+	if err := s.validate(ctx, sentence); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, sentence)
+}
+
+// DeleteSentence removes a sentence by its ID
+func (s *SentenceService) DeleteSentence(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// ListSentences retrieves sentences with pagination and optional filtering
+func (s *SentenceService) ListSentences(ctx context.Context, params repository.ListSentencesParams) ([]models.Sentence, int, error) {
+	return s.repo.List(ctx, params)
+}
+
+// validate checks the sentence, the scripts of its language pair and that
+// every linked word exists in the sentence's language
+func (s *SentenceService) validate(ctx context.Context, sentence *models.Sentence) error {
+	if err := sentence.Validate(); err != nil {
+		return err
+	}
+
+	target, err := lookupLanguage(ctx, s.languages, sentence.Language)
+	if err != nil {
+		return err
+	}
+	native, err := lookupLanguage(ctx, s.languages, sentence.NativeLanguage)
+	if err != nil {
+		return err
+	}
+	if err := sentence.ValidateScripts(target, native); err != nil {
+		return err
+	}
+
+	for _, wordID := range sentence.WordIDs {
+		word, err := s.words.GetByID(ctx, wordID)
+		if errors.Is(err, models.ErrNotFound) {
+			return fmt.Errorf("word %d does not exist: %w", wordID, models.ErrInvalidInput)
+		}
+		if err != nil {
+			return err
+		}
+		if word.Language != sentence.Language {
+			return fmt.Errorf("word %d is not a %s word: %w", wordID, target.Name, models.ErrInvalidLanguage)
+		}
+	}
+
+	return nil
+}
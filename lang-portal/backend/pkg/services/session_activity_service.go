@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
 	"github.com/pavittarx/lang-portal/backend/pkg/models"
 	"github.com/pavittarx/lang-portal/backend/pkg/repository"
 )
@@ -12,31 +14,51 @@ import (
 type SessionActivityService struct {
 	repo *repository.SessionActivityRepository
 	sessionRepo *repository.SessionRepository
+	studyActivityRepo *repository.StudyActivityRepository
 }
 
 // NewSessionActivityService creates a new instance of SessionActivityService
 func NewSessionActivityService(
 	repo *repository.SessionActivityRepository, 
 	sessionRepo *repository.SessionRepository,
+	studyActivityRepo *repository.StudyActivityRepository,
 ) *SessionActivityService {
 	return &SessionActivityService{
 		repo: repo,
 		sessionRepo: sessionRepo,
+		studyActivityRepo: studyActivityRepo,
 	}
 }
 
-// AddSessionActivity adds a new activity to an existing session
+// AddSessionActivity adds a new activity to an existing session of the user,
+// with the result the client graded it with. Only client graded activities
+// take results from the client, those of the other activity engines,
+// external ones included, are only recorded by the server and are rejected.
 func (s *SessionActivityService) AddSessionActivity(
 	ctx context.Context, 
-	sessionID, activityID int64, 
+	userID, sessionID, activityID int64, 
 	challenge, answer, input string,
 	score int,
 ) (*models.SessionActivity, error) {
 	// Validate session exists
-	_, err := s.sessionRepo.GetByID(ctx, sessionID)
+	session, err := s.sessionRepo.GetByID(ctx, userID, sessionID)
 	if err != nil {
 		return nil, err
 	}
+	if session.ActivityID != activityID {
+		return nil, fmt.Errorf("session %d is not of study activity %d: %w", sessionID, activityID, models.ErrInvalidInput)
+	}
+	if session.IsCompleted() {
+		return nil, fmt.Errorf("session %d: %w", sessionID, models.ErrSessionEnded)
+	}
+
+	activity, err := s.studyActivityRepo.GetByID(ctx, activityID)
+	if err != nil {
+		return nil, err
+	}
+	if activity.Type != activities.ClientGradedType {
+		return nil, fmt.Errorf("%s activity %d: %w", activity.Type, activityID, models.ErrGradedActivity)
+	}
 
 	// Create session activity
 	sessionActivity := &models.SessionActivity{
@@ -63,18 +85,31 @@ func (s *SessionActivityService) AddSessionActivity(
 	return sessionActivity, nil
 }
 
-// GetSessionActivities retrieves all activities for a specific session
+// GetSessionActivities retrieves all activities for a specific session of the user
 func (s *SessionActivityService) GetSessionActivities(
 	ctx context.Context, 
-	sessionID int64,
+	userID, sessionID int64,
 ) ([]models.SessionActivity, error) {
+	if _, err := s.sessionRepo.GetByID(ctx, userID, sessionID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.ListBySessionID(ctx, sessionID)
 }
 
-// DeleteSessionActivity removes a session activity
+// DeleteSessionActivity removes a session activity from a session of the user
 func (s *SessionActivityService) DeleteSessionActivity(
 	ctx context.Context, 
-	sessionActivityID int64,
+	userID, sessionActivityID int64,
 ) error {
+	sessionActivity, err := s.repo.GetByID(ctx, sessionActivityID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.sessionRepo.GetByID(ctx, userID, sessionActivity.SessionID); err != nil {
+		return err
+	}
+
 	return s.repo.Delete(ctx, sessionActivityID)
 }
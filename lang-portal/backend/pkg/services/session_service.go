@@ -19,11 +19,13 @@ func NewSessionService(repo *repository.SessionRepository) *SessionService {
 	return &SessionService{repo: repo}
 }
 
-// CreateSession starts a new learning session
-func (s *SessionService) CreateSession(ctx context.Context, activityID int64) (*models.Session, error) {
+// CreateSession starts a new learning session for the user, optionally limited to a group of words
+func (s *SessionService) CreateSession(ctx context.Context, userID, activityID int64, groupID *int64) (*models.Session, error) {
 	// Create a new session with the current time as start_time
 	session := &models.Session{
+		UserID:     userID,
 		ActivityID: activityID,
+		GroupID:    groupID,
 		StartTime:  time.Now(),
 	}
 
@@ -36,24 +38,28 @@ func (s *SessionService) CreateSession(ctx context.Context, activityID int64) (*
 	return session, nil
 }
 
-// GetSessionByID retrieves a specific session
-func (s *SessionService) GetSessionByID(ctx context.Context, id int64) (*models.Session, error) {
-	return s.repo.GetByID(ctx, id)
+// GetSessionByID retrieves a specific session of the user
+func (s *SessionService) GetSessionByID(ctx context.Context, userID, id int64) (*models.Session, error) {
+	return s.repo.GetByID(ctx, userID, id)
 }
 
-// GetSessionByIDWithActivities retrieves a specific session with its activities
-func (s *SessionService) GetSessionByIDWithActivities(ctx context.Context, id int64) (*models.SessionWithActivities, error) {
+// GetSessionByIDWithActivities retrieves a specific session of the user with its activities
+func (s *SessionService) GetSessionByIDWithActivities(ctx context.Context, userID, id int64) (*models.SessionWithActivities, error) {
 	// Retrieve session with activities from repository
-	return s.repo.GetByIDWithActivities(ctx, id)
+	return s.repo.GetByIDWithActivities(ctx, userID, id)
 }
 
-// EndSession completes a session by setting the end time and calculating the score
-func (s *SessionService) EndSession(ctx context.Context, id int64, score int) error {
+// EndSession completes a session of the user by setting the end time and
+// calculating the score. A session is ended once.
+func (s *SessionService) EndSession(ctx context.Context, userID, id int64, score int) error {
 	// Retrieve the existing session
-	session, err := s.repo.GetByID(ctx, id)
+	session, err := s.repo.GetByID(ctx, userID, id)
 	if err != nil {
 		return err
 	}
+	if session.IsCompleted() {
+		return fmt.Errorf("session %d: %w", id, models.ErrSessionEnded)
+	}
 
 	// Set end time and score
 	now := time.Now()
@@ -69,24 +75,25 @@ func (s *SessionService) EndSession(ctx context.Context, id int64, score int) er
 	return s.repo.Update(ctx, session)
 }
 
-// ListSessions retrieves a list of sessions with pagination
-func (s *SessionService) ListSessions(ctx context.Context, page, pageSize int) ([]models.Session, error) {
+// ListSessions retrieves a list of the user's sessions with pagination
+func (s *SessionService) ListSessions(ctx context.Context, userID int64, page, pageSize int) ([]models.Session, error) {
 	// Calculate offset based on page and page size
 	offset := (page - 1) * pageSize
 
-	return s.repo.List(ctx, pageSize, offset)
+	return s.repo.List(ctx, userID, pageSize, offset)
 }
 
-// DeleteAllSessions removes all sessions and their associated session activities
-func (s *SessionService) DeleteAllSessions(ctx context.Context) (int64, error) {
+// DeleteAllSessions removes the sessions of one learner, or of every learner when
+// userID is nil, together with their associated session activities
+func (s *SessionService) DeleteAllSessions(ctx context.Context, userID *int64) (int64, error) {
 	// First, delete all session activities
-	_, err := s.repo.DeleteAllSessionActivities(ctx)
+	_, err := s.repo.DeleteAllSessionActivities(ctx, userID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete session activities: %w", err)
 	}
 
 	// Then, delete all sessions
-	sessionsDeleted, err := s.repo.DeleteAll(ctx)
+	sessionsDeleted, err := s.repo.DeleteAll(ctx, userID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete sessions: %w", err)
 	}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/speech"
+	"github.com/pavittarx/lang-portal/backend/pkg/storage"
+)
+
+// SpeakingService grades spoken answers to speaking challenges. Recordings
+// are transcribed by a speech recognizer, graded like typed answers and kept
+// for the learner to listen back to.
+type SpeakingService struct {
+	provider       speech.ASRProvider
+	recordings     repository.RecordingRepository
+	challenges     *ChallengeService
+	storage        storage.Storage
+	maxUploadBytes int64
+}
+
+// NewSpeakingService creates a new instance of SpeakingService, provider may
+// be nil when no speech recognizer is configured
+func NewSpeakingService(
+	provider speech.ASRProvider,
+	recordings repository.RecordingRepository,
+	challenges *ChallengeService,
+	files storage.Storage,
+	maxUploadBytes int64,
+) *SpeakingService {
+	return &SpeakingService{
+		provider:       provider,
+		recordings:     recordings,
+		challenges:     challenges,
+		storage:        files,
+		maxUploadBytes: maxUploadBytes,
+	}
+}
+
+// RecordingUpload is a learner's recorded answer
+type RecordingUpload struct {
+	ContentType string // as declared by the client, used when sniffing the content fails
+	DurationMS  *int64 // for audio formats whose duration cannot be read from the file
+	Content     io.Reader
+}
+
+// SpokenAnswer is a graded recording with the session activity it was recorded as
+type SpokenAnswer struct {
+	SessionActivity *models.SessionActivity `json:"session_activity"`
+	Grade           *activities.Grade       `json:"grade"`
+	Recording       *models.Recording       `json:"recording"`
+}
+
+// SubmitRecording transcribes a recorded answer to a speaking challenge
+// issued for the session, grades the transcript and keeps the recording
+func (s *SpeakingService) SubmitRecording(
+	ctx context.Context,
+	userID, sessionID int64,
+	challengeID string,
+	upload RecordingUpload,
+) (*SpokenAnswer, error) {
+	if s.provider == nil {
+		return nil, fmt.Errorf("speaking practice: %w", models.ErrNotConfigured)
+	}
+
+	// Check the session and challenge before paying for a transcription
+	session, activity, err := s.challenges.OpenSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if activity.Type != activities.SpeakingType {
+		return nil, fmt.Errorf("%w: expected a %s challenge", activities.ErrInvalidChallenge, activity.Type)
+	}
+	challenge, err := s.challenges.IssuedChallenge(ctx, userID, session.ID, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	var payload activities.SpeakingPayload
+	if err := activities.DecodePayload(challenge, &payload); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(upload.Content, s.maxUploadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	if int64(len(data)) > s.maxUploadBytes {
+		return nil, fmt.Errorf("recordings are limited to %d bytes: %w", s.maxUploadBytes, models.ErrFileTooLarge)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("recording cannot be empty: %w", models.ErrInvalidInput)
+	}
+	mediaType := sniffMediaType(data, upload.ContentType)
+	if !strings.HasPrefix(models.NormalizeMediaType(mediaType), models.AssetAudio+"/") {
+		return nil, fmt.Errorf("%q is not an accepted audio type: %w", mediaType, models.ErrUnsupportedMedia)
+	}
+
+	transcript, err := s.provider.Transcribe(ctx, &speech.Audio{Data: data, ContentType: mediaType}, payload.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionActivity, grade, err := s.challenges.SubmitAnswer(ctx, userID, session.ID, challenge.ID, transcript, "")
+	if err != nil {
+		return nil, err
+	}
+
+	recording := &models.Recording{
+		UserID:            userID,
+		SessionID:         session.ID,
+		SessionActivityID: sessionActivity.ID,
+		MimeType:          mediaType,
+		Size:              int64(len(data)),
+		DurationMS:        upload.DurationMS,
+		Transcript:        sessionActivity.Input,
+		Model:             s.provider.Model(),
+	}
+	if payload.WordID > 0 {
+		recording.WordID = &payload.WordID
+	}
+	if mediaType == "audio/wav" {
+		if duration, ok := wavDuration(data); ok {
+			recording.DurationMS = &duration
+		}
+	}
+	// Session activities are never reused, so their IDs name the files apart
+	recording.StorageKey = fmt.Sprintf("recordings/%d/%d%s", userID, sessionActivity.ID, recording.Extension())
+
+	if err := s.storage.Put(ctx, recording.StorageKey, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	if err := s.recordings.Create(ctx, recording); err != nil {
+		if deleteErr := s.storage.Delete(ctx, recording.StorageKey); deleteErr != nil {
+			log.Printf("Failed to remove orphaned recording %s: %v", recording.StorageKey, deleteErr)
+		}
+		return nil, err
+	}
+
+	return &SpokenAnswer{SessionActivity: sessionActivity, Grade: grade, Recording: recording}, nil
+}
+
+// ListRecordings retrieves the recordings of the user, newest first,
+// restricted to one session when sessionID is not zero
+func (s *SpeakingService) ListRecordings(ctx context.Context, userID, sessionID int64) ([]models.Recording, error) {
+	return s.recordings.List(ctx, userID, sessionID)
+}
+
+// OpenRecording retrieves a recording of the user together with its content,
+// which the caller must close
+func (s *SpeakingService) OpenRecording(ctx context.Context, userID, id int64) (*models.Recording, io.ReadSeekCloser, error) {
+	recording, err := s.recordings.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.storage.Open(ctx, recording.StorageKey)
+	if errors.Is(err, storage.ErrNotExist) {
+		return nil, nil, fmt.Errorf("content of recording %d: %w", id, models.ErrNotFound)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return recording, content, nil
+}
+
+// DeleteRecording removes a recording of the user and its file, the graded
+// answer stays in the session
+func (s *SpeakingService) DeleteRecording(ctx context.Context, userID, id int64) error {
+	recording, err := s.recordings.GetByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.recordings.Delete(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.storage.Delete(ctx, recording.StorageKey)
+}
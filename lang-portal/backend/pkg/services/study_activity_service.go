@@ -2,20 +2,24 @@ package services
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
 	"github.com/pavittarx/lang-portal/backend/pkg/models"
 	"github.com/pavittarx/lang-portal/backend/pkg/repository"
 )
 
 // StudyActivityService provides business logic for study activity operations
 type StudyActivityService struct {
-	repo *repository.StudyActivityRepository
+	repo     *repository.StudyActivityRepository
+	registry *activities.Registry
 }
 
 // NewStudyActivityService creates a new instance of StudyActivityService
-func NewStudyActivityService(repo *repository.StudyActivityRepository) *StudyActivityService {
+func NewStudyActivityService(repo *repository.StudyActivityRepository, registry *activities.Registry) *StudyActivityService {
 	return &StudyActivityService{
-		repo: repo,
+		repo:     repo,
+		registry: registry,
 	}
 }
 
@@ -23,3 +27,64 @@ func NewStudyActivityService(repo *repository.StudyActivityRepository) *StudyAct
 func (s *StudyActivityService) GetStudyActivities(ctx context.Context) ([]models.StudyActivity, error) {
 	return s.repo.GetAll(ctx)
 }
+
+// GetStudyActivityByID retrieves a study activity by its ID
+func (s *StudyActivityService) GetStudyActivityByID(ctx context.Context, id int64) (*models.StudyActivity, error) {
+	if id <= 0 {
+		return nil, models.ErrInvalidID
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreateStudyActivity validates and stores a new study activity
+func (s *StudyActivityService) CreateStudyActivity(ctx context.Context, activity *models.StudyActivity) error {
+	if err := s.validate(activity); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, activity)
+}
+
+// UpdateStudyActivity validates and updates an existing study activity
+func (s *StudyActivityService) UpdateStudyActivity(ctx context.Context, activity *models.StudyActivity) error {
+	if activity.ID <= 0 {
+		return models.ErrInvalidID
+	}
+	if err := s.validate(activity); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, activity)
+}
+
+// DeleteStudyActivity removes a study activity by its ID
+func (s *StudyActivityService) DeleteStudyActivity(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return models.ErrInvalidID
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// GetActivityTypes lists the activity types that have a registered engine
+func (s *StudyActivityService) GetActivityTypes() []string {
+	return s.registry.Types()
+}
+
+// validate checks the activity and that an engine accepts its type and config
+func (s *StudyActivityService) validate(activity *models.StudyActivity) error {
+	activity.Sanitize()
+	if err := activity.Validate(); err != nil {
+		return fmt.Errorf("invalid study activity: %w", err)
+	}
+
+	engine, err := s.registry.Get(activity.Type)
+	if err != nil {
+		return fmt.Errorf("invalid study activity: %w", err)
+	}
+
+	if validator, ok := engine.(activities.ConfigValidator); ok {
+		if err := validator.ValidateConfig(activity.Config); err != nil {
+			return fmt.Errorf("invalid study activity: %s config: %w", activity.Type, err)
+		}
+	}
+
+	return nil
+}
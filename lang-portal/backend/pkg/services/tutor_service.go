@@ -0,0 +1,513 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/ai"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/prompts"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+const (
+	// maxTutorHistory limits the messages sent back to the model with each turn
+	maxTutorHistory = 20
+
+	// maxVocabulary limits the rows of a conversation's vocabulary table
+	maxVocabulary = 10
+
+	// maxPhraseWords is the longest native phrase looked up in the words table
+	maxPhraseWords = 3
+
+	// attemptPrefix marks attempts at the sentence for the model
+	attemptPrefix = "Attempt: "
+)
+
+// TutorService runs conversations in which a language model coaches a learner
+// through translating a sentence, following the sentence-constructor
+// teaching-assistant prompts. Attempts are recorded in the learner's session.
+type TutorService struct {
+	provider            ai.LLMProvider
+	conversations       repository.TutorRepository
+	words               repository.WordRepository
+	sentences           repository.SentenceRepository
+	languages           repository.LanguageRepository
+	activityRepo        *repository.StudyActivityRepository
+	sessionRepo         *repository.SessionRepository
+	sessionActivityRepo *repository.SessionActivityRepository
+}
+
+// NewTutorService creates a new instance of TutorService, provider may be nil
+// when no language model is configured
+func NewTutorService(
+	provider ai.LLMProvider,
+	conversations repository.TutorRepository,
+	words repository.WordRepository,
+	sentences repository.SentenceRepository,
+	languages repository.LanguageRepository,
+	activityRepo *repository.StudyActivityRepository,
+	sessionRepo *repository.SessionRepository,
+	sessionActivityRepo *repository.SessionActivityRepository,
+) *TutorService {
+	return &TutorService{
+		provider:            provider,
+		conversations:       conversations,
+		words:               words,
+		sentences:           sentences,
+		languages:           languages,
+		activityRepo:        activityRepo,
+		sessionRepo:         sessionRepo,
+		sessionActivityRepo: sessionActivityRepo,
+	}
+}
+
+// StartConversationRequest starts a conversation in a tutor session
+type StartConversationRequest struct {
+	SessionID int64  `json:"session_id"`
+	Sentence  string `json:"sentence"` // picked from the example sentences when empty
+}
+
+// SendMessageRequest is a learner's message, either a question or an attempt
+// at the sentence
+type SendMessageRequest struct {
+	Content string `json:"content"`
+	Attempt bool   `json:"attempt"`
+}
+
+// TutorTurn is a learner's message with the tutor's reply
+type TutorTurn struct {
+	Learner models.TutorMessage     `json:"learner"`
+	Tutor   models.TutorMessage     `json:"tutor"`
+	Attempt *models.SessionActivity `json:"attempt,omitempty"` // the graded attempt, for attempts
+}
+
+// tutorAnswer is the JSON object the model answers with
+type tutorAnswer struct {
+	Structure string   `json:"structure"`
+	Reply     string   `json:"reply"`
+	Clues     []string `json:"clues"`
+	Correct   bool     `json:"correct"`
+	Score     int      `json:"score"`
+	Answer    string   `json:"answer"`
+}
+
+// tutorPromptData fills in the tutor prompt
+type tutorPromptData struct {
+	Language       string
+	NativeLanguage string
+	Script         string
+	Prompt         string
+	Vocabulary     []models.VocabularyEntry
+}
+
+// StartConversation presents a sentence to translate with its vocabulary
+// table and the tutor's first clues
+func (s *TutorService) StartConversation(ctx context.Context, userID int64, req StartConversationRequest) (*models.TutorConversation, error) {
+	if s.provider == nil {
+		return nil, fmt.Errorf("sentence tutor: %w", models.ErrNotConfigured)
+	}
+
+	session, err := s.tutorSession(ctx, userID, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	conversation := &models.TutorConversation{
+		UserID:    userID,
+		SessionID: session.ID,
+		Model:     s.provider.Model(),
+	}
+	if sentence := strings.TrimSpace(req.Sentence); sentence != "" {
+		if utf8.RuneCountInString(sentence) > models.MaxSentenceLength {
+			return nil, fmt.Errorf("sentences have at most %d characters: %w", models.MaxSentenceLength, models.ErrInvalidInput)
+		}
+		conversation.Language = models.DefaultLanguage
+		conversation.NativeLanguage = models.DefaultNativeLanguage
+		conversation.Prompt = sentence
+	} else {
+		sentence, err := s.pickSentence(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+		conversation.SentenceID = &sentence.ID
+		conversation.Language = sentence.Language
+		conversation.NativeLanguage = sentence.NativeLanguage
+		conversation.Prompt = sentence.Native
+		conversation.Answer = sentence.Target
+	}
+
+	conversation.Vocabulary, err = s.vocabulary(ctx, conversation.Language, conversation.Prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	answer, err := s.complete(ctx, conversation, nil, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	conversation.Structure = strings.TrimSpace(answer.Structure)
+	if conversation.Answer == "" {
+		conversation.Answer = strings.TrimSpace(answer.Answer)
+	}
+
+	if err := s.conversations.CreateConversation(ctx, conversation); err != nil {
+		return nil, err
+	}
+
+	opening := tutorMessage(conversation.ID, answer)
+	if err := s.conversations.AddMessage(ctx, &opening); err != nil {
+		return nil, err
+	}
+	conversation.Messages = []models.TutorMessage{opening}
+
+	return conversation, nil
+}
+
+// SendMessage sends a learner's message to the tutor. Attempts are graded
+// and recorded as session activities, a correct attempt solves the conversation.
+func (s *TutorService) SendMessage(ctx context.Context, userID, conversationID int64, req SendMessageRequest) (*TutorTurn, error) {
+	if s.provider == nil {
+		return nil, fmt.Errorf("sentence tutor: %w", models.ErrNotConfigured)
+	}
+
+	content := strings.TrimSpace(req.Content)
+	if content == "" || utf8.RuneCountInString(content) > models.MaxSentenceLength {
+		return nil, fmt.Errorf("messages must have 1 to %d characters: %w", models.MaxSentenceLength, models.ErrInvalidInput)
+	}
+
+	conversation, err := s.conversations.GetConversation(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.tutorSession(ctx, userID, conversation.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.conversations.ListMessages(ctx, conversation.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	learner := models.TutorMessage{
+		ConversationID: conversation.ID,
+		Role:           models.TutorLearner,
+		Content:        content,
+	}
+	answer, err := s.complete(ctx, conversation, history, &learner, req.Attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	turn := &TutorTurn{}
+	if req.Attempt {
+		attempt, err := s.recordAttempt(ctx, session, conversation, content, answer)
+		if err != nil {
+			return nil, err
+		}
+		learner.SessionActivityID = &attempt.ID
+		turn.Attempt = attempt
+	}
+
+	if err := s.conversations.AddMessage(ctx, &learner); err != nil {
+		return nil, err
+	}
+	tutor := tutorMessage(conversation.ID, answer)
+	if err := s.conversations.AddMessage(ctx, &tutor); err != nil {
+		return nil, err
+	}
+	if turn.Attempt != nil && turn.Attempt.IsSuccessful() && !conversation.Solved {
+		if err := s.conversations.MarkSolved(ctx, conversation.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	turn.Learner, turn.Tutor = learner, tutor
+	return turn, nil
+}
+
+// GetConversation retrieves a conversation of the user with its messages
+func (s *TutorService) GetConversation(ctx context.Context, userID, id int64) (*models.TutorConversation, error) {
+	conversation, err := s.conversations.GetConversation(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	conversation.Messages, err = s.conversations.ListMessages(ctx, conversation.ID)
+	if err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+// ListConversations retrieves the conversations of the user, newest first,
+// restricted to one session when sessionID is not zero
+func (s *TutorService) ListConversations(ctx context.Context, userID, sessionID int64) ([]models.TutorConversation, error) {
+	return s.conversations.ListConversations(ctx, userID, sessionID)
+}
+
+// tutorSession loads an open session of the user whose study activity is the tutor
+func (s *TutorService) tutorSession(ctx context.Context, userID, sessionID int64) (*models.Session, error) {
+	session, err := s.sessionRepo.GetByID(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsCompleted() {
+		return nil, fmt.Errorf("session %d: %w", session.ID, models.ErrSessionEnded)
+	}
+
+	activity, err := s.activityRepo.GetByID(ctx, session.ActivityID)
+	if err != nil {
+		return nil, err
+	}
+	if activity.Type != activities.TutorType {
+		return nil, fmt.Errorf("session %d is not a %s session: %w", session.ID, activities.TutorType, models.ErrInvalidInput)
+	}
+	if !activity.Enabled {
+		return nil, fmt.Errorf("study activity %d: %w", activity.ID, models.ErrActivityDisabled)
+	}
+
+	return session, nil
+}
+
+// pickSentence picks a random example sentence, one using the words of the
+// session's group when it has one
+func (s *TutorService) pickSentence(ctx context.Context, session *models.Session) (*models.Sentence, error) {
+	var wordIDs []int64
+	if session.GroupID != nil {
+		groupWords, err := s.words.GetWordsByGroupID(ctx, *session.GroupID)
+		if err != nil {
+			return nil, err
+		}
+		if len(groupWords) == 0 {
+			return nil, fmt.Errorf("no words found in group %d: %w", *session.GroupID, models.ErrNotFound)
+		}
+		for _, word := range groupWords {
+			wordIDs = append(wordIDs, word.ID)
+		}
+	}
+
+	return s.sentences.GetRandomForWords(ctx, wordIDs)
+}
+
+// vocabulary builds the vocabulary table of a sentence from the words whose
+// native form appears in it, as a word or a phrase of up to three words
+func (s *TutorService) vocabulary(ctx context.Context, language, prompt string) ([]models.VocabularyEntry, error) {
+	tokens := models.SentenceWords(strings.ToLower(prompt))
+
+	var phrases []string
+	position := make(map[string]int)
+	for i := range tokens {
+		for n := 1; n <= maxPhraseWords && i+n <= len(tokens); n++ {
+			phrase := strings.Join(tokens[i:i+n], " ")
+			if _, ok := position[phrase]; !ok {
+				position[phrase] = i
+				phrases = append(phrases, phrase)
+			}
+		}
+	}
+
+	words, err := s.words.ListByNative(ctx, language, phrases)
+	if err != nil {
+		return nil, err
+	}
+
+	// One row per phrase, in the order of the sentence
+	entries := []models.VocabularyEntry{}
+	seen := make(map[string]bool)
+	for i := range tokens {
+		for _, word := range words {
+			native := strings.ToLower(word.Native)
+			if position[native] != i || seen[native] || len(entries) == maxVocabulary {
+				continue
+			}
+			seen[native] = true
+			entries = append(entries, models.VocabularyEntry{
+				WordID:    word.ID,
+				Native:    word.Native,
+				Target:    word.Target,
+				Romanized: word.Romanized,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// complete renders the tutor prompt with the conversation's history and the
+// learner's new message, if any, and decodes the model's answer
+func (s *TutorService) complete(
+	ctx context.Context,
+	conversation *models.TutorConversation,
+	history []models.TutorMessage,
+	learner *models.TutorMessage,
+	attempt bool,
+) (*tutorAnswer, error) {
+	language, err := lookupLanguage(ctx, s.languages, conversation.Language)
+	if err != nil {
+		return nil, err
+	}
+	native, err := lookupLanguage(ctx, s.languages, conversation.NativeLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	system, err := prompts.Render(prompts.Tutor, tutorPromptData{
+		Language:       language.Name,
+		NativeLanguage: native.Name,
+		Script:         language.Script.Name(),
+		Prompt:         conversation.Prompt,
+		Vocabulary:     conversation.Vocabulary,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []ai.Message{
+		{Role: ai.RoleSystem, Content: system},
+		{Role: ai.RoleUser, Content: "Student Prompt: " + conversation.Prompt},
+	}
+	if len(history) > maxTutorHistory {
+		history = history[len(history)-maxTutorHistory:]
+	}
+	for _, message := range history {
+		messages = append(messages, historyMessage(message))
+	}
+	if learner != nil {
+		message := historyMessage(*learner)
+		if attempt {
+			message.Content = attemptPrefix + message.Content
+		}
+		messages = append(messages, message)
+	}
+
+	var answer tutorAnswer
+	if err := ai.CompleteJSON(ctx, s.provider, messages, &answer); err != nil {
+		return nil, err
+	}
+	answer.Reply = strings.TrimSpace(answer.Reply)
+	if answer.Reply == "" && len(answer.Clues) == 0 {
+		return nil, fmt.Errorf("%w: the tutor's answer has no reply", ai.ErrInvalidOutput)
+	}
+	return &answer, nil
+}
+
+// recordAttempt grades an attempt and records it in the session. An attempt
+// matching the example sentence or one of its other word orders is always
+// correct, otherwise the tutor's judgement counts.
+func (s *TutorService) recordAttempt(
+	ctx context.Context,
+	session *models.Session,
+	conversation *models.TutorConversation,
+	input string,
+	answer *tutorAnswer,
+) (*models.SessionActivity, error) {
+	correct, score := answer.Correct, answer.Score
+	if score < 0 {
+		score = 0
+	}
+	if score > 100 {
+		score = 100
+	}
+	if correct && score == 0 {
+		score = 100
+	}
+
+	matches, err := s.matchesSentence(ctx, conversation, input)
+	if err != nil {
+		return nil, err
+	}
+	if matches {
+		correct, score = true, 100
+	}
+
+	expected := conversation.Answer
+	if expected == "" {
+		expected = strings.TrimSpace(answer.Answer)
+	}
+	if expected == "" {
+		expected = input
+	}
+
+	result := models.ResultFailure
+	if correct {
+		result = models.ResultSuccess
+	}
+
+	attempt := &models.SessionActivity{
+		SessionID:  session.ID,
+		ActivityID: session.ActivityID,
+		Challenge:  conversation.Prompt,
+		Answer:     expected,
+		Input:      input,
+		Result:     result,
+		Score:      score,
+		CreatedAt:  time.Now(),
+	}
+	if err := attempt.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.sessionActivityRepo.Create(ctx, attempt); err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+// matchesSentence reports whether the input is the conversation's example
+// sentence or one of its other word orders, ignoring punctuation
+func (s *TutorService) matchesSentence(ctx context.Context, conversation *models.TutorConversation, input string) (bool, error) {
+	if conversation.SentenceID == nil {
+		return false, nil
+	}
+	sentence, err := s.sentences.GetByID(ctx, *conversation.SentenceID)
+	if err != nil {
+		return false, err
+	}
+
+	normalized := strings.Join(models.SentenceWords(input), " ")
+	for _, candidate := range append([]string{sentence.Target}, sentence.Alternatives...) {
+		if strings.Join(models.SentenceWords(candidate), " ") == normalized {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// tutorMessage builds the tutor's message from the model's answer
+func tutorMessage(conversationID int64, answer *tutorAnswer) models.TutorMessage {
+	var clues []string
+	for _, clue := range answer.Clues {
+		if clue = strings.TrimSpace(clue); clue != "" {
+			clues = append(clues, clue)
+		}
+	}
+
+	return models.TutorMessage{
+		ConversationID: conversationID,
+		Role:           models.TutorTutor,
+		Content:        answer.Reply,
+		Clues:          clues,
+	}
+}
+
+// historyMessage converts a stored message for the model. The tutor's
+// messages are sent back in the JSON format it answers in.
+func historyMessage(message models.TutorMessage) ai.Message {
+	if message.Role == models.TutorLearner {
+		content := message.Content
+		if message.SessionActivityID != nil {
+			content = attemptPrefix + content
+		}
+		return ai.Message{Role: ai.RoleUser, Content: content}
+	}
+
+	content := mustMarshal(struct {
+		Reply string   `json:"reply"`
+		Clues []string `json:"clues"`
+	}{message.Content, message.Clues})
+	return ai.Message{Role: ai.RoleAssistant, Content: string(content)}
+}
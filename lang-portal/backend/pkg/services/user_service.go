@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+)
+
+// ErrInvalidCredentials is returned when a username and password do not match
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// dummyPasswordHash is compared against when a username is unknown, a bcrypt
+// hash of the default cost, so a failed login takes as long whether or not
+// the username exists
+const dummyPasswordHash = "$2a$10$yIzCVWaUk8re7O5nUgdph.FKkUCpalWQQDP.8zhItwkkN34.tnjJ2"
+
+// Login is the result of a successful login
+type Login struct {
+	User      *models.User `json:"user"`
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+}
+
+// NewAPIKey is a freshly created API key, the key itself is only shown once
+type NewAPIKey struct {
+	*models.APIKey
+	Key string `json:"key"`
+}
+
+// UserService handles accounts, their roles and the credentials they authenticate with
+type UserService struct {
+	repo       *repository.UserRepository
+	apiKeyRepo *repository.APIKeyRepository
+	signer     *auth.Signer
+	tokenTTL   time.Duration
+}
+
+// NewUserService creates a new instance of UserService
+func NewUserService(
+	repo *repository.UserRepository,
+	apiKeyRepo *repository.APIKeyRepository,
+	signer *auth.Signer,
+	tokenTTL time.Duration,
+) *UserService {
+	return &UserService{
+		repo:       repo,
+		apiKeyRepo: apiKeyRepo,
+		signer:     signer,
+		tokenTTL:   tokenTTL,
+	}
+}
+
+// Register creates a learner account with a bcrypt hashed password
+func (s *UserService) Register(ctx context.Context, username, password string) (*models.User, error) {
+	if err := models.ValidatePassword(password); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &models.User{
+		Username:     username,
+		PasswordHash: string(hash),
+		Role:         models.RoleLearner,
+		CreatedAt:    time.Now(),
+	}
+	if err := user.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Login checks the credentials and issues a login token for the user
+func (s *UserService) Login(ctx context.Context, username, password string) (*Login, error) {
+	user, err := s.repo.GetByUsername(ctx, username)
+	if errors.Is(err, models.ErrNotFound) {
+		bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := s.signer.Issue(auth.Claims{
+		Subject: strconv.FormatInt(user.ID, 10),
+	}, auth.AudienceUser, s.tokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Login{
+		User:      user,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// GetUser retrieves an account by its ID
+func (s *UserService) GetUser(ctx context.Context, id int64) (*models.User, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// SetRole changes the role of an account
+func (s *UserService) SetRole(ctx context.Context, id int64, role models.Role) (*models.User, error) {
+	if !role.Valid() {
+		return nil, models.ErrInvalidRole
+	}
+
+	if err := s.repo.UpdateRole(ctx, id, role); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// AuthenticateToken resolves the principal a login token was issued for
+func (s *UserService) AuthenticateToken(ctx context.Context, token string) (*auth.Principal, error) {
+	claims, err := s.signer.Verify(token, auth.AudienceUser)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return nil, auth.ErrInvalidToken
+	}
+
+	// Load the account so role changes and deletions apply to issued tokens
+	user, err := s.repo.GetByID(ctx, userID)
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, fmt.Errorf("user %d no longer exists: %w", userID, auth.ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return principalFor(user, auth.MethodToken), nil
+}
+
+// AuthenticateAPIKey resolves the principal an API key belongs to
+func (s *UserService) AuthenticateAPIKey(ctx context.Context, key string) (*auth.Principal, error) {
+	user, err := s.apiKeyRepo.GetUserByKeyHash(ctx, auth.HashAPIKey(key))
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, fmt.Errorf("unknown api key: %w", auth.ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return principalFor(user, auth.MethodAPIKey), nil
+}
+
+// CreateAPIKey issues an API key that acts as the user
+func (s *UserService) CreateAPIKey(ctx context.Context, userID int64, name string) (*NewAPIKey, error) {
+	key, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &models.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    key[:len(auth.APIKeyPrefix)+8],
+		KeyHash:   hash,
+		CreatedAt: time.Now(),
+	}
+	if err := apiKey.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &NewAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// ListAPIKeys retrieves the API keys of the user
+func (s *UserService) ListAPIKeys(ctx context.Context, userID int64) ([]models.APIKey, error) {
+	return s.apiKeyRepo.ListByUserID(ctx, userID)
+}
+
+// DeleteAPIKey revokes an API key of the user
+func (s *UserService) DeleteAPIKey(ctx context.Context, userID, id int64) error {
+	return s.apiKeyRepo.Delete(ctx, userID, id)
+}
+
+func principalFor(user *models.User, method string) *auth.Principal {
+	return &auth.Principal{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+		Method:   method,
+	}
+}
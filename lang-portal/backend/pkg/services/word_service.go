@@ -2,23 +2,34 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
+	"strings"
 	"time"
 
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
 	"github.com/pavittarx/lang-portal/backend/pkg/models"
 	"github.com/pavittarx/lang-portal/backend/pkg/repository"
 )
 
 // WordService provides business logic for word-related operations
 type WordService struct {
-	repo repository.WordRepository
+	repo      repository.WordRepository
+	languages repository.LanguageRepository
+	sentences repository.SentenceRepository
+	assets    repository.AssetRepository
 }
 
 // NewWordService creates a new instance of WordService
-func NewWordService(repo repository.WordRepository) *WordService {
-	return &WordService{repo: repo}
+func NewWordService(
+	repo repository.WordRepository,
+	languages repository.LanguageRepository,
+	sentences repository.SentenceRepository,
+	assets repository.AssetRepository,
+) *WordService {
+	return &WordService{repo: repo, languages: languages, sentences: sentences, assets: assets}
 }
 
 // CreateWord handles the creation of a new word
@@ -30,14 +41,35 @@ func (s *WordService) CreateWord(ctx context.Context, word *models.Word) error {
 	if err := word.Validate(); err != nil {
 		return fmt.Errorf("word validation failed: %w", err)
 	}
+	if err := s.validateScripts(ctx, word); err != nil {
+		return err
+	}
+	if err := s.validateAudio(ctx, word); err != nil {
+		return err
+	}
 
 	// Generate scrambled word if not provided
 	word.GenerateScrambledWord()
 
+	// Romanize Hindi words entered without a romanization
+	if word.Romanized == "" && word.Language == hindi.Code {
+		word.Romanized = hindi.Romanize(word.Target, hindi.Hinglish)
+	}
+	setIPA(word, nil)
+	setForms(word, nil)
+
 	// Persist the word
 	return s.repo.Create(ctx, word)
 }
 
+// ValidateWord checks a word and the scripts of its language pair without storing it
+func (s *WordService) ValidateWord(ctx context.Context, word *models.Word) error {
+	if err := word.Validate(); err != nil {
+		return fmt.Errorf("word validation failed: %w", err)
+	}
+	return s.validateScripts(ctx, word)
+}
+
 // GetWordByID retrieves a word by its ID
 func (s *WordService) GetWordByID(ctx context.Context, id int64) (*models.Word, error) {
 	// Additional business logic can be added here
@@ -46,11 +78,30 @@ func (s *WordService) GetWordByID(ctx context.Context, id int64) (*models.Word,
 	return s.repo.GetByID(ctx, id)
 }
 
+// GetWordWithSentences retrieves a word with the example sentences that use it
+func (s *WordService) GetWordWithSentences(ctx context.Context, id int64) (*models.WordWithSentences, error) {
+	word, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sentences, err := s.sentences.ListByWordID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve word sentences: %w", err)
+	}
+
+	return &models.WordWithSentences{Word: *word, Sentences: sentences}, nil
+}
+
 // UpdateWord updates an existing word
 func (s *WordService) UpdateWord(ctx context.Context, word *models.Word) error {
 	// Additional business logic can be added here
 	// For example, checking permissions, logging changes, etc.
 
+	// Languages left out of the request keep their stored value rather than the defaults
+	keepLanguage := strings.TrimSpace(word.Language) == ""
+	keepNativeLanguage := strings.TrimSpace(word.NativeLanguage) == ""
+
 	// Validate the word
 	if err := word.Validate(); err != nil {
 		return fmt.Errorf("word validation failed: %w", err)
@@ -63,23 +114,151 @@ func (s *WordService) UpdateWord(ctx context.Context, word *models.Word) error {
 	}
 
 	// Merge existing and new word data
-	if word.Hindi == "" {
-		word.Hindi = existingWord.Hindi
+	if keepLanguage {
+		word.Language = existingWord.Language
+	}
+	if keepNativeLanguage {
+		word.NativeLanguage = existingWord.NativeLanguage
+	}
+	// A romanization left out is kept while the target is, and romanized
+	// again from a new Hindi target as when the word was created
+	if word.Romanized == "" && word.Target == existingWord.Target {
+		word.Romanized = existingWord.Romanized
+	} else if word.Romanized == "" && word.Language == hindi.Code {
+		word.Romanized = hindi.Romanize(word.Target, hindi.Hinglish)
 	}
-	if word.English == "" {
-		word.English = existingWord.English
+	if word.AudioAssetID == nil {
+		word.AudioAssetID = existingWord.AudioAssetID
 	}
-	if word.Hinglish == "" {
-		word.Hinglish = existingWord.Hinglish
+	if err := s.validateScripts(ctx, word); err != nil {
+		return err
+	}
+	if err := s.validateAudio(ctx, word); err != nil {
+		return err
 	}
 	if word.Scrambled == "" {
 		word.GenerateScrambledWord()
 	}
+	setIPA(word, existingWord)
+	setForms(word, existingWord)
 
 	// Update the word
 	return s.repo.Update(ctx, word)
 }
 
+// ResetIPA drops the IPA an editor entered for a word and generates it again
+func (s *WordService) ResetIPA(ctx context.Context, id int64) (*models.Word, error) {
+	word, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	word.IPA, word.IPAOverride = GenerateIPA(word), false
+	if err := s.repo.SetIPA(ctx, id, word.IPA, word.IPAOverride); err != nil {
+		return nil, err
+	}
+	return word, nil
+}
+
+// GenerateIPA returns the generated IPA pronunciation of a word, which is
+// empty for languages without a converter
+func GenerateIPA(word *models.Word) string {
+	if word.Language == hindi.Code {
+		return hindi.IPA(word.Target)
+	}
+	return ""
+}
+
+// setIPA generates the IPA of a word unless an editor entered another one.
+// On update, an IPA left out or sent back unchanged keeps an editor's entry
+// and follows the target otherwise.
+func setIPA(word, existing *models.Word) {
+	if existing != nil && (word.IPA == "" || word.IPA == existing.IPA) {
+		if existing.IPAOverride {
+			word.IPA, word.IPAOverride = existing.IPA, true
+			return
+		}
+		word.IPA = ""
+	}
+
+	generated := GenerateIPA(word)
+	word.IPAOverride = word.IPA != "" && word.IPA != generated
+	if !word.IPAOverride {
+		word.IPA = generated
+	}
+}
+
+// ResetForms drops the forms an editor entered for a word and generates them again
+func (s *WordService) ResetForms(ctx context.Context, id int64) (*models.Word, error) {
+	word, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	word.WordForms, word.FormsOverride = GenerateForms(word), false
+	if err := s.repo.SetForms(ctx, id, word.WordForms, word.FormsOverride); err != nil {
+		return nil, err
+	}
+	return word, nil
+}
+
+// GenerateForms returns the regular inflected forms of a word by its grammar:
+// the declension of a noun with a gender and the root of a verb. They are
+// empty for other words and languages without a generator.
+func GenerateForms(word *models.Word) models.WordForms {
+	if word.Language != hindi.Code {
+		return models.WordForms{}
+	}
+
+	switch word.PartOfSpeech {
+	case models.PartOfSpeechNoun:
+		if word.Gender == "" {
+			return models.WordForms{}
+		}
+		declension := hindi.Decline(word.Target, word.Gender == models.GenderFeminine)
+		return models.WordForms{
+			Plural:        declension.Plural,
+			Oblique:       declension.Oblique,
+			ObliquePlural: declension.ObliquePlural,
+		}
+	case models.PartOfSpeechVerb:
+		root, _ := hindi.VerbRoot(word.Target)
+		return models.WordForms{Root: root}
+	}
+	return models.WordForms{}
+}
+
+// setForms generates the forms of a word unless an editor entered others,
+// filling the forms left out with generated ones. On update, forms left out
+// or sent back unchanged keep an editor's entry while the part of speech
+// stays the same, and follow the target and grammar otherwise.
+func setForms(word, existing *models.Word) {
+	if existing != nil && (word.WordForms.IsZero() || word.WordForms == existing.WordForms) {
+		if existing.FormsOverride && word.PartOfSpeech == existing.PartOfSpeech {
+			word.WordForms, word.FormsOverride = existing.WordForms, true
+			return
+		}
+		word.WordForms = models.WordForms{}
+	}
+
+	generated := GenerateForms(word)
+	forms := word.WordForms
+	for _, form := range []struct {
+		entered   *string
+		generated string
+	}{
+		{&forms.Root, generated.Root},
+		{&forms.Plural, generated.Plural},
+		{&forms.Oblique, generated.Oblique},
+		{&forms.ObliquePlural, generated.ObliquePlural},
+	} {
+		if *form.entered == "" {
+			*form.entered = form.generated
+		}
+	}
+	word.WordForms, word.FormsOverride = forms, forms != generated
+}
+
 // DeleteWord removes a word by its ID
 func (s *WordService) DeleteWord(ctx context.Context, id int64) error {
 	// Additional business logic can be added here
@@ -112,11 +291,12 @@ func (s *WordService) ListWords(ctx context.Context, params repository.ListWords
 	return words, totalCount, nil
 }
 
-// SearchWords provides a convenient method for searching words
-func (s *WordService) SearchWords(ctx context.Context, query string, language string) ([]models.Word, int, error) {
+// SearchWords provides a convenient method for searching words, field is one
+// of "target", "native", "romanized" or "ipa" and searches all of them when empty
+func (s *WordService) SearchWords(ctx context.Context, query string, field string) ([]models.Word, int, error) {
 	params := repository.ListWordsParams{
 		Search:   query,
-		Language: language,
+		Field:    field,
 		Page:     1,
 		PageSize: 50, // Allow a larger default page size for search results
 	}
@@ -134,14 +314,9 @@ func (s *WordService) GetWordsByGroupID(ctx context.Context, groupID int64) ([]m
 	return s.repo.GetWordsByGroupID(ctx, groupID)
 }
 
-// GetWords retrieves a paginated list of words
-func (s *WordService) GetWords(ctx context.Context, page, pageSize int) ([]*models.Word, int64, error) {
-	// Prepare parameters for list operation
-	params := repository.ListWordsParams{
-		Page:     page,
-		PageSize: pageSize,
-	}
-
+// GetWords retrieves a paginated list of words, filtered by language, search
+// and grammar as given in params
+func (s *WordService) GetWords(ctx context.Context, params repository.ListWordsParams) ([]*models.Word, int64, error) {
 	// Retrieve words with pagination
 	words, totalCount, err := s.repo.List(ctx, params)
 	if err != nil {
@@ -185,8 +360,8 @@ func (s *WordService) GetRandomWordWithGroup(ctx context.Context, groupID *int64
 func (s *WordService) SearchWordsWithTerm(ctx context.Context, searchTerm string) ([]*models.Word, error) {
 	// Prepare search parameters
 	params := repository.ListWordsParams{
-		Search: searchTerm,
-		Page:   1,
+		Search:   searchTerm,
+		Page:     1,
 		PageSize: 50, // Allow a larger default page size for search results
 	}
 
@@ -221,3 +396,60 @@ func (s *WordService) GetWordsByGroup(ctx context.Context, groupID int64) ([]*mo
 
 	return wordPtrs, nil
 }
+
+// ListLanguages retrieves the languages words can be written in
+func (s *WordService) ListLanguages(ctx context.Context) ([]models.Language, error) {
+	return s.languages.List(ctx)
+}
+
+// CreateLanguage adds a language words can be written in
+func (s *WordService) CreateLanguage(ctx context.Context, language *models.Language) error {
+	return s.languages.Create(ctx, language)
+}
+
+// validateScripts checks the word against the scripts of its language pair
+func (s *WordService) validateScripts(ctx context.Context, word *models.Word) error {
+	target, err := lookupLanguage(ctx, s.languages, word.Language)
+	if err != nil {
+		return err
+	}
+	native, err := lookupLanguage(ctx, s.languages, word.NativeLanguage)
+	if err != nil {
+		return err
+	}
+
+	if err := word.ValidateScripts(target, native); err != nil {
+		return fmt.Errorf("word validation failed: %w", err)
+	}
+	return nil
+}
+
+// validateAudio checks that the word's pronunciation is an uploaded audio asset
+func (s *WordService) validateAudio(ctx context.Context, word *models.Word) error {
+	if word.AudioAssetID == nil {
+		return nil
+	}
+
+	asset, err := s.assets.GetByID(ctx, *word.AudioAssetID)
+	if errors.Is(err, models.ErrNotFound) {
+		return fmt.Errorf("audio asset %d does not exist: %w", *word.AudioAssetID, models.ErrInvalidInput)
+	}
+	if err != nil {
+		return err
+	}
+	if asset.Kind != models.AssetAudio {
+		return fmt.Errorf("asset %d is not audio: %w", asset.ID, models.ErrInvalidInput)
+	}
+
+	word.AudioURL = asset.URL
+	return nil
+}
+
+// lookupLanguage looks up a language, reporting unknown codes as invalid input
+func lookupLanguage(ctx context.Context, languages repository.LanguageRepository, code string) (*models.Language, error) {
+	language, err := languages.GetByCode(ctx, code)
+	if errors.Is(err, models.ErrNotFound) {
+		return nil, fmt.Errorf("unknown language %q: %w", code, models.ErrInvalidLanguage)
+	}
+	return language, err
+}
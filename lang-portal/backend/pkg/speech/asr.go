@@ -0,0 +1,127 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+// ErrRecognition is returned when the speech recognition service cannot be
+// reached or fails
+var ErrRecognition = errors.New("speech recognition request failed")
+
+// maxTranscriptBytes limits the response read from a transcription service
+const maxTranscriptBytes = 1 << 20
+
+// ASRProvider turns recorded speech into text
+type ASRProvider interface {
+	// Model identifies the service and model the transcripts come from
+	Model() string
+
+	// Transcribe writes down the speech in the audio, spoken in the language
+	// with the ISO 639 code
+	Transcribe(ctx context.Context, audio *Audio, language string) (string, error)
+}
+
+// audioExtensions names uploaded recordings, transcription services pick the
+// decoder from the file name
+var audioExtensions = map[string]string{
+	"audio/mpeg": ".mp3",
+	"audio/ogg":  ".ogg",
+	"audio/wav":  ".wav",
+	"audio/webm": ".webm",
+	"audio/mp4":  ".m4a",
+}
+
+// WhisperASR transcribes speech with an OpenAI-compatible
+// /audio/transcriptions endpoint, as served by OpenAI and by local Whisper
+// servers such as faster-whisper-server
+type WhisperASR struct {
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+// NewWhisperASR creates a new instance of WhisperASR. The URL is the API
+// base, such as "http://localhost:8000/v1".
+func NewWhisperASR(cfg HTTPConfig) *WhisperASR {
+	return &WhisperASR{cfg: cfg, client: httpClient(cfg)}
+}
+
+type whisperResponse struct {
+	Text string `json:"text"`
+}
+
+// Model identifies the Whisper model
+func (p *WhisperASR) Model() string {
+	return "whisper:" + p.cfg.Model
+}
+
+// Transcribe uploads the audio as a multipart form, telling the model the
+// language so short recordings are not mistaken for another one
+func (p *WhisperASR) Transcribe(ctx context.Context, audio *Audio, language string) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	fields := map[string]string{
+		"model":           p.cfg.Model,
+		"language":        language,
+		"response_format": "json",
+	}
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := form.WriteField(name, value); err != nil {
+			return "", err
+		}
+	}
+	ext := audioExtensions[audio.ContentType]
+	if ext == "" {
+		ext = ".wav"
+	}
+	file, err := form.CreateFormFile("file", "recording"+ext)
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(audio.Data); err != nil {
+		return "", err
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	url := strings.TrimSuffix(p.cfg.URL, "/") + "/audio/transcriptions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to build transcription request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	if p.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.cfg.APIKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRecognition, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxTranscriptBytes))
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to read response: %v", ErrRecognition, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: service returned %s: %s", ErrRecognition, resp.Status, snippet(data))
+	}
+
+	var transcript whisperResponse
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return "", fmt.Errorf("%w: malformed response: %s", ErrRecognition, snippet(data))
+	}
+	return strings.TrimSpace(transcript.Text), nil
+}
@@ -0,0 +1,62 @@
+package speech
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// FakeASR is an ASRProvider that answers with scripted transcripts, for tests
+// and for working on the portal without a speech recognizer
+type FakeASR struct {
+	mu          sync.Mutex
+	transcripts []string
+	requests    []TranscriptionRequest
+}
+
+// TranscriptionRequest is a recording the fake was asked to transcribe
+type TranscriptionRequest struct {
+	Audio    *Audio
+	Language string
+}
+
+// NewFakeASR creates a FakeASR answering with the transcripts in order
+func NewFakeASR(transcripts ...string) *FakeASR {
+	return &FakeASR{transcripts: transcripts}
+}
+
+// Model identifies the fake
+func (f *FakeASR) Model() string {
+	return "fake"
+}
+
+// Respond queues more transcripts
+func (f *FakeASR) Respond(transcripts ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.transcripts = append(f.transcripts, transcripts...)
+}
+
+// Transcribe records the request and returns the next transcript
+func (f *FakeASR) Transcribe(ctx context.Context, audio *Audio, language string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, TranscriptionRequest{Audio: audio, Language: language})
+	if len(f.transcripts) == 0 {
+		return "", fmt.Errorf("%w: fake has no transcript left", ErrRecognition)
+	}
+	transcript := f.transcripts[0]
+	f.transcripts = f.transcripts[1:]
+	return transcript, nil
+}
+
+// Requests returns the recordings received so far
+func (f *FakeASR) Requests() []TranscriptionRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]TranscriptionRequest(nil), f.requests...)
+}
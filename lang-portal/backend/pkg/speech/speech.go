@@ -0,0 +1,114 @@
+// Package speech talks to speech services behind interfaces, so a hosted API
+// can be swapped for a model running on the team's own machines
+package speech
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxAudioBytes limits the audio read from a service response
+const maxAudioBytes = 20 << 20
+
+// defaultTimeout bounds a request when the config does not set a timeout
+const defaultTimeout = 30 * time.Second
+
+// Audio is synthesized speech
+type Audio struct {
+	Data        []byte
+	ContentType string
+}
+
+// TTSProvider turns text into speech
+type TTSProvider interface {
+	// Voice identifies the service, model and voice, audio generated with
+	// another voice sounds different and is cached apart
+	Voice() string
+
+	// Synthesize speaks text written in the language with the ISO 639 code
+	Synthesize(ctx context.Context, text, language string) (*Audio, error)
+}
+
+// HTTPConfig configures a speech service reached over HTTP
+type HTTPConfig struct {
+	URL     string
+	Model   string
+	Voice   string
+	APIKey  string // sent as a bearer token when set
+	Timeout time.Duration
+}
+
+// CacheKey returns the content address of the speech a provider generates
+// for text, so regenerating it can reuse earlier audio
+func CacheKey(provider TTSProvider, text, language string) string {
+	sum := sha256.Sum256([]byte(provider.Voice() + "\x00" + language + "\x00" + strings.TrimSpace(text)))
+	return hex.EncodeToString(sum[:])
+}
+
+// httpClient returns a client with the configured timeout
+func httpClient(cfg HTTPConfig) *http.Client {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// postAudio posts a JSON body and reads the audio in the response, using
+// fallbackType when the service does not say what it returned
+func postAudio(ctx context.Context, client *http.Client, url, apiKey string, body []byte, fallbackType string) (*Audio, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build speech request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("speech request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAudioBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read speech response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("speech service returned %s: %s", resp.Status, snippet(data))
+	}
+	if len(data) > maxAudioBytes {
+		return nil, fmt.Errorf("speech service returned more than %d bytes", maxAudioBytes)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("speech service returned no audio")
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" || strings.HasPrefix(contentType, "application/octet-stream") {
+		contentType = fallbackType
+	}
+	if strings.HasPrefix(contentType, "application/json") || strings.HasPrefix(contentType, "text/") {
+		return nil, fmt.Errorf("speech service returned %s instead of audio: %s", contentType, snippet(data))
+	}
+
+	return &Audio{Data: data, ContentType: contentType}, nil
+}
+
+// snippet shortens an error body for a message
+func snippet(data []byte) string {
+	text := strings.TrimSpace(string(data))
+	if len(text) > 200 {
+		text = text[:200] + "…"
+	}
+	return text
+}
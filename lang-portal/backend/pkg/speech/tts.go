@@ -0,0 +1,86 @@
+package speech
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OpenAITTS synthesizes speech with an OpenAI-compatible /audio/speech
+// endpoint, as served by OpenAI and by local servers such as the OPEA TTS
+// microservice
+type OpenAITTS struct {
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+// NewOpenAITTS creates a new instance of OpenAITTS. The URL is the API base,
+// such as "http://localhost:9088/v1".
+func NewOpenAITTS(cfg HTTPConfig) *OpenAITTS {
+	return &OpenAITTS{cfg: cfg, client: httpClient(cfg)}
+}
+
+type openAISpeechRequest struct {
+	Model          string `json:"model"`
+	Input          string `json:"input"`
+	Voice          string `json:"voice"`
+	ResponseFormat string `json:"response_format"`
+}
+
+// Voice identifies the model and voice
+func (p *OpenAITTS) Voice() string {
+	return fmt.Sprintf("openai:%s:%s", p.cfg.Model, p.cfg.Voice)
+}
+
+// Synthesize requests MP3 speech for the text. The endpoint picks the
+// pronunciation from the text itself, so the language is not sent.
+func (p *OpenAITTS) Synthesize(ctx context.Context, text, _ string) (*Audio, error) {
+	body, err := json.Marshal(openAISpeechRequest{
+		Model:          p.cfg.Model,
+		Input:          text,
+		Voice:          p.cfg.Voice,
+		ResponseFormat: "mp3",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSuffix(p.cfg.URL, "/") + "/audio/speech"
+	return postAudio(ctx, p.client, url, p.cfg.APIKey, body, "audio/mpeg")
+}
+
+// PiperTTS synthesizes speech with the HTTP server of the Piper neural TTS
+// engine, which answers a JSON request with WAV audio
+type PiperTTS struct {
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+// NewPiperTTS creates a new instance of PiperTTS. The URL is the address the
+// server listens on, such as "http://localhost:5000".
+func NewPiperTTS(cfg HTTPConfig) *PiperTTS {
+	return &PiperTTS{cfg: cfg, client: httpClient(cfg)}
+}
+
+type piperRequest struct {
+	Text  string `json:"text"`
+	Voice string `json:"voice,omitempty"`
+}
+
+// Voice identifies the Piper voice model
+func (p *PiperTTS) Voice() string {
+	return "piper:" + p.cfg.Voice
+}
+
+// Synthesize requests WAV speech for the text. Piper voices are trained for
+// one language, so the voice decides the language.
+func (p *PiperTTS) Synthesize(ctx context.Context, text, _ string) (*Audio, error) {
+	body, err := json.Marshal(piperRequest{Text: text, Voice: p.cfg.Voice})
+	if err != nil {
+		return nil, err
+	}
+
+	return postAudio(ctx, p.client, p.cfg.URL, p.cfg.APIKey, body, "audio/wav")
+}
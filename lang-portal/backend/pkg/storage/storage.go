@@ -0,0 +1,27 @@
+// Package storage keeps uploaded files behind an interface, so the local disk
+// can later be swapped for an S3-compatible object store
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotExist is returned when no file is stored under a key
+var ErrNotExist = errors.New("file does not exist")
+
+// ErrInvalidKey is returned for keys that are empty or escape the storage root
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Storage stores files under slash separated keys such as "ab/abcdef.mp3"
+type Storage interface {
+	// Put stores the content of r under key, replacing any existing file
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Open returns the file stored under key, seekable for range requests
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+
+	// Delete removes the file stored under key, deleting a missing file is not an error
+	Delete(ctx context.Context, key string) error
+}
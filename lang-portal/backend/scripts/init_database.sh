@@ -12,23 +12,45 @@ TIMESTAMP="$(date '+%Y-%m-%d %H:%M:%S')"
 # Remove existing database if it exists
 rm -f "${DB_FILE}"
 
+# The schema already contains every migration, so mark them as applied
+MIGRATIONS_SQL=""
+for MIGRATION in "${PROJECT_DIR}"/db/migrations/*.sql; do
+    [ -e "${MIGRATION}" ] || continue
+    MIGRATIONS_SQL="${MIGRATIONS_SQL}INSERT INTO schema_migrations (name) VALUES ('$(basename "${MIGRATION}")');"
+done
+
 # Initialize SQLite database
 sqlite3 "${DB_FILE}" << EOF
 -- Create schema
 .read ${PROJECT_DIR}/db/schema.sql
 
--- Import groups with timestamp
+-- Record migrations included in the schema
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    name TEXT PRIMARY KEY,
+    applied_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+${MIGRATIONS_SQL}
+
+-- Import languages with timestamp
 .mode csv
+.import --skip 1 ${PROJECT_DIR}/db/seeds/languages.csv languages
+
+-- Update languages created_at
+UPDATE languages SET created_at = '${TIMESTAMP}' WHERE created_at IS NULL;
+
+-- Import groups with timestamp
 .import --skip 1 ${PROJECT_DIR}/db/seeds/groups.csv groups
 
 -- Update groups created_at
 UPDATE groups SET created_at = '${TIMESTAMP}' WHERE created_at IS NULL;
 
--- Import words with timestamp
-.import --skip 1 ${PROJECT_DIR}/db/seeds/words.csv words
-
--- Update words created_at
-UPDATE words SET created_at = '${TIMESTAMP}' WHERE created_at IS NULL;
+-- Import words with timestamp, through a staging table as the seeds leave
+-- out the generated ipa and the grammar columns
+CREATE TEMP TABLE words_seed (id, language, native_language, target, scrambled, romanized, native, difficulty);
+.import --skip 1 ${PROJECT_DIR}/db/seeds/words.csv words_seed
+INSERT INTO words (id, language, native_language, target, scrambled, romanized, native, difficulty, created_at)
+SELECT id, language, native_language, target, scrambled, romanized, native, difficulty, '${TIMESTAMP}' FROM words_seed;
+DROP TABLE words_seed;
 
 -- Import word groups with timestamp
 .import --skip 1 ${PROJECT_DIR}/db/seeds/word_groups.csv word_groups
@@ -36,6 +58,18 @@ UPDATE words SET created_at = '${TIMESTAMP}' WHERE created_at IS NULL;
 -- Update word_groups created_at
 UPDATE word_groups SET created_at = '${TIMESTAMP}' WHERE created_at IS NULL;
 
+-- Import example sentences with timestamp
+.import --skip 1 ${PROJECT_DIR}/db/seeds/sentences.csv sentences
+
+-- Update sentences created_at
+UPDATE sentences SET created_at = '${TIMESTAMP}' WHERE created_at IS NULL;
+
+-- Import word sentences with timestamp
+.import --skip 1 ${PROJECT_DIR}/db/seeds/word_sentences.csv word_sentences
+
+-- Update word_sentences created_at
+UPDATE word_sentences SET created_at = '${TIMESTAMP}' WHERE created_at IS NULL;
+
 -- Import study activities with timestamp
 .import --skip 1 ${PROJECT_DIR}/db/seeds/study_activities.csv study_activities
 
@@ -45,14 +79,13 @@ UPDATE study_activities SET created_at = '${TIMESTAMP}' WHERE created_at IS NULL
 -- Verify study activities import
 SELECT 'Study Activities count: ' || COUNT(*) FROM study_activities;
 
--- Populate full-text search index
-INSERT INTO words_fts (hindi, hinglish, english)
-SELECT hindi, hinglish, english FROM words;
-
 -- Verify imports
+SELECT 'Languages count: ' || COUNT(*) FROM languages;
 SELECT 'Groups count: ' || COUNT(*) FROM groups;
 SELECT 'Words count: ' || COUNT(*) FROM words;
 SELECT 'Word Groups count: ' || COUNT(*) FROM word_groups;
+SELECT 'Sentences count: ' || COUNT(*) FROM sentences;
+SELECT 'Word Sentences count: ' || COUNT(*) FROM word_sentences;
 SELECT 'Study Activities count: ' || COUNT(*) FROM study_activities;
 SELECT 'First group created_at: ' || created_at FROM groups LIMIT 1;
 SELECT 'First word created_at: ' || created_at FROM words LIMIT 1;
@@ -0,0 +1,47 @@
+#!/bin/bash
+
+# Set the path to the project directory
+PROJECT_DIR="$( cd "$( dirname "${BASH_SOURCE[0]}" )/.." && pwd )"
+
+# Database file path
+DB_FILE="${PROJECT_DIR}/lang-portal.db"
+
+# Migrations directory
+MIGRATIONS_DIR="${PROJECT_DIR}/db/migrations"
+
+if [ ! -f "${DB_FILE}" ]; then
+    echo "Database not found, run scripts/init_database.sh first."
+    exit 1
+fi
+
+# Track applied migrations
+sqlite3 "${DB_FILE}" "CREATE TABLE IF NOT EXISTS schema_migrations (
+    name TEXT PRIMARY KEY,
+    applied_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);"
+
+for MIGRATION in "${MIGRATIONS_DIR}"/*.sql; do
+    [ -e "${MIGRATION}" ] || continue
+    NAME="$(basename "${MIGRATION}")"
+
+    APPLIED="$(sqlite3 "${DB_FILE}" "SELECT COUNT(*) FROM schema_migrations WHERE name = '${NAME}';")"
+    if [ "${APPLIED}" != "0" ]; then
+        continue
+    fi
+
+    echo "Applying ${NAME}"
+    sqlite3 "${DB_FILE}" << EOSQL
+PRAGMA foreign_keys = ON;
+BEGIN;
+.read ${MIGRATION}
+INSERT INTO schema_migrations (name) VALUES ('${NAME}');
+COMMIT;
+EOSQL
+
+    if [ $? -ne 0 ]; then
+        echo "Error applying ${NAME}."
+        exit 1
+    fi
+done
+
+echo "Database migrated successfully!"
@@ -0,0 +1,166 @@
+package activities_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSentences is an in-memory SentenceSource
+type fakeSentences struct {
+	sentences []models.Sentence
+}
+
+func (f *fakeSentences) GetByID(_ context.Context, id int64) (*models.Sentence, error) {
+	for i := range f.sentences {
+		if f.sentences[i].ID == id {
+			return &f.sentences[i], nil
+		}
+	}
+	return nil, fmt.Errorf("sentence with ID %d not found", id)
+}
+
+func (f *fakeSentences) GetRandomForWords(_ context.Context, wordIDs []int64) (*models.Sentence, error) {
+	for i := range f.sentences {
+		for _, linked := range f.sentences[i].WordIDs {
+			for _, id := range wordIDs {
+				if linked == id {
+					return &f.sentences[i], nil
+				}
+			}
+		}
+		if len(wordIDs) == 0 {
+			return &f.sentences[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no sentence linked to %v", wordIDs)
+}
+
+func newClozeEngine(sentence string) *activities.ClozeEngine {
+	words := &fakeWords{
+		words: []models.Word{
+			{ID: 1, Language: "hi", Target: "पहाड़", Native: "Mountain"},
+			{ID: 2, Language: "hi", Target: "घर", Native: "House"},
+			{ID: 3, Language: "hi", Target: "लड़का", Native: "Boy"},
+		},
+		groups: map[int64][]int64{1: {1}, 2: {2}, 3: {3}},
+	}
+	sentences := &fakeSentences{sentences: []models.Sentence{
+		{ID: 1, Language: "hi", Target: sentence, WordIDs: []int64{1, 2, 3}},
+	}}
+	return activities.NewClozeEngine(words, sentences)
+}
+
+func TestClozeEngine(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		sentence string
+		groupID  int64
+		prompt   string
+		hints    []string
+		expected string
+	}{
+		{
+			name:     "inflected form",
+			sentence: "पहाड़ों की यात्रा मज़ेदार थी।",
+			groupID:  1,
+			prompt:   "____ की यात्रा मज़ेदार थी।",
+			hints:    []string{"प…", "Mountain"},
+			expected: "पहाड़ों",
+		},
+		{
+			name:     "joined postposition",
+			sentence: "वह घरमें है।",
+			groupID:  2,
+			prompt:   "वह ____में है।",
+			hints:    []string{"घ…", "House"},
+			expected: "घर",
+		},
+		{
+			name:     "oblique form before punctuation",
+			sentence: "मैंने लड़के, को देखा।",
+			groupID:  3,
+			prompt:   "मैंने ____, को देखा।",
+			hints:    []string{"ल…", "Boy"},
+			expected: "लड़के",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newClozeEngine(tt.sentence)
+			challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{
+				Session: &models.Session{GroupID: &tt.groupID},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, activities.ClozeType, challenge.Type)
+			assert.Equal(t, tt.prompt, challenge.Prompt)
+			assert.Equal(t, tt.hints, challenge.Hints)
+			assert.NotContains(t, string(challenge.Payload), tt.expected)
+
+			grade, err := engine.GradeAnswer(ctx, challenge, " "+tt.expected+" ")
+			assert.NoError(t, err)
+			assert.True(t, grade.Correct)
+			assert.Equal(t, 100, grade.Score)
+			assert.Equal(t, tt.expected, grade.Expected)
+		})
+	}
+}
+
+func TestClozeEngineGrading(t *testing.T) {
+	ctx := context.Background()
+	engine := newClozeEngine("पहाड़ों की यात्रा मज़ेदार थी।")
+
+	groupID := int64(1)
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{
+		Session: &models.Session{GroupID: &groupID},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		input   string
+		correct bool
+		score   int
+	}{
+		{name: "form in the sentence", input: "पहाड़ों", correct: true, score: 100},
+		{name: "chandrabindu spelling", input: "पहाड़ोँ", correct: true, score: 100},
+		{name: "dictionary form", input: "पहाड़", correct: true, score: 50},
+		{name: "other inflection", input: "पहाड़ो", correct: true, score: 50},
+		{name: "different word", input: "घर", correct: false, score: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade, err := engine.GradeAnswer(ctx, challenge, tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.correct, grade.Correct)
+			assert.Equal(t, tt.score, grade.Score)
+			assert.Equal(t, "पहाड़ों", grade.Expected)
+		})
+	}
+
+	// A joined postposition is shown in the prompt but may be typed along
+	engine = newClozeEngine("वह घरमें है।")
+	groupID = 2
+	challenge, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{
+		Session: &models.Session{GroupID: &groupID},
+	})
+	assert.NoError(t, err)
+
+	grade, err := engine.GradeAnswer(ctx, challenge, "घरमें")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, 100, grade.Score)
+
+	stale, _ := json.Marshal(map[string]int64{"sentence_id": 1, "word_id": 2, "token": 0})
+	_, err = engine.GradeAnswer(ctx, &activities.Challenge{Type: activities.ClozeType, Payload: stale}, "घर")
+	assert.ErrorIs(t, err, activities.ErrInvalidChallenge)
+}
@@ -0,0 +1,101 @@
+package activities_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeVerbs struct {
+	verbs        []models.Word
+	conjugations map[int64][]models.Conjugation
+}
+
+func (f *fakeVerbs) ListVerbs(_ context.Context, _ *int64) ([]models.Word, error) {
+	return f.verbs, nil
+}
+
+func (f *fakeVerbs) Conjugate(_ context.Context, id int64) (*models.WordConjugations, error) {
+	for _, verb := range f.verbs {
+		if verb.ID == id {
+			return &models.WordConjugations{Word: verb, Conjugations: f.conjugations[id]}, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+func TestConjugationEngine(t *testing.T) {
+	ctx := context.Background()
+	verbs := &fakeVerbs{
+		verbs: []models.Word{{ID: 1, Target: "करना", Native: "To do"}},
+		conjugations: map[int64][]models.Conjugation{1: {
+			{Tense: "future", Person: 3, Gender: "masculine", Subject: "वह", Form: "करेगा"},
+			{Tense: "future", Person: 3, Gender: "feminine", Subject: "वह", Form: "करेगी"},
+			{Tense: "past_perfective", Person: 3, Gender: "masculine", Subject: "उसने", Ergative: true, Form: "किया"},
+			{Tense: "past_perfective", Person: 3, Gender: "feminine", Subject: "उसने", Ergative: true, Form: "किया"},
+		}},
+	}
+	engine := activities.NewConjugationEngine(verbs)
+
+	// The gender is named when the form depends on it
+	activity := &models.StudyActivity{Type: activities.ConjugationType, Config: json.RawMessage(`{"tenses":["future"]}`)}
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+	assert.NoError(t, err)
+	assert.Equal(t, activities.ConjugationType, challenge.Type)
+	assert.Contains(t, []string{"करना, future: वह (masculine) ___", "करना, future: वह (feminine) ___"}, challenge.Prompt)
+	assert.Equal(t, []string{"To do"}, challenge.Hints)
+
+	expected := "करेगा"
+	if challenge.Prompt == "करना, future: वह (feminine) ___" {
+		expected = "करेगी"
+	}
+	grade, err := engine.GradeAnswer(ctx, challenge, " "+expected+" ")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, 100, grade.Score)
+
+	// The subject may be written with the form
+	grade, err = engine.GradeAnswer(ctx, challenge, "वह "+expected)
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+
+	grade, err = engine.GradeAnswer(ctx, challenge, "करता")
+	assert.NoError(t, err)
+	assert.False(t, grade.Correct)
+	assert.Equal(t, expected, grade.Expected)
+	assert.Equal(t, "वह "+expected, grade.Feedback)
+
+	// The ergative subject is prompted and explained
+	activity.Config = json.RawMessage(`{"tenses":["past_perfective"]}`)
+	challenge, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+	assert.NoError(t, err)
+	assert.Equal(t, "करना, past perfective: उसने ___", challenge.Prompt)
+	assert.Len(t, challenge.Hints, 2)
+
+	grade, err = engine.GradeAnswer(ctx, challenge, "उसने किया")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+
+	// No form of the tense
+	activity.Config = json.RawMessage(`{"tenses":["imperative"]}`)
+	_, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+
+	// No verbs
+	_, err = activities.NewConjugationEngine(&fakeVerbs{}).GenerateChallenge(ctx, activities.ChallengeRequest{})
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+}
+
+func TestConjugationEngine_ValidateConfig(t *testing.T) {
+	engine := activities.NewConjugationEngine(&fakeVerbs{})
+
+	assert.NoError(t, engine.ValidateConfig(json.RawMessage(`{}`)))
+	assert.NoError(t, engine.ValidateConfig(json.RawMessage(`{"tenses":["future","imperative"]}`)))
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"tenses":["pluperfect"]}`)))
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"tenses":"future"}`)))
+}
@@ -0,0 +1,168 @@
+package activities_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeWords is an in-memory WordSource
+type fakeWords struct {
+	words  []models.Word
+	groups map[int64][]int64
+}
+
+func newFakeWords() *fakeWords {
+	return &fakeWords{
+		words: []models.Word{
+			{ID: 1, Target: "कमरा", Native: "Room"},
+			{ID: 2, Target: "खुश", Native: "Happy"},
+		},
+		groups: map[int64][]int64{1: {1}, 2: {2}},
+	}
+}
+
+func (f *fakeWords) GetByID(_ context.Context, id int64) (*models.Word, error) {
+	for i := range f.words {
+		if f.words[i].ID == id {
+			return &f.words[i], nil
+		}
+	}
+	return nil, fmt.Errorf("word with ID %d not found", id)
+}
+
+func (f *fakeWords) GetRandomWord(_ context.Context) (*models.Word, error) {
+	return &f.words[0], nil
+}
+
+func (f *fakeWords) GetWordsByGroupID(ctx context.Context, groupID int64) ([]models.Word, error) {
+	var words []models.Word
+	for _, id := range f.groups[groupID] {
+		word, _ := f.GetByID(ctx, id)
+		words = append(words, *word)
+	}
+	return words, nil
+}
+
+func (f *fakeWords) List(_ context.Context, _, _ int, _ string) ([]models.Group, int, error) {
+	return []models.Group{{ID: 1, Name: "Home"}, {ID: 2, Name: "Emotions"}}, 2, nil
+}
+
+func (f *fakeWords) CountWords(_ context.Context, groupIDs []int64) (map[int64]int, error) {
+	counts := make(map[int64]int)
+	for _, id := range groupIDs {
+		if len(f.groups[id]) > 0 {
+			counts[id] = len(f.groups[id])
+		}
+	}
+	return counts, nil
+}
+
+func (f *fakeWords) GetGroupsByWordID(ctx context.Context, wordID int64) ([]models.Group, error) {
+	all, _, _ := f.List(ctx, 1, 0, "")
+	var groups []models.Group
+	for _, group := range all {
+		for _, id := range f.groups[group.ID] {
+			if id == wordID {
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups, nil
+}
+
+func TestUnscrambleEngine(t *testing.T) {
+	ctx := context.Background()
+	engine := activities.NewUnscrambleEngine(newFakeWords())
+
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, activities.UnscrambleType, challenge.Type)
+	assert.ElementsMatch(t, []string{"क", "म", "रा"}, strings.Fields(challenge.Prompt))
+
+	tests := []struct {
+		name    string
+		input   string
+		correct bool
+	}{
+		{name: "exact word", input: "कमरा", correct: true},
+		{name: "tiles with spaces", input: " क म रा ", correct: true},
+		{name: "wrong order", input: "मकरा", correct: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade, err := engine.GradeAnswer(ctx, challenge, tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.correct, grade.Correct)
+			assert.Equal(t, "कमरा", grade.Expected)
+		})
+	}
+
+	_, err = engine.GradeAnswer(ctx, &activities.Challenge{Type: activities.UnscrambleType}, "कमरा")
+	assert.ErrorIs(t, err, activities.ErrInvalidChallenge)
+}
+
+func TestCompleteWordEngine(t *testing.T) {
+	ctx := context.Background()
+	engine := activities.NewCompleteWordEngine(newFakeWords())
+
+	activity := &models.StudyActivity{Type: activities.CompleteWordType, Config: json.RawMessage(`{"missing":1}`)}
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(challenge.Prompt, "_"))
+
+	var payload struct {
+		Missing []int `json:"missing"`
+	}
+	assert.NoError(t, json.Unmarshal(challenge.Payload, &payload))
+	assert.Len(t, payload.Missing, 1)
+
+	grade, err := engine.GradeAnswer(ctx, challenge, "कमरा")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+
+	missing := devanagari.Aksharas("कमरा")[payload.Missing[0]]
+	grade, err = engine.GradeAnswer(ctx, challenge, missing)
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+
+	grade, err = engine.GradeAnswer(ctx, challenge, "कमला")
+	assert.NoError(t, err)
+	assert.False(t, grade.Correct)
+	assert.Equal(t, 0, grade.Score)
+
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"missing":5}`)))
+	assert.NoError(t, engine.ValidateConfig(json.RawMessage(`{"missing":2}`)))
+}
+
+func TestGroupWordsEngine(t *testing.T) {
+	ctx := context.Background()
+	words := newFakeWords()
+	engine := activities.NewGroupWordsEngine(words, words)
+
+	groupID := int64(2)
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{
+		Session: &models.Session{GroupID: &groupID},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "खुश", challenge.Prompt)
+	assert.ElementsMatch(t, []string{"Home", "Emotions"}, challenge.Options)
+
+	grade, err := engine.GradeAnswer(ctx, challenge, "emotions")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, models.ResultSuccess, grade.Result())
+
+	grade, err = engine.GradeAnswer(ctx, challenge, "Home")
+	assert.NoError(t, err)
+	assert.False(t, grade.Correct)
+	assert.Equal(t, models.ResultFailure, grade.Result())
+}
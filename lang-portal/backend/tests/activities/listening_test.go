@@ -0,0 +1,138 @@
+package activities_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeListening serves one exercise, and others that are never picked at random
+type fakeListening struct {
+	exercise models.ListeningExercise
+	others   []models.ListeningExercise
+}
+
+func (f *fakeListening) GetByID(_ context.Context, id int64) (*models.ListeningExercise, error) {
+	for _, exercise := range append([]models.ListeningExercise{f.exercise}, f.others...) {
+		if exercise.ID == id {
+			return &exercise, nil
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+func (f *fakeListening) GetQuestion(_ context.Context, id int64) (*models.ListeningQuestion, error) {
+	for _, exercise := range append([]models.ListeningExercise{f.exercise}, f.others...) {
+		for _, question := range exercise.Questions {
+			if question.ID == id {
+				return &question, nil
+			}
+		}
+	}
+	return nil, models.ErrNotFound
+}
+
+func (f *fakeListening) GetRandomQuestion(_ context.Context) (*models.ListeningQuestion, error) {
+	return &f.exercise.Questions[0], nil
+}
+
+func TestListeningEngine(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeListening{exercise: models.ListeningExercise{
+		ID:           3,
+		Introduction: "दो दोस्त बात कर रहे हैं।",
+		Transcript:   "तुम कहाँ जा रहे हो? मैं बाज़ार जा रहा हूँ।",
+		AudioURL:     "/api/assets/9/content",
+		Questions: []models.ListeningQuestion{{
+			ID:         5,
+			ExerciseID: 3,
+			Question:   "वह कहाँ जा रहा है?",
+			Options:    []string{"घर", "बाज़ार", "स्कूल"},
+			Answer:     "बाज़ार",
+		}},
+	}}
+	engine := activities.NewListeningEngine(source)
+
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: &models.StudyActivity{Type: activities.ListeningType}})
+	assert.NoError(t, err)
+	assert.Equal(t, "वह कहाँ जा रहा है?", challenge.Prompt)
+	assert.Equal(t, "/api/assets/9/content", challenge.Audio)
+	assert.ElementsMatch(t, []string{"घर", "बाज़ार", "स्कूल"}, challenge.Options)
+	assert.Equal(t, []string{"दो दोस्त बात कर रहे हैं।"}, challenge.Hints)
+
+	grade, err := engine.GradeAnswer(ctx, challenge, " बाज़ार ")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, 100, grade.Score)
+
+	grade, err = engine.GradeAnswer(ctx, challenge, "घर")
+	assert.NoError(t, err)
+	assert.False(t, grade.Correct)
+	assert.Equal(t, "बाज़ार", grade.Expected)
+
+	// The transcript is a hint when configured, or when there is no recording
+	activity := &models.StudyActivity{Type: activities.ListeningType, Config: json.RawMessage(`{"show_transcript":true}`)}
+	challenge, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+	assert.NoError(t, err)
+	assert.Contains(t, challenge.Hints, source.exercise.Transcript)
+
+	source.exercise.AudioURL = ""
+	challenge, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{})
+	assert.NoError(t, err)
+	assert.Contains(t, challenge.Hints, source.exercise.Transcript)
+
+	challenge.Payload = json.RawMessage(`{"exercise_id":4,"question_id":5}`)
+	_, err = engine.GradeAnswer(ctx, challenge, "घर")
+	assert.True(t, errors.Is(err, activities.ErrInvalidChallenge))
+}
+
+func TestListeningEngine_Session(t *testing.T) {
+	ctx := context.Background()
+	question := func(id, exerciseID int64, text string) models.ListeningQuestion {
+		return models.ListeningQuestion{ID: id, ExerciseID: exerciseID, Question: text, Options: []string{"हाँ", "नहीं"}, Answer: "हाँ"}
+	}
+	source := &fakeListening{
+		exercise: models.ListeningExercise{ID: 3, Transcript: "बाज़ार", Questions: []models.ListeningQuestion{
+			question(5, 3, "क्या वह बाज़ार जा रहा है?"),
+			question(6, 3, "क्या वह पैदल जा रहा है?"),
+		}},
+		others: []models.ListeningExercise{{ID: 4, Transcript: "स्कूल", Questions: []models.ListeningQuestion{
+			question(7, 4, "क्या वह स्कूल जा रहा है?"),
+		}}},
+	}
+	engine := activities.NewListeningEngine(source)
+	answered := time.Now()
+	issued := func(exerciseID, questionID int64, answeredAt *time.Time) models.IssuedChallenge {
+		payload, _ := json.Marshal(map[string]int64{"exercise_id": exerciseID, "question_id": questionID})
+		return models.IssuedChallenge{Type: activities.ListeningType, Payload: payload, AnsweredAt: answeredAt}
+	}
+
+	// The exercise of the session's first challenge is kept
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{Issued: []models.IssuedChallenge{issued(4, 7, nil)}})
+	assert.NoError(t, err)
+	assert.Equal(t, "क्या वह स्कूल जा रहा है?", challenge.Prompt)
+
+	// Answered questions are not asked again
+	challenge, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{Issued: []models.IssuedChallenge{issued(3, 5, &answered)}})
+	assert.NoError(t, err)
+	assert.Equal(t, "क्या वह पैदल जा रहा है?", challenge.Prompt)
+
+	_, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{Issued: []models.IssuedChallenge{
+		issued(3, 5, &answered), issued(3, 6, &answered),
+	}})
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+
+	// The configured exercise is asked about
+	activity := &models.StudyActivity{Type: activities.ListeningType, Config: json.RawMessage(`{"exercise_id":4}`)}
+	challenge, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+	assert.NoError(t, err)
+	assert.Equal(t, "क्या वह स्कूल जा रहा है?", challenge.Prompt)
+
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"exercise_id":-1}`)))
+}
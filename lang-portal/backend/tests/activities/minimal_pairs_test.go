@@ -0,0 +1,85 @@
+package activities_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePairs struct {
+	pairs []models.MinimalPair
+}
+
+func (f *fakePairs) FindPairs(_ context.Context, _ *int64) ([]models.MinimalPair, error) {
+	return f.pairs, nil
+}
+
+func TestMinimalPairsEngine(t *testing.T) {
+	ctx := context.Background()
+	assetID := int64(1)
+	words := &fakeWords{words: []models.Word{
+		{ID: 1, Target: "कल", Native: "Tomorrow", IPA: "kəl", AudioAssetID: &assetID, AudioURL: models.AssetURL(assetID)},
+		{ID: 2, Target: "खल", Native: "Villain", IPA: "kʰəl"},
+		{ID: 3, Target: "दिन", Native: "Day", IPA: "d̪ɪn"},
+		{ID: 4, Target: "दीन", Native: "Poor", IPA: "d̪iːn"},
+	}}
+	pairs := &fakePairs{pairs: []models.MinimalPair{
+		{Contrast: "aspiration", First: words.words[0], Second: words.words[1]},
+		{Contrast: "vowel_length", First: words.words[2], Second: words.words[3]},
+	}}
+	engine := activities.NewMinimalPairsEngine(words, pairs)
+
+	// The recorded word is played
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, activities.MinimalPairsType, challenge.Type)
+	assert.Equal(t, "Which word do you hear?", challenge.Prompt)
+	assert.Equal(t, &assetID, challenge.AudioAssetID)
+	assert.Empty(t, challenge.Audio)
+	assert.ElementsMatch(t, []string{"कल", "खल"}, challenge.Options)
+	assert.Len(t, challenge.Hints, 1)
+
+	// The learner is not told which option was played
+	data, err := json.Marshal(challenge)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "word_id")
+	assert.NotContains(t, string(data), models.AssetURL(assetID))
+
+	grade, err := engine.GradeAnswer(ctx, challenge, " कल ")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, 100, grade.Score)
+
+	grade, err = engine.GradeAnswer(ctx, challenge, "खल")
+	assert.NoError(t, err)
+	assert.False(t, grade.Correct)
+	assert.Equal(t, "कल", grade.Expected)
+	assert.Equal(t, "It was कल /kəl/, not खल /kʰəl/", grade.Feedback)
+
+	// Without a recording the IPA is the prompt
+	activity := &models.StudyActivity{Type: activities.MinimalPairsType, Config: json.RawMessage(`{"contrasts":["vowel_length"]}`)}
+	challenge, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+	assert.NoError(t, err)
+	assert.Nil(t, challenge.AudioAssetID)
+	assert.Contains(t, []string{"Which word is pronounced /d̪ɪn/?", "Which word is pronounced /d̪iːn/?"}, challenge.Prompt)
+	assert.ElementsMatch(t, []string{"दिन", "दीन"}, challenge.Options)
+
+	// No pair of the contrast
+	activity.Config = json.RawMessage(`{"contrasts":["retroflex"]}`)
+	_, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+}
+
+func TestMinimalPairsEngine_ValidateConfig(t *testing.T) {
+	engine := activities.NewMinimalPairsEngine(newFakeWords(), &fakePairs{})
+
+	assert.NoError(t, engine.ValidateConfig(json.RawMessage(`{}`)))
+	assert.NoError(t, engine.ValidateConfig(json.RawMessage(`{"contrasts":["aspiration","nasalization"]}`)))
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"contrasts":["tone"]}`)))
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"contrasts":"aspiration"}`)))
+}
@@ -0,0 +1,130 @@
+package activities_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSpeaker struct {
+	spoken []string
+}
+
+func (f *fakeSpeaker) Synthesize(_ context.Context, text, _ string) (*models.Asset, error) {
+	f.spoken = append(f.spoken, text)
+	return &models.Asset{ID: 7}, nil
+}
+
+// numbersActivity configures the numbers engine to drill one number in one mode
+func numbersActivity(mode string, number int) *models.StudyActivity {
+	config, _ := json.Marshal(activities.NumbersConfig{Modes: []string{mode}, Min: number, Max: number})
+	return &models.StudyActivity{Type: activities.NumbersType, Config: config}
+}
+
+func TestNumbersEngine(t *testing.T) {
+	ctx := context.Background()
+	speaker := &fakeSpeaker{}
+	engine := activities.NewNumbersEngine(speaker)
+
+	tests := []struct {
+		mode     string
+		accepted []string
+		rejected []string
+		expected string
+	}{
+		{mode: activities.DigitToWord, accepted: []string{"सैंतालीस", " सैंतालीस "}, rejected: []string{"47", "सत्तालीस"}, expected: "सैंतालीस"},
+		{mode: activities.WordToDigit, accepted: []string{"47", "४७"}, rejected: []string{"सैंतालीस", "74"}, expected: "47"},
+		{mode: activities.NumberDictation, accepted: []string{"47", "४७", "सैंतालीस"}, rejected: []string{"57"}, expected: "47"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: numbersActivity(tt.mode, 47)})
+			assert.NoError(t, err)
+			assert.Equal(t, activities.NumbersType, challenge.Type)
+
+			for _, input := range tt.accepted {
+				grade, err := engine.GradeAnswer(ctx, challenge, input)
+				assert.NoError(t, err)
+				assert.True(t, grade.Correct, input)
+				assert.Equal(t, 100, grade.Score)
+			}
+			for _, input := range tt.rejected {
+				grade, err := engine.GradeAnswer(ctx, challenge, input)
+				assert.NoError(t, err)
+				assert.False(t, grade.Correct, input)
+				assert.Equal(t, tt.expected, grade.Expected)
+				assert.Equal(t, "47 is सैंतालीस, ४७", grade.Feedback)
+			}
+		})
+	}
+
+	// Dictations are spoken
+	assert.Equal(t, []string{"सैंतालीस"}, speaker.spoken)
+}
+
+func TestNumbersEngine_Digits(t *testing.T) {
+	ctx := context.Background()
+	engine := activities.NewNumbersEngine(nil)
+
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: numbersActivity(activities.DigitScripts, 47)})
+	assert.NoError(t, err)
+
+	// Only the system asked for is accepted
+	answer, other := "47", "४७"
+	if strings.Contains(challenge.Prompt, "Devanagari") {
+		answer, other = other, answer
+	}
+	grade, err := engine.GradeAnswer(ctx, challenge, answer)
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+
+	grade, err = engine.GradeAnswer(ctx, challenge, other)
+	assert.NoError(t, err)
+	assert.False(t, grade.Correct)
+	assert.Equal(t, answer, grade.Expected)
+}
+
+func TestNumbersEngine_Dictation(t *testing.T) {
+	ctx := context.Background()
+
+	challenge, err := activities.NewNumbersEngine(&fakeSpeaker{}).GenerateChallenge(ctx,
+		activities.ChallengeRequest{Activity: numbersActivity(activities.NumberDictation, 8)})
+	assert.NoError(t, err)
+	assert.Equal(t, "Write the number you hear", challenge.Prompt)
+	assert.Equal(t, "/api/assets/7/content", challenge.Audio)
+
+	// The number heard is not sent to the learner
+	data, err := json.Marshal(challenge)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "value")
+	assert.NotContains(t, string(data), "8")
+
+	// Without a speaker dictation is left out
+	engine := activities.NewNumbersEngine(nil)
+	_, err = engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: numbersActivity(activities.NumberDictation, 8)})
+	assert.True(t, errors.Is(err, models.ErrNotConfigured))
+
+	for i := 0; i < 20; i++ {
+		challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{})
+		assert.NoError(t, err)
+		assert.Empty(t, challenge.Audio)
+	}
+}
+
+func TestNumbersEngine_ValidateConfig(t *testing.T) {
+	engine := activities.NewNumbersEngine(nil)
+
+	assert.NoError(t, engine.ValidateConfig(json.RawMessage(`{}`)))
+	assert.NoError(t, engine.ValidateConfig(json.RawMessage(`{"modes":["digits","dictation"],"min":0,"max":20}`)))
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"modes":["roman"]}`)))
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"max":1000}`)))
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"min":50,"max":20}`)))
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"min":-1}`)))
+}
@@ -0,0 +1,88 @@
+package activities_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubEngine is a minimal engine used to exercise the registry
+type stubEngine struct {
+	activityType string
+}
+
+func (e *stubEngine) Type() string { return e.activityType }
+
+func (e *stubEngine) GenerateChallenge(_ context.Context, _ activities.ChallengeRequest) (*activities.Challenge, error) {
+	return activities.NewChallenge(e.activityType, "prompt", nil)
+}
+
+func (e *stubEngine) GradeAnswer(_ context.Context, _ *activities.Challenge, input string) (*activities.Grade, error) {
+	return &activities.Grade{Correct: input == "answer", Expected: "answer"}, nil
+}
+
+func (e *stubEngine) SummarizeSession(_ context.Context, sessionActivities []models.SessionActivity) (*activities.Summary, error) {
+	return activities.SummarizeActivities(sessionActivities), nil
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry, err := activities.NewRegistry(&stubEngine{activityType: "stub"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		engine  activities.ActivityEngine
+		wantErr bool
+	}{
+		{name: "new type", engine: &stubEngine{activityType: "other"}, wantErr: false},
+		{name: "duplicate type", engine: &stubEngine{activityType: "stub"}, wantErr: true},
+		{name: "empty type", engine: &stubEngine{}, wantErr: true},
+		{name: "nil engine", engine: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Register(tt.engine)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.Equal(t, []string{"other", "stub"}, registry.Types())
+}
+
+func TestRegistry_Get(t *testing.T) {
+	registry, err := activities.NewRegistry(&stubEngine{activityType: "stub"})
+	assert.NoError(t, err)
+
+	engine, err := registry.Get("stub")
+	assert.NoError(t, err)
+	assert.Equal(t, "stub", engine.Type())
+
+	_, err = registry.Get("missing")
+	assert.Error(t, err)
+}
+
+func TestSummarizeActivities(t *testing.T) {
+	summary := activities.SummarizeActivities([]models.SessionActivity{
+		{Result: models.ResultSuccess, Score: 100},
+		{Result: models.ResultFailure, Score: 0},
+		{Result: models.ResultSuccess, Score: 50},
+		{Result: models.ResultFailure, Score: 0},
+	})
+
+	assert.Equal(t, 4, summary.Attempts)
+	assert.Equal(t, 2, summary.Correct)
+	assert.Equal(t, 0.5, summary.Accuracy)
+	assert.Equal(t, 37, summary.Score)
+
+	empty := activities.SummarizeActivities(nil)
+	assert.Equal(t, 0, empty.Attempts)
+	assert.Equal(t, 0.0, empty.Accuracy)
+}
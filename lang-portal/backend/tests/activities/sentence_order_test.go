@@ -0,0 +1,74 @@
+package activities_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentenceOrderEngine(t *testing.T) {
+	ctx := context.Background()
+	sentences := &fakeSentences{sentences: []models.Sentence{{
+		ID:           1,
+		Language:     "hi",
+		Target:       "नदी का पानी ठंडा है।",
+		Native:       "The river water is cold.",
+		Alternatives: []string{"ठंडा है नदी का पानी।"},
+		WordIDs:      []int64{1},
+	}}}
+	engine := activities.NewSentenceOrderEngine(newFakeWords(), sentences)
+
+	tests := []struct {
+		name   string
+		config string
+		chunks []string
+	}{
+		{name: "words", config: `{}`, chunks: []string{"नदी", "का", "पानी", "ठंडा", "है"}},
+		{name: "postpositions attached", config: `{"attach_postpositions":true}`, chunks: []string{"नदी का", "पानी", "ठंडा", "है"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := &models.StudyActivity{Type: activities.SentenceOrderType, Config: json.RawMessage(tt.config)}
+			challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+			assert.NoError(t, err)
+			assert.Equal(t, activities.SentenceOrderType, challenge.Type)
+			assert.Equal(t, "The river water is cold.", challenge.Prompt)
+			assert.ElementsMatch(t, tt.chunks, challenge.Options)
+			assert.NotEqual(t, tt.chunks, challenge.Options)
+		})
+	}
+
+	activity := &models.StudyActivity{Type: activities.SentenceOrderType, Config: json.RawMessage(`{"attach_postpositions":true}`)}
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{Activity: activity})
+	assert.NoError(t, err)
+
+	grades := []struct {
+		name    string
+		input   string
+		correct bool
+	}{
+		{name: "sentence order", input: "नदी का पानी ठंडा है", correct: true},
+		{name: "with punctuation", input: "नदी का पानी ठंडा है।", correct: true},
+		{name: "alternative order", input: "ठंडा है नदी का पानी", correct: true},
+		{name: "order not listed", input: "पानी नदी का ठंडा है", correct: false},
+		{name: "shuffled chunks", input: strings.Join(challenge.Options, " "), correct: false},
+	}
+
+	for _, tt := range grades {
+		t.Run(tt.name, func(t *testing.T) {
+			grade, err := engine.GradeAnswer(ctx, challenge, tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.correct, grade.Correct)
+			assert.Equal(t, "नदी का पानी ठंडा है।", grade.Expected)
+		})
+	}
+
+	assert.Error(t, engine.ValidateConfig(json.RawMessage(`{"attach_postpositions":"yes"}`)))
+	assert.NoError(t, engine.ValidateConfig(json.RawMessage(`{"attach_postpositions":true}`)))
+}
@@ -0,0 +1,54 @@
+package activities_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpeakingEngine(t *testing.T) {
+	ctx := context.Background()
+	words := newFakeWords()
+	words.words[0].Language = "hi"
+	words.words[0].Romanized = "Kamra"
+	words.words[0].AudioURL = "/api/assets/7/content"
+	engine := activities.NewSpeakingEngine(words)
+
+	challenge, err := engine.GenerateChallenge(ctx, activities.ChallengeRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, activities.SpeakingType, challenge.Type)
+	assert.Equal(t, "कमरा", challenge.Prompt)
+	assert.Equal(t, []string{"Kamra", "Room"}, challenge.Hints)
+	assert.Equal(t, "/api/assets/7/content", challenge.Audio)
+
+	var payload activities.SpeakingPayload
+	assert.NoError(t, activities.DecodePayload(challenge, &payload))
+	assert.Equal(t, activities.SpeakingPayload{WordID: 1, Language: "hi"}, payload)
+
+	tests := []struct {
+		name       string
+		transcript string
+		correct    bool
+		score      int
+		feedback   string
+	}{
+		{name: "exact", transcript: "कमरा", correct: true, score: 100},
+		{name: "punctuated", transcript: " कमरा। ", correct: true, score: 100},
+		{name: "one of four letters wrong", transcript: "कमला", correct: false, score: 75, feedback: `Heard "कमला"`},
+		{name: "other word", transcript: "खुश", correct: false, score: 0, feedback: `Heard "खुश"`},
+		{name: "silence", transcript: "", correct: false, score: 0, feedback: "No speech was recognized in the recording"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grade, err := engine.GradeAnswer(ctx, challenge, tt.transcript)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.correct, grade.Correct)
+			assert.Equal(t, tt.score, grade.Score)
+			assert.Equal(t, tt.feedback, grade.Feedback)
+			assert.Equal(t, "कमरा", grade.Expected)
+		})
+	}
+}
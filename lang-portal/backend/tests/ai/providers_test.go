@@ -0,0 +1,162 @@
+package ai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/ai"
+	"github.com/stretchr/testify/assert"
+)
+
+var conversation = []ai.Message{
+	{Role: ai.RoleSystem, Content: "You are a Hindi teacher."},
+	{Role: ai.RoleUser, Content: "Give me a word."},
+}
+
+func TestOpenAIChat_Complete(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"{\"word\":\"पानी\"}"}}]}`))
+	}))
+	defer server.Close()
+
+	provider := ai.NewOpenAIChat(ai.HTTPConfig{URL: server.URL + "/v1/", Model: "gpt-4o-mini", APIKey: "secret"})
+
+	var out struct {
+		Word string `json:"word"`
+	}
+	err := ai.CompleteJSON(context.Background(), provider, conversation, &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "पानी", out.Word)
+	assert.Equal(t, "openai:gpt-4o-mini", provider.Model())
+	assert.Equal(t, "gpt-4o-mini", received["model"])
+	assert.Equal(t, map[string]interface{}{"type": "json_object"}, received["response_format"])
+	assert.Len(t, received["messages"], 2)
+}
+
+func TestOllama_Complete(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"model":"llama3.2:1b","message":{"role":"assistant","content":"नमस्ते"},"done":true}`))
+	}))
+	defer server.Close()
+
+	provider := ai.NewOllama(ai.HTTPConfig{URL: server.URL, Model: "llama3.2:1b"})
+
+	answer, err := provider.Complete(context.Background(), ai.CompletionRequest{
+		Messages:    conversation,
+		JSON:        true,
+		Temperature: 0.2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "नमस्ते", answer)
+	assert.Equal(t, "ollama:llama3.2:1b", provider.Model())
+	assert.Equal(t, false, received["stream"])
+	assert.Equal(t, "json", received["format"])
+	assert.Equal(t, map[string]interface{}{"temperature": 0.2}, received["options"])
+}
+
+func TestProviders_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "error status", status: http.StatusInternalServerError, body: `{"error":"model not found"}`},
+		{name: "malformed response", status: http.StatusOK, body: `not json`},
+		{name: "no choices", status: http.StatusOK, body: `{"choices":[]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			provider := ai.NewOpenAIChat(ai.HTTPConfig{URL: server.URL, Model: "gpt-4o-mini"})
+			_, err := provider.Complete(context.Background(), ai.CompletionRequest{Messages: conversation})
+			assert.True(t, errors.Is(err, ai.ErrProvider), "got %v", err)
+		})
+	}
+}
+
+func TestProviders_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	provider := ai.NewOllama(ai.HTTPConfig{URL: server.URL, Model: "llama3.2:1b", Timeout: 50 * time.Millisecond})
+	_, err := provider.Complete(context.Background(), ai.CompletionRequest{Messages: conversation})
+	assert.True(t, errors.Is(err, ai.ErrProvider), "got %v", err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+}
+
+func TestCompleteJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "plain object", answer: `{"words":["एक","दो"]}`, want: []string{"एक", "दो"}},
+		{name: "code fence", answer: "Here you go:\n```json\n{\"words\":[\"तीन\"]}\n```", want: []string{"तीन"}},
+		{name: "no object", answer: "Sorry, I cannot help with that.", wantErr: true},
+		{name: "broken object", answer: `{"words":["एक",}`, wantErr: true},
+		{name: "wrong shape", answer: `{"words":"एक"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := ai.NewFakeLLM(tt.answer)
+
+			var out struct {
+				Words []string `json:"words"`
+			}
+			err := ai.CompleteJSON(context.Background(), fake, conversation, &out)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ai.ErrInvalidOutput), "got %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, out.Words)
+			assert.True(t, fake.Requests()[0].JSON)
+		})
+	}
+}
+
+func TestFakeLLM(t *testing.T) {
+	fake := ai.NewFakeLLM("first")
+	fake.Respond("second")
+
+	for _, want := range []string{"first", "second"} {
+		answer, err := fake.Complete(context.Background(), ai.CompletionRequest{Messages: conversation})
+		assert.NoError(t, err)
+		assert.Equal(t, want, answer)
+	}
+
+	_, err := fake.Complete(context.Background(), ai.CompletionRequest{})
+	assert.True(t, errors.Is(err, ai.ErrProvider))
+	assert.Len(t, fake.Requests(), 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = fake.Complete(ctx, ai.CompletionRequest{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
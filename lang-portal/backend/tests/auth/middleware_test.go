@@ -0,0 +1,102 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubAuthenticator accepts fixed credentials for each role
+type stubAuthenticator struct{}
+
+func (stubAuthenticator) AuthenticateToken(_ context.Context, token string) (*auth.Principal, error) {
+	if role := models.Role(token); role.Valid() {
+		return &auth.Principal{UserID: 1, Role: role, Method: auth.MethodToken}, nil
+	}
+	return nil, auth.ErrInvalidToken
+}
+
+func (stubAuthenticator) AuthenticateAPIKey(_ context.Context, key string) (*auth.Principal, error) {
+	if key == "script-key" {
+		return &auth.Principal{UserID: 2, Role: models.RoleEditor, Method: auth.MethodAPIKey}, nil
+	}
+	return nil, auth.ErrInvalidToken
+}
+
+func newProtectedServer() *echo.Echo {
+	e := echo.New()
+	e.Use(auth.Middleware(stubAuthenticator{}))
+
+	ok := func(c echo.Context) error {
+		return c.JSON(http.StatusOK, auth.PrincipalFrom(c))
+	}
+	e.GET("/public", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/words", ok, auth.RequireRole(models.RoleLearner))
+	e.POST("/words", ok, auth.RequireRole(models.RoleEditor))
+	e.DELETE("/sessions", ok, auth.RequireRole(models.RoleAdmin))
+	return e
+}
+
+func TestMiddleware_EnforcesRoles(t *testing.T) {
+	e := newProtectedServer()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		apiKey     string
+		wantStatus int
+	}{
+		{"anonymous public route", http.MethodGet, "/public", "", "", http.StatusOK},
+		{"anonymous protected route", http.MethodGet, "/words", "", "", http.StatusUnauthorized},
+		{"invalid token", http.MethodGet, "/public", "forged", "", http.StatusUnauthorized},
+		{"learner reads content", http.MethodGet, "/words", "learner", "", http.StatusOK},
+		{"learner cannot edit", http.MethodPost, "/words", "learner", "", http.StatusForbidden},
+		{"editor edits", http.MethodPost, "/words", "editor", "", http.StatusOK},
+		{"editor cannot delete sessions", http.MethodDelete, "/sessions", "editor", "", http.StatusForbidden},
+		{"admin deletes sessions", http.MethodDelete, "/sessions", "admin", "", http.StatusOK},
+		{"api key acts as its user", http.MethodPost, "/words", "", "script-key", http.StatusOK},
+		{"unknown api key", http.MethodGet, "/words", "", "leaked", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+tt.token)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set(auth.HeaderAPIKey, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestMiddlewareWithConfig_Skipper(t *testing.T) {
+	e := echo.New()
+	e.Use(auth.MiddlewareWithConfig(auth.MiddlewareConfig{
+		Skipper:       func(c echo.Context) bool { return c.Path() == "/callback" },
+		Authenticator: stubAuthenticator{},
+	}))
+	e.POST("/callback", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+
+	// Skipped routes receive tokens meant for someone else untouched
+	req := httptest.NewRequest(http.MethodPost, "/callback", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer launch-token")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,78 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestNewSigner_RejectsShortKey(t *testing.T) {
+	_, err := auth.NewSigner([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestSigner_IssueAndVerify(t *testing.T) {
+	signer, err := auth.NewSigner(testKey)
+	assert.NoError(t, err)
+
+	token, expiresAt, err := signer.Issue(auth.Claims{SessionID: 7, ActivityID: 3}, auth.AudienceLaunch, time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, expiresAt.After(time.Now()))
+	assert.Len(t, strings.Split(token, "."), 3)
+
+	claims, err := signer.Verify(token, auth.AudienceLaunch)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), claims.SessionID)
+	assert.Equal(t, int64(3), claims.ActivityID)
+	assert.NotEmpty(t, claims.ID)
+}
+
+func TestSigner_VerifyRejects(t *testing.T) {
+	signer, err := auth.NewSigner(testKey)
+	assert.NoError(t, err)
+	other, err := auth.NewSigner([]byte("fedcba9876543210fedcba9876543210"))
+	assert.NoError(t, err)
+
+	valid, _, err := signer.Issue(auth.Claims{SessionID: 1}, auth.AudienceLaunch, time.Hour)
+	assert.NoError(t, err)
+	expired, _, err := signer.Issue(auth.Claims{SessionID: 1}, auth.AudienceLaunch, -time.Second)
+	assert.NoError(t, err)
+	foreign, _, err := other.Issue(auth.Claims{SessionID: 1}, auth.AudienceLaunch, time.Hour)
+	assert.NoError(t, err)
+
+	parts := strings.Split(valid, ".")
+	tampered := parts[0] + "." + parts[1] + "x." + parts[2]
+
+	tests := []struct {
+		name     string
+		token    string
+		audience string
+		wantErr  error
+	}{
+		{name: "expired", token: expired, audience: auth.AudienceLaunch, wantErr: auth.ErrTokenExpired},
+		{name: "wrong audience", token: valid, audience: "other", wantErr: auth.ErrInvalidToken},
+		{name: "different key", token: foreign, audience: auth.AudienceLaunch, wantErr: auth.ErrInvalidToken},
+		{name: "tampered payload", token: tampered, audience: auth.AudienceLaunch, wantErr: auth.ErrInvalidToken},
+		{name: "malformed", token: "not-a-token", audience: auth.AudienceLaunch, wantErr: auth.ErrInvalidToken},
+		{name: "empty", token: "", audience: auth.AudienceLaunch, wantErr: auth.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Verify(tt.token, tt.audience)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	assert.Equal(t, "abc", auth.BearerToken("Bearer abc"))
+	assert.Equal(t, "abc", auth.BearerToken("bearer abc"))
+	assert.Equal(t, "", auth.BearerToken("Basic abc"))
+	assert.Equal(t, "", auth.BearerToken(""))
+}
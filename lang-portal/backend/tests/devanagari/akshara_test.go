@@ -0,0 +1,30 @@
+package devanagari_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAksharas(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "consonants with matras", input: "दिन", want: []string{"दि", "न"}},
+		{name: "conjunct", input: "क्षमा", want: []string{"क्ष", "मा"}},
+		{name: "nasalization", input: "हाँ", want: []string{"हाँ"}},
+		{name: "independent vowel", input: "आम", want: []string{"आ", "म"}},
+		{name: "nukta", input: "ज़मीन", want: []string{"ज़", "मी", "न"}},
+		{name: "half consonant", input: "लक्ष्य", want: []string{"ल", "क्ष्य"}},
+		{name: "empty", input: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, devanagari.Aksharas(tt.input))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package devanagari_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	// A Hindi dictionary lists nasalized syllables first, vowels before
+	// consonants, the inherent vowel before the matras and conjuncts after
+	// them, and a nukta consonant with the consonant
+	dictionary := []string{
+		"अंक", "अक्षर", "अचानक", "आम", "इमली", "ऊन", "ऋषि", "ॠ", "ऌ", "एक", "ओस", "औरत",
+		"कंघा", "कई", "कमरा", "का", "काँच", "कागज", "किताब", "कृपा", "कौन", "क्या", "क्षमा",
+		"खाना", "जमीन", "ज़मीन", "जल", "ज्ञान", "दुःख", "दुकान", "हम", "हम सब",
+	}
+
+	shuffled := slices.Clone(dictionary)
+	slices.Reverse(shuffled)
+	slices.SortFunc(shuffled, devanagari.Compare)
+	assert.Equal(t, dictionary, shuffled)
+
+	assert.Equal(t, 0, devanagari.Compare("कमरा", "कमरा"))
+	assert.Equal(t, -1, devanagari.Compare("कमरा", "कमला"))
+	assert.Equal(t, 1, devanagari.Compare("ज़मीन", "जमीन"))
+	// The long vocalic vowels follow their short ones, before ए
+	assert.Equal(t, -1, devanagari.Compare("ॠ", "औ"))
+	assert.Equal(t, -1, devanagari.Compare("ऋ", "ॠ"))
+	assert.Equal(t, -1, devanagari.Compare("ॠ", "ऌ"))
+	assert.Equal(t, -1, devanagari.Compare("ऌ", "ॡ"))
+	assert.Equal(t, -1, devanagari.Compare("ॡ", "ए"))
+	// Joiners are ignored
+	assert.Equal(t, -1, devanagari.Compare("क्‍षमा", "खाना"))
+}
@@ -0,0 +1,46 @@
+package devanagari_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "precomposed nukta", input: "ज़मीन", want: "ज़मीन"},
+		{name: "combining nukta", input: "ज़मीन", want: "ज़मीन"},
+		{name: "chandrabindu", input: "हाँ", want: "हां"},
+		{name: "danda and spaces", input: "  नमस्ते ।  दोस्त। ", want: "नमस्ते दोस्त"},
+		{name: "joiners", input: "क्‍ष", want: "क्ष"},
+		{name: "latin case", input: "Namaste!", want: "namaste"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, devanagari.Fold(tt.input))
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, devanagari.Distance("हाँ।", "हां"))
+	assert.Equal(t, 0, devanagari.Distance("ज़मीन", "ज़मीन"))
+	// A missing nukta is one edit
+	assert.Equal(t, 1, devanagari.Distance("जमीन", "ज़मीन"))
+	// कमरा to कमला substitutes र with ल
+	assert.Equal(t, 1, devanagari.Distance("कमरा", "कमला"))
+	assert.Equal(t, 4, devanagari.Distance("", "कमरा"))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 100, devanagari.Similarity("नमस्ते।", "नमस्ते"))
+	assert.Equal(t, 75, devanagari.Similarity("कमला", "कमरा"))
+	assert.Equal(t, 0, devanagari.Similarity("", "कमरा"))
+	assert.Equal(t, 100, devanagari.Similarity("", ""))
+}
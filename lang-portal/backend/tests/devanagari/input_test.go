@@ -0,0 +1,98 @@
+package devanagari_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert_ITRANS(t *testing.T) {
+	tests := map[string]string{
+		"namaste":     "नमस्ते",
+		"kamalA":      "कमला",
+		"kamala":      "कमल",
+		"ghar":        "घर्",
+		"ghar.h":      "घर्",
+		"dhanyavaada": "धन्यवाद",
+		"hiMdI":       "हिंदी",
+		"ha.Nsa":      "हँस",
+		"kShamA":      "क्षमा",
+		"j~nAna":      "ज्ञान",
+		"RRiShi":      "ऋषि",
+		"aura":        "और",
+		"Thaka":       "ठक",
+		"la.DakA":     "लड़का",
+		"zarUra":      "ज़रूर",
+		"raama| 47":   "राम। ४७",
+		"कमरा":        "कमरा",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, devanagari.Convert(input, devanagari.ITRANS), input)
+	}
+}
+
+func TestConvert_Phonetic(t *testing.T) {
+	tests := map[string]string{
+		"namaste":       "नमस्ते",
+		"ghar":          "घर",
+		"kamara":        "कमरा",
+		"paani":         "पानी",
+		"aalu":          "आलू",
+		"kitaab":        "किताब",
+		"deewaar":       "दीवार",
+		"kaise ho?":     "कैसे हो?",
+		"kyaa haal hai": "क्या हाल है",
+		"main ghar jaa": "मैन घर जा",
+		"maiM ghar jaa": "मैं घर जा",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, devanagari.Convert(input, devanagari.Phonetic), input)
+	}
+}
+
+func TestSpellings(t *testing.T) {
+	tests := []struct {
+		input  string
+		method devanagari.InputMethod
+		want   string
+	}{
+		{input: "kamra", method: devanagari.Phonetic, want: "कमरा"},
+		{input: "main", method: devanagari.Phonetic, want: "मैं"},
+		{input: "main ghar jaa", method: devanagari.Phonetic, want: "मैं घर जा"},
+		{input: "ham kamre men", method: devanagari.Phonetic, want: "हम कमरे में"},
+		{input: "namaste", method: devanagari.Phonetic, want: "नमस्ते"},
+		{input: "kamalA", method: devanagari.ITRANS, want: "कमला"},
+	}
+	for _, tt := range tests {
+		spellings := devanagari.Spellings(tt.input, tt.method)
+		assert.Equal(t, devanagari.Convert(tt.input, tt.method), spellings[0], tt.input)
+		assert.Contains(t, spellings, tt.want, tt.input)
+	}
+
+	// Only the phonetic method has other spellings
+	assert.Equal(t, []string{"कम्रा"}, devanagari.Spellings("kamrA", devanagari.ITRANS))
+
+	// Long input is bounded
+	assert.LessOrEqual(t, len(devanagari.Spellings("kamra kamra kamra kamra kamra", devanagari.Phonetic)), 16)
+}
+
+func TestConvert_InScript(t *testing.T) {
+	tests := map[string]string{
+		"kf":     "कि",
+		"ufvdor": "हिन्दी",
+		"jeu":    "राह",
+		"uc &":   "हम क्ष",
+		"Eh 47":  "आप ४७",
+		"कमरा":   "कमरा",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, devanagari.Convert(input, devanagari.InScript), input)
+	}
+}
+
+func TestIsInputMethod(t *testing.T) {
+	assert.True(t, devanagari.IsInputMethod("itrans"))
+	assert.True(t, devanagari.IsInputMethod("inscript"))
+	assert.False(t, devanagari.IsInputMethod("hunterian"))
+}
@@ -0,0 +1,34 @@
+package devanagari_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/devanagari"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "precomposed nukta", input: "क़लम", want: "क़लम"},
+		{name: "combining nukta", input: "क़लम", want: "क़लम"},
+		{name: "nukta before matra", input: "ज़िंदगी", want: "ज़िंदगी"},
+		{name: "zwj", input: "क्‍ष", want: "क्ष"},
+		{name: "zwnj", input: "क्‌ष", want: "क्ष"},
+		{name: "vowel and matra", input: "अाम", want: "आम"},
+		{name: "o spelled with a", input: "अोर", want: "ओर"},
+		{name: "ai spelled with e", input: "एेनक", want: "ऐनक"},
+		{name: "chandrabindu kept", input: "हाँ", want: "हाँ"},
+		{name: "latin composed", input: "é", want: "é"},
+		{name: "joiner outside devanagari", input: "a‍b", want: "a‍b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, devanagari.Canonical(tt.input))
+		})
+	}
+}
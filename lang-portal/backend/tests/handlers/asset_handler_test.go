@@ -0,0 +1,202 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/handlers"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/pkg/storage"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// testWAV builds a mono 8 kHz 8-bit PCM WAV file of the given length
+func testWAV(milliseconds int) []byte {
+	samples := bytes.Repeat([]byte{0x80}, 8*milliseconds)
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+len(samples)))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, struct {
+		Size          uint32
+		Format        uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+	}{16, 1, 1, 8000, 8000, 1, 8})
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(len(samples)))
+	buf.Write(samples)
+
+	return buf.Bytes()
+}
+
+func setupAssetTest(t *testing.T, maxUploadBytes int64) (*echo.Echo, *handlers.AssetHandler, *handlers.WordHandler, func()) {
+	e := echo.New()
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+
+	files, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	assets := repository.NewSQLiteAssetRepository(db)
+	words := repository.NewSQLiteWordRepository(db)
+	wordService := services.NewWordService(words, repository.NewSQLiteLanguageRepository(db), repository.NewSQLiteSentenceRepository(db), assets)
+
+	assetHandler := handlers.NewAssetHandler(services.NewAssetService(assets, files, maxUploadBytes))
+	wordHandler := handlers.NewWordHandler(wordService, words)
+
+	return e, assetHandler, wordHandler, cleanup
+}
+
+// uploadAsset posts content as the multipart file field
+func uploadAsset(e *echo.Echo, handler *handlers.AssetHandler, filename, contentType string, content []byte) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := make(map[string][]string)
+	header["Content-Disposition"] = []string{`form-data; name="file"; filename="` + filename + `"`}
+	header["Content-Type"] = []string{contentType}
+	part, _ := writer.CreatePart(header)
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/assets", &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	handler.UploadAsset(e.NewContext(req, rec))
+	return rec
+}
+
+// serveAsset requests the content of an asset with the given headers
+func serveAsset(e *echo.Echo, handler *handlers.AssetHandler, id int64, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/assets/"+strconv.FormatInt(id, 10)+"/content", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.FormatInt(id, 10))
+	handler.ServeAsset(c)
+	return rec
+}
+
+func TestAssetHandler_Upload(t *testing.T) {
+	e, handler, _, cleanup := setupAssetTest(t, 1<<20)
+	defer cleanup()
+
+	wav := testWAV(250)
+	rec := uploadAsset(e, handler, "namaste.wav", "application/octet-stream", wav)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var asset models.Asset
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &asset))
+	assert.Equal(t, models.AssetAudio, asset.Kind)
+	assert.Equal(t, "audio/wav", asset.MimeType)
+	assert.Equal(t, int64(len(wav)), asset.Size)
+	assert.Equal(t, int64(250), *asset.DurationMS)
+	assert.Equal(t, models.AssetURL(asset.ID), asset.URL)
+
+	// Uploading the same content again returns the stored asset
+	rec = uploadAsset(e, handler, "copy.wav", "audio/wav", wav)
+	var again models.Asset
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &again))
+	assert.Equal(t, asset.ID, again.ID)
+
+	rec = uploadAsset(e, handler, "notes.txt", "text/plain", []byte("not audio"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+
+	// A declared type is not trusted over the sniffed content
+	rec = uploadAsset(e, handler, "fake.mp3", "audio/mpeg", []byte("<html><body>not audio</body></html>"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+
+	rec = uploadAsset(e, handler, "large.wav", "audio/wav", testWAV(200000))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestAssetHandler_Serve(t *testing.T) {
+	e, handler, _, cleanup := setupAssetTest(t, 1<<20)
+	defer cleanup()
+
+	wav := testWAV(100)
+	var asset models.Asset
+	rec := uploadAsset(e, handler, "namaste.wav", "audio/wav", wav)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &asset))
+
+	rec = serveAsset(e, handler, asset.ID, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, wav, rec.Body.Bytes())
+	assert.Equal(t, "audio/wav", rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Header().Get("Cache-Control"), "immutable")
+	assert.Equal(t, "bytes", rec.Header().Get("Accept-Ranges"))
+
+	rec = serveAsset(e, handler, asset.ID, map[string]string{"Range": "bytes=0-3"})
+	assert.Equal(t, http.StatusPartialContent, rec.Code)
+	assert.Equal(t, "RIFF", rec.Body.String())
+	assert.Equal(t, "bytes 0-3/"+strconv.Itoa(len(wav)), rec.Header().Get("Content-Range"))
+
+	rec = serveAsset(e, handler, asset.ID, map[string]string{"If-None-Match": `"` + asset.Checksum + `"`})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	rec = serveAsset(e, handler, asset.ID+1, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestWordHandler_CreateWordWithAudio(t *testing.T) {
+	e, assetHandler, wordHandler, cleanup := setupAssetTest(t, 1<<20)
+	defer cleanup()
+
+	var audio, image models.Asset
+	rec := uploadAsset(e, assetHandler, "namaste.wav", "audio/wav", testWAV(100))
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &audio))
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+	rec = uploadAsset(e, assetHandler, "namaste.png", "image/png", png)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &image))
+	assert.Equal(t, models.AssetImage, image.Kind)
+
+	tests := []struct {
+		name       string
+		assetID    int64
+		wantStatus int
+	}{
+		{name: "audio asset", assetID: audio.ID, wantStatus: http.StatusCreated},
+		{name: "image asset", assetID: image.ID, wantStatus: http.StatusBadRequest},
+		{name: "missing asset", assetID: image.ID + 1, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			word := models.Word{Target: "नमस्ते", Native: "Hello", AudioAssetID: &tt.assetID}
+			jsonBytes, _ := json.Marshal(word)
+			req := httptest.NewRequest(http.MethodPost, "/api/words", bytes.NewReader(jsonBytes))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			assert.NoError(t, wordHandler.CreateWord(e.NewContext(req, rec)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantStatus == http.StatusCreated {
+				var created models.Word
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+				assert.Equal(t, audio.URL, created.AudioURL)
+			}
+		})
+	}
+}
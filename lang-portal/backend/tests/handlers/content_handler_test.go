@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/ai"
+	"github.com/pavittarx/lang-portal/backend/pkg/handlers"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentHandler(t *testing.T) {
+	e := echo.New()
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer cleanup()
+
+	wordRepo := repository.NewSQLiteWordRepository(db)
+	languageRepo := repository.NewSQLiteLanguageRepository(db)
+	sentenceRepo := repository.NewSQLiteSentenceRepository(db)
+	newHandler := func(provider ai.LLMProvider) *handlers.ContentHandler {
+		return handlers.NewContentHandler(services.NewContentService(
+			provider,
+			repository.NewSQLiteDraftRepository(db),
+			repository.NewSQLiteWordHintRepository(db),
+			languageRepo,
+			services.NewWordService(wordRepo, languageRepo, sentenceRepo, repository.NewSQLiteAssetRepository(db)),
+			services.NewSentenceService(sentenceRepo, wordRepo, languageRepo),
+			services.NewGroupService(repository.NewSQLiteGroupRepository(db)),
+		))
+	}
+
+	contentRequest := func(method, target, body string, id int64, call func(echo.Context) error) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		if id != 0 {
+			c.SetParamNames("id")
+			c.SetParamValues(strconv.FormatInt(id, 10))
+		}
+		assert.NoError(t, call(c))
+		return rec
+	}
+
+	water := &models.Word{Target: "पानी", Romanized: "Paani", Native: "Water"}
+	assert.NoError(t, wordRepo.Create(context.Background(), water))
+	body := `{"word_id":` + strconv.FormatInt(water.ID, 10) + `}`
+
+	// Without a language model there is nothing to generate with
+	rec := contentRequest(http.MethodPost, "/api/drafts/hints", body, 0, newHandler(nil).ProposeHints)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	fake := ai.NewFakeLLM("I cannot answer that.", `{"hints": ["You drink it when you are thirsty."]}`)
+	handler := newHandler(fake)
+
+	rec = contentRequest(http.MethodPost, "/api/drafts/hints", body, 0, handler.ProposeHints)
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+
+	rec = contentRequest(http.MethodPost, "/api/drafts/hints", `{}`, 0, handler.ProposeHints)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = contentRequest(http.MethodPost, "/api/drafts/hints", body, 0, handler.ProposeHints)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var drafts []models.Draft
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &drafts))
+	assert.Len(t, drafts, 1)
+
+	rec = contentRequest(http.MethodGet, "/api/drafts?status=pending&kind=hint", "", 0, handler.ListDrafts)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "thirsty")
+
+	rec = contentRequest(http.MethodPost, "/api/drafts/1/approve", `{"content":{"text":"It fills rivers."}}`, drafts[0].ID, handler.ApproveDraft)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"approved"`)
+
+	rec = contentRequest(http.MethodPost, "/api/drafts/1/reject", "", drafts[0].ID, handler.RejectDraft)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = contentRequest(http.MethodGet, "/api/words/1/hints", "", water.ID, handler.ListWordHints)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "It fills rivers.")
+
+	rec = contentRequest(http.MethodGet, "/api/drafts/999", "", 999, handler.GetDraft)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,75 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pavittarx/lang-portal/backend/pkg/handlers"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobHandler(t *testing.T) {
+	e := echo.New()
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("failed to create test db: %v", err)
+	}
+	defer cleanup()
+
+	jobs := repository.NewSQLiteJobRepository(db)
+	handler := handlers.NewJobHandler(services.NewJobService(jobs))
+
+	jobRequest := func(method, target, body string, id int64, call func(echo.Context) error) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		if id != 0 {
+			c.SetParamNames("id")
+			c.SetParamValues(strconv.FormatInt(id, 10))
+		}
+		assert.NoError(t, call(c))
+		return rec
+	}
+
+	// Without a text-to-speech provider there is no pronunciation runner
+	rec := jobRequest(http.MethodPost, "/api/jobs", `{"kind":"pronunciation"}`, 0, handler.EnqueueJobs)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = jobRequest(http.MethodPost, "/api/jobs", `{}`, 0, handler.EnqueueJobs)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	ctx := context.Background()
+	_, err = jobs.Enqueue(ctx, models.JobPronunciation, 1)
+	assert.NoError(t, err)
+	job, err := jobs.Claim(ctx, models.JobPronunciation)
+	assert.NoError(t, err)
+
+	rec = jobRequest(http.MethodGet, "/api/jobs?status=running", "", 0, handler.ListJobs)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"subject_id":1`)
+
+	rec = jobRequest(http.MethodGet, "/api/jobs?status=stuck", "", 0, handler.ListJobs)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Only failed jobs can be retried
+	rec = jobRequest(http.MethodPost, "/api/jobs/1/retry", "", job.ID, handler.RetryJob)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	assert.NoError(t, jobs.Fail(ctx, job.ID, "speech service returned 500"))
+	rec = jobRequest(http.MethodPost, "/api/jobs/1/retry", "", job.ID, handler.RetryJob)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"pending"`)
+
+	rec = jobRequest(http.MethodGet, "/api/jobs/99", "", 99, handler.GetJob)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -1,11 +1,12 @@
 package handlers_test
 
 import (
-"github.com/pavittarx/lang-portal/backend/tests/testutils"
 	"bytes"
 	"encoding/json"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -24,7 +25,7 @@ func setupTest(t *testing.T) (*echo.Echo, *handlers.WordHandler, func()) {
 	}
 
 	repo := repository.NewSQLiteWordRepository(db)
-	service := services.NewWordService(repo)
+	service := services.NewWordService(repo, repository.NewSQLiteLanguageRepository(db), repository.NewSQLiteSentenceRepository(db), repository.NewSQLiteAssetRepository(db))
 	handler := handlers.NewWordHandler(service, repo)
 
 	return e, handler, cleanup
@@ -42,18 +43,48 @@ func TestWordHandler_CreateWord(t *testing.T) {
 		{
 			name: "valid word",
 			word: models.Word{
-				Hindi:    "नमस्ते",
-				English:  "Hello",
-				Hinglish: "Namaste",
+				Target:    "नमस्ते",
+				Native:    "Hello",
+				Romanized: "Namaste",
 			},
 			wantStatus: http.StatusCreated,
 		},
 		{
 			name: "invalid word",
 			word: models.Word{
-				Hindi:    "",
-				English:  "Hello",
-				Hinglish: "Namaste",
+				Target:    "",
+				Native:    "Hello",
+				Romanized: "Namaste",
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "target not in the language's script",
+			word: models.Word{
+				Language:       "pa",
+				NativeLanguage: "en",
+				Target:         "नमस्ते",
+				Native:         "Hello",
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown language",
+			word: models.Word{
+				Language:       "xx",
+				NativeLanguage: "en",
+				Target:         "नमस्ते",
+				Native:         "Hello",
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "gender on a verb",
+			word: models.Word{
+				Target:       "करना",
+				Native:       "To do",
+				PartOfSpeech: "verb",
+				Gender:       "feminine",
 			},
 			wantStatus: http.StatusBadRequest,
 		},
@@ -78,15 +109,58 @@ func TestWordHandler_CreateWord(t *testing.T) {
 	}
 }
 
+func TestWordHandler_CreateWord_LegacyKeys(t *testing.T) {
+	e, handler, cleanup := setupTest(t)
+	defer cleanup()
+
+	body := `{"hindi":"पानी","hinglish":"Paani","english":"Water"}`
+	req := httptest.NewRequest(http.MethodPost, "/words", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	assert.NoError(t, handler.CreateWord(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "पानी", response["target"])
+	assert.Equal(t, "Paani", response["romanized"])
+	assert.Equal(t, "Water", response["native"])
+	assert.Equal(t, "पानी", response["hindi"])
+	assert.Equal(t, "Paani", response["hinglish"])
+	assert.Equal(t, "Water", response["english"])
+}
+
+func TestWordHandler_UpdateWord_Invalid(t *testing.T) {
+	e, handler, cleanup := setupTest(t)
+	defer cleanup()
+
+	req := httptest.NewRequest(http.MethodPost, "/words", bytes.NewReader([]byte(`{"target":"पानी","native":"Water"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	assert.NoError(t, handler.CreateWord(e.NewContext(req, rec)))
+	var word models.Word
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &word))
+
+	// An invalid word is the client's error
+	req = httptest.NewRequest(http.MethodPut, "/words/", bytes.NewReader([]byte(`{"target":"","native":"Water"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec = httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.FormatInt(word.ID, 10))
+	assert.NoError(t, handler.UpdateWord(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestWordHandler_GetWordByID(t *testing.T) {
 	e, handler, cleanup := setupTest(t)
 	defer cleanup()
 
 	// First create a word to test with
 	word := &models.Word{
-		Hindi:    "नमस्ते",
-		English:  "Hello",
-		Hinglish: "Namaste",
+		Target:    "नमस्ते",
+		Native:    "Hello",
+		Romanized: "Namaste",
 	}
 	jsonBytes, _ := json.Marshal(word)
 	req := httptest.NewRequest(http.MethodPost, "/words", bytes.NewReader(jsonBytes))
@@ -142,8 +216,8 @@ func TestWordHandler_ListWords(t *testing.T) {
 
 	// Add some test words
 	words := []models.Word{
-		{Hindi: "नमस्ते", English: "Hello", Hinglish: "Namaste"},
-		{Hindi: "धन्यवाद", English: "Thank you", Hinglish: "Dhanyavaad"},
+		{Target: "नमस्ते", Native: "Hello", Romanized: "Namaste"},
+		{Target: "धन्यवाद", Native: "Thank you", Romanized: "Dhanyavaad"},
 	}
 
 	for _, w := range words {
@@ -170,20 +244,80 @@ func TestWordHandler_ListWords(t *testing.T) {
 
 	var response struct {
 		Words []models.Word `json:"words"`
-		Total int          `json:"total"`
+		Total int           `json:"total"`
 	}
 	json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.Equal(t, len(words), len(response.Words))
 }
 
+func TestWordHandler_GetWords_Grammar(t *testing.T) {
+	e, handler, cleanup := setupTest(t)
+	defer cleanup()
+
+	words := []models.Word{
+		{Target: "लड़की", Native: "Girl", PartOfSpeech: "noun", Gender: "feminine"},
+		{Target: "लड़का", Native: "Boy", PartOfSpeech: "noun", Gender: "masculine"},
+		{Target: "करना", Native: "To do", PartOfSpeech: "verb", Transitivity: "transitive"},
+	}
+	for _, w := range words {
+		jsonBytes, _ := json.Marshal(w)
+		req := httptest.NewRequest(http.MethodPost, "/words", bytes.NewReader(jsonBytes))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		assert.NoError(t, handler.CreateWord(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		want       []string
+	}{
+		{name: "feminine nouns", query: "?part_of_speech=noun&gender=feminine", wantStatus: http.StatusOK, want: []string{"लड़की"}},
+		{name: "verbs", query: "?part_of_speech=verb", wantStatus: http.StatusOK, want: []string{"करना"}},
+		{name: "unknown gender", query: "?gender=neuter", wantStatus: http.StatusBadRequest},
+		{name: "dictionary order", query: "?sort=hindi", wantStatus: http.StatusOK, want: []string{"करना", "लड़का", "लड़की"}},
+		{name: "english descending", query: "?sort=english&order=desc", wantStatus: http.StatusOK, want: []string{"करना", "लड़की", "लड़का"}},
+		{name: "unknown sort", query: "?sort=length", wantStatus: http.StatusBadRequest},
+		{name: "unknown order", query: "?sort=hindi&order=up", wantStatus: http.StatusBadRequest},
+		{name: "legacy language field", query: "?search=Boy&language=english", wantStatus: http.StatusOK, want: []string{"लड़का"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/words"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			assert.NoError(t, handler.GetWords(e.NewContext(req, rec)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			var response struct {
+				Words []models.Word `json:"words"`
+			}
+			json.Unmarshal(rec.Body.Bytes(), &response)
+			var targets []string
+			for _, word := range response.Words {
+				targets = append(targets, word.Target)
+			}
+			assert.Equal(t, tt.want, targets)
+		})
+	}
+
+	// The declension is generated and flattened into the word
+	req := httptest.NewRequest(http.MethodGet, "/api/words?gender=masculine", nil)
+	rec := httptest.NewRecorder()
+	assert.NoError(t, handler.GetWords(e.NewContext(req, rec)))
+	assert.Contains(t, rec.Body.String(), `"oblique_plural":"लड़कों"`)
+}
+
 func TestWordHandler_GetRandomWord(t *testing.T) {
 	e, handler, cleanup := setupTest(t)
 	defer cleanup()
 
 	// Add some test words
 	words := []models.Word{
-		{Hindi: "नमस्ते", English: "Hello", Hinglish: "Namaste"},
-		{Hindi: "धन्यवाद", English: "Thank you", Hinglish: "Dhanyavaad"},
+		{Target: "नमस्ते", Native: "Hello", Romanized: "Namaste"},
+		{Target: "धन्यवाद", Native: "Thank you", Romanized: "Dhanyavaad"},
 	}
 
 	for _, w := range words {
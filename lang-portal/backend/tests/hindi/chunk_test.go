@@ -0,0 +1,28 @@
+package hindi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		sentence string
+		want     []string
+	}{
+		{sentence: "नदी का पानी ठंडा है", want: []string{"नदी का", "पानी", "ठंडा", "है"}},
+		{sentence: "वह घर के पास रहता है", want: []string{"वह", "घर के पास", "रहता", "है"}},
+		{sentence: "मेरे पास समय नहीं है", want: []string{"मेरे", "पास", "समय", "नहीं", "है"}},
+		{sentence: "हम उसके बारे में बात करते हैं", want: []string{"हम", "उसके बारे में", "बात", "करते", "हैं"}},
+		{sentence: "बच्चों ने माँ को फूल दिए", want: []string{"बच्चों ने", "माँ को", "फूल", "दिए"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sentence, func(t *testing.T) {
+			assert.Equal(t, tt.want, hindi.Chunk(strings.Fields(tt.sentence)))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package hindi_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/stretchr/testify/assert"
+)
+
+// conjugated finds the form of a verb for a tense, subject pronoun and gender
+func conjugated(conjugations []hindi.Conjugation, tense hindi.Tense, pronoun string, feminine bool) (hindi.Conjugation, bool) {
+	for _, c := range conjugations {
+		if c.Tense == tense && c.Subject.Pronoun == pronoun && c.Feminine == feminine {
+			return c, true
+		}
+	}
+	return hindi.Conjugation{}, false
+}
+
+func TestConjugate(t *testing.T) {
+	tests := []struct {
+		root       string
+		transitive bool
+		tense      hindi.Tense
+		pronoun    string
+		feminine   bool
+		want       string
+	}{
+		// Present habitual agrees with the subject and takes an auxiliary
+		{root: "पढ़", tense: hindi.PresentHabitual, pronoun: "मैं", want: "पढ़ता हूँ"},
+		{root: "पढ़", tense: hindi.PresentHabitual, pronoun: "मैं", feminine: true, want: "पढ़ती हूँ"},
+		{root: "पढ़", tense: hindi.PresentHabitual, pronoun: "तुम", want: "पढ़ते हो"},
+		{root: "पढ़", tense: hindi.PresentHabitual, pronoun: "वे", feminine: true, want: "पढ़ती हैं"},
+		// Intransitive perfectives agree with the subject
+		{root: "बैठ", tense: hindi.PastPerfective, pronoun: "वह", want: "बैठा"},
+		{root: "बैठ", tense: hindi.PastPerfective, pronoun: "वे", feminine: true, want: "बैठीं"},
+		{root: "आ", tense: hindi.PastPerfective, pronoun: "हम", want: "आए"},
+		{root: "जा", tense: hindi.PastPerfective, pronoun: "वह", feminine: true, want: "गई"},
+		{root: "हो", tense: hindi.PastPerfective, pronoun: "मैं", want: "हुआ"},
+		// Transitive perfectives do not agree with an ergative subject
+		{root: "कर", transitive: true, tense: hindi.PastPerfective, pronoun: "वे", feminine: true, want: "किया"},
+		{root: "पी", transitive: true, tense: hindi.PastPerfective, pronoun: "मैं", want: "पिया"},
+		{root: "ला", transitive: true, tense: hindi.PastPerfective, pronoun: "वह", feminine: true, want: "लाई"},
+		// Future and subjunctive by ending and irregular root
+		{root: "पढ़", tense: hindi.Future, pronoun: "मैं", want: "पढ़ूँगा"},
+		{root: "खा", tense: hindi.Future, pronoun: "आप", want: "खाएँगे"},
+		{root: "खा", tense: hindi.Future, pronoun: "तुम", feminine: true, want: "खाओगी"},
+		{root: "ले", tense: hindi.Future, pronoun: "मैं", want: "लूँगा"},
+		{root: "पी", tense: hindi.Future, pronoun: "वह", want: "पिएगा"},
+		{root: "दे", tense: hindi.Subjunctive, pronoun: "वे", want: "दें"},
+		{root: "कर", tense: hindi.Subjunctive, pronoun: "तू", feminine: true, want: "करे"},
+		// Imperative by formality
+		{root: "पढ़", tense: hindi.Imperative, pronoun: "तू", want: "पढ़"},
+		{root: "पढ़", tense: hindi.Imperative, pronoun: "तुम", want: "पढ़ो"},
+		{root: "पढ़", tense: hindi.Imperative, pronoun: "आप", want: "पढ़िए"},
+		{root: "खा", tense: hindi.Imperative, pronoun: "आप", want: "खाइए"},
+		{root: "कर", tense: hindi.Imperative, pronoun: "आप", want: "कीजिए"},
+		{root: "पी", tense: hindi.Imperative, pronoun: "आप", want: "पीजिए"},
+		// Compound verbs conjugate their last word
+		{root: "काम कर", tense: hindi.Future, pronoun: "हम", want: "काम करेंगे"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.root+" "+string(tt.tense)+" "+tt.pronoun, func(t *testing.T) {
+			c, ok := conjugated(hindi.Conjugate(tt.root, tt.transitive), tt.tense, tt.pronoun, tt.feminine)
+			if assert.True(t, ok) {
+				assert.Equal(t, tt.want, c.Form)
+			}
+		})
+	}
+}
+
+func TestConjugate_Ergative(t *testing.T) {
+	c, ok := conjugated(hindi.Conjugate("खा", true), hindi.PastPerfective, "वह", false)
+	assert.True(t, ok)
+	assert.True(t, c.Ergative)
+	assert.Equal(t, "उसने", c.SubjectForm())
+
+	c, ok = conjugated(hindi.Conjugate("खा", true), hindi.Future, "वह", false)
+	assert.True(t, ok)
+	assert.False(t, c.Ergative)
+	assert.Equal(t, "वह", c.SubjectForm())
+
+	// लाना is transitive but does not take ने
+	c, ok = conjugated(hindi.Conjugate("ला", true), hindi.PastPerfective, "वह", false)
+	assert.True(t, ok)
+	assert.False(t, c.Ergative)
+}
+
+func TestConjugate_Cells(t *testing.T) {
+	// Seven subjects by two genders in four tenses, three subjects in the imperative
+	assert.Len(t, hindi.Conjugate("पढ़", false), 4*7*2+3*2)
+
+	_, ok := conjugated(hindi.Conjugate("पढ़", false), hindi.Imperative, "वह", false)
+	assert.False(t, ok)
+}
+
+func TestIsTense(t *testing.T) {
+	assert.True(t, hindi.IsTense("past_perfective"))
+	assert.False(t, hindi.IsTense("pluperfect"))
+}
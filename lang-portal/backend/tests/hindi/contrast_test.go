@@ -0,0 +1,59 @@
+package hindi_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindContrast(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want hindi.Contrast
+		ok   bool
+	}{
+		{a: "कल", b: "खल", want: hindi.Aspiration, ok: true},
+		{a: "बाल", b: "भाल", want: hindi.Aspiration, ok: true},
+		{a: "टाल", b: "ताल", want: hindi.Retroflex, ok: true},
+		{a: "डाल", b: "दाल", want: hindi.Retroflex, ok: true},
+		{a: "दिन", b: "दीन", want: hindi.VowelLength, ok: true},
+		{a: "कम", b: "काम", want: hindi.VowelLength, ok: true},
+		{a: "है", b: "हैं", want: hindi.Nasalization, ok: true},
+		{a: "हाँ", b: "हा", want: hindi.Nasalization, ok: true},
+		// Aspiration inside a conjunct
+		{a: "अस्त", b: "अस्थ", want: hindi.Aspiration, ok: true},
+		// Not minimal pairs
+		{a: "कल", b: "कल", ok: false},
+		{a: "कल", b: "मल", ok: false},
+		{a: "कल", b: "खाल", ok: false},
+		{a: "दिन", b: "दिनों", ok: false},
+		{a: "हाँ", b: "हां", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			contrast, ok := hindi.FindContrast(tt.a, tt.b)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, contrast)
+
+			// The order of the words does not matter
+			contrast, ok = hindi.FindContrast(tt.b, tt.a)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, contrast)
+		})
+	}
+}
+
+func TestMinimalPairs(t *testing.T) {
+	words := []string{"ताल", "कल", "दिन", "टाल", "खल", "घर", "दीन", "थाल"}
+
+	assert.Equal(t, []hindi.Pair{
+		{First: 0, Second: 3, Contrast: hindi.Retroflex},
+		{First: 0, Second: 7, Contrast: hindi.Aspiration},
+		{First: 1, Second: 4, Contrast: hindi.Aspiration},
+		{First: 2, Second: 6, Contrast: hindi.VowelLength},
+	}, hindi.MinimalPairs(words))
+
+	assert.Empty(t, hindi.MinimalPairs(nil))
+}
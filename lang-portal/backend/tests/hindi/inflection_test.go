@@ -0,0 +1,53 @@
+package hindi_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInflectionOf(t *testing.T) {
+	tests := []struct {
+		form  string
+		lemma string
+		want  bool
+	}{
+		{form: "लड़का", lemma: "लड़का", want: true},
+		{form: "लड़के", lemma: "लड़का", want: true},
+		{form: "लड़कों", lemma: "लड़का", want: true},
+		{form: "लड़कियाँ", lemma: "लड़की", want: true},
+		{form: "लड़कियां", lemma: "लड़की", want: true},
+		{form: "बातें", lemma: "बात", want: true},
+		{form: "वस्तुएँ", lemma: "वस्तु", want: true},
+		{form: "पहाड़ों", lemma: "पहाड़", want: true},
+		{form: "लड़की", lemma: "लड़का", want: false},
+		{form: "घरे", lemma: "घर", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.form, func(t *testing.T) {
+			assert.Equal(t, tt.want, hindi.IsInflectionOf(tt.form, tt.lemma))
+		})
+	}
+}
+
+func TestSplitPostposition(t *testing.T) {
+	word, postposition, ok := hindi.SplitPostposition("घरमें", "घर")
+	assert.True(t, ok)
+	assert.Equal(t, "घर", word)
+	assert.Equal(t, "में", postposition)
+
+	word, postposition, ok = hindi.SplitPostposition("लड़कोंको", "लड़का")
+	assert.True(t, ok)
+	assert.Equal(t, "लड़कों", word)
+	assert.Equal(t, "को", postposition)
+
+	word, postposition, ok = hindi.SplitPostposition("घर", "घर")
+	assert.True(t, ok)
+	assert.Equal(t, "घर", word)
+	assert.Empty(t, postposition)
+
+	_, _, ok = hindi.SplitPostposition("कमरेमें", "घर")
+	assert.False(t, ok)
+}
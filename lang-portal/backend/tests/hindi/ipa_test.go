@@ -0,0 +1,48 @@
+package hindi_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPA(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		// Schwa deletion
+		{word: "कमरा", want: "kəmraː"},
+		{word: "समझना", want: "səməd͡ʒʱnaː"},
+		{word: "घर", want: "ɡʱər"},
+		{word: "मित्र", want: "mɪt̪rə"},
+		// Dental, retroflex and aspirated consonants
+		{word: "ताल", want: "t̪aːl"},
+		{word: "टाल", want: "ʈaːl"},
+		{word: "खाना", want: "kʰaːnaː"},
+		{word: "धूप", want: "d̪ʱuːp"},
+		// Nasal vowels, and anusvara before a stop
+		{word: "हाँ", want: "ɦãː"},
+		{word: "नहीं", want: "nəɦĩː"},
+		{word: "मैं", want: "mɛ̃ː"},
+		{word: "हिंदी", want: "ɦɪnd̪iː"},
+		{word: "संबंध", want: "səmbənd̪ʱ"},
+		{word: "सड़कों", want: "səɽkõː"},
+		// Nukta consonants, combining and precomposed
+		{word: "ज़मीन", want: "zəmiːn"},
+		{word: "ज़मीन", want: "zəmiːn"},
+		{word: "ख़त", want: "xət̪"},
+		{word: "लड़का", want: "ləɽkaː"},
+		// Conjuncts
+		{word: "क्षमा", want: "kʃəmaː"},
+		{word: "ज्ञान", want: "ɡjaːn"},
+		{word: "मेरा घर।", want: "meːraː ɡʱər"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.want, hindi.IPA(tt.word))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package hindi_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberWord(t *testing.T) {
+	tests := map[int]string{0: "शून्य", 5: "पाँच", 15: "पंद्रह", 49: "उनचास", 51: "इक्यावन", 59: "उनसठ", 99: "निन्यानवे", 100: "सौ"}
+	for n, want := range tests {
+		word, ok := hindi.NumberWord(n)
+		assert.True(t, ok)
+		assert.Equal(t, want, word)
+	}
+
+	_, ok := hindi.NumberWord(101)
+	assert.False(t, ok)
+	_, ok = hindi.NumberWord(-1)
+	assert.False(t, ok)
+}
+
+func TestParseNumberWord(t *testing.T) {
+	for n := 0; n <= hindi.MaxNumber; n++ {
+		word, _ := hindi.NumberWord(n)
+		parsed, ok := hindi.ParseNumberWord(word)
+		assert.True(t, ok, word)
+		assert.Equal(t, n, parsed, word)
+	}
+
+	// Other spellings
+	tests := map[string]int{"पांच": 5, "छः": 6, "पन्द्रह": 15, "निन्यानबे": 99, "एक सौ": 100, " सौ ": 100}
+	for word, want := range tests {
+		n, ok := hindi.ParseNumberWord(word)
+		assert.True(t, ok, word)
+		assert.Equal(t, want, n, word)
+	}
+
+	_, ok := hindi.ParseNumberWord("हज़ार")
+	assert.False(t, ok)
+}
+
+func TestParseDigits(t *testing.T) {
+	tests := map[string]int{"47": 47, "४७": 47, "४7": 47, " 100 ": 100, "०": 0}
+	for s, want := range tests {
+		n, ok := hindi.ParseDigits(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, want, n, s)
+	}
+
+	for _, s := range []string{"", "4.7", "-1", "सैंतालीस"} {
+		_, ok := hindi.ParseDigits(s)
+		assert.False(t, ok, s)
+	}
+
+	n, ok := hindi.ParseNumber("सैंतालीस")
+	assert.True(t, ok)
+	assert.Equal(t, 47, n)
+}
+
+func TestDigits(t *testing.T) {
+	assert.Equal(t, "४७", hindi.DevanagariDigits("47"))
+	assert.Equal(t, "47", hindi.ArabicDigits("४७"))
+	assert.Equal(t, "कक्षा १०", hindi.DevanagariDigits("कक्षा 10"))
+}
+
+func TestNumerals(t *testing.T) {
+	numerals := hindi.Numerals(9, 11)
+	assert.Equal(t, []hindi.Numeral{
+		{Value: 9, Word: "नौ", Digits: "९"},
+		{Value: 10, Word: "दस", Digits: "१०"},
+		{Value: 11, Word: "ग्यारह", Digits: "११"},
+	}, numerals)
+
+	assert.Len(t, hindi.Numerals(-5, 500), 101)
+}
@@ -0,0 +1,51 @@
+package hindi_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecline(t *testing.T) {
+	tests := []struct {
+		noun     string
+		feminine bool
+		want     hindi.Declension
+	}{
+		{noun: "लड़का", want: hindi.Declension{Plural: "लड़के", Oblique: "लड़के", ObliquePlural: "लड़कों"}},
+		{noun: "कुआँ", want: hindi.Declension{Plural: "कुएँ", Oblique: "कुएँ", ObliquePlural: "कुओं"}},
+		{noun: "घर", want: hindi.Declension{Plural: "घर", Oblique: "घर", ObliquePlural: "घरों"}},
+		{noun: "आदमी", want: hindi.Declension{Plural: "आदमी", Oblique: "आदमी", ObliquePlural: "आदमियों"}},
+		{noun: "भाई", want: hindi.Declension{Plural: "भाई", Oblique: "भाई", ObliquePlural: "भाइयों"}},
+		{noun: "आलू", want: hindi.Declension{Plural: "आलू", Oblique: "आलू", ObliquePlural: "आलुओं"}},
+		{noun: "पिता", want: hindi.Declension{Plural: "पिता", Oblique: "पिता", ObliquePlural: "पिताओं"}},
+		{noun: "लड़की", feminine: true, want: hindi.Declension{Plural: "लड़कियाँ", Oblique: "लड़की", ObliquePlural: "लड़कियों"}},
+		{noun: "चिड़िया", feminine: true, want: hindi.Declension{Plural: "चिड़ियाँ", Oblique: "चिड़िया", ObliquePlural: "चिड़ियों"}},
+		{noun: "मिठाई", feminine: true, want: hindi.Declension{Plural: "मिठाइयाँ", Oblique: "मिठाई", ObliquePlural: "मिठाइयों"}},
+		{noun: "बात", feminine: true, want: hindi.Declension{Plural: "बातें", Oblique: "बात", ObliquePlural: "बातों"}},
+		{noun: "माला", feminine: true, want: hindi.Declension{Plural: "मालाएँ", Oblique: "माला", ObliquePlural: "मालाओं"}},
+		{noun: "बहू", feminine: true, want: hindi.Declension{Plural: "बहुएँ", Oblique: "बहू", ObliquePlural: "बहुओं"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.noun, func(t *testing.T) {
+			assert.Equal(t, tt.want, hindi.Decline(tt.noun, tt.feminine))
+		})
+	}
+}
+
+func TestVerbRoot(t *testing.T) {
+	root, ok := hindi.VerbRoot("करना")
+	assert.True(t, ok)
+	assert.Equal(t, "कर", root)
+
+	root, ok = hindi.VerbRoot("काम करना")
+	assert.True(t, ok)
+	assert.Equal(t, "काम कर", root)
+
+	_, ok = hindi.VerbRoot("घर")
+	assert.False(t, ok)
+	_, ok = hindi.VerbRoot("ना")
+	assert.False(t, ok)
+}
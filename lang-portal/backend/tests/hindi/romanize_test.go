@@ -0,0 +1,61 @@
+package hindi_test
+
+import (
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRomanize(t *testing.T) {
+	tests := []struct {
+		word     string
+		hinglish string
+		iso      string
+		iast     string
+	}{
+		// Schwa deletion at the end of words and between single consonants
+		{word: "कमरा", hinglish: "kamra", iso: "kamrā", iast: "kamrā"},
+		{word: "घर", hinglish: "ghar", iso: "ghar", iast: "ghar"},
+		{word: "समय", hinglish: "samay", iso: "samay", iast: "samay"},
+		{word: "समझना", hinglish: "samajhna", iso: "samajhnā", iast: "samajhnā"},
+		{word: "बचपन", hinglish: "bachpan", iso: "bacpan", iast: "bacpan"},
+		{word: "न", hinglish: "na", iso: "na", iast: "na"},
+		// The final schwa is kept after conjuncts with र and य
+		{word: "मित्र", hinglish: "mitra", iso: "mitra", iast: "mitra"},
+		{word: "धर्म", hinglish: "dharm", iso: "dharm", iast: "dharm"},
+		{word: "प्रिय", hinglish: "priya", iso: "priya", iast: "priya"},
+		// Long vowels
+		{word: "रात", hinglish: "raat", iso: "rāt", iast: "rāt"},
+		{word: "पानी", hinglish: "paani", iso: "pānī", iast: "pānī"},
+		{word: "हमारा", hinglish: "hamara", iso: "hamārā", iast: "hamārā"},
+		{word: "तीन", hinglish: "teen", iso: "tīn", iast: "tīn"},
+		{word: "नमस्ते", hinglish: "namaste", iso: "namastē", iast: "namaste"},
+		// Nasalization
+		{word: "हाँ", hinglish: "haan", iso: "hām̐", iast: "hām̐"},
+		{word: "नहीं", hinglish: "nahin", iso: "nahīṁ", iast: "nahīṃ"},
+		{word: "संबंध", hinglish: "sambandh", iso: "saṁbaṁdh", iast: "saṃbaṃdh"},
+		{word: "सड़कों", hinglish: "sadkon", iso: "saṛkōṁ", iast: "saṛkoṃ"},
+		// Nukta consonants, combining and precomposed
+		{word: "ज़मीन", hinglish: "zameen", iso: "zamīn", iast: "zamīn"},
+		{word: "ज़मीन", hinglish: "zameen", iso: "zamīn", iast: "zamīn"},
+		{word: "लड़का", hinglish: "ladka", iso: "laṛkā", iast: "laṛkā"},
+		// Conjuncts and signs
+		{word: "क्षमा", hinglish: "kshama", iso: "kṣamā", iast: "kṣamā"},
+		{word: "ज्ञान", hinglish: "gyaan", iso: "jñān", iast: "jñān"},
+		{word: "दुःख", hinglish: "duhkh", iso: "duḥkh", iast: "duḥkh"},
+		{word: "कृपया", hinglish: "kripya", iso: "kr̥pyā", iast: "kṛpyā"},
+		{word: "डॉक्टर", hinglish: "doktar", iso: "ḍôkṭar", iast: "ḍôkṭar"},
+		// Words are romanized one by one, other text is kept
+		{word: "मेरा घर।", hinglish: "mera ghar.", iso: "mērā ghar.", iast: "merā ghar."},
+		{word: "१२ kg", hinglish: "12 kg", iso: "12 kg", iast: "12 kg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.hinglish, hindi.Romanize(tt.word, hindi.Hinglish))
+			assert.Equal(t, tt.iso, hindi.Romanize(tt.word, hindi.ISO15919))
+			assert.Equal(t, tt.iast, hindi.Romanize(tt.word, hindi.IAST))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package listening_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/listening"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialogue string
+		want     []string
+	}{
+		{name: "danda", dialogue: "नमस्ते। आप कैसे हैं?", want: []string{"नमस्ते।", "आप कैसे हैं?"}},
+		{name: "repeated marks", dialogue: "सच में?! हाँ।", want: []string{"सच में?!", "हाँ।"}},
+		{name: "no final mark", dialogue: "ठीक है। चलो चलते हैं", want: []string{"ठीक है।", "चलो चलते हैं"}},
+		{name: "empty", dialogue: "  ", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, listening.SplitSentences(tt.dialogue))
+		})
+	}
+}
+
+func TestTimedSegments(t *testing.T) {
+	segments := listening.TimedSegments([]listening.TimedLine{
+		{Text: "नमस्ते\nदोस्त", Start: 1.5, Duration: 2.25},
+		{Text: " ", Start: 3, Duration: 1},
+		{Text: "कैसे हो?", Start: 3.75, Duration: 1.0004},
+	})
+
+	if assert.Len(t, segments, 2) {
+		assert.Equal(t, "नमस्ते दोस्त", segments[0].Text)
+		assert.Equal(t, int64(1500), *segments[0].StartMS)
+		assert.Equal(t, int64(3750), *segments[0].EndMS)
+		assert.Equal(t, int64(4750), *segments[1].EndMS)
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	root := t.TempDir()
+	structured := filepath.Join(root, "structured_transcripts")
+	timed := filepath.Join(root, "transcripts")
+	assert.NoError(t, os.MkdirAll(structured, 0755))
+	assert.NoError(t, os.MkdirAll(timed, 0755))
+
+	transcript := `{
+		"introduction": "दो दोस्त बात कर रहे हैं।",
+		"dialogue": "तुम कहाँ जा रहे हो? मैं बाज़ार जा रहा हूँ।",
+		"question": "वह कहाँ जा रहा है?",
+		"options": ["घर", "बाज़ार"],
+		"answer": "बाज़ार"
+	}`
+	assert.NoError(t, os.WriteFile(filepath.Join(structured, "transcript_a_structured.json"), []byte(transcript), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(structured, "transcript_b_structured.json"), []byte(transcript), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(structured, "notes.json"), []byte(`{}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(timed, "transcript_b.json"), []byte(`[
+		{"text": "तुम कहाँ जा रहे हो?", "start": 0.5, "duration": 2},
+		{"text": "मैं बाज़ार जा रहा हूँ।", "start": 2.5, "duration": 2}
+	]`), 0644))
+
+	exercises, err := listening.ReadDir(structured)
+	assert.NoError(t, err)
+	if assert.Len(t, exercises, 2) {
+		untimed, timed := exercises[0], exercises[1]
+		assert.Equal(t, "transcript_a", untimed.Source)
+		assert.Equal(t, "hi", untimed.Language)
+		assert.Equal(t, "दो दोस्त बात कर रहे हैं।", untimed.Introduction)
+		if assert.Len(t, untimed.Segments, 2) {
+			assert.Equal(t, "तुम कहाँ जा रहे हो?", untimed.Segments[0].Text)
+			assert.Nil(t, untimed.Segments[0].StartMS)
+		}
+		if assert.Len(t, untimed.Questions, 1) {
+			assert.Equal(t, "बाज़ार", untimed.Questions[0].Answer)
+		}
+
+		assert.Equal(t, "transcript_b", timed.Source)
+		if assert.Len(t, timed.Segments, 2) {
+			assert.Equal(t, int64(2500), *timed.Segments[1].StartMS)
+		}
+	}
+
+	assert.NoError(t, os.WriteFile(filepath.Join(timed, "transcript_a.json"), []byte(`{}`), 0644))
+	_, err = listening.ReadDir(structured)
+	assert.Error(t, err)
+}
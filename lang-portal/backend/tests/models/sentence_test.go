@@ -0,0 +1,69 @@
+package models_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+)
+
+func TestSentence_ValidateAlternatives(t *testing.T) {
+	tests := []struct {
+		name         string
+		alternatives []string
+		want         []string
+		wantErr      error
+	}{
+		{
+			name:         "reordered words",
+			alternatives: []string{" तारे रात में चमकते हैं। "},
+			want:         []string{"तारे रात में चमकते हैं।"},
+		},
+		{
+			name:         "duplicates and the sentence itself are dropped",
+			alternatives: []string{"रात में तारे चमकते हैं।", "तारे रात में चमकते हैं।", "तारे रात में चमकते हैं।", ""},
+			want:         []string{"तारे रात में चमकते हैं।"},
+		},
+		{
+			name:         "punctuation is ignored",
+			alternatives: []string{"तारे, रात में चमकते हैं"},
+			want:         []string{"तारे, रात में चमकते हैं"},
+		},
+		{
+			name:         "different words",
+			alternatives: []string{"तारे दिन में चमकते हैं।"},
+			wantErr:      models.ErrInvalidInput,
+		},
+		{
+			name:         "missing word",
+			alternatives: []string{"तारे रात चमकते हैं।"},
+			wantErr:      models.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sentence := models.Sentence{
+				Target:       "रात में तारे चमकते हैं।",
+				Native:       "Stars shine at night.",
+				Alternatives: tt.alternatives,
+			}
+			err := sentence.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sentence.Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(sentence.Alternatives, tt.want) {
+				t.Errorf("Sentence.Validate() alternatives = %v, want %v", sentence.Alternatives, tt.want)
+			}
+		})
+	}
+}
+
+func TestSentenceWords(t *testing.T) {
+	got := models.SentenceWords("“नदी” का पानी, ठंडा है ।")
+	want := []string{"नदी", "का", "पानी", "ठंडा", "है"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SentenceWords() = %v, want %v", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package models_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/pavittarx/lang-portal/backend/pkg/models"
@@ -15,83 +16,207 @@ func TestWord_Validate(t *testing.T) {
 		{
 			name: "valid word",
 			word: models.Word{
-				Hindi:    "नमस्ते",
-				English:  "Hello",
-				Hinglish: "Namaste",
+				Target:    "नमस्ते",
+				Native:    "Hello",
+				Romanized: "Namaste",
 			},
 			wantErr: false,
 		},
 		{
-			name: "empty hindi",
+			name: "empty target",
 			word: models.Word{
-				Hindi:    "",
-				English:  "Hello",
-				Hinglish: "Namaste",
+				Target:    "",
+				Native:    "Hello",
+				Romanized: "Namaste",
 			},
 			wantErr: true,
 		},
 		{
-			name: "empty english",
+			name: "empty native",
 			word: models.Word{
-				Hindi:    "नमस्ते",
-				English:  "",
-				Hinglish: "Namaste",
+				Target:    "नमस्ते",
+				Native:    "",
+				Romanized: "Namaste",
 			},
 			wantErr: true,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.word.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Word.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, models.ErrInvalidInput) {
+				t.Errorf("Word.Validate() error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestWord_ValidateDefaultsLanguagePair(t *testing.T) {
+	word := &models.Word{Target: "नमस्ते", Native: "Hello"}
+
+	if err := word.Validate(); err != nil {
+		t.Fatalf("Word.Validate() error = %v", err)
+	}
+	if word.Language != models.DefaultLanguage || word.NativeLanguage != models.DefaultNativeLanguage {
+		t.Errorf("Word.Validate() language pair = %s-%s, want %s-%s",
+			word.Language, word.NativeLanguage, models.DefaultLanguage, models.DefaultNativeLanguage)
+	}
+}
+
+func TestWord_ValidateScripts(t *testing.T) {
+	hindi := &models.Language{Code: "hi", Name: "Hindi", Script: models.ScriptDevanagari}
+	marathi := &models.Language{Code: "mr", Name: "Marathi", Script: models.ScriptDevanagari}
+	punjabi := &models.Language{Code: "pa", Name: "Punjabi", Script: models.ScriptGurmukhi}
+	english := &models.Language{Code: "en", Name: "English", Script: models.ScriptLatin}
+
+	tests := []struct {
+		name    string
+		word    models.Word
+		target  *models.Language
+		wantErr error
+	}{
 		{
-			name: "invalid hindi characters",
-			word: models.Word{
-				Hindi:    "नमस्ते123",
-				English:  "Hello",
-				Hinglish: "Namaste",
-			},
-			wantErr: true,
+			name:   "hindi word",
+			word:   models.Word{Language: "hi", NativeLanguage: "en", Target: "नमस्ते", Native: "Hello"},
+			target: hindi,
 		},
 		{
-			name: "invalid english characters",
-			word: models.Word{
-				Hindi:    "नमस्ते",
-				English:  "Hello123!",
-				Hinglish: "Namaste",
-			},
-			wantErr: true,
+			name:   "marathi word",
+			word:   models.Word{Language: "mr", NativeLanguage: "en", Target: "पाणी", Native: "Water"},
+			target: marathi,
+		},
+		{
+			name:   "punjabi word",
+			word:   models.Word{Language: "pa", NativeLanguage: "en", Target: "ਸਤ ਸ੍ਰੀ ਅਕਾਲ", Native: "Hello"},
+			target: punjabi,
+		},
+		{
+			name:    "invalid hindi characters",
+			word:    models.Word{Language: "hi", NativeLanguage: "en", Target: "नमस्ते123", Native: "Hello"},
+			target:  hindi,
+			wantErr: models.ErrInvalidScript,
+		},
+		{
+			name:    "gurmukhi in a hindi word",
+			word:    models.Word{Language: "hi", NativeLanguage: "en", Target: "ਪਾਣੀ", Native: "Water"},
+			target:  hindi,
+			wantErr: models.ErrInvalidScript,
+		},
+		{
+			name:    "invalid english characters",
+			word:    models.Word{Language: "hi", NativeLanguage: "en", Target: "नमस्ते", Native: "Hello123!"},
+			target:  hindi,
+			wantErr: models.ErrInvalidScript,
+		},
+		{
+			name:    "mismatched language",
+			word:    models.Word{Language: "pa", NativeLanguage: "en", Target: "ਪਾਣੀ", Native: "Water"},
+			target:  hindi,
+			wantErr: models.ErrInvalidLanguage,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			err := tt.word.ValidateScripts(tt.target, english)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Word.ValidateScripts() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWord_ValidateGrammar(t *testing.T) {
+	tests := []struct {
+		name    string
+		word    models.Word
+		wantErr bool
+	}{
+		{name: "no grammar", word: models.Word{}},
+		{name: "feminine noun", word: models.Word{PartOfSpeech: " Noun ", Gender: "feminine"}},
+		{name: "noun forms", word: models.Word{PartOfSpeech: "noun", WordForms: models.WordForms{Plural: "लड़के"}}},
+		{name: "transitive verb", word: models.Word{PartOfSpeech: "verb", Transitivity: "transitive", WordForms: models.WordForms{Root: "कर"}}},
+		{name: "unknown part of speech", word: models.Word{PartOfSpeech: "article"}, wantErr: true},
+		{name: "unknown gender", word: models.Word{PartOfSpeech: "noun", Gender: "neuter"}, wantErr: true},
+		{name: "gendered adjective", word: models.Word{PartOfSpeech: "adjective", Gender: "masculine"}, wantErr: true},
+		{name: "transitive noun", word: models.Word{PartOfSpeech: "noun", Transitivity: "transitive"}, wantErr: true},
+		{name: "verb plural", word: models.Word{PartOfSpeech: "verb", WordForms: models.WordForms{Plural: "करने"}}, wantErr: true},
+		{name: "noun root", word: models.Word{PartOfSpeech: "noun", WordForms: models.WordForms{Root: "घर"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.word.Target, tt.word.Native = "शब्द", "Word"
 			err := tt.word.Validate()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Word.Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if err != nil && !errors.Is(err, models.ErrInvalidInput) {
+				t.Errorf("Word.Validate() error = %v, want %v", err, models.ErrInvalidInput)
+			}
+		})
+	}
+}
+
+func TestLanguage_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		language models.Language
+		wantErr  bool
+	}{
+		{
+			name:     "valid language",
+			language: models.Language{Code: " PA ", Name: "Punjabi", Script: models.ScriptGurmukhi},
+		},
+		{
+			name:     "invalid code",
+			language: models.Language{Code: "punjabi", Name: "Punjabi", Script: models.ScriptGurmukhi},
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported script",
+			language: models.Language{Code: "ur", Name: "Urdu", Script: "Arab"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.language.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Language.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
 		})
 	}
 }
 
 func TestWord_Sanitize(t *testing.T) {
 	word := &models.Word{
-		Hindi:    "  नमस्ते  ",
-		English:  "  Hello  ",
-		Hinglish: "  Namaste  ",
+		Target:    "  नमस्ते  ",
+		Native:    "  Hello  ",
+		Romanized: "  Namaste  ",
 	}
 
 	word.Sanitize()
 
-	if word.Hindi != "नमस्ते" {
-		t.Errorf("Word.Sanitize() Hindi = %v, want %v", word.Hindi, "नमस्ते")
+	if word.Target != "नमस्ते" {
+		t.Errorf("Word.Sanitize() Target = %v, want %v", word.Target, "नमस्ते")
 	}
-	if word.English != "Hello" {
-		t.Errorf("Word.Sanitize() English = %v, want %v", word.English, "Hello")
+	if word.Native != "Hello" {
+		t.Errorf("Word.Sanitize() Native = %v, want %v", word.Native, "Hello")
 	}
-	if word.Hinglish != "Namaste" {
-		t.Errorf("Word.Sanitize() Hinglish = %v, want %v", word.Hinglish, "Namaste")
+	if word.Romanized != "Namaste" {
+		t.Errorf("Word.Sanitize() Romanized = %v, want %v", word.Romanized, "Namaste")
 	}
 }
 
 func TestWord_GenerateScrambledWord(t *testing.T) {
 	word := &models.Word{
-		Hindi: "नमस्ते",
+		Target: "नमस्ते",
 	}
 
 	word.GenerateScrambledWord()
@@ -99,10 +224,10 @@ func TestWord_GenerateScrambledWord(t *testing.T) {
 	if word.Scrambled == "" {
 		t.Error("Word.GenerateScrambledWord() failed to generate scrambled word")
 	}
-	if word.Scrambled == word.Hindi {
+	if word.Scrambled == word.Target {
 		t.Error("Word.GenerateScrambledWord() generated same word as original")
 	}
-	if len(word.Scrambled) != len(word.Hindi) {
-		t.Errorf("Word.GenerateScrambledWord() length mismatch: got %v, want %v", len(word.Scrambled), len(word.Hindi))
+	if len(word.Scrambled) != len(word.Target) {
+		t.Errorf("Word.GenerateScrambledWord() length mismatch: got %v, want %v", len(word.Scrambled), len(word.Target))
 	}
 }
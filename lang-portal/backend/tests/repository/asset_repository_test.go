@@ -0,0 +1,61 @@
+package repository_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetRepository(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewSQLiteAssetRepository(db)
+
+	duration := int64(1200)
+	checksum := strings.Repeat("ab", 32)
+	asset := &models.Asset{
+		MimeType:   "audio/mp3",
+		Filename:   `C:\recordings\namaste.mp3`,
+		Size:       2048,
+		DurationMS: &duration,
+		Checksum:   checksum,
+		StorageKey: "ab/" + checksum + ".mp3",
+	}
+	assert.NoError(t, repo.Create(ctx, asset))
+	assert.Equal(t, models.AssetAudio, asset.Kind)
+	assert.Equal(t, "audio/mpeg", asset.MimeType)
+	assert.Equal(t, "namaste.mp3", asset.Filename)
+	assert.Equal(t, models.AssetURL(asset.ID), asset.URL)
+
+	stored, err := repo.GetByChecksum(ctx, checksum)
+	assert.NoError(t, err)
+	assert.Equal(t, asset.ID, stored.ID)
+	assert.Equal(t, duration, *stored.DurationMS)
+	assert.Equal(t, asset.StorageKey, stored.StorageKey)
+
+	// Words expose the URL of their audio
+	words := repository.NewSQLiteWordRepository(db)
+	word := &models.Word{Target: "नमस्ते", Native: "Hello", AudioAssetID: &asset.ID}
+	assert.NoError(t, words.Create(ctx, word))
+	storedWord, err := words.GetByID(ctx, word.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, asset.URL, storedWord.AudioURL)
+
+	err = repo.Create(ctx, &models.Asset{MimeType: "text/plain", Size: 1, Checksum: checksum})
+	assert.ErrorIs(t, err, models.ErrUnsupportedMedia)
+
+	assert.NoError(t, repo.Delete(ctx, asset.ID))
+	_, err = repo.GetByID(ctx, asset.ID)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, asset.ID), models.ErrNotFound)
+}
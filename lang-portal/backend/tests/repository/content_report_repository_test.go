@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentReportRepository(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	groups := repository.NewSQLiteGroupRepository(db)
+	repo := repository.NewSQLiteContentReportRepository(db)
+
+	water := &models.Word{Target: "पानी", Native: "Water"}
+	milk := &models.Word{Target: "दूध", Native: "Milk"}
+	assert.NoError(t, words.Create(ctx, water))
+	assert.NoError(t, words.Create(ctx, milk))
+
+	drinks := &models.Group{Name: "Drinks"}
+	empty := &models.Group{Name: "Empty"}
+	assert.NoError(t, groups.Create(ctx, drinks))
+	assert.NoError(t, groups.Create(ctx, empty))
+	assert.NoError(t, groups.AddWord(ctx, drinks.ID, water.ID))
+
+	ids, err := repo.ListUngroupedWordIDs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{milk.ID}, ids)
+
+	emptyGroups, err := repo.ListEmptyGroups(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, emptyGroups, 1) {
+		assert.Equal(t, "Empty", emptyGroups[0].Name)
+	}
+
+	// Words without a difficulty are left out
+	_, err = db.Exec(`UPDATE words SET difficulty = 'easy' WHERE id = ?`, water.ID)
+	assert.NoError(t, err)
+	difficulties, err := repo.ListDifficulties(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]string{water.ID: models.DifficultyEasy}, difficulties)
+}
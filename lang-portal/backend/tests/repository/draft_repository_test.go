@@ -0,0 +1,71 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraftRepository(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	repo := repository.NewSQLiteDraftRepository(db)
+
+	water := &models.Word{Target: "पानी", Native: "Water"}
+	assert.NoError(t, words.Create(ctx, water))
+
+	hint := &models.Draft{Kind: models.DraftHint, Content: []byte(`{"text":"You drink it."}`), Model: "fake", WordID: &water.ID}
+	assert.NoError(t, repo.Create(ctx, hint))
+	assert.Equal(t, models.DraftPending, hint.Status)
+	word := &models.Draft{Kind: models.DraftWord, Content: []byte(`{"target":"दूध"}`), Model: "fake"}
+	assert.NoError(t, repo.Create(ctx, word))
+
+	stored, err := repo.GetByID(ctx, hint.ID)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"text":"You drink it."}`, string(stored.Content))
+	assert.Equal(t, water.ID, *stored.WordID)
+	assert.Nil(t, stored.GroupID)
+	assert.Nil(t, stored.ReviewedAt)
+
+	// Drafts are listed newest first
+	drafts, err := repo.List(ctx, repository.ListDraftsParams{})
+	assert.NoError(t, err)
+	if assert.Len(t, drafts, 2) {
+		assert.Equal(t, word.ID, drafts[0].ID)
+	}
+	drafts, err = repo.List(ctx, repository.ListDraftsParams{WordID: water.ID})
+	assert.NoError(t, err)
+	assert.Len(t, drafts, 1)
+
+	resultID, reviewer := int64(7), int64(1)
+	stored.Status = models.DraftApproved
+	stored.ResultID = &resultID
+	stored.ReviewedBy = &reviewer
+	assert.NoError(t, repo.Review(ctx, stored))
+
+	reviewed, err := repo.GetByID(ctx, hint.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DraftApproved, reviewed.Status)
+	assert.Equal(t, resultID, *reviewed.ResultID)
+	assert.NotNil(t, reviewed.ReviewedAt)
+
+	// A draft is reviewed once
+	stored.Status = models.DraftRejected
+	assert.True(t, errors.Is(repo.Review(ctx, stored), models.ErrNotFound))
+
+	drafts, err = repo.List(ctx, repository.ListDraftsParams{Kind: models.DraftHint, Status: models.DraftPending})
+	assert.NoError(t, err)
+	assert.Empty(t, drafts)
+
+}
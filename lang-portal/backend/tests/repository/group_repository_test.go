@@ -189,3 +189,72 @@ func TestGroupRepository_List(t *testing.T) {
 	assert.Equal(t, 1, len(listedGroups))
 	assert.Equal(t, "Travel Words", listedGroups[0].Name)
 }
+
+func TestGroupRepository_Normalization(t *testing.T) {
+	repo, cleanup := setupGroupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// é typed as e and a combining accent is stored composed
+	group := &models.Group{Name: "Café Words", Description: "  Words for the café  "}
+	assert.NoError(t, repo.Create(ctx, group))
+
+	stored, err := repo.GetByID(ctx, group.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Café Words", stored.Name)
+	assert.Equal(t, "Words for the café", stored.Description)
+
+	// Either spelling finds it
+	for _, search := range []string{"Café", "Café"} {
+		groups, total, err := repo.List(ctx, 1, 10, search)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, groups, 1)
+	}
+}
+
+func TestGroupRepository_WordMemberships(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	groups := repository.NewSQLiteGroupRepository(db)
+	words := repository.NewSQLiteWordRepository(db)
+
+	home := &models.Group{Name: "Home"}
+	rooms := &models.Group{Name: "Rooms"}
+	empty := &models.Group{Name: "Empty"}
+	for _, group := range []*models.Group{home, rooms, empty} {
+		assert.NoError(t, groups.Create(ctx, group))
+	}
+	room := &models.Word{Target: "कमरा", Native: "Room"}
+	door := &models.Word{Target: "दरवाज़ा", Native: "Door"}
+	assert.NoError(t, words.Create(ctx, room))
+	assert.NoError(t, words.Create(ctx, door))
+	assert.NoError(t, groups.AddWord(ctx, home.ID, room.ID))
+	assert.NoError(t, groups.AddWord(ctx, home.ID, door.ID))
+	assert.NoError(t, groups.AddWord(ctx, rooms.ID, room.ID))
+
+	counts, err := groups.CountWords(ctx, []int64{home.ID, rooms.ID, empty.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]int{home.ID: 2, rooms.ID: 1}, counts)
+
+	counts, err = groups.CountWords(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, counts)
+
+	memberOf, err := groups.GetGroupsByWordID(ctx, room.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, memberOf, 2) {
+		assert.Equal(t, "Home", memberOf[0].Name)
+		assert.Equal(t, "Rooms", memberOf[1].Name)
+	}
+
+	memberOf, err = groups.GetGroupsByWordID(ctx, 999)
+	assert.NoError(t, err)
+	assert.Empty(t, memberOf)
+}
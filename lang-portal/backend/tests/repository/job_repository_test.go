@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobRepository(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewSQLiteJobRepository(db)
+
+	queued, err := repo.Enqueue(ctx, models.JobPronunciation, 1)
+	assert.NoError(t, err)
+	assert.True(t, queued)
+	queued, err = repo.Enqueue(ctx, models.JobPronunciation, 2)
+	assert.NoError(t, err)
+	assert.True(t, queued)
+
+	// A subject has one job of a kind
+	queued, err = repo.Enqueue(ctx, models.JobPronunciation, 1)
+	assert.NoError(t, err)
+	assert.False(t, queued)
+
+	// Jobs are claimed oldest first
+	first, err := repo.Claim(ctx, models.JobPronunciation)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), first.SubjectID)
+	assert.Equal(t, models.JobRunning, first.Status)
+	assert.Equal(t, 1, first.Attempts)
+
+	second, err := repo.Claim(ctx, models.JobPronunciation)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), second.SubjectID)
+
+	_, err = repo.Claim(ctx, models.JobPronunciation)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	assert.NoError(t, repo.Succeed(ctx, first.ID))
+	assert.NoError(t, repo.Fail(ctx, second.ID, "speech service returned 500"))
+	assert.ErrorIs(t, repo.Succeed(ctx, second.ID), models.ErrNotFound)
+
+	failed, err := repo.List(ctx, repository.ListJobsParams{Status: models.JobFailed})
+	assert.NoError(t, err)
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, second.ID, failed[0].ID)
+		assert.Equal(t, "speech service returned 500", failed[0].LastError)
+	}
+
+	// Failed jobs wait for a retry, succeeded ones are queued again
+	queued, err = repo.Enqueue(ctx, models.JobPronunciation, 2)
+	assert.NoError(t, err)
+	assert.False(t, queued)
+	queued, err = repo.Enqueue(ctx, models.JobPronunciation, 1)
+	assert.NoError(t, err)
+	assert.True(t, queued)
+
+	_, err = repo.Retry(ctx, first.ID)
+	assert.ErrorIs(t, err, models.ErrInvalidInput)
+	_, err = repo.Retry(ctx, 99)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	retried, err := repo.Retry(ctx, second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobPending, retried.Status)
+
+	// Running jobs left by an interrupted run are queued again
+	claimed, err := repo.Claim(ctx, models.JobPronunciation)
+	assert.NoError(t, err)
+	reset, err := repo.ResetRunning(ctx, models.JobPronunciation)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), reset)
+
+	stored, err := repo.GetByID(ctx, claimed.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobPending, stored.Status)
+	assert.Equal(t, 2, stored.Attempts)
+
+	all, err := repo.List(ctx, repository.ListJobsParams{Kind: models.JobPronunciation})
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+}
@@ -0,0 +1,39 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageRepository(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewSQLiteLanguageRepository(db)
+
+	languages, err := repo.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, languages, 4)
+
+	punjabi, err := repo.GetByCode(ctx, "pa")
+	assert.NoError(t, err)
+	assert.Equal(t, models.ScriptGurmukhi, punjabi.Script)
+
+	_, err = repo.GetByCode(ctx, "ur")
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	nepali := &models.Language{Code: "ne", Name: "Nepali", Script: models.ScriptDevanagari, Romanization: "iso15919"}
+	assert.NoError(t, repo.Create(ctx, nepali))
+
+	err = repo.Create(ctx, &models.Language{Code: "hi", Name: "Hindi", Script: models.ScriptDevanagari})
+	assert.ErrorIs(t, err, models.ErrInvalidLanguage)
+}
@@ -0,0 +1,83 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListeningRepository(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewSQLiteListeningRepository(db)
+
+	start, end := int64(0), int64(1800)
+	exercise := &models.ListeningExercise{
+		Source:       "transcript_a",
+		Introduction: "दो दोस्त बात कर रहे हैं।",
+		Transcript:   "तुम कहाँ जा रहे हो? मैं बाज़ार जा रहा हूँ।",
+		AudioURL:     "https://example.com/a.mp3",
+		Segments: []models.ListeningSegment{
+			{Text: "तुम कहाँ जा रहे हो?", StartMS: &start, EndMS: &end},
+			{Text: "मैं बाज़ार जा रहा हूँ।"},
+		},
+		Questions: []models.ListeningQuestion{
+			{Question: "वह कहाँ जा रहा है?", Options: []string{"घर", "बाज़ार"}, Answer: "बाज़ार"},
+		},
+	}
+	assert.NoError(t, repo.Create(ctx, exercise))
+	assert.NotZero(t, exercise.Questions[0].ID)
+
+	stored, err := repo.GetByID(ctx, exercise.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", stored.Language)
+	assert.Equal(t, "https://example.com/a.mp3", stored.AudioURL)
+	if assert.Len(t, stored.Segments, 2) {
+		assert.Equal(t, 1, stored.Segments[0].Position)
+		assert.Equal(t, end, *stored.Segments[0].EndMS)
+		assert.Nil(t, stored.Segments[1].StartMS)
+	}
+	if assert.Len(t, stored.Questions, 1) {
+		assert.Equal(t, []string{"घर", "बाज़ार"}, stored.Questions[0].Options)
+	}
+
+	bySource, err := repo.GetBySource(ctx, "transcript_a")
+	assert.NoError(t, err)
+	assert.Equal(t, exercise.ID, bySource.ID)
+	_, err = repo.GetBySource(ctx, "transcript_b")
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+
+	// Exercises without a source do not clash
+	for i := 0; i < 2; i++ {
+		other := &models.ListeningExercise{Language: "hi", Transcript: "नमस्ते।", Questions: []models.ListeningQuestion{{Question: "क्या?", Options: []string{"हाँ", "ना"}, Answer: "हाँ"}}}
+		assert.NoError(t, repo.Create(ctx, other))
+	}
+	list, err := repo.List(ctx, "hi")
+	assert.NoError(t, err)
+	assert.Len(t, list, 3)
+	list, err = repo.List(ctx, "ja")
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	question, err := repo.GetRandomQuestion(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, question.Answer)
+	question, err = repo.GetQuestion(ctx, exercise.Questions[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, exercise.ID, question.ExerciseID)
+
+	assert.NoError(t, repo.Delete(ctx, exercise.ID))
+	_, err = repo.GetQuestion(ctx, exercise.Questions[0].ID)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+	assert.True(t, errors.Is(repo.Delete(ctx, exercise.ID), models.ErrNotFound))
+}
@@ -0,0 +1,78 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentenceRepository(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	repo := repository.NewSQLiteSentenceRepository(db)
+
+	water := &models.Word{Target: "पानी", Romanized: "Paani", Native: "Water"}
+	river := &models.Word{Target: "नदी", Romanized: "Nadi", Native: "River"}
+	assert.NoError(t, words.Create(ctx, water))
+	assert.NoError(t, words.Create(ctx, river))
+
+	sentence := &models.Sentence{
+		Target:       "नदी का पानी ठंडा है।",
+		Romanized:    "Nadi ka paani thanda hai.",
+		Native:       "The river water is cold.",
+		WordIDs:      []int64{river.ID, water.ID, river.ID},
+		Alternatives: []string{"पानी नदी का ठंडा है।"},
+	}
+	assert.NoError(t, repo.Create(ctx, sentence))
+	assert.Equal(t, []int64{river.ID, water.ID}, sentence.WordIDs)
+
+	stored, err := repo.GetByID(ctx, sentence.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", stored.Language)
+	assert.ElementsMatch(t, []int64{water.ID, river.ID}, stored.WordIDs)
+	assert.Equal(t, []string{"पानी नदी का ठंडा है।"}, stored.Alternatives)
+
+	byWord, err := repo.ListByWordID(ctx, water.ID)
+	assert.NoError(t, err)
+	assert.Len(t, byWord, 1)
+
+	// Updating replaces the word links
+	sentence.WordIDs = []int64{river.ID}
+	assert.NoError(t, repo.Update(ctx, sentence))
+
+	byWord, err = repo.ListByWordID(ctx, water.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, byWord)
+
+	listed, total, err := repo.List(ctx, repository.ListSentencesParams{WordID: river.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, []int64{river.ID}, listed[0].WordIDs)
+
+	random, err := repo.GetRandomForWords(ctx, []int64{river.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, sentence.ID, random.ID)
+	assert.Equal(t, []int64{river.ID}, random.WordIDs)
+
+	random, err = repo.GetRandomForWords(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sentence.ID, random.ID)
+
+	_, err = repo.GetRandomForWords(ctx, []int64{water.ID})
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	assert.NoError(t, repo.Delete(ctx, sentence.ID))
+	_, err = repo.GetByID(ctx, sentence.ID)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, sentence.ID), models.ErrNotFound)
+}
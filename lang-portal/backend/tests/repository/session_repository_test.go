@@ -0,0 +1,105 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionRepository_ScopedToUser(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	sessionRepo := repository.NewSessionRepository(db)
+	sessionActivityRepo := repository.NewSessionActivityRepository(db)
+
+	createSession := func(userID int64) *models.Session {
+		now := time.Now()
+		session := &models.Session{UserID: userID, ActivityID: 1, StartTime: now, CreatedAt: now}
+		assert.NoError(t, sessionRepo.Create(ctx, session))
+		assert.NoError(t, sessionActivityRepo.Create(ctx, &models.SessionActivity{
+			SessionID:  session.ID,
+			ActivityID: 1,
+			Challenge:  "दिन",
+			Answer:     "Day",
+			CreatedAt:  now,
+		}))
+		return session
+	}
+
+	asha := createSession(1)
+	ravi := createSession(2)
+
+	sessions, err := sessionRepo.List(ctx, 1, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, asha.ID, sessions[0].ID)
+
+	withActivities, err := sessionRepo.GetByIDWithActivities(ctx, 1, asha.ID)
+	assert.NoError(t, err)
+	assert.Len(t, withActivities.Activities, 1)
+
+	// Another learner's session is reported as missing
+	_, err = sessionRepo.GetByIDWithActivities(ctx, 1, ravi.ID)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	learnerID := int64(1)
+	_, err = sessionRepo.DeleteAllSessionActivities(ctx, &learnerID)
+	assert.NoError(t, err)
+	deleted, err := sessionRepo.DeleteAll(ctx, &learnerID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	remaining, err := sessionRepo.GetByIDWithActivities(ctx, 2, ravi.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remaining.Activities, 1)
+}
+
+func TestSessionRepository_AssignUnowned(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	sessionRepo := repository.NewSessionRepository(db)
+
+	// Sessions recorded before accounts existed have no user
+	for i := 0; i < 2; i++ {
+		_, err := db.ExecContext(ctx, `INSERT INTO sessions (activity_id, start_time) VALUES (1, ?)`, time.Now())
+		assert.NoError(t, err)
+	}
+	owned := &models.Session{UserID: 2, ActivityID: 1, StartTime: time.Now(), CreatedAt: time.Now()}
+	assert.NoError(t, sessionRepo.Create(ctx, owned))
+
+	count, err := sessionRepo.CountUnowned(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	assigned, err := sessionRepo.AssignUnowned(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), assigned)
+
+	sessions, err := sessionRepo.List(ctx, 1, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	// Sessions of other users are kept
+	sessions, err = sessionRepo.List(ctx, 2, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+
+	count, err = sessionRepo.CountUnowned(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,64 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupStudyActivityTest(t *testing.T) (*repository.StudyActivityRepository, func()) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	return repository.NewStudyActivityRepository(db), cleanup
+}
+
+func TestStudyActivityRepository_CRUD(t *testing.T) {
+	repo, cleanup := setupStudyActivityTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	activity := &models.StudyActivity{
+		Name:        "Complete the Word",
+		Description: "Fill in missing letters",
+		Type:        "complete_word",
+		Config:      json.RawMessage(`{"missing":2}`),
+		Enabled:     true,
+	}
+	assert.NoError(t, repo.Create(ctx, activity))
+	assert.NotZero(t, activity.ID)
+
+	retrieved, err := repo.GetByID(ctx, activity.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "complete_word", retrieved.Type)
+	assert.JSONEq(t, `{"missing":2}`, string(retrieved.Config))
+	assert.True(t, retrieved.Enabled)
+
+	retrieved.Enabled = false
+	retrieved.Config = json.RawMessage(`{}`)
+	assert.NoError(t, repo.Update(ctx, retrieved))
+
+	all, err := repo.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+	assert.False(t, all[0].Enabled)
+
+	assert.NoError(t, repo.Delete(ctx, activity.ID))
+
+	_, err = repo.GetByID(ctx, activity.ID)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	err = repo.Delete(ctx, activity.ID)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	err = repo.Update(ctx, &models.StudyActivity{ID: 999, Name: "Missing", Description: "Missing", Type: "unscramble"})
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
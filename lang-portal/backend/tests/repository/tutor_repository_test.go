@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTutorRepository(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewSQLiteTutorRepository(db)
+
+	first := &models.TutorConversation{
+		UserID:         1,
+		SessionID:      1,
+		Language:       "hi",
+		NativeLanguage: "en",
+		Prompt:         "The water is cold.",
+		Answer:         "पानी ठंडा है।",
+		Vocabulary:     []models.VocabularyEntry{{WordID: 1, Native: "Water", Target: "पानी", Romanized: "Paani"}},
+		Model:          "fake",
+	}
+	assert.NoError(t, repo.CreateConversation(ctx, first))
+	second := &models.TutorConversation{UserID: 1, SessionID: 2, Language: "hi", NativeLanguage: "en", Prompt: "I eat.", Vocabulary: []models.VocabularyEntry{}}
+	assert.NoError(t, repo.CreateConversation(ctx, second))
+
+	stored, err := repo.GetConversation(ctx, 1, first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, first.Vocabulary, stored.Vocabulary)
+	assert.Equal(t, "पानी ठंडा है।", stored.Answer)
+	assert.Nil(t, stored.SentenceID)
+	assert.False(t, stored.Solved)
+
+	_, err = repo.GetConversation(ctx, 2, first.ID)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+
+	all, err := repo.ListConversations(ctx, 1, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, all, 2) {
+		assert.Equal(t, second.ID, all[0].ID)
+	}
+	inSession, err := repo.ListConversations(ctx, 1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, inSession, 1)
+
+	activityID := int64(7)
+	learner := &models.TutorMessage{ConversationID: first.ID, Role: models.TutorLearner, Content: "पानी ठंडा है", SessionActivityID: &activityID}
+	assert.NoError(t, repo.AddMessage(ctx, learner))
+	tutor := &models.TutorMessage{ConversationID: first.ID, Role: models.TutorTutor, Content: "Well done!", Clues: []string{"Nothing to fix."}}
+	assert.NoError(t, repo.AddMessage(ctx, tutor))
+
+	messages, err := repo.ListMessages(ctx, first.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, activityID, *messages[0].SessionActivityID)
+		assert.Empty(t, messages[0].Clues)
+		assert.Nil(t, messages[1].SessionActivityID)
+		assert.Equal(t, []string{"Nothing to fix."}, messages[1].Clues)
+	}
+
+	assert.NoError(t, repo.MarkSolved(ctx, first.ID))
+	stored, err = repo.GetConversation(ctx, 1, first.ID)
+	assert.NoError(t, err)
+	assert.True(t, stored.Solved)
+	assert.True(t, errors.Is(repo.MarkSolved(ctx, 999), models.ErrNotFound))
+}
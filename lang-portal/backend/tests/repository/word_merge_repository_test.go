@@ -0,0 +1,148 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordMergeRepository(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	groups := repository.NewSQLiteGroupRepository(db)
+	hints := repository.NewSQLiteWordHintRepository(db)
+	repo := repository.NewSQLiteWordMergeRepository(db)
+
+	paper := &models.Word{Target: "कागज़", Native: "Paper"}
+	typo := &models.Word{Target: "कागज", Native: "Paper"}
+	again := &models.Word{Target: "काग़ज", Native: "Paper"}
+	marathi := &models.Word{Language: "mr", Target: "कागद", Native: "Paper"}
+	for _, word := range []*models.Word{paper, typo, again, marathi} {
+		assert.NoError(t, words.Create(ctx, word))
+	}
+
+	basics := &models.Group{Name: "Basics"}
+	office := &models.Group{Name: "Office"}
+	assert.NoError(t, groups.Create(ctx, basics))
+	assert.NoError(t, groups.Create(ctx, office))
+	assert.NoError(t, groups.AddWord(ctx, basics.ID, paper.ID))
+	assert.NoError(t, groups.AddWord(ctx, basics.ID, typo.ID))
+	assert.NoError(t, groups.AddWord(ctx, office.ID, typo.ID))
+	assert.NoError(t, hints.Create(ctx, &models.WordHint{WordID: typo.ID, Text: "You write on it."}))
+
+	// Words are merged into another word of the same language pair
+	_, err = repo.Merge(ctx, paper.ID, []int64{paper.ID}, nil)
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+	_, err = repo.Merge(ctx, paper.ID, []int64{marathi.ID}, nil)
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+	_, err = repo.Merge(ctx, 9999, []int64{typo.ID}, nil)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+
+	// A failed merge merges nothing
+	_, err = repo.Merge(ctx, paper.ID, []int64{typo.ID, 9999}, nil)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+	_, err = words.GetByID(ctx, typo.ID)
+	assert.NoError(t, err)
+
+	admin := int64(1)
+	merges, err := repo.Merge(ctx, paper.ID, []int64{typo.ID}, &admin)
+	assert.NoError(t, err)
+	if assert.Len(t, merges, 1) {
+		assert.Equal(t, typo.ID, merges[0].MergedWordID)
+		assert.Equal(t, []int64{basics.ID, office.ID}, merges[0].GroupIDs)
+	}
+
+	// The word kept joins the groups of the merged word and takes its hints
+	_, err = words.GetByID(ctx, typo.ID)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+	officeWords, err := words.GetWordsByGroupID(ctx, office.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, officeWords, 1) {
+		assert.Equal(t, paper.ID, officeWords[0].ID)
+	}
+	basicsWords, err := words.GetWordsByGroupID(ctx, basics.ID)
+	assert.NoError(t, err)
+	assert.Len(t, basicsWords, 1)
+	paperHints, err := hints.ListByWordID(ctx, paper.ID)
+	assert.NoError(t, err)
+	assert.Len(t, paperHints, 1)
+
+	// Merging the word kept moves its earlier merges along
+	_, err = repo.Merge(ctx, again.ID, []int64{paper.ID}, nil)
+	assert.NoError(t, err)
+
+	recorded, err := repo.List(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, recorded, 2) {
+		assert.Equal(t, paper.ID, recorded[0].MergedWordID)
+		assert.Nil(t, recorded[0].MergedBy)
+		assert.Equal(t, again.ID, recorded[1].KeptWordID)
+		assert.Equal(t, "कागज", recorded[1].MergedWord.Target)
+		assert.Equal(t, admin, *recorded[1].MergedBy)
+	}
+}
+
+func TestWordMergeRepository_KeepsAttributes(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	repo := repository.NewSQLiteWordMergeRepository(db)
+
+	checksum := strings.Repeat("cd", 32)
+	audio := &models.Asset{MimeType: "audio/mpeg", Size: 1, Checksum: checksum, StorageKey: "cd/" + checksum + ".mp3"}
+	assert.NoError(t, repository.NewSQLiteAssetRepository(db).Create(ctx, audio))
+
+	boy := &models.Word{Target: "लड़का", Native: "Boy", PartOfSpeech: "noun", Gender: "masculine"}
+	recorded := &models.Word{Target: "लडका", Native: "Boy", AudioAssetID: &audio.ID}
+	edited := &models.Word{
+		Target: "लड़का", Native: "Boy", PartOfSpeech: "noun", Gender: "masculine",
+		IPA: "ləɽkaː", IPAOverride: true,
+		WordForms:     models.WordForms{Plural: "लड़के", Oblique: "लड़के", ObliquePlural: "लड़कों"},
+		FormsOverride: true,
+	}
+	for _, word := range []*models.Word{boy, recorded, edited} {
+		assert.NoError(t, words.Create(ctx, word))
+	}
+
+	_, err = repo.Merge(ctx, boy.ID, []int64{recorded.ID, edited.ID}, nil)
+	assert.NoError(t, err)
+
+	// The word kept takes the audio and what editors entered
+	stored, err := words.GetByID(ctx, boy.ID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, stored.AudioAssetID) {
+		assert.Equal(t, audio.ID, *stored.AudioAssetID)
+	}
+	assert.Equal(t, "ləɽkaː", stored.IPA)
+	assert.True(t, stored.IPAOverride)
+	assert.Equal(t, "लड़कों", stored.ObliquePlural)
+	assert.True(t, stored.FormsOverride)
+
+	// What the word kept has of its own is kept
+	girl := &models.Word{Target: "लड़की", Native: "Girl", IPA: "ləɽkiː", IPAOverride: true}
+	typo := &models.Word{Target: "लडकी", Native: "Girl", IPA: "ləɖkiː", IPAOverride: true}
+	assert.NoError(t, words.Create(ctx, girl))
+	assert.NoError(t, words.Create(ctx, typo))
+	_, err = repo.Merge(ctx, girl.ID, []int64{typo.ID}, nil)
+	assert.NoError(t, err)
+	stored, err = words.GetByID(ctx, girl.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "ləɽkiː", stored.IPA)
+}
@@ -1,8 +1,8 @@
 package repository_test
 
 import (
-"github.com/pavittarx/lang-portal/backend/tests/testutils"
 	"context"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
 	"testing"
 	"time"
 
@@ -17,22 +17,6 @@ func setupWordRepositoryTest(t *testing.T) (*repository.SQLiteWordRepository, fu
 		t.Fatalf("Failed to create test database: %v", err)
 	}
 
-	// Create words table if not exists
-	_, err = db.Exec(`
-		CREATE TABLE IF NOT EXISTS words (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			hindi TEXT NOT NULL,
-			scrambled TEXT NOT NULL,
-			hinglish TEXT NOT NULL,
-			english TEXT NOT NULL,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		)
-	`)
-	if err != nil {
-		cleanup()
-		t.Fatalf("Failed to create words table: %v", err)
-	}
-
 	// Clear existing data
 	_, err = db.Exec(`DELETE FROM words`)
 	if err != nil {
@@ -46,9 +30,9 @@ func setupWordRepositoryTest(t *testing.T) (*repository.SQLiteWordRepository, fu
 
 func createTestWord() *models.Word {
 	return &models.Word{
-		Hindi:     "नमस्ते",
-		Hinglish:  "Namaste",
-		English:   "Hello",
+		Target:    "नमस्ते",
+		Romanized: "Namaste",
+		Native:    "Hello",
 		CreatedAt: time.Now(),
 	}
 }
@@ -78,25 +62,25 @@ func TestWordRepository_CreateValidation(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "valid word",
-			word: createTestWord(),
+			name:    "valid word",
+			word:    createTestWord(),
 			wantErr: false,
 		},
 		{
-			name: "empty hindi",
+			name: "empty target",
 			word: &models.Word{
-				Hindi:    "",
-				Hinglish: "Namaste",
-				English:  "Hello",
+				Target:    "",
+				Romanized: "Namaste",
+				Native:    "Hello",
 			},
 			wantErr: true,
 		},
 		{
-			name: "empty english",
+			name: "empty native",
 			word: &models.Word{
-				Hindi:    "नमस्ते",
-				Hinglish: "Namaste",
-				English:  "",
+				Target:    "नमस्ते",
+				Romanized: "Namaste",
+				Native:    "",
 			},
 			wantErr: true,
 		},
@@ -128,9 +112,9 @@ func TestWordRepository_GetByID(t *testing.T) {
 	// Then retrieve it
 	retrievedWord, err := repo.GetByID(ctx, word.ID)
 	assert.NoError(t, err)
-	assert.Equal(t, word.Hindi, retrievedWord.Hindi)
-	assert.Equal(t, word.English, retrievedWord.English)
-	assert.Equal(t, word.Hinglish, retrievedWord.Hinglish)
+	assert.Equal(t, word.Target, retrievedWord.Target)
+	assert.Equal(t, word.Native, retrievedWord.Native)
+	assert.Equal(t, word.Romanized, retrievedWord.Romanized)
 	assert.NotZero(t, retrievedWord.CreatedAt)
 
 	// Try to get non-existing word
@@ -150,28 +134,119 @@ func TestWordRepository_Update(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Update the word
-	word.Hindi = "अलविदा"
-	word.English = "Goodbye"
+	word.Target = "अलविदा"
+	word.Native = "Goodbye"
 	err = repo.Update(ctx, word)
 	assert.NoError(t, err)
 
 	// Retrieve and verify
 	updatedWord, err := repo.GetByID(ctx, word.ID)
 	assert.NoError(t, err)
-	assert.Equal(t, "अलविदा", updatedWord.Hindi)
-	assert.Equal(t, "Goodbye", updatedWord.English)
+	assert.Equal(t, "अलविदा", updatedWord.Target)
+	assert.Equal(t, "Goodbye", updatedWord.Native)
 
 	// Try to update non-existing word
 	nonExistingWord := &models.Word{
-		ID:       999,
-		Hindi:    "Test",
-		English:  "Test",
-		Hinglish: "Test",
+		ID:        999,
+		Target:    "Test",
+		Native:    "Test",
+		Romanized: "Test",
 	}
 	err = repo.Update(ctx, nonExistingWord)
 	assert.Error(t, err)
 }
 
+func TestWordRepository_SetRomanized(t *testing.T) {
+	repo, cleanup := setupWordRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	word := createTestWord()
+	assert.NoError(t, repo.Create(ctx, word))
+
+	assert.NoError(t, repo.SetRomanized(ctx, word.ID, "namaste"))
+	updated, err := repo.GetByID(ctx, word.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "namaste", updated.Romanized)
+	assert.Equal(t, "नमस्ते", updated.Target)
+
+	assert.ErrorIs(t, repo.SetRomanized(ctx, 999, "test"), models.ErrNotFound)
+}
+
+func TestWordRepository_IPA(t *testing.T) {
+	repo, cleanup := setupWordRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	room := &models.Word{Target: "कमरा", Romanized: "kamra", Native: "Room", IPA: "kəmraː"}
+	assert.NoError(t, repo.Create(ctx, room))
+	assert.NoError(t, repo.Create(ctx, &models.Word{Target: "टाल", Romanized: "taal", Native: "Postpone", IPA: "ʈaːl"}))
+
+	assert.NoError(t, repo.SetIPA(ctx, room.ID, "kəmᵊraː", true))
+	stored, err := repo.GetByID(ctx, room.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "kəmᵊraː", stored.IPA)
+	assert.True(t, stored.IPAOverride)
+	assert.ErrorIs(t, repo.SetIPA(ctx, 999, "", false), models.ErrNotFound)
+
+	// The retroflex ʈ is searchable, in the ipa field and across all fields
+	for _, field := range []string{"ipa", ""} {
+		words, total, err := repo.List(ctx, repository.ListWordsParams{Search: "ʈ", Field: field})
+		assert.NoError(t, err)
+		if assert.Equal(t, 1, total) {
+			assert.Equal(t, "टाल", words[0].Target)
+		}
+	}
+}
+
+func TestWordRepository_Grammar(t *testing.T) {
+	repo, cleanup := setupWordRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	girl := &models.Word{Target: "लड़की", Romanized: "ladki", Native: "Girl", PartOfSpeech: "noun", Gender: "feminine"}
+	girl.WordForms = models.WordForms{Plural: "लड़कियाँ", Oblique: "लड़की", ObliquePlural: "लड़कियों"}
+	assert.NoError(t, repo.Create(ctx, girl))
+	assert.NoError(t, repo.Create(ctx, &models.Word{Target: "घर", Romanized: "ghar", Native: "House", PartOfSpeech: "noun", Gender: "masculine"}))
+	assert.NoError(t, repo.Create(ctx, &models.Word{Target: "करना", Romanized: "karna", Native: "To do", PartOfSpeech: "verb", Transitivity: "transitive"}))
+
+	stored, err := repo.GetByID(ctx, girl.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "feminine", stored.Gender)
+	assert.Equal(t, girl.WordForms, stored.WordForms)
+
+	forms := models.WordForms{Plural: "लड़कियां", Oblique: "लड़की", ObliquePlural: "लड़कियों"}
+	assert.NoError(t, repo.SetForms(ctx, girl.ID, forms, true))
+	stored, err = repo.GetByID(ctx, girl.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, forms, stored.WordForms)
+	assert.True(t, stored.FormsOverride)
+	assert.ErrorIs(t, repo.SetForms(ctx, 999, forms, false), models.ErrNotFound)
+
+	tests := []struct {
+		name   string
+		params repository.ListWordsParams
+		want   []string
+	}{
+		{name: "nouns", params: repository.ListWordsParams{PartOfSpeech: "noun"}, want: []string{"लड़की", "घर"}},
+		{name: "feminine nouns", params: repository.ListWordsParams{PartOfSpeech: "noun", Gender: "feminine"}, want: []string{"लड़की"}},
+		{name: "transitive verbs", params: repository.ListWordsParams{Transitivity: "transitive"}, want: []string{"करना"}},
+		{name: "adjectives", params: repository.ListWordsParams{PartOfSpeech: "adjective"}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words, total, err := repo.List(ctx, tt.params)
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.want), total)
+			var targets []string
+			for _, word := range words {
+				targets = append(targets, word.Target)
+			}
+			assert.ElementsMatch(t, tt.want, targets)
+		})
+	}
+}
+
 func TestWordRepository_Delete(t *testing.T) {
 	repo, cleanup := setupWordRepositoryTest(t)
 	defer cleanup()
@@ -205,21 +280,21 @@ func TestWordRepository_List(t *testing.T) {
 	// Create multiple words
 	words := []models.Word{
 		{
-			Hindi:     "नमस्ते",
-			Hinglish:  "Namaste",
-			English:   "Hello",
+			Target:    "नमस्ते",
+			Romanized: "Namaste",
+			Native:    "Hello",
 			CreatedAt: time.Now(),
 		},
 		{
-			Hindi:     "अलविदा",
-			Hinglish:  "Alvida",
-			English:   "Goodbye",
+			Target:    "अलविदा",
+			Romanized: "Alvida",
+			Native:    "Goodbye",
 			CreatedAt: time.Now(),
 		},
 		{
-			Hindi:     "धन्यवाद",
-			Hinglish:  "Dhanyavaad",
-			English:   "Thank you",
+			Target:    "धन्यवाद",
+			Romanized: "Dhanyavaad",
+			Native:    "Thank you",
 			CreatedAt: time.Now(),
 		},
 	}
@@ -252,7 +327,7 @@ func TestWordRepository_List(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
 	assert.Equal(t, 1, len(listedWords))
-	assert.Equal(t, "नमस्ते", listedWords[0].Hindi)
+	assert.Equal(t, "नमस्ते", listedWords[0].Target)
 }
 
 func TestWordRepository_GetRandomWord(t *testing.T) {
@@ -264,15 +339,15 @@ func TestWordRepository_GetRandomWord(t *testing.T) {
 	// Create multiple words
 	words := []models.Word{
 		{
-			Hindi:     "नमस्ते",
-			Hinglish:  "Namaste",
-			English:   "Hello",
+			Target:    "नमस्ते",
+			Romanized: "Namaste",
+			Native:    "Hello",
 			CreatedAt: time.Now(),
 		},
 		{
-			Hindi:     "अलविदा",
-			Hinglish:  "Alvida",
-			English:   "Goodbye",
+			Target:    "अलविदा",
+			Romanized: "Alvida",
+			Native:    "Goodbye",
 			CreatedAt: time.Now(),
 		},
 	}
@@ -286,6 +361,90 @@ func TestWordRepository_GetRandomWord(t *testing.T) {
 	randomWord, err := repo.GetRandomWord(ctx)
 	assert.NoError(t, err)
 	assert.NotNil(t, randomWord)
-	assert.NotEmpty(t, randomWord.Hindi)
-	assert.NotEmpty(t, randomWord.English)
+	assert.NotEmpty(t, randomWord.Target)
+	assert.NotEmpty(t, randomWord.Native)
+}
+
+func TestWordRepository_Normalization(t *testing.T) {
+	repo, cleanup := setupWordRepositoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// The precomposed क़ and a stray joiner are stored in canonical form
+	word := &models.Word{
+		Target: "क़लम", Romanized: "qalam", Native: "Pen", PartOfSpeech: models.PartOfSpeechNoun,
+		WordForms: models.WordForms{Plural: "क़लमें‌"},
+	}
+	assert.NoError(t, repo.Create(ctx, word))
+
+	stored, err := repo.GetByID(ctx, word.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "क़लम", stored.Target)
+	assert.Equal(t, "क़लमें", stored.Plural)
+
+	// Either spelling finds it
+	for _, search := range []string{"क़लम", "क़लम"} {
+		words, total, err := repo.List(ctx, repository.ListWordsParams{Page: 1, PageSize: 10, Search: search})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, words, 1)
+	}
+
+	// आ spelled as अ and its matra
+	stored.Target, stored.Scrambled = "अाम", ""
+	assert.NoError(t, repo.Update(ctx, stored))
+	assert.NoError(t, repo.SetForms(ctx, word.ID, models.WordForms{Plural: "अाम"}, true))
+
+	stored, err = repo.GetByID(ctx, word.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "आम", stored.Target)
+	assert.Equal(t, "आम", stored.Plural)
+}
+
+func TestWordRepository_ListSort(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewSQLiteWordRepository(db)
+
+	for _, word := range []models.Word{
+		{Target: "कौन", Romanized: "kaun", Native: "who"},
+		{Target: "क्या", Romanized: "kya", Native: "What"},
+		{Target: "अंक", Romanized: "ank", Native: "number"},
+		{Target: "कई", Romanized: "kai", Native: "Many"},
+	} {
+		word := word
+		assert.NoError(t, repo.Create(ctx, &word))
+	}
+	_, err = db.Exec(`UPDATE words SET difficulty = CASE target WHEN 'क्या' THEN 'easy' WHEN 'कई' THEN 'medium' WHEN 'अंक' THEN 'hard' END`)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		sort, order string
+		want        []string
+	}{
+		{sort: "", want: []string{"कौन", "क्या", "अंक", "कई"}},
+		{sort: models.WordSortHindi, want: []string{"अंक", "कई", "कौन", "क्या"}},
+		{sort: models.WordSortHindi, order: models.SortDescending, want: []string{"क्या", "कौन", "कई", "अंक"}},
+		{sort: models.WordSortEnglish, want: []string{"कई", "अंक", "क्या", "कौन"}},
+		{sort: models.WordSortHinglish, order: models.SortDescending, want: []string{"क्या", "कौन", "कई", "अंक"}},
+		{sort: models.WordSortDifficulty, want: []string{"क्या", "कई", "अंक", "कौन"}},
+	}
+	for _, tt := range tests {
+		words, _, err := repo.List(ctx, repository.ListWordsParams{Page: 1, PageSize: 10, Sort: tt.sort, Order: tt.order})
+		assert.NoError(t, err)
+		var targets []string
+		for _, word := range words {
+			targets = append(targets, word.Target)
+		}
+		assert.Equal(t, tt.want, targets, "sort %q %q", tt.sort, tt.order)
+	}
+
+	_, _, err = repo.List(ctx, repository.ListWordsParams{Sort: "length"})
+	assert.ErrorIs(t, err, models.ErrInvalidInput)
 }
@@ -0,0 +1,213 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChallengeService_SubmitAnswer_InputMethod(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	registry, err := activities.NewRegistry(activities.NewNumbersEngine(nil))
+	assert.NoError(t, err)
+	challenges, issued := newChallengeService(db, registry)
+	session := createSession(t, db, activities.NumbersType)
+
+	// Challenges are issued with a known number, numbers engines pick one at random
+	issue := func() string {
+		challenge, err := activities.NewChallenge(activities.NumbersType, "Write 10 in Hindi words",
+			map[string]interface{}{"mode": activities.DigitToWord, "value": 10})
+		assert.NoError(t, err)
+		id, err := auth.RandomID()
+		assert.NoError(t, err)
+		assert.NoError(t, issued.Create(ctx, &models.IssuedChallenge{
+			ID: id, SessionID: session.ID, Type: challenge.Type, Prompt: challenge.Prompt, Payload: challenge.Payload,
+		}))
+		return id
+	}
+
+	// Typed on a Latin keyboard, the converted input is graded and recorded
+	answer, grade, err := challenges.SubmitAnswer(ctx, learnerID, session.ID, issue(), "das", "phonetic")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, "दस", answer.Input)
+
+	// Devanagari input is graded as it is
+	answer, grade, err = challenges.SubmitAnswer(ctx, learnerID, session.ID, issue(), "दस", "itrans")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, "दस", answer.Input)
+
+	// A wrong answer keeps what was typed
+	answer, grade, err = challenges.SubmitAnswer(ctx, learnerID, session.ID, issue(), "bIsa", "itrans")
+	assert.NoError(t, err)
+	assert.False(t, grade.Correct)
+	assert.Equal(t, "bIsa", answer.Input)
+
+	// Without an input method nothing is converted
+	_, grade, err = challenges.SubmitAnswer(ctx, learnerID, session.ID, issue(), "das", "")
+	assert.NoError(t, err)
+	assert.False(t, grade.Correct)
+
+	_, _, err = challenges.SubmitAnswer(ctx, learnerID, session.ID, issue(), "das", "hunterian")
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+}
+
+func TestChallengeService_IssuedChallenges(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	assert.NoError(t, words.Create(ctx, &models.Word{Target: "कमरा", Native: "Room"}))
+	registry, err := activities.NewRegistry(activities.NewUnscrambleEngine(words))
+	assert.NoError(t, err)
+	challenges, _ := newChallengeService(db, registry)
+	session := createSession(t, db, activities.UnscrambleType)
+	other := &models.Session{UserID: learnerID, ActivityID: session.ActivityID, StartTime: time.Now(), CreatedAt: time.Now()}
+	assert.NoError(t, repository.NewSessionRepository(db).Create(ctx, other))
+
+	// The payload the answer is graded by stays on the server
+	challenge, err := challenges.GenerateChallenge(ctx, learnerID, session.ID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, challenge.ID)
+	data, err := json.Marshal(challenge)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "payload")
+	assert.NotContains(t, string(data), "word_id")
+
+	// Challenges that were not issued for the session are not graded
+	_, _, err = challenges.SubmitAnswer(ctx, learnerID, session.ID, "forged", "कमरा", "")
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+	_, _, err = challenges.SubmitAnswer(ctx, learnerID, other.ID, challenge.ID, "कमरा", "")
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+
+	answer, grade, err := challenges.SubmitAnswer(ctx, learnerID, session.ID, challenge.ID, "कमरा", "")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, challenge.Prompt, answer.Challenge)
+
+	// A challenge is answered once
+	_, _, err = challenges.SubmitAnswer(ctx, learnerID, session.ID, challenge.ID, "कमरा", "")
+	assert.True(t, errors.Is(err, models.ErrAnswered))
+
+	// Typed as it is said, the inherent vowel Hindi does not pronounce is
+	// left out
+	challenge, err = challenges.GenerateChallenge(ctx, learnerID, session.ID)
+	assert.NoError(t, err)
+	answer, grade, err = challenges.SubmitAnswer(ctx, learnerID, session.ID, challenge.ID, "kamra", "phonetic")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, "कमरा", answer.Input)
+}
+
+func TestChallengeService_ChallengeAudio(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	assets := repository.NewSQLiteAssetRepository(db)
+	words := repository.NewSQLiteWordRepository(db)
+	var ids []int64
+	for i, target := range []string{"कल", "खल"} {
+		audio := &models.Asset{Kind: models.AssetAudio, MimeType: "audio/mpeg", Filename: target + ".mp3", Size: 10, Checksum: strings.Repeat(string(rune('a'+i)), 64), StorageKey: target + ".mp3"}
+		assert.NoError(t, assets.Create(ctx, audio))
+		word := &models.Word{Target: target, Native: target, AudioAssetID: &audio.ID}
+		assert.NoError(t, words.Create(ctx, word))
+		ids = append(ids, word.ID)
+	}
+	registry, err := activities.NewRegistry(activities.NewMinimalPairsEngine(words, services.NewMinimalPairService(words, repository.NewSQLiteGroupRepository(db))))
+	assert.NoError(t, err)
+	challenges, _ := newChallengeService(db, registry)
+	session := createSession(t, db, activities.MinimalPairsType)
+
+	// The recording is served through the challenge, not by the played word's URL
+	challenge, err := challenges.GenerateChallenge(ctx, learnerID, session.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ChallengeAudioURL(session.ID, challenge.ID), challenge.Audio)
+	var played []int64
+	for _, id := range ids {
+		word, err := words.GetByID(ctx, id)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, word.AudioURL)
+		assert.NotEqual(t, word.AudioURL, challenge.Audio)
+		played = append(played, *word.AudioAssetID)
+	}
+
+	assetID, err := challenges.ChallengeAudio(ctx, learnerID, session.ID, challenge.ID)
+	assert.NoError(t, err)
+	assert.Contains(t, played, assetID)
+
+	_, err = challenges.ChallengeAudio(ctx, learnerID, session.ID, "forged")
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+	_, err = challenges.ChallengeAudio(ctx, learnerID+1, session.ID, challenge.ID)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+}
+
+func TestChallengeService_AnswerAfterMerge(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	typo := &models.Word{Target: "कागज", Native: "Paper"}
+	assert.NoError(t, words.Create(ctx, typo))
+	registry, err := activities.NewRegistry(activities.NewUnscrambleEngine(words))
+	assert.NoError(t, err)
+	challenges, _ := newChallengeService(db, registry)
+	session := createSession(t, db, activities.UnscrambleType)
+
+	challenge, err := challenges.GenerateChallenge(ctx, learnerID, session.ID)
+	assert.NoError(t, err)
+
+	// A challenge issued with a merged word is graded against the word kept
+	paper := &models.Word{Target: "कागज़", Native: "Paper"}
+	assert.NoError(t, words.Create(ctx, paper))
+	_, err = repository.NewSQLiteWordMergeRepository(db).Merge(ctx, paper.ID, []int64{typo.ID}, nil)
+	assert.NoError(t, err)
+
+	_, grade, err := challenges.SubmitAnswer(ctx, learnerID, session.ID, challenge.ID, "कागज़", "")
+	assert.NoError(t, err)
+	assert.True(t, grade.Correct)
+	assert.Equal(t, "कागज़", grade.Expected)
+}
+
+// newChallengeService creates a challenge service with the engines of
+// registry, returning the repository of its issued challenges
+func newChallengeService(db *sql.DB, registry *activities.Registry) (*services.ChallengeService, *repository.SQLiteIssuedChallengeRepository) {
+	issued := repository.NewSQLiteIssuedChallengeRepository(db)
+	return services.NewChallengeService(
+		registry,
+		repository.NewStudyActivityRepository(db),
+		repository.NewSessionRepository(db),
+		repository.NewSessionActivityRepository(db),
+		issued,
+	), issued
+}
@@ -0,0 +1,84 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConjugationService(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	groups := repository.NewSQLiteGroupRepository(db)
+	service := services.NewConjugationService(words)
+
+	created := map[string]*models.Word{}
+	for _, word := range []models.Word{
+		{Target: "करना", Romanized: "karna", Native: "To do", PartOfSpeech: models.PartOfSpeechVerb,
+			Transitivity: models.TransitivityTransitive, WordForms: models.WordForms{Root: "कर"}},
+		{Target: "सोना", Romanized: "sona", Native: "To sleep", PartOfSpeech: models.PartOfSpeechVerb},
+		{Target: "कमरा", Romanized: "kamra", Native: "Room", PartOfSpeech: models.PartOfSpeechNoun},
+	} {
+		word := word
+		assert.NoError(t, words.Create(ctx, &word))
+		created[word.Target] = &word
+	}
+
+	conjugated, err := service.Conjugate(ctx, created["करना"].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "करना", conjugated.Word.Target)
+	assert.Len(t, conjugated.Conjugations, 4*7*2+3*2)
+	assert.Contains(t, conjugated.Conjugations, models.Conjugation{
+		Tense: "past_perfective", Person: 3, Plural: true, Gender: "feminine",
+		Subject: "उन्होंने", Ergative: true, Form: "किया",
+	})
+	assert.Contains(t, conjugated.Conjugations, models.Conjugation{
+		Tense: "imperative", Person: 2, Plural: true, Gender: "masculine", Formality: "formal",
+		Subject: "आप", Form: "कीजिए",
+	})
+
+	// Without a transitivity the perfective is left out, the root comes
+	// from the infinitive
+	conjugated, err = service.Conjugate(ctx, created["सोना"].ID)
+	assert.NoError(t, err)
+	assert.Len(t, conjugated.Conjugations, 3*7*2+3*2)
+	for _, c := range conjugated.Conjugations {
+		assert.NotEqual(t, "past_perfective", c.Tense)
+	}
+	assert.Contains(t, conjugated.Conjugations, models.Conjugation{
+		Tense: "future", Person: 1, Gender: "feminine", Subject: "मैं", Form: "सोऊँगी",
+	})
+
+	_, err = service.Conjugate(ctx, created["कमरा"].ID)
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+
+	_, err = service.Conjugate(ctx, 9999)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+
+	verbs, err := service.ListVerbs(ctx, nil)
+	assert.NoError(t, err)
+	assert.Len(t, verbs, 2)
+
+	group := &models.Group{Name: "Home"}
+	assert.NoError(t, groups.Create(ctx, group))
+	assert.NoError(t, groups.AddWord(ctx, group.ID, created["सोना"].ID))
+	assert.NoError(t, groups.AddWord(ctx, group.ID, created["कमरा"].ID))
+
+	verbs, err = service.ListVerbs(ctx, &group.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, verbs, 1) {
+		assert.Equal(t, "सोना", verbs[0].Target)
+	}
+}
@@ -0,0 +1,100 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/hindi"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentReportService(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	groups := repository.NewSQLiteGroupRepository(db)
+	service := services.NewContentReportService(words, repository.NewSQLiteLanguageRepository(db), repository.NewSQLiteContentReportRepository(db))
+
+	basics := &models.Group{Name: "Basics"}
+	assert.NoError(t, groups.Create(ctx, basics))
+	assert.NoError(t, groups.Create(ctx, &models.Group{Name: "Empty"}))
+
+	created := map[string]*models.Word{}
+	for _, word := range []models.Word{
+		{Target: "दिन", Scrambled: "नदि", Romanized: "din", Native: "Day"},
+		{Target: "रात", Scrambled: "ातर", Native: "Night"},
+		{Target: "लाल", Scrambled: "लाल", Romanized: "laal", Native: "Red"},
+		{Target: "सास", Scrambled: "ससा", Romanized: "saas", Native: "Mother-in-law"},
+		{Target: "नदी", Scrambled: "दीन", Romanized: "nadi", Native: "River"},
+		{Target: "नदी", Scrambled: "दीन", Romanized: "nadi", Native: "Stream"},
+		{Target: "घर", Scrambled: "रघ", Romanized: "ghar", Native: "Home"},
+	} {
+		word := word
+		assert.NoError(t, words.Create(ctx, &word))
+		created[word.Target+word.Native] = &word
+		if word.Target != "सास" {
+			assert.NoError(t, groups.AddWord(ctx, basics.ID, word.ID))
+		}
+	}
+	river, stream := created["नदीRiver"].ID, created["नदीStream"].ID
+	for id, difficulty := range map[int64]string{
+		created["दिनDay"].ID: models.DifficultyEasy,
+		river:                models.DifficultyEasy,
+		stream:               models.DifficultyMedium,
+		created["घरHome"].ID: models.DifficultyHard,
+	} {
+		_, err := db.Exec(`UPDATE words SET difficulty = ? WHERE id = ?`, difficulty, id)
+		assert.NoError(t, err)
+	}
+
+	report, err := service.Report(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		models.CheckMissingRomanized:   1,
+		models.CheckScrambledUnchanged: 1,
+		models.CheckScrambledBroken:    1,
+		models.CheckUngroupedWord:      1,
+		models.CheckEmptyGroup:         1,
+		models.CheckInvalidNative:      1,
+		models.CheckDifficultyMismatch: 2,
+	}, report.Counts)
+	assert.Equal(t, 8, report.Total)
+
+	findings := map[string][]models.ContentFinding{}
+	for _, finding := range report.Findings {
+		findings[finding.Check] = append(findings[finding.Check], finding)
+	}
+	assert.Equal(t, hindi.Romanize("रात", hindi.Hinglish), findings[models.CheckMissingRomanized][0].Suggested)
+	assert.Equal(t, "लला", findings[models.CheckScrambledUnchanged][0].Suggested)
+	assert.Equal(t, []int64{created["रातNight"].ID}, findings[models.CheckScrambledBroken][0].WordIDs)
+	assert.Equal(t, "तरा", findings[models.CheckScrambledBroken][0].Suggested)
+	assert.Equal(t, []int64{created["सासMother-in-law"].ID}, findings[models.CheckUngroupedWord][0].WordIDs)
+	assert.Len(t, findings[models.CheckEmptyGroup][0].GroupIDs, 1)
+	assert.Equal(t, "Mother in law", findings[models.CheckInvalidNative][0].Suggested)
+
+	// Entries of a word disagreeing come first, then difficulties far from
+	// the length of the word
+	mismatches := findings[models.CheckDifficultyMismatch]
+	assert.Equal(t, []int64{river, stream}, mismatches[0].WordIDs)
+	assert.Equal(t, []int64{created["घरHome"].ID}, mismatches[1].WordIDs)
+	assert.Equal(t, models.DifficultyMedium, mismatches[1].Suggested)
+
+	// One check runs alone
+	report, err = service.Report(ctx, models.CheckEmptyGroup)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{models.CheckEmptyGroup: 1}, report.Counts)
+	assert.Equal(t, 1, report.Total)
+
+	_, err = service.Report(ctx, "spelling")
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+}
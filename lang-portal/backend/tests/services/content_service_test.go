@@ -0,0 +1,254 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/ai"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContentService(db *sql.DB, provider ai.LLMProvider) *services.ContentService {
+	wordRepo := repository.NewSQLiteWordRepository(db)
+	languageRepo := repository.NewSQLiteLanguageRepository(db)
+	sentenceRepo := repository.NewSQLiteSentenceRepository(db)
+	return services.NewContentService(
+		provider,
+		repository.NewSQLiteDraftRepository(db),
+		repository.NewSQLiteWordHintRepository(db),
+		languageRepo,
+		services.NewWordService(wordRepo, languageRepo, sentenceRepo, repository.NewSQLiteAssetRepository(db)),
+		services.NewSentenceService(sentenceRepo, wordRepo, languageRepo),
+		services.NewGroupService(repository.NewSQLiteGroupRepository(db)),
+	)
+}
+
+func TestContentService_ProposeWords(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	groups := repository.NewSQLiteGroupRepository(db)
+
+	fruits := &models.Group{Name: "Fruits", Description: "Common fruits"}
+	assert.NoError(t, groups.Create(ctx, fruits))
+	apple := &models.Word{Target: "सेब", Romanized: "Seb", Native: "Apple"}
+	assert.NoError(t, words.Create(ctx, apple))
+	assert.NoError(t, groups.AddWord(ctx, fruits.ID, apple.ID))
+	mango := &models.Word{Target: "आम", Romanized: "Aam", Native: "Mango"}
+	assert.NoError(t, words.Create(ctx, mango))
+
+	fake := ai.NewFakeLLM("```json\n" + `{"words": [
+		{"target": "केला", "romanized": "Kela", "native": "Banana"},
+		{"target": "सेब", "romanized": "Seb", "native": "Apple"},
+		{"target": "आम", "romanized": "Aam", "native": "Mango"},
+		{"target": "Grapes", "romanized": "Angoor", "native": "Grapes"},
+		{"target": "संतरा", "romanized": "", "native": ""},
+		{"target": "केला", "romanized": "Kela", "native": "Banana"}
+	]}` + "\n```")
+	service := newContentService(db, fake)
+
+	drafts, err := service.ProposeWords(ctx, 1, services.ProposeWordsRequest{GroupID: fruits.ID, Count: 3})
+	assert.NoError(t, err)
+	// Known, invalid and repeated words are dropped
+	if assert.Len(t, drafts, 1) {
+		draft := drafts[0]
+		assert.Equal(t, models.DraftWord, draft.Kind)
+		assert.Equal(t, models.DraftPending, draft.Status)
+		assert.Equal(t, "fake", draft.Model)
+		assert.Equal(t, fruits.ID, *draft.GroupID)
+		assert.JSONEq(t, `{"language":"hi","native_language":"en","target":"केला","romanized":"Kela","native":"Banana"}`, string(draft.Content))
+	}
+
+	requests := fake.Requests()
+	if assert.Len(t, requests, 1) {
+		prompt := requests[0].Messages[1].Content
+		assert.Contains(t, prompt, "Propose 3 Hindi vocabulary words")
+		assert.Contains(t, prompt, `"Fruits: Common fruits"`)
+		assert.Contains(t, prompt, "do not repeat them: सेब")
+		assert.Contains(t, requests[0].Messages[0].Content, "Devanagari")
+	}
+
+	// Nothing usable in the answer
+	fake.Respond(`{"words": [{"target": "आम", "romanized": "Aam", "native": "Mango"}]}`)
+	_, err = service.ProposeWords(ctx, 1, services.ProposeWordsRequest{GroupID: fruits.ID})
+	assert.True(t, errors.Is(err, ai.ErrInvalidOutput), "got %v", err)
+
+	_, err = service.ProposeWords(ctx, 1, services.ProposeWordsRequest{GroupID: fruits.ID, Count: 50})
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+	_, err = service.ProposeWords(ctx, 1, services.ProposeWordsRequest{GroupID: 999})
+	assert.Error(t, err)
+}
+
+func TestContentService_ApproveWord(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	groups := repository.NewSQLiteGroupRepository(db)
+	fruits := &models.Group{Name: "Fruits"}
+	assert.NoError(t, groups.Create(ctx, fruits))
+
+	fake := ai.NewFakeLLM(`{"words": [
+		{"target": "केला", "romanized": "Kela", "native": "Banana"},
+		{"target": "अंगूर", "romanized": "Angur", "native": "Grape"}
+	]}`)
+	service := newContentService(db, fake)
+
+	drafts, err := service.ProposeWords(ctx, 1, services.ProposeWordsRequest{GroupID: fruits.ID})
+	assert.NoError(t, err)
+	assert.Len(t, drafts, 2)
+
+	// Editors may correct the content before approving it
+	edited := json.RawMessage(`{"language":"hi","native_language":"en","target":"अंगूर","romanized":"Angoor","native":"Grapes"}`)
+	approved, err := service.ApproveDraft(ctx, 2, drafts[1].ID, edited)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DraftApproved, approved.Status)
+	assert.Equal(t, int64(2), *approved.ReviewedBy)
+	assert.NotNil(t, approved.ReviewedAt)
+
+	word, err := words.GetByID(ctx, *approved.ResultID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Angoor", word.Romanized)
+	assert.Equal(t, "Grapes", word.Native)
+	groupWords, err := words.GetWordsByGroupID(ctx, fruits.ID)
+	assert.NoError(t, err)
+	assert.Len(t, groupWords, 1)
+
+	// Reviewed drafts cannot be reviewed again
+	_, err = service.ApproveDraft(ctx, 2, drafts[1].ID, nil)
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+
+	// Invalid corrections are refused and leave the draft pending
+	_, err = service.ApproveDraft(ctx, 2, drafts[0].ID, json.RawMessage(`{"language":"hi","native_language":"en","target":"","native":"Banana"}`))
+	assert.True(t, errors.Is(err, models.ErrInvalidInput), "got %v", err)
+	_, err = service.ApproveDraft(ctx, 2, drafts[0].ID, json.RawMessage(`{"language":"hi","native_language":"en","target":"Banana","native":"Banana"}`))
+	assert.True(t, errors.Is(err, models.ErrInvalidScript), "got %v", err)
+
+	rejected, err := service.RejectDraft(ctx, 2, drafts[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DraftRejected, rejected.Status)
+	assert.Nil(t, rejected.ResultID)
+
+	pending, err := service.ListDrafts(ctx, repository.ListDraftsParams{Status: models.DraftPending})
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+	_, err = service.ListDrafts(ctx, repository.ListDraftsParams{Status: "unknown"})
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+}
+
+func TestContentService_Sentences(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	sentences := repository.NewSQLiteSentenceRepository(db)
+	water := &models.Word{Target: "पानी", Romanized: "Paani", Native: "Water"}
+	assert.NoError(t, words.Create(ctx, water))
+
+	fake := ai.NewFakeLLM(`{"sentences": [
+		{"target": "मुझे पानी चाहिए।", "romanized": "Mujhe paani chahiye.", "native": "I need water."},
+		{"target": "मुझे दूध चाहिए।", "romanized": "Mujhe doodh chahiye.", "native": "I need milk."},
+		{"target": "पानी ठंडा है।", "romanized": "Paani thanda hai.", "native": ""}
+	]}`)
+	service := newContentService(db, fake)
+
+	drafts, err := service.ProposeSentences(ctx, 1, services.ProposeRequest{WordID: water.ID, Count: 3})
+	assert.NoError(t, err)
+	// Sentences without the word or a translation are dropped
+	if assert.Len(t, drafts, 1) {
+		assert.Equal(t, water.ID, *drafts[0].WordID)
+	}
+	assert.Contains(t, fake.Requests()[0].Messages[1].Content, `use the word "पानी" (Water)`)
+
+	approved, err := service.ApproveDraft(ctx, 1, drafts[0].ID, nil)
+	assert.NoError(t, err)
+
+	sentence, err := sentences.GetByID(ctx, *approved.ResultID)
+	assert.NoError(t, err)
+	assert.Equal(t, "मुझे पानी चाहिए।", sentence.Target)
+	assert.Equal(t, []int64{water.ID}, sentence.WordIDs)
+
+	_, err = service.ProposeSentences(ctx, 1, services.ProposeRequest{WordID: 999})
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+}
+
+func TestContentService_Hints(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	water := &models.Word{Target: "पानी", Romanized: "Paani", Native: "Water"}
+	assert.NoError(t, words.Create(ctx, water))
+
+	fake := ai.NewFakeLLM(`{"hints": [
+		"You drink it when you are thirsty.",
+		"It sounds like paani.",
+		"पानी is clear.",
+		"  ",
+		"` + strings.Repeat("a", 201) + `"
+	]}`)
+	service := newContentService(db, fake)
+
+	drafts, err := service.ProposeHints(ctx, 1, services.ProposeRequest{WordID: water.ID})
+	assert.NoError(t, err)
+	// Hints giving the word away, empty and overly long hints are dropped
+	if assert.Len(t, drafts, 1) {
+		assert.JSONEq(t, `{"text":"You drink it when you are thirsty."}`, string(drafts[0].Content))
+	}
+
+	_, err = service.ApproveDraft(ctx, 1, drafts[0].ID, nil)
+	assert.NoError(t, err)
+
+	hints, err := service.ListWordHints(ctx, water.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, hints, 1) {
+		assert.Equal(t, "You drink it when you are thirsty.", hints[0].Text)
+		assert.True(t, errors.Is(service.DeleteWordHint(ctx, water.ID+1, hints[0].ID), models.ErrNotFound))
+		assert.NoError(t, service.DeleteWordHint(ctx, water.ID, hints[0].ID))
+	}
+
+	hints, err = service.ListWordHints(ctx, water.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, hints)
+}
+
+func TestContentService_NotConfigured(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	water := &models.Word{Target: "पानी", Native: "Water"}
+	assert.NoError(t, repository.NewSQLiteWordRepository(db).Create(ctx, water))
+
+	service := newContentService(db, nil)
+	_, err = service.ProposeHints(ctx, 1, services.ProposeRequest{WordID: water.ID})
+	assert.True(t, errors.Is(err, models.ErrNotConfigured))
+}
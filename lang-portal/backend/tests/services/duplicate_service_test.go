@@ -0,0 +1,86 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateService(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	service := services.NewDuplicateService(words, repository.NewSQLiteWordMergeRepository(db))
+
+	created := map[string]*models.Word{}
+	for _, word := range []models.Word{
+		{Target: "कागज़", Romanized: "kaagaz", Native: "Paper"},
+		{Target: "कागज", Romanized: "kagaz", Native: "paper"},
+		{Target: "कमरा", Romanized: "kamra", Native: "Room"},
+		{Target: "कमर", Romanized: "kamar", Native: "a room."},
+		{Target: "ऋषि", Romanized: "rishi", Native: "Sage"},
+		{Target: "रिशि", Romanized: "Rishi", Native: "sage"},
+		{Target: "कल", Romanized: "kal", Native: "Tomorrow"},
+		{Target: "कब", Romanized: "kab", Native: "When"},
+		{Language: "mr", Target: "कमरा", Romanized: "kamra", Native: "Waist"},
+	} {
+		word := word
+		assert.NoError(t, words.Create(ctx, &word))
+		created[word.Language+word.Target] = &word
+	}
+
+	clusters, err := service.FindDuplicates(ctx, "hi")
+	assert.NoError(t, err)
+	if assert.Len(t, clusters, 3) {
+		assert.Equal(t, []string{models.DuplicateSameRomanized, models.DuplicateSameTarget}, clusters[0].Reasons)
+		assert.Equal(t, []int64{created["hiकागज़"].ID, created["hiकागज"].ID}, wordIDs(clusters[0].Words))
+		assert.Equal(t, []string{models.DuplicateSimilarTarget}, clusters[1].Reasons)
+		assert.Equal(t, []int64{created["hiकमरा"].ID, created["hiकमर"].ID}, wordIDs(clusters[1].Words))
+		assert.Equal(t, []string{models.DuplicateSameRomanized}, clusters[2].Reasons)
+		assert.Equal(t, "hi", clusters[2].Language)
+	}
+
+	// Words of other language pairs are not compared
+	clusters, err = service.FindDuplicates(ctx, "")
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 3)
+
+	_, err = service.Merge(ctx, 0, created["hiकागज़"].ID, services.MergeWordsRequest{WordIDs: []int64{-1}})
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+
+	// Repeated IDs are merged once
+	merges, err := service.Merge(ctx, 0, created["hiकागज़"].ID, services.MergeWordsRequest{
+		WordIDs: []int64{created["hiकागज"].ID, created["hiकागज"].ID},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, merges, 1) {
+		assert.Nil(t, merges[0].MergedBy)
+	}
+
+	clusters, err = service.FindDuplicates(ctx, "hi")
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 2)
+
+	recorded, err := service.ListMerges(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, recorded, 1)
+}
+
+func wordIDs(words []models.Word) []int64 {
+	ids := make([]int64, 0, len(words))
+	for _, word := range words {
+		ids = append(ids, word.ID)
+	}
+	return ids
+}
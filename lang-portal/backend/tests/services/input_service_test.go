@@ -0,0 +1,25 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputService_Convert(t *testing.T) {
+	service := services.NewInputService()
+
+	converted, err := service.Convert("paani", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "पानी", converted)
+
+	converted, err = service.Convert("pAnI", "itrans")
+	assert.NoError(t, err)
+	assert.Equal(t, "पानी", converted)
+
+	_, err = service.Convert("paani", "hunterian")
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+}
@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// learnerID is the user launching activities in the launch tests
+const learnerID int64 = 1
+
+func setupLaunchTest(t *testing.T) (*services.LaunchService, *repository.StudyActivityRepository, func()) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	signer, err := auth.NewSigner([]byte("0123456789abcdef0123456789abcdef"))
+	assert.NoError(t, err)
+
+	activityRepo := repository.NewStudyActivityRepository(db)
+	service := services.NewLaunchService(
+		signer,
+		time.Hour,
+		activityRepo,
+		repository.NewSessionRepository(db),
+		repository.NewSessionActivityRepository(db),
+	)
+
+	return service, activityRepo, cleanup
+}
+
+func createExternalActivity(t *testing.T, repo *repository.StudyActivityRepository, name, config string) *models.StudyActivity {
+	activity := &models.StudyActivity{
+		Name:        name,
+		Description: "Hosted as a separate app",
+		Type:        "external",
+		Config:      json.RawMessage(config),
+		Enabled:     true,
+	}
+	assert.NoError(t, repo.Create(context.Background(), activity))
+	return activity
+}
+
+func TestLaunchService_LaunchAndReport(t *testing.T) {
+	service, activityRepo, cleanup := setupLaunchTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	activity := createExternalActivity(t, activityRepo, "Word Match", `{"launch_url":"https://match.example/play"}`)
+
+	launch, err := service.LaunchActivity(ctx, learnerID, activity.ID, nil)
+	assert.NoError(t, err)
+	assert.NotZero(t, launch.Session.ID)
+	assert.Equal(t, learnerID, launch.Session.UserID)
+	assert.NotEmpty(t, launch.Token)
+
+	target, err := url.Parse(launch.LaunchURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "match.example", target.Host)
+	assert.Equal(t, launch.Token, target.Query().Get("token"))
+
+	result := &models.SessionActivity{Challenge: "दिन", Answer: "Day", Input: "Day", Result: models.ResultSuccess, Score: 100}
+	assert.NoError(t, service.RecordResult(ctx, launch.Token, launch.Session.ID, result))
+	assert.Equal(t, launch.Session.ID, result.SessionID)
+	assert.Equal(t, activity.ID, result.ActivityID)
+
+	// A token only covers the session it was issued for
+	other, err := service.LaunchActivity(ctx, learnerID, activity.ID, nil)
+	assert.NoError(t, err)
+	err = service.RecordResult(ctx, launch.Token, other.Session.ID, &models.SessionActivity{Challenge: "a", Answer: "b"})
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	err = service.RecordResult(ctx, "forged", launch.Session.ID, &models.SessionActivity{Challenge: "a", Answer: "b"})
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	session, err := service.CompleteSession(ctx, launch.Token, launch.Session.ID, 90)
+	assert.NoError(t, err)
+	assert.True(t, session.IsCompleted())
+
+	// Completed sessions no longer accept results
+	err = service.RecordResult(ctx, launch.Token, launch.Session.ID, &models.SessionActivity{Challenge: "a", Answer: "b"})
+	assert.ErrorIs(t, err, models.ErrSessionEnded)
+}
+
+func TestLaunchService_RejectsUnlaunchableActivities(t *testing.T) {
+	service, activityRepo, cleanup := setupLaunchTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	withoutURL := createExternalActivity(t, activityRepo, "No URL", `{}`)
+	_, err := service.LaunchActivity(ctx, learnerID, withoutURL.ID, nil)
+	assert.ErrorIs(t, err, services.ErrNotLaunchable)
+
+	disabled := createExternalActivity(t, activityRepo, "Disabled", `{"launch_url":"https://app.example"}`)
+	disabled.Enabled = false
+	assert.NoError(t, activityRepo.Update(ctx, disabled))
+	_, err = service.LaunchActivity(ctx, learnerID, disabled.ID, nil)
+	assert.ErrorIs(t, err, models.ErrActivityDisabled)
+
+	_, err = service.LaunchActivity(ctx, learnerID, 999, nil)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
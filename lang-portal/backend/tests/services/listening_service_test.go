@@ -0,0 +1,140 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/listening"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// structuredTranscripts holds the transcripts structured by listening-comp
+const structuredTranscripts = "../../../../listening-comp/backend/structured_transcripts"
+
+func TestListeningService_Import(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	service := services.NewListeningService(
+		repository.NewSQLiteListeningRepository(db),
+		repository.NewSQLiteLanguageRepository(db),
+		repository.NewSQLiteAssetRepository(db),
+	)
+
+	exercises, err := listening.ReadDir(structuredTranscripts)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, exercises)
+	for _, exercise := range exercises {
+		_, created, err := service.ImportExercise(ctx, exercise)
+		assert.NoError(t, err)
+		assert.True(t, created)
+	}
+
+	// Importing again keeps the exercises already imported
+	again, err := listening.ReadDir(structuredTranscripts)
+	assert.NoError(t, err)
+	stored, created, err := service.ImportExercise(ctx, again[0])
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, exercises[0].ID, stored.ID)
+
+	list, err := service.ListExercises(ctx, "hi")
+	assert.NoError(t, err)
+	assert.Len(t, list, len(exercises))
+
+	exercise, err := service.GetExercise(ctx, exercises[0].ID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, exercise.Segments)
+	if assert.Len(t, exercise.Questions, 1) {
+		assert.Contains(t, exercise.Questions[0].Options, exercise.Questions[0].Answer)
+	}
+
+	// Placeholder options left by a failed generation are refused
+	transcript := listening.StructuredTranscript{
+		Dialogue: "तुम कहाँ जा रहे हो? मैं बाज़ार जा रहा हूँ।",
+		Question: "वह कहाँ जा रहा है?",
+		Options:  []string{"Incorrect version 1 of बाज़ार", "बाज़ार"},
+		Answer:   "बाज़ार",
+	}
+	_, _, err = service.ImportTranscript(ctx, services.ImportTranscriptRequest{Source: "placeholder", Transcript: transcript})
+	assert.True(t, errors.Is(err, models.ErrInvalidScript), "got %v", err)
+
+	transcript.Options = []string{"घर", "स्कूल"}
+	_, _, err = service.ImportTranscript(ctx, services.ImportTranscriptRequest{Source: "unanswered", Transcript: transcript})
+	assert.True(t, errors.Is(err, models.ErrInvalidInput), "got %v", err)
+
+	transcript.Options = []string{"घर", "बाज़ार"}
+	_, _, err = service.ImportTranscript(ctx, services.ImportTranscriptRequest{Transcript: transcript})
+	assert.True(t, errors.Is(err, models.ErrInvalidInput), "got %v", err)
+
+	imported, created, err := service.ImportTranscript(ctx, services.ImportTranscriptRequest{
+		Source:     "market",
+		Transcript: transcript,
+		Lines:      []listening.TimedLine{{Text: "तुम कहाँ जा रहे हो?", Start: 2, Duration: 1}, {Text: "मैं बाज़ार जा रहा हूँ।", Start: 1, Duration: 1}},
+	})
+	assert.True(t, errors.Is(err, models.ErrInvalidTimeRange), "got %v", err)
+	assert.False(t, created)
+	assert.Nil(t, imported)
+}
+
+func TestListeningService_SetAudio(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	assets := repository.NewSQLiteAssetRepository(db)
+	service := services.NewListeningService(repository.NewSQLiteListeningRepository(db), repository.NewSQLiteLanguageRepository(db), assets)
+
+	exercise := &models.ListeningExercise{
+		Transcript: "तुम कहाँ जा रहे हो? मैं बाज़ार जा रहा हूँ।",
+		Questions:  []models.ListeningQuestion{{Question: "वह कहाँ जा रहा है?", Options: []string{"घर", "बाज़ार"}, Answer: "बाज़ार"}},
+	}
+	assert.NoError(t, service.CreateExercise(ctx, exercise))
+
+	audio := &models.Asset{Kind: models.AssetAudio, MimeType: "audio/mpeg", Filename: "market.mp3", Size: 10, Checksum: strings.Repeat("a", 64), StorageKey: "a.mp3"}
+	assert.NoError(t, assets.Create(ctx, audio))
+	image := &models.Asset{Kind: models.AssetImage, MimeType: "image/png", Filename: "market.png", Size: 10, Checksum: strings.Repeat("b", 64), StorageKey: "b.png"}
+	assert.NoError(t, assets.Create(ctx, image))
+
+	updated, err := service.SetAudio(ctx, exercise.ID, services.SetAudioRequest{AssetID: &audio.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, models.AssetURL(audio.ID), updated.AudioURL)
+
+	updated, err = service.SetAudio(ctx, exercise.ID, services.SetAudioRequest{URL: "https://example.com/market.mp3"})
+	assert.NoError(t, err)
+	assert.Nil(t, updated.AudioAssetID)
+	assert.Equal(t, "https://example.com/market.mp3", updated.AudioURL)
+
+	for _, req := range []services.SetAudioRequest{
+		{AssetID: &image.ID},
+		{URL: "file:///etc/passwd"},
+		{AssetID: &audio.ID, URL: "https://example.com/market.mp3"},
+	} {
+		_, err = service.SetAudio(ctx, exercise.ID, req)
+		assert.True(t, errors.Is(err, models.ErrInvalidInput), "got %v", err)
+	}
+
+	updated, err = service.SetAudio(ctx, exercise.ID, services.SetAudioRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, updated.AudioURL)
+
+	_, err = service.SetAudio(ctx, 999, services.SetAudioRequest{})
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+
+	assert.NoError(t, service.DeleteExercise(ctx, exercise.ID))
+	_, err = service.GetExercise(ctx, exercise.ID)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinimalPairService(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	groups := repository.NewSQLiteGroupRepository(db)
+	service := services.NewMinimalPairService(words, groups)
+
+	created := map[string]*models.Word{}
+	for _, word := range []models.Word{
+		{Target: "कल", Romanized: "kal", Native: "Tomorrow"},
+		{Target: "खल", Romanized: "khal", Native: "Villain"},
+		{Target: "टाल", Romanized: "taal", Native: "Postpone"},
+		{Target: "ताल", Romanized: "taal", Native: "Rhythm"},
+		{Target: "कमरा", Romanized: "kamra", Native: "Room"},
+	} {
+		word := word
+		assert.NoError(t, words.Create(ctx, &word))
+		created[word.Target] = &word
+	}
+
+	basics := &models.Group{Name: "Basics"}
+	music := &models.Group{Name: "Music"}
+	assert.NoError(t, groups.Create(ctx, basics))
+	assert.NoError(t, groups.Create(ctx, music))
+	for _, target := range []string{"कल", "खल", "कमरा"} {
+		assert.NoError(t, groups.AddWord(ctx, basics.ID, created[target].ID))
+	}
+	assert.NoError(t, groups.AddWord(ctx, music.ID, created["ताल"].ID))
+
+	pairs, err := service.ListPairs(ctx, nil, "")
+	assert.NoError(t, err)
+	assert.Len(t, pairs, 2)
+
+	pairs, err = service.ListPairs(ctx, nil, "retroflex")
+	assert.NoError(t, err)
+	if assert.Len(t, pairs, 1) {
+		assert.Equal(t, "टाल", pairs[0].First.Target)
+		assert.Equal(t, "ताल", pairs[0].Second.Target)
+	}
+
+	pairs, err = service.ListPairs(ctx, &basics.ID, "")
+	assert.NoError(t, err)
+	if assert.Len(t, pairs, 1) {
+		assert.Equal(t, "aspiration", pairs[0].Contrast)
+	}
+
+	_, err = service.ListPairs(ctx, nil, "tone")
+	assert.ErrorIs(t, err, models.ErrInvalidInput)
+
+	coverage, err := service.Coverage(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, coverage, 2) {
+		assert.Equal(t, "Basics", coverage[0].GroupName)
+		assert.Equal(t, 3, coverage[0].Words)
+		assert.Equal(t, map[string]int{"aspiration": 1, "retroflex": 0, "vowel_length": 0, "nasalization": 0}, coverage[0].Pairs)
+		assert.Equal(t, []string{"retroflex", "vowel_length", "nasalization"}, coverage[0].Missing)
+		assert.Equal(t, 1, coverage[1].Words)
+		assert.Len(t, coverage[1].Missing, 4)
+	}
+}
@@ -0,0 +1,33 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumeralService_List(t *testing.T) {
+	service := services.NewNumeralService()
+
+	numerals, err := service.List(0, 100)
+	assert.NoError(t, err)
+	assert.Len(t, numerals, 101)
+
+	numerals, err = service.List(47, 47)
+	assert.NoError(t, err)
+	if assert.Len(t, numerals, 1) {
+		assert.Equal(t, 47, numerals[0].Value)
+		assert.Equal(t, "४७", numerals[0].Digits)
+		assert.Equal(t, "सैंतालीस", numerals[0].Word)
+		assert.NotEmpty(t, numerals[0].Romanized)
+		assert.NotEmpty(t, numerals[0].IPA)
+	}
+
+	for _, r := range [][2]int{{-1, 10}, {0, 101}, {20, 10}} {
+		_, err := service.List(r[0], r[1])
+		assert.True(t, errors.Is(err, models.ErrInvalidInput))
+	}
+}
@@ -0,0 +1,153 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/pkg/speech"
+	"github.com/pavittarx/lang-portal/backend/pkg/storage"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTTSServer speaks every input as an ID3 tagged MP3 naming the input,
+// failing the inputs in fail
+type fakeTTSServer struct {
+	mu       sync.Mutex
+	requests map[string]int
+	fail     map[string]bool
+}
+
+func (f *fakeTTSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Input string `json:"input"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests[req.Input]++
+	if f.fail[req.Input] {
+		http.Error(w, "voice not loaded", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "audio/mpeg")
+	w.Write([]byte("ID3\x04\x00\x00\x00\x00\x00\x00" + req.Input))
+}
+
+func (f *fakeTTSServer) count(input string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[input]
+}
+
+func TestPronunciationService(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	fake := &fakeTTSServer{requests: map[string]int{}, fail: map[string]bool{"धन्यवाद": true}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	files, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	jobs := repository.NewSQLiteJobRepository(db)
+	assets := services.NewAssetService(repository.NewSQLiteAssetRepository(db), files, 1<<20)
+	provider := speech.NewOpenAITTS(speech.HTTPConfig{URL: server.URL, Model: "tts-1", Voice: "alloy"})
+	pronunciations := services.NewPronunciationService(provider, words, repository.NewSQLiteSpeechCacheRepository(db), assets)
+	service := services.NewJobService(jobs, pronunciations)
+
+	namaste := &models.Word{Target: "नमस्ते", Native: "Hello"}
+	thanks := &models.Word{Target: "धन्यवाद", Native: "Thank you"}
+	for _, word := range []*models.Word{namaste, thanks} {
+		assert.NoError(t, words.Create(ctx, word))
+	}
+
+	queued, err := service.EnqueueBacklog(ctx, models.JobPronunciation)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, queued)
+
+	run, err := service.RunPending(ctx, models.JobPronunciation)
+	assert.NoError(t, err)
+	assert.Equal(t, &services.JobRun{Succeeded: 1, Failed: 1}, run)
+
+	stored, err := words.GetByID(ctx, namaste.ID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, stored.AudioAssetID) {
+		asset, err := assets.GetAsset(ctx, *stored.AudioAssetID)
+		assert.NoError(t, err)
+		assert.Equal(t, "audio/mpeg", asset.MimeType)
+	}
+
+	failed, err := service.ListJobs(ctx, repository.ListJobsParams{Status: models.JobFailed})
+	assert.NoError(t, err)
+	if !assert.Len(t, failed, 1) {
+		return
+	}
+	assert.Equal(t, thanks.ID, failed[0].SubjectID)
+	assert.Contains(t, failed[0].LastError, "voice not loaded")
+
+	// Failed jobs are retried from the jobs table
+	fake.mu.Lock()
+	fake.fail = nil
+	fake.mu.Unlock()
+	retried, err := service.Retry(ctx, failed[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobPending, retried.Status)
+	service.Wait()
+
+	job, err := service.GetJob(ctx, failed[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobSucceeded, job.Status)
+	assert.Equal(t, 2, job.Attempts)
+
+	// Regenerating audio that was generated before reuses the cached asset
+	withoutAudio, err := words.GetByID(ctx, namaste.ID)
+	assert.NoError(t, err)
+	withoutAudio.AudioAssetID = nil
+	assert.NoError(t, words.Update(ctx, withoutAudio))
+
+	queued, err = service.EnqueueBacklog(ctx, models.JobPronunciation)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, queued)
+	run, err = service.RunPending(ctx, models.JobPronunciation)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, run.Succeeded)
+
+	regenerated, err := words.GetByID(ctx, namaste.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, stored.AudioAssetID, regenerated.AudioAssetID)
+	assert.Equal(t, 1, fake.count("नमस्ते"))
+}
+
+func TestJobService_NotConfigured(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	service := services.NewJobService(repository.NewSQLiteJobRepository(db))
+
+	_, err = service.EnqueueBacklog(context.Background(), models.JobPronunciation)
+	assert.ErrorIs(t, err, models.ErrNotConfigured)
+
+	_, err = service.ListJobs(context.Background(), repository.ListJobsParams{Status: "stuck"})
+	assert.ErrorIs(t, err, models.ErrInvalidInput)
+}
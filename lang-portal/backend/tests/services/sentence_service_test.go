@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentenceService_CreateSentence(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	wordRepo := repository.NewSQLiteWordRepository(db)
+	languageRepo := repository.NewSQLiteLanguageRepository(db)
+	sentenceRepo := repository.NewSQLiteSentenceRepository(db)
+	service := services.NewSentenceService(sentenceRepo, wordRepo, languageRepo)
+
+	water := &models.Word{Target: "पानी", Romanized: "Paani", Native: "Water"}
+	assert.NoError(t, wordRepo.Create(ctx, water))
+	milk := &models.Word{Language: "pa", NativeLanguage: "en", Target: "ਦੁੱਧ", Native: "Milk"}
+	assert.NoError(t, wordRepo.Create(ctx, milk))
+
+	tests := []struct {
+		name     string
+		sentence *models.Sentence
+		wantErr  error
+	}{
+		{
+			name:     "hindi sentence",
+			sentence: &models.Sentence{Target: "मुझे पानी चाहिए।", Native: "I need water.", WordIDs: []int64{water.ID}},
+		},
+		{
+			name:     "empty translation",
+			sentence: &models.Sentence{Target: "मुझे पानी चाहिए।"},
+			wantErr:  models.ErrInvalidInput,
+		},
+		{
+			name:     "translation in the wrong script",
+			sentence: &models.Sentence{Target: "मुझे पानी चाहिए।", Native: "मुझे पानी चाहिए।"},
+			wantErr:  models.ErrInvalidScript,
+		},
+		{
+			name:     "word of another language",
+			sentence: &models.Sentence{Target: "मुझे दूध चाहिए।", Native: "I need milk.", WordIDs: []int64{milk.ID}},
+			wantErr:  models.ErrInvalidLanguage,
+		},
+		{
+			name:     "missing word",
+			sentence: &models.Sentence{Target: "मुझे पानी चाहिए।", Native: "I need water.", WordIDs: []int64{999}},
+			wantErr:  models.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.CreateSentence(ctx, tt.sentence)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	word, err := services.NewWordService(wordRepo, languageRepo, sentenceRepo, nil).GetWordWithSentences(ctx, water.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, word.Sentences, 1) {
+		assert.Equal(t, "I need water.", word.Sentences[0].Native)
+	}
+}
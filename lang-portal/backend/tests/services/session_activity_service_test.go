@@ -0,0 +1,66 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionActivityService_AddSessionActivity(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	service := services.NewSessionActivityService(
+		repository.NewSessionActivityRepository(db),
+		repository.NewSessionRepository(db),
+		repository.NewStudyActivityRepository(db),
+	)
+
+	// Client graded activities are accepted by the registry and take the
+	// result the client graded
+	registry, err := activities.NewRegistry(activities.NewClientGradedEngine())
+	assert.NoError(t, err)
+	activity := &models.StudyActivity{Name: "Unscramble Words Game", Description: "Unscramble words in the browser", Type: activities.ClientGradedType, Enabled: true}
+	assert.NoError(t, services.NewStudyActivityService(repository.NewStudyActivityRepository(db), registry).CreateStudyActivity(ctx, activity))
+	client := &models.Session{UserID: learnerID, ActivityID: activity.ID, StartTime: time.Now(), CreatedAt: time.Now()}
+	assert.NoError(t, repository.NewSessionRepository(db).Create(ctx, client))
+	added, err := service.AddSessionActivity(ctx, learnerID, client.ID, client.ActivityID, "कमरा", "कमरा", "कमरा", 100)
+	assert.NoError(t, err)
+	assert.NotZero(t, added.ID)
+	recorded, err := service.GetSessionActivities(ctx, learnerID, client.ID)
+	assert.NoError(t, err)
+	assert.Len(t, recorded, 1)
+
+	unscramble := createSession(t, db, activities.UnscrambleType)
+	external := createSession(t, db, activities.ExternalType)
+
+	tests := []struct {
+		name       string
+		sessionID  int64
+		activityID int64
+		want       error
+	}{
+		{name: "engine graded", sessionID: unscramble.ID, activityID: unscramble.ActivityID, want: models.ErrGradedActivity},
+		{name: "external", sessionID: external.ID, activityID: external.ActivityID, want: models.ErrGradedActivity},
+		{name: "another activity", sessionID: client.ID, activityID: unscramble.ActivityID, want: models.ErrInvalidInput},
+		{name: "session of another learner", sessionID: 999, activityID: client.ActivityID, want: models.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AddSessionActivity(ctx, learnerID, tt.sessionID, tt.activityID, "कमरा", "कमरा", "कमरा", 100)
+			assert.True(t, errors.Is(err, tt.want), err)
+		})
+	}
+}
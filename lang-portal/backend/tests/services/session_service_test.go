@@ -0,0 +1,39 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionService_EndSession(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	service := services.NewSessionService(repository.NewSessionRepository(db))
+	session := createSession(t, db, activities.ClientGradedType)
+
+	assert.NoError(t, service.EndSession(ctx, learnerID, session.ID, 80))
+	ended, err := service.GetSessionByID(ctx, learnerID, session.ID)
+	assert.NoError(t, err)
+	assert.True(t, ended.IsCompleted())
+	assert.Equal(t, 80, ended.Score)
+
+	// A session is ended once
+	err = service.EndSession(ctx, learnerID, session.ID, 100)
+	assert.True(t, errors.Is(err, models.ErrSessionEnded), err)
+
+	err = service.EndSession(ctx, learnerID, 999, 100)
+	assert.True(t, errors.Is(err, models.ErrNotFound), err)
+}
@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/activities"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/pkg/speech"
+	"github.com/pavittarx/lang-portal/backend/pkg/storage"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRecording is the start of a WebM file, which content sniffing recognizes
+var testRecording = []byte("\x1a\x45\xdf\xa3 fake webm recording")
+
+func newSpeakingService(t *testing.T, db *sql.DB, provider speech.ASRProvider) (*services.SpeakingService, *services.ChallengeService) {
+	words := repository.NewSQLiteWordRepository(db)
+	registry, err := activities.NewRegistry(
+		activities.NewSpeakingEngine(words),
+		activities.NewUnscrambleEngine(words),
+	)
+	assert.NoError(t, err)
+
+	files, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	challenges := services.NewChallengeService(
+		registry,
+		repository.NewStudyActivityRepository(db),
+		repository.NewSessionRepository(db),
+		repository.NewSessionActivityRepository(db),
+		repository.NewSQLiteIssuedChallengeRepository(db),
+	)
+	service := services.NewSpeakingService(provider, repository.NewSQLiteRecordingRepository(db), challenges, files, 1<<20)
+	return service, challenges
+}
+
+// createSession starts a session of a new activity of the given type for learnerID
+func createSession(t *testing.T, db *sql.DB, activityType string) *models.Session {
+	ctx := context.Background()
+	activity := &models.StudyActivity{Name: activityType, Type: activityType, Enabled: true}
+	assert.NoError(t, repository.NewStudyActivityRepository(db).Create(ctx, activity))
+
+	session := &models.Session{UserID: learnerID, ActivityID: activity.ID, StartTime: time.Now(), CreatedAt: time.Now()}
+	assert.NoError(t, repository.NewSessionRepository(db).Create(ctx, session))
+	return session
+}
+
+func TestSpeakingService_SubmitRecording(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	room := &models.Word{Target: "कमरा", Romanized: "Kamra", Native: "Room"}
+	assert.NoError(t, repository.NewSQLiteWordRepository(db).Create(ctx, room))
+	session := createSession(t, db, activities.SpeakingType)
+
+	fake := speech.NewFakeASR("कमरा।", "कमला")
+	service, challenges := newSpeakingService(t, db, fake)
+
+	challenge, err := challenges.GenerateChallenge(ctx, learnerID, session.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "कमरा", challenge.Prompt)
+
+	answer, err := service.SubmitRecording(ctx, learnerID, session.ID, challenge.ID, services.RecordingUpload{
+		ContentType: "audio/webm;codecs=opus",
+		Content:     bytes.NewReader(testRecording),
+	})
+	assert.NoError(t, err)
+	assert.True(t, answer.Grade.Correct)
+	assert.Equal(t, 100, answer.Grade.Score)
+	assert.Equal(t, "कमरा।", answer.SessionActivity.Input)
+	assert.Equal(t, "कमरा", answer.SessionActivity.Answer)
+	assert.Equal(t, models.ResultSuccess, answer.SessionActivity.Result)
+	assert.Equal(t, answer.SessionActivity.ID, answer.Recording.SessionActivityID)
+	assert.Equal(t, room.ID, *answer.Recording.WordID)
+	assert.Equal(t, "audio/webm", answer.Recording.MimeType)
+	assert.Equal(t, "कमरा।", answer.Recording.Transcript)
+	assert.Equal(t, "fake", answer.Recording.Model)
+	assert.Equal(t, models.RecordingURL(answer.Recording.ID), answer.Recording.URL)
+
+	requests := fake.Requests()
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, "hi", requests[0].Language)
+		assert.Equal(t, "audio/webm", requests[0].Audio.ContentType)
+	}
+
+	// A challenge is answered once
+	_, err = service.SubmitRecording(ctx, learnerID, session.ID, challenge.ID, services.RecordingUpload{
+		Content: bytes.NewReader(testRecording),
+	})
+	assert.True(t, errors.Is(err, models.ErrAnswered))
+
+	// A near miss is recorded as a failure with what was heard
+	challenge, err = challenges.GenerateChallenge(ctx, learnerID, session.ID)
+	assert.NoError(t, err)
+	second, err := service.SubmitRecording(ctx, learnerID, session.ID, challenge.ID, services.RecordingUpload{
+		Content: bytes.NewReader(testRecording),
+	})
+	assert.NoError(t, err)
+	assert.False(t, second.Grade.Correct)
+	assert.Equal(t, 75, second.SessionActivity.Score)
+	assert.Equal(t, `Heard "कमला"`, second.Grade.Feedback)
+
+	// The learner can listen back to their recordings, newest first
+	recordings, err := service.ListRecordings(ctx, learnerID, session.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, recordings, 2) {
+		assert.Equal(t, second.Recording.ID, recordings[0].ID)
+	}
+
+	recording, content, err := service.OpenRecording(ctx, learnerID, answer.Recording.ID)
+	if assert.NoError(t, err) {
+		data, _ := io.ReadAll(content)
+		content.Close()
+		assert.Equal(t, testRecording, data)
+		assert.Equal(t, "कमरा।", recording.Transcript)
+	}
+
+	// Recordings are private to their learner
+	_, _, err = service.OpenRecording(ctx, learnerID+1, answer.Recording.ID)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+	assert.True(t, errors.Is(service.DeleteRecording(ctx, learnerID+1, answer.Recording.ID), models.ErrNotFound))
+
+	assert.NoError(t, service.DeleteRecording(ctx, learnerID, answer.Recording.ID))
+	_, _, err = service.OpenRecording(ctx, learnerID, answer.Recording.ID)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+}
+
+func TestSpeakingService_Rejects(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	assert.NoError(t, repository.NewSQLiteWordRepository(db).Create(ctx, &models.Word{Target: "कमरा", Native: "Room"}))
+	speaking := createSession(t, db, activities.SpeakingType)
+	unscramble := createSession(t, db, activities.UnscrambleType)
+
+	fake := speech.NewFakeASR()
+	service, challenges := newSpeakingService(t, db, fake)
+	issued, err := challenges.GenerateChallenge(ctx, learnerID, speaking.ID)
+	assert.NoError(t, err)
+	challenge := issued.ID
+	upload := func(data []byte) services.RecordingUpload {
+		return services.RecordingUpload{Content: bytes.NewReader(data)}
+	}
+
+	tests := []struct {
+		name      string
+		service   *services.SpeakingService
+		sessionID int64
+		challenge string
+		upload    services.RecordingUpload
+		want      error
+	}{
+		{name: "no speech recognizer", service: services.NewSpeakingService(nil, nil, nil, nil, 0), sessionID: speaking.ID, challenge: challenge, upload: upload(testRecording), want: models.ErrNotConfigured},
+		{name: "forged challenge", service: service, sessionID: speaking.ID, challenge: "forged", upload: upload(testRecording), want: models.ErrNotFound},
+		{name: "session of another activity", service: service, sessionID: unscramble.ID, challenge: challenge, upload: upload(testRecording), want: activities.ErrInvalidChallenge},
+		{name: "session of another learner", service: service, sessionID: 999, challenge: challenge, upload: upload(testRecording), want: models.ErrNotFound},
+		{name: "not audio", service: service, sessionID: speaking.ID, challenge: challenge, upload: upload([]byte("\x89PNG\r\n\x1a\n")), want: models.ErrUnsupportedMedia},
+		{name: "empty recording", service: service, sessionID: speaking.ID, challenge: challenge, upload: upload(nil), want: models.ErrInvalidInput},
+		{name: "too large", service: service, sessionID: speaking.ID, challenge: challenge, upload: upload(bytes.Repeat(testRecording, 1<<16)), want: models.ErrFileTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service.SubmitRecording(ctx, learnerID, tt.sessionID, tt.challenge, tt.upload)
+			assert.True(t, errors.Is(err, tt.want), err)
+		})
+	}
+
+	// Nothing was sent to the speech recognizer
+	assert.Empty(t, fake.Requests())
+}
@@ -0,0 +1,230 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/ai"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTutorService(db *sql.DB, provider ai.LLMProvider) *services.TutorService {
+	return services.NewTutorService(
+		provider,
+		repository.NewSQLiteTutorRepository(db),
+		repository.NewSQLiteWordRepository(db),
+		repository.NewSQLiteSentenceRepository(db),
+		repository.NewSQLiteLanguageRepository(db),
+		repository.NewStudyActivityRepository(db),
+		repository.NewSessionRepository(db),
+		repository.NewSessionActivityRepository(db),
+	)
+}
+
+// createTutorSession starts a session of a tutor activity for learnerID
+func createTutorSession(t *testing.T, db *sql.DB) *models.Session {
+	ctx := context.Background()
+	activity := &models.StudyActivity{Name: "Sentence Tutor", Type: "tutor", Enabled: true}
+	assert.NoError(t, repository.NewStudyActivityRepository(db).Create(ctx, activity))
+
+	session := &models.Session{UserID: learnerID, ActivityID: activity.ID, StartTime: time.Now(), CreatedAt: time.Now()}
+	assert.NoError(t, repository.NewSessionRepository(db).Create(ctx, session))
+	return session
+}
+
+func TestTutorService_Conversation(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	words := repository.NewSQLiteWordRepository(db)
+	water := &models.Word{Target: "पानी", Romanized: "Paani", Native: "Water"}
+	assert.NoError(t, words.Create(ctx, water))
+	cold := &models.Word{Target: "ठंडा", Romanized: "Thanda", Native: "Cold"}
+	assert.NoError(t, words.Create(ctx, cold))
+	goodMorning := &models.Word{Target: "सुप्रभात", Romanized: "Suprabhaat", Native: "Good morning"}
+	assert.NoError(t, words.Create(ctx, goodMorning))
+
+	sentence := &models.Sentence{
+		Target:       "पानी ठंडा है।",
+		Romanized:    "Paani thanda hai.",
+		Native:       "Good morning, the water is cold.",
+		WordIDs:      []int64{water.ID, cold.ID},
+		Alternatives: []string{"ठंडा है पानी।"},
+	}
+	assert.NoError(t, repository.NewSQLiteSentenceRepository(db).Create(ctx, sentence))
+	session := createTutorSession(t, db)
+
+	fake := ai.NewFakeLLM(`{"structure": "[Subject] [Adjective] [Verb]", "reply": "Let's start!", "clues": ["Start with the subject.", " "], "answer": "पानी ठंडा है।"}`)
+	service := newTutorService(db, fake)
+
+	conversation, err := service.StartConversation(ctx, learnerID, services.StartConversationRequest{SessionID: session.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, sentence.ID, *conversation.SentenceID)
+	// Neither the answer nor the sentence holding it is sent to the learner
+	data, err := json.Marshal(conversation)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "sentence_id")
+	assert.NotContains(t, string(data), "पानी ठंडा है")
+	assert.Equal(t, "Good morning, the water is cold.", conversation.Prompt)
+	assert.Equal(t, "[Subject] [Adjective] [Verb]", conversation.Structure)
+	assert.Equal(t, "fake", conversation.Model)
+	// Phrases are looked up too, in the order of the sentence
+	if assert.Len(t, conversation.Vocabulary, 3) {
+		assert.Equal(t, goodMorning.ID, conversation.Vocabulary[0].WordID)
+		assert.Equal(t, water.ID, conversation.Vocabulary[1].WordID)
+		assert.Equal(t, cold.ID, conversation.Vocabulary[2].WordID)
+	}
+	if assert.Len(t, conversation.Messages, 1) {
+		assert.Equal(t, models.TutorTutor, conversation.Messages[0].Role)
+		assert.Equal(t, []string{"Start with the subject."}, conversation.Messages[0].Clues)
+	}
+
+	system := fake.Requests()[0].Messages[0].Content
+	assert.Contains(t, system, "| Water | पानी | Paani |")
+	assert.Contains(t, system, "Devanagari")
+	assert.NotContains(t, system, "पानी ठंडा है।")
+
+	// A question is answered without grading
+	fake.Respond(`{"reply": "Cold is ठंडा.", "clues": [], "correct": false, "score": 0}`)
+	turn, err := service.SendMessage(ctx, learnerID, conversation.ID, services.SendMessageRequest{Content: "How do I say cold?"})
+	assert.NoError(t, err)
+	assert.Nil(t, turn.Attempt)
+	assert.Nil(t, turn.Learner.SessionActivityID)
+	assert.Equal(t, "Cold is ठंडा.", turn.Tutor.Content)
+
+	// A wrong attempt is recorded with the tutor's score
+	fake.Respond(`{"reply": "Almost, check the verb.", "clues": ["है ends the sentence."], "correct": false, "score": 150}`)
+	turn, err = service.SendMessage(ctx, learnerID, conversation.ID, services.SendMessageRequest{Content: "पानी ठंडा", Attempt: true})
+	assert.NoError(t, err)
+	if assert.NotNil(t, turn.Attempt) {
+		assert.Equal(t, models.ResultFailure, turn.Attempt.Result)
+		assert.Equal(t, 100, turn.Attempt.Score)
+		assert.Equal(t, "पानी ठंडा है।", turn.Attempt.Answer)
+		assert.Equal(t, session.ActivityID, turn.Attempt.ActivityID)
+		assert.Equal(t, turn.Attempt.ID, *turn.Learner.SessionActivityID)
+	}
+
+	// The history is sent back, attempts marked as such
+	messages := fake.Requests()[2].Messages
+	if assert.Len(t, messages, 6) {
+		assert.Equal(t, ai.RoleAssistant, messages[2].Role)
+		assert.JSONEq(t, `{"reply":"Let's start!","clues":["Start with the subject."]}`, messages[2].Content)
+		assert.Equal(t, "Attempt: पानी ठंडा", messages[5].Content)
+	}
+
+	// Another word order of the sentence is correct whatever the tutor says
+	fake.Respond(`{"reply": "Not quite.", "correct": false, "score": 10}`)
+	turn, err = service.SendMessage(ctx, learnerID, conversation.ID, services.SendMessageRequest{Content: "ठंडा है पानी", Attempt: true})
+	assert.NoError(t, err)
+	if assert.NotNil(t, turn.Attempt) {
+		assert.Equal(t, models.ResultSuccess, turn.Attempt.Result)
+		assert.Equal(t, 100, turn.Attempt.Score)
+	}
+
+	conversation, err = service.GetConversation(ctx, learnerID, conversation.ID)
+	assert.NoError(t, err)
+	assert.True(t, conversation.Solved)
+	assert.Len(t, conversation.Messages, 7)
+
+	attempts, err := repository.NewSessionActivityRepository(db).ListBySessionID(ctx, session.ID)
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 2)
+
+	conversations, err := service.ListConversations(ctx, learnerID, session.ID)
+	assert.NoError(t, err)
+	assert.Len(t, conversations, 1)
+
+	// Conversations belong to their learner
+	_, err = service.GetConversation(ctx, learnerID+1, conversation.ID)
+	assert.True(t, errors.Is(err, models.ErrNotFound))
+}
+
+func TestTutorService_FreeSentence(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	session := createTutorSession(t, db)
+
+	fake := ai.NewFakeLLM(`{"structure": "[Subject] [Verb]", "reply": "Try it!", "answer": "मैं खाता हूँ।"}`)
+	service := newTutorService(db, fake)
+
+	conversation, err := service.StartConversation(ctx, learnerID, services.StartConversationRequest{SessionID: session.ID, Sentence: "I eat."})
+	assert.NoError(t, err)
+	assert.Nil(t, conversation.SentenceID)
+	assert.Empty(t, conversation.Vocabulary)
+	assert.Equal(t, "I eat.", conversation.Prompt)
+
+	// Without an example sentence the tutor's judgement counts
+	fake.Respond(`{"reply": "Well done!", "correct": true}`)
+	turn, err := service.SendMessage(ctx, learnerID, conversation.ID, services.SendMessageRequest{Content: "मैं खाता हूँ", Attempt: true})
+	assert.NoError(t, err)
+	if assert.NotNil(t, turn.Attempt) {
+		assert.Equal(t, models.ResultSuccess, turn.Attempt.Result)
+		assert.Equal(t, 100, turn.Attempt.Score)
+		assert.Equal(t, "मैं खाता हूँ।", turn.Attempt.Answer)
+	}
+
+	// Answers without a reply are refused and nothing is stored
+	fake.Respond(`{"structure": ""}`)
+	_, err = service.SendMessage(ctx, learnerID, conversation.ID, services.SendMessageRequest{Content: "Help?"})
+	assert.True(t, errors.Is(err, ai.ErrInvalidOutput), "got %v", err)
+
+	conversation, err = service.GetConversation(ctx, learnerID, conversation.ID)
+	assert.NoError(t, err)
+	assert.Len(t, conversation.Messages, 3)
+
+	_, err = service.SendMessage(ctx, learnerID, conversation.ID, services.SendMessageRequest{Content: "  "})
+	assert.True(t, errors.Is(err, models.ErrInvalidInput))
+}
+
+func TestTutorService_Sessions(t *testing.T) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	activities := repository.NewStudyActivityRepository(db)
+	sessions := repository.NewSessionRepository(db)
+	service := newTutorService(db, ai.NewFakeLLM())
+	start := services.StartConversationRequest{Sentence: "I eat."}
+
+	flashcards := &models.StudyActivity{Name: "Flashcards", Type: "unscramble", Enabled: true}
+	assert.NoError(t, activities.Create(ctx, flashcards))
+	other := &models.Session{UserID: learnerID, ActivityID: flashcards.ID, StartTime: time.Now()}
+	assert.NoError(t, sessions.Create(ctx, other))
+	start.SessionID = other.ID
+	_, err = service.StartConversation(ctx, learnerID, start)
+	assert.True(t, errors.Is(err, models.ErrInvalidInput), "got %v", err)
+
+	session := createTutorSession(t, db)
+	start.SessionID = session.ID
+	_, err = service.StartConversation(ctx, learnerID+1, start)
+	assert.True(t, errors.Is(err, models.ErrNotFound), "got %v", err)
+
+	end := time.Now()
+	session.EndTime = &end
+	assert.NoError(t, sessions.Update(ctx, session))
+	_, err = service.StartConversation(ctx, learnerID, start)
+	assert.True(t, errors.Is(err, models.ErrSessionEnded), "got %v", err)
+
+	_, err = newTutorService(db, nil).StartConversation(ctx, learnerID, start)
+	assert.True(t, errors.Is(err, models.ErrNotConfigured))
+}
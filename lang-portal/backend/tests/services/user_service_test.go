@@ -0,0 +1,129 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pavittarx/lang-portal/backend/internal/auth"
+	"github.com/pavittarx/lang-portal/backend/pkg/models"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
+	"github.com/pavittarx/lang-portal/backend/pkg/services"
+	"github.com/pavittarx/lang-portal/backend/tests/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupUserTest(t *testing.T) (*services.UserService, func()) {
+	db, cleanup, err := testutils.CreateTestDB()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	signer, err := auth.NewSigner([]byte("0123456789abcdef0123456789abcdef"))
+	assert.NoError(t, err)
+
+	return services.NewUserService(
+		repository.NewUserRepository(db),
+		repository.NewAPIKeyRepository(db),
+		signer,
+		time.Hour,
+	), cleanup
+}
+
+func TestUserService_RegisterAndLogin(t *testing.T) {
+	service, cleanup := setupUserTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	user, err := service.Register(ctx, "asha", "namaste123")
+	assert.NoError(t, err)
+	assert.NotZero(t, user.ID)
+	assert.NotEqual(t, "namaste123", user.PasswordHash)
+	assert.Equal(t, models.RoleLearner, user.Role)
+
+	// Usernames are unique regardless of case
+	_, err = service.Register(ctx, "Asha", "another-password")
+	assert.ErrorIs(t, err, models.ErrUsernameTaken)
+
+	login, err := service.Login(ctx, "asha", "namaste123")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, login.User.ID)
+	assert.NotEmpty(t, login.Token)
+
+	principal, err := service.AuthenticateToken(ctx, login.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, principal.UserID)
+	assert.Equal(t, auth.MethodToken, principal.Method)
+
+	// Role changes apply to tokens that were already issued
+	_, err = service.SetRole(ctx, user.ID, models.RoleEditor)
+	assert.NoError(t, err)
+	principal, err = service.AuthenticateToken(ctx, login.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RoleEditor, principal.Role)
+
+	_, err = service.SetRole(ctx, user.ID, "owner")
+	assert.ErrorIs(t, err, models.ErrInvalidRole)
+
+	_, err = service.Login(ctx, "asha", "wrong-password")
+	assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+
+	_, err = service.Login(ctx, "ravi", "namaste123")
+	assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+}
+
+func TestUserService_RejectsInvalidAccounts(t *testing.T) {
+	service, cleanup := setupUserTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	_, err := service.Register(ctx, "ab", "namaste123")
+	assert.ErrorIs(t, err, models.ErrInvalidUsername)
+
+	_, err = service.Register(ctx, "आशा", "namaste123")
+	assert.ErrorIs(t, err, models.ErrInvalidUsername)
+
+	_, err = service.Register(ctx, "asha", "short")
+	assert.ErrorIs(t, err, models.ErrInvalidPassword)
+
+	_, err = service.AuthenticateToken(ctx, "forged")
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestUserService_APIKeys(t *testing.T) {
+	service, cleanup := setupUserTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	user, err := service.Register(ctx, "seed-script", "namaste123")
+	assert.NoError(t, err)
+
+	created, err := service.CreateAPIKey(ctx, user.ID, "nightly import")
+	assert.NoError(t, err)
+	assert.Contains(t, created.Key, auth.APIKeyPrefix)
+	assert.NotContains(t, created.KeyHash, created.Key)
+
+	principal, err := service.AuthenticateAPIKey(ctx, created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, principal.UserID)
+	assert.Equal(t, auth.MethodAPIKey, principal.Method)
+
+	keys, err := service.ListAPIKeys(ctx, user.ID)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.NotNil(t, keys[0].LastUsedAt)
+
+	// Keys can only be revoked by their owner
+	err = service.DeleteAPIKey(ctx, user.ID+1, created.ID)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+
+	assert.NoError(t, service.DeleteAPIKey(ctx, user.ID, created.ID))
+	_, err = service.AuthenticateAPIKey(ctx, created.Key)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	_, err = service.CreateAPIKey(ctx, user.ID, " ")
+	assert.ErrorIs(t, err, models.ErrInvalidInput)
+}
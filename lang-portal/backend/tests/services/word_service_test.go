@@ -58,19 +58,81 @@ func (m *MockWordRepository) GetWordsByGroupID(ctx context.Context, groupID int6
 	return args.Get(0).([]models.Word), args.Error(1)
 }
 
+func (m *MockWordRepository) ListWithoutAudio(ctx context.Context) ([]models.Word, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]models.Word), args.Error(1)
+}
+
+func (m *MockWordRepository) SetAudio(ctx context.Context, id, assetID int64) error {
+	args := m.Called(ctx, id, assetID)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) SetRomanized(ctx context.Context, id int64, romanized string) error {
+	args := m.Called(ctx, id, romanized)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) SetIPA(ctx context.Context, id int64, ipa string, override bool) error {
+	args := m.Called(ctx, id, ipa, override)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) SetForms(ctx context.Context, id int64, forms models.WordForms, override bool) error {
+	args := m.Called(ctx, id, forms, override)
+	return args.Error(0)
+}
+
+func (m *MockWordRepository) ListByNative(ctx context.Context, language string, natives []string) ([]models.Word, error) {
+	args := m.Called(ctx, language, natives)
+	return args.Get(0).([]models.Word), args.Error(1)
+}
+
+// stubLanguageRepository serves a fixed set of languages
+type stubLanguageRepository map[string]models.Language
+
+func (s stubLanguageRepository) Create(_ context.Context, language *models.Language) error {
+	s[language.Code] = *language
+	return nil
+}
+
+func (s stubLanguageRepository) GetByCode(_ context.Context, code string) (*models.Language, error) {
+	language, ok := s[code]
+	if !ok {
+		return nil, models.ErrNotFound
+	}
+	return &language, nil
+}
+
+func (s stubLanguageRepository) List(_ context.Context) ([]models.Language, error) {
+	languages := make([]models.Language, 0, len(s))
+	for _, language := range s {
+		languages = append(languages, language)
+	}
+	return languages, nil
+}
+
+var testLanguages = stubLanguageRepository{
+	"hi": {Code: "hi", Name: "Hindi", Script: models.ScriptDevanagari},
+	"pa": {Code: "pa", Name: "Punjabi", Script: models.ScriptGurmukhi},
+	"en": {Code: "en", Name: "English", Script: models.ScriptLatin},
+}
+
 func createTestWord() *models.Word {
 	return &models.Word{
-		ID:        1,
-		Hindi:     "नमस्ते",
-		Hinglish:  "Namaste",
-		English:   "Hello",
-		CreatedAt: time.Now(),
+		ID:             1,
+		Language:       "hi",
+		NativeLanguage: "en",
+		Target:         "नमस्ते",
+		Romanized:      "Namaste",
+		Native:         "Hello",
+		CreatedAt:      time.Now(),
 	}
 }
 
 func TestWordService_CreateWord(t *testing.T) {
 	mockRepo := new(MockWordRepository)
-	service := services.NewWordService(mockRepo)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
 
 	ctx := context.Background()
 	word := createTestWord()
@@ -85,6 +147,24 @@ func TestWordService_CreateWord(t *testing.T) {
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 	assert.NotEmpty(t, word.Scrambled)
+	assert.Equal(t, "Namaste", word.Romanized)
+}
+
+func TestWordService_CreateWord_Romanizes(t *testing.T) {
+	mockRepo := new(MockWordRepository)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
+
+	ctx := context.Background()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
+
+	hindiWord := &models.Word{Language: "hi", NativeLanguage: "en", Target: "कमरा", Native: "Room"}
+	assert.NoError(t, service.CreateWord(ctx, hindiWord))
+	assert.Equal(t, "kamra", hindiWord.Romanized)
+
+	// Other languages have no romanizer yet
+	punjabiWord := &models.Word{Language: "pa", NativeLanguage: "en", Target: "ਕਮਰਾ", Native: "Room"}
+	assert.NoError(t, service.CreateWord(ctx, punjabiWord))
+	assert.Empty(t, punjabiWord.Romanized)
 }
 
 func TestWordService_CreateWord_Validation(t *testing.T) {
@@ -94,25 +174,25 @@ func TestWordService_CreateWord_Validation(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "valid word",
-			word: createTestWord(),
+			name:    "valid word",
+			word:    createTestWord(),
 			wantErr: false,
 		},
 		{
-			name: "empty hindi",
+			name: "empty target",
 			word: &models.Word{
-				Hindi:    "",
-				Hinglish: "Namaste",
-				English:  "Hello",
+				Target:    "",
+				Romanized: "Namaste",
+				Native:    "Hello",
 			},
 			wantErr: true,
 		},
 		{
-			name: "empty english",
+			name: "empty native",
 			word: &models.Word{
-				Hindi:    "नमस्ते",
-				Hinglish: "Namaste",
-				English:  "",
+				Target:    "नमस्ते",
+				Romanized: "Namaste",
+				Native:    "",
 			},
 			wantErr: true,
 		},
@@ -127,7 +207,7 @@ func TestWordService_CreateWord_Validation(t *testing.T) {
 				mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
 			}
 
-			err := services.NewWordService(mockRepo).CreateWord(ctx, tt.word)
+			err := services.NewWordService(mockRepo, testLanguages, nil, nil).CreateWord(ctx, tt.word)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -137,9 +217,337 @@ func TestWordService_CreateWord_Validation(t *testing.T) {
 	}
 }
 
+func TestWordService_CreateWord_Scripts(t *testing.T) {
+	tests := []struct {
+		name    string
+		word    *models.Word
+		wantErr error
+	}{
+		{
+			name: "punjabi word",
+			word: &models.Word{Language: "pa", NativeLanguage: "en", Target: "ਪਾਣੀ", Romanized: "Paani", Native: "Water"},
+		},
+		{
+			name:    "devanagari in a punjabi word",
+			word:    &models.Word{Language: "pa", NativeLanguage: "en", Target: "पानी", Native: "Water"},
+			wantErr: models.ErrInvalidScript,
+		},
+		{
+			name:    "unknown language",
+			word:    &models.Word{Language: "xx", NativeLanguage: "en", Target: "पानी", Native: "Water"},
+			wantErr: models.ErrInvalidLanguage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockWordRepository)
+			ctx := context.Background()
+
+			if tt.wantErr == nil {
+				mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
+			}
+
+			err := services.NewWordService(mockRepo, testLanguages, nil, nil).CreateWord(ctx, tt.word)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWordService_UpdateWord_KeepsLanguage(t *testing.T) {
+	mockRepo := new(MockWordRepository)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
+
+	ctx := context.Background()
+	existingWord := &models.Word{ID: 1, Language: "pa", NativeLanguage: "en", Target: "ਪਾਣੀ", Native: "Water"}
+	mockRepo.On("GetByID", ctx, existingWord.ID).Return(existingWord, nil)
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
+
+	word := &models.Word{ID: 1, Target: "ਦੁੱਧ", Native: "Milk"}
+	err := service.UpdateWord(ctx, word)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pa", word.Language)
+	assert.Equal(t, "en", word.NativeLanguage)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWordService_CreateWord_IPA(t *testing.T) {
+	mockRepo := new(MockWordRepository)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
+
+	ctx := context.Background()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
+
+	generated := &models.Word{Language: "hi", Target: "कमरा", Native: "Room"}
+	assert.NoError(t, service.CreateWord(ctx, generated))
+	assert.Equal(t, "kəmraː", generated.IPA)
+	assert.False(t, generated.IPAOverride)
+
+	entered := &models.Word{Language: "hi", Target: "कमरा", Native: "Room", IPA: "kəmᵊraː"}
+	assert.NoError(t, service.CreateWord(ctx, entered))
+	assert.Equal(t, "kəmᵊraː", entered.IPA)
+	assert.True(t, entered.IPAOverride)
+
+	// Other languages have no converter, only entered IPA
+	punjabi := &models.Word{Language: "pa", Target: "ਕਮਰਾ", Native: "Room"}
+	assert.NoError(t, service.CreateWord(ctx, punjabi))
+	assert.Empty(t, punjabi.IPA)
+	assert.False(t, punjabi.IPAOverride)
+}
+
+func TestWordService_UpdateWord_IPA(t *testing.T) {
+	tests := []struct {
+		name         string
+		existing     models.Word
+		update       models.Word
+		wantIPA      string
+		wantOverride bool
+	}{
+		{
+			name:     "generated IPA follows the target",
+			existing: models.Word{IPA: "kəmraː"},
+			update:   models.Word{Target: "घर"},
+			wantIPA:  "ɡʱər",
+		},
+		{
+			name:     "generated IPA sent back follows the target",
+			existing: models.Word{IPA: "kəmraː"},
+			update:   models.Word{Target: "घर", IPA: "kəmraː"},
+			wantIPA:  "ɡʱər",
+		},
+		{
+			name:         "entered IPA overrides",
+			existing:     models.Word{IPA: "kəmraː"},
+			update:       models.Word{Target: "कमरा", IPA: "kəmᵊraː"},
+			wantIPA:      "kəmᵊraː",
+			wantOverride: true,
+		},
+		{
+			name:         "override is kept when left out",
+			existing:     models.Word{IPA: "kəmᵊraː", IPAOverride: true},
+			update:       models.Word{Target: "कमरा"},
+			wantIPA:      "kəmᵊraː",
+			wantOverride: true,
+		},
+		{
+			name:     "entering the generated IPA is no override",
+			existing: models.Word{IPA: "kəmᵊraː", IPAOverride: true},
+			update:   models.Word{Target: "कमरा", IPA: "kəmraː"},
+			wantIPA:  "kəmraː",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockWordRepository)
+			service := services.NewWordService(mockRepo, testLanguages, nil, nil)
+			ctx := context.Background()
+
+			existing := tt.existing
+			existing.ID, existing.Language, existing.NativeLanguage = 1, "hi", "en"
+			existing.Target, existing.Native = "कमरा", "Room"
+			mockRepo.On("GetByID", ctx, int64(1)).Return(&existing, nil)
+			mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
+
+			word := tt.update
+			word.ID, word.Native = 1, "Room"
+			assert.NoError(t, service.UpdateWord(ctx, &word))
+			assert.Equal(t, tt.wantIPA, word.IPA)
+			assert.Equal(t, tt.wantOverride, word.IPAOverride)
+		})
+	}
+}
+
+func TestWordService_UpdateWord_Romanized(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      models.Word
+		update        models.Word
+		wantRomanized string
+	}{
+		{
+			name:          "kept with the target",
+			existing:      models.Word{Language: "hi", Target: "कमरा", Romanized: "Kamraa"},
+			update:        models.Word{Target: "कमरा"},
+			wantRomanized: "Kamraa",
+		},
+		{
+			name:          "romanized again with a new target",
+			existing:      models.Word{Language: "hi", Target: "कमरा", Romanized: "Kamraa"},
+			update:        models.Word{Target: "घर"},
+			wantRomanized: "ghar",
+		},
+		{
+			name:          "entered romanization is kept",
+			existing:      models.Word{Language: "hi", Target: "कमरा", Romanized: "Kamraa"},
+			update:        models.Word{Target: "घर", Romanized: "Ghar"},
+			wantRomanized: "Ghar",
+		},
+		{
+			name:     "dropped with a new target of another language",
+			existing: models.Word{Language: "pa", Target: "ਪਾਣੀ", Romanized: "Paani"},
+			update:   models.Word{Target: "ਦੁੱਧ"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockWordRepository)
+			service := services.NewWordService(mockRepo, testLanguages, nil, nil)
+			ctx := context.Background()
+
+			existing := tt.existing
+			existing.ID, existing.NativeLanguage, existing.Native = 1, "en", "Room"
+			mockRepo.On("GetByID", ctx, int64(1)).Return(&existing, nil)
+			mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
+
+			word := tt.update
+			word.ID, word.Native = 1, "Room"
+			assert.NoError(t, service.UpdateWord(ctx, &word))
+			assert.Equal(t, tt.wantRomanized, word.Romanized)
+		})
+	}
+}
+
+func TestWordService_ResetIPA(t *testing.T) {
+	mockRepo := new(MockWordRepository)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
+
+	ctx := context.Background()
+	existing := &models.Word{ID: 1, Language: "hi", Target: "कमरा", Native: "Room", IPA: "kəmᵊraː", IPAOverride: true}
+	mockRepo.On("GetByID", ctx, int64(1)).Return(existing, nil)
+	mockRepo.On("SetIPA", ctx, int64(1), "kəmraː", false).Return(nil)
+
+	word, err := service.ResetIPA(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "kəmraː", word.IPA)
+	assert.False(t, word.IPAOverride)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWordService_CreateWord_Forms(t *testing.T) {
+	mockRepo := new(MockWordRepository)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
+
+	ctx := context.Background()
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
+
+	boy := &models.Word{Language: "hi", Target: "लड़का", Native: "Boy", PartOfSpeech: "noun", Gender: "masculine"}
+	assert.NoError(t, service.CreateWord(ctx, boy))
+	assert.Equal(t, models.WordForms{Plural: "लड़के", Oblique: "लड़के", ObliquePlural: "लड़कों"}, boy.WordForms)
+	assert.False(t, boy.FormsOverride)
+
+	do := &models.Word{Language: "hi", Target: "करना", Native: "To do", PartOfSpeech: "verb", Transitivity: "transitive"}
+	assert.NoError(t, service.CreateWord(ctx, do))
+	assert.Equal(t, models.WordForms{Root: "कर"}, do.WordForms)
+
+	// Forms left out of an entry are generated
+	father := &models.Word{Language: "hi", Target: "पिता", Native: "Father", PartOfSpeech: "noun", Gender: "masculine"}
+	father.ObliquePlural = "पितरों"
+	assert.NoError(t, service.CreateWord(ctx, father))
+	assert.Equal(t, models.WordForms{Plural: "पिता", Oblique: "पिता", ObliquePlural: "पितरों"}, father.WordForms)
+	assert.True(t, father.FormsOverride)
+
+	// Words without a gender get no declension
+	water := &models.Word{Language: "hi", Target: "पानी", Native: "Water", PartOfSpeech: "noun"}
+	assert.NoError(t, service.CreateWord(ctx, water))
+	assert.True(t, water.WordForms.IsZero())
+
+	invalid := &models.Word{Language: "hi", Target: "अच्छा", Native: "Good", PartOfSpeech: "adjective", Gender: "masculine"}
+	assert.ErrorIs(t, service.CreateWord(ctx, invalid), models.ErrInvalidInput)
+}
+
+func TestWordService_UpdateWord_Forms(t *testing.T) {
+	boy := models.WordForms{Plural: "लड़के", Oblique: "लड़के", ObliquePlural: "लड़कों"}
+	entered := models.WordForms{Plural: "लड़के", Oblique: "लड़के", ObliquePlural: "लड़को"}
+
+	tests := []struct {
+		name         string
+		existing     models.Word
+		update       models.Word
+		wantForms    models.WordForms
+		wantOverride bool
+	}{
+		{
+			name:      "generated forms follow the target",
+			existing:  models.Word{WordForms: boy},
+			update:    models.Word{Target: "बेटा", WordForms: boy},
+			wantForms: models.WordForms{Plural: "बेटे", Oblique: "बेटे", ObliquePlural: "बेटों"},
+		},
+		{
+			name:         "entered forms override",
+			existing:     models.Word{WordForms: boy},
+			update:       models.Word{Target: "लड़का", WordForms: entered},
+			wantForms:    entered,
+			wantOverride: true,
+		},
+		{
+			name:         "override is kept when left out",
+			existing:     models.Word{WordForms: entered, FormsOverride: true},
+			update:       models.Word{Target: "लड़का"},
+			wantForms:    entered,
+			wantOverride: true,
+		},
+		{
+			name:      "feminine nouns in ा are unmarked",
+			existing:  models.Word{WordForms: boy},
+			update:    models.Word{Target: "माला", Gender: "feminine"},
+			wantForms: models.WordForms{Plural: "मालाएँ", Oblique: "माला", ObliquePlural: "मालाओं"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockWordRepository)
+			service := services.NewWordService(mockRepo, testLanguages, nil, nil)
+			ctx := context.Background()
+
+			existing := tt.existing
+			existing.ID, existing.Language, existing.NativeLanguage = 1, "hi", "en"
+			existing.Target, existing.Native = "लड़का", "Boy"
+			existing.PartOfSpeech, existing.Gender = "noun", "masculine"
+			mockRepo.On("GetByID", ctx, int64(1)).Return(&existing, nil)
+			mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
+
+			word := tt.update
+			word.ID, word.Native, word.PartOfSpeech = 1, "Boy", "noun"
+			if word.Gender == "" {
+				word.Gender = "masculine"
+			}
+			assert.NoError(t, service.UpdateWord(ctx, &word))
+			assert.Equal(t, tt.wantForms, word.WordForms)
+			assert.Equal(t, tt.wantOverride, word.FormsOverride)
+		})
+	}
+}
+
+func TestWordService_ResetForms(t *testing.T) {
+	mockRepo := new(MockWordRepository)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
+
+	ctx := context.Background()
+	existing := &models.Word{ID: 1, Language: "hi", Target: "करना", Native: "To do", PartOfSpeech: "verb", FormsOverride: true}
+	existing.Root = "क"
+	generated := models.WordForms{Root: "कर"}
+	mockRepo.On("GetByID", ctx, int64(1)).Return(existing, nil)
+	mockRepo.On("SetForms", ctx, int64(1), generated, false).Return(nil)
+
+	word, err := service.ResetForms(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, generated, word.WordForms)
+	assert.False(t, word.FormsOverride)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestWordService_GetWordByID(t *testing.T) {
 	mockRepo := new(MockWordRepository)
-	service := services.NewWordService(mockRepo)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
 
 	ctx := context.Background()
 	word := createTestWord()
@@ -158,15 +566,15 @@ func TestWordService_GetWordByID(t *testing.T) {
 
 func TestWordService_UpdateWord(t *testing.T) {
 	mockRepo := new(MockWordRepository)
-	service := services.NewWordService(mockRepo)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
 
 	ctx := context.Background()
 	existingWord := createTestWord()
 	updatedWord := &models.Word{
-		ID:       existingWord.ID,
-		Hindi:    "अलविदा",
-		Hinglish: "Alvida",
-		English:  "Goodbye",
+		ID:        existingWord.ID,
+		Target:    "अलविदा",
+		Romanized: "Alvida",
+		Native:    "Goodbye",
 	}
 
 	// Set up expectations
@@ -190,10 +598,10 @@ func TestWordService_UpdateWord_Validation(t *testing.T) {
 		{
 			name: "valid update",
 			word: &models.Word{
-				ID:       1,
-				Hindi:    "नमस्ते",
-				English:  "Hello",
-				Hinglish: "Namaste",
+				ID:        1,
+				Target:    "नमस्ते",
+				Native:    "Hello",
+				Romanized: "Namaste",
 			},
 			wantErr: false,
 		},
@@ -205,20 +613,20 @@ func TestWordService_UpdateWord_Validation(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "empty hindi",
+			name: "empty target",
 			word: &models.Word{
-				ID:       1,
-				Hinglish: "Alvida",
-				English:  "Goodbye",
+				ID:        1,
+				Romanized: "Alvida",
+				Native:    "Goodbye",
 			},
 			wantErr: true,
 		},
 		{
-			name: "empty english",
+			name: "empty native",
 			word: &models.Word{
-				ID:       1,
-				Hindi:    "अलविदा",
-				Hinglish: "Alvida",
+				ID:        1,
+				Target:    "अलविदा",
+				Romanized: "Alvida",
 			},
 			wantErr: true,
 		},
@@ -235,7 +643,7 @@ func TestWordService_UpdateWord_Validation(t *testing.T) {
 				mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Word")).Return(nil)
 			}
 
-			err := services.NewWordService(mockRepo).UpdateWord(ctx, tt.word)
+			err := services.NewWordService(mockRepo, testLanguages, nil, nil).UpdateWord(ctx, tt.word)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -247,7 +655,7 @@ func TestWordService_UpdateWord_Validation(t *testing.T) {
 
 func TestWordService_DeleteWord(t *testing.T) {
 	mockRepo := new(MockWordRepository)
-	service := services.NewWordService(mockRepo)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
 
 	ctx := context.Background()
 	word := createTestWord()
@@ -266,16 +674,16 @@ func TestWordService_DeleteWord(t *testing.T) {
 
 func TestWordService_ListWords(t *testing.T) {
 	mockRepo := new(MockWordRepository)
-	service := services.NewWordService(mockRepo)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
 
 	ctx := context.Background()
 	words := []models.Word{
 		*createTestWord(),
 		{
 			ID:        2,
-			Hindi:     "अलविदा",
-			Hinglish:  "Alvida",
-			English:   "Goodbye",
+			Target:    "अलविदा",
+			Romanized: "Alvida",
+			Native:    "Goodbye",
 			CreatedAt: time.Now(),
 		},
 	}
@@ -300,7 +708,7 @@ func TestWordService_ListWords(t *testing.T) {
 
 func TestWordService_SearchWords(t *testing.T) {
 	mockRepo := new(MockWordRepository)
-	service := services.NewWordService(mockRepo)
+	service := services.NewWordService(mockRepo, testLanguages, nil, nil)
 
 	ctx := context.Background()
 	words := []models.Word{
@@ -310,13 +718,13 @@ func TestWordService_SearchWords(t *testing.T) {
 	// Set up expectations
 	mockRepo.On("List", ctx, repository.ListWordsParams{
 		Search:   "नमस्ते",
-		Language: "hindi",
+		Field:    "target",
 		Page:     1,
 		PageSize: 50,
 	}).Return(words, len(words), nil)
 
 	// Call the method
-	listedWords, total, err := service.SearchWords(ctx, "नमस्ते", "hindi")
+	listedWords, total, err := service.SearchWords(ctx, "नमस्ते", "target")
 
 	// Assert
 	assert.NoError(t, err)
@@ -328,21 +736,21 @@ func TestWordService_SearchWords(t *testing.T) {
 func TestWordService_GetWordsByGroupID(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockWordRepository)
-	wordService := services.NewWordService(mockRepo)
+	wordService := services.NewWordService(mockRepo, testLanguages, nil, nil)
 
 	groupID := int64(1)
 	expectedWords := []models.Word{
 		{
-			ID:       1,
-			English:  "test1",
-			Hindi:    "परीक्षण1",
-			Hinglish: "test1",
+			ID:        1,
+			Native:    "test1",
+			Target:    "परीक्षण1",
+			Romanized: "test1",
 		},
 		{
-			ID:       2,
-			English:  "test2",
-			Hindi:    "परीक्षण2",
-			Hinglish: "test2",
+			ID:        2,
+			Native:    "test2",
+			Target:    "परीक्षण2",
+			Romanized: "test2",
 		},
 	}
 
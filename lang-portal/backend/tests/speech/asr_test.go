@@ -0,0 +1,91 @@
+package speech_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/speech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhisperASR_Transcribe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/audio/transcriptions", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "whisper-1", r.FormValue("model"))
+		assert.Equal(t, "hi", r.FormValue("language"))
+		assert.Equal(t, "json", r.FormValue("response_format"))
+
+		file, header, err := r.FormFile("file")
+		if assert.NoError(t, err) {
+			defer file.Close()
+			data, _ := io.ReadAll(file)
+			assert.Equal(t, "recording.webm", header.Filename)
+			assert.Equal(t, []byte("fake webm"), data)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"text": " नमस्ते। "}`))
+	}))
+	defer server.Close()
+
+	provider := speech.NewWhisperASR(speech.HTTPConfig{
+		URL:    server.URL + "/v1/",
+		Model:  "whisper-1",
+		APIKey: "secret",
+	})
+
+	transcript, err := provider.Transcribe(context.Background(), &speech.Audio{Data: []byte("fake webm"), ContentType: "audio/webm"}, "hi")
+	assert.NoError(t, err)
+	assert.Equal(t, "नमस्ते।", transcript)
+	assert.Equal(t, "whisper:whisper-1", provider.Model())
+}
+
+func TestWhisperASR_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "error status", status: http.StatusInternalServerError, body: `{"error":"model not loaded"}`},
+		{name: "malformed response", status: http.StatusOK, body: `not json`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			provider := speech.NewWhisperASR(speech.HTTPConfig{URL: server.URL, Model: "whisper-1"})
+			_, err := provider.Transcribe(context.Background(), &speech.Audio{Data: []byte("RIFF"), ContentType: "audio/wav"}, "hi")
+			assert.True(t, errors.Is(err, speech.ErrRecognition), err)
+		})
+	}
+}
+
+func TestFakeASR(t *testing.T) {
+	fake := speech.NewFakeASR("पानी")
+	fake.Respond("कमरा")
+	audio := &speech.Audio{Data: []byte("RIFF"), ContentType: "audio/wav"}
+
+	for _, want := range []string{"पानी", "कमरा"} {
+		transcript, err := fake.Transcribe(context.Background(), audio, "hi")
+		assert.NoError(t, err)
+		assert.Equal(t, want, transcript)
+	}
+	_, err := fake.Transcribe(context.Background(), audio, "hi")
+	assert.True(t, errors.Is(err, speech.ErrRecognition))
+
+	requests := fake.Requests()
+	if assert.Len(t, requests, 3) {
+		assert.Equal(t, "hi", requests[0].Language)
+		assert.Equal(t, audio, requests[0].Audio)
+	}
+}
@@ -0,0 +1,102 @@
+package speech_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/speech"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAITTS_Synthesize(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/audio/speech", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write([]byte("ID3 fake mp3"))
+	}))
+	defer server.Close()
+
+	provider := speech.NewOpenAITTS(speech.HTTPConfig{
+		URL:    server.URL + "/v1/",
+		Model:  "tts-1",
+		Voice:  "alloy",
+		APIKey: "secret",
+	})
+
+	audio, err := provider.Synthesize(context.Background(), "नमस्ते", "hi")
+	assert.NoError(t, err)
+	assert.Equal(t, "audio/mpeg", audio.ContentType)
+	assert.Equal(t, []byte("ID3 fake mp3"), audio.Data)
+	assert.Equal(t, map[string]string{
+		"model":           "tts-1",
+		"input":           "नमस्ते",
+		"voice":           "alloy",
+		"response_format": "mp3",
+	}, received)
+}
+
+func TestPiperTTS_Synthesize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		assert.Equal(t, "नमस्ते", req["text"])
+		assert.Equal(t, "hi_IN-pratham-medium", req["voice"])
+		// Piper does not always label its WAV output
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("RIFF fake wav"))
+	}))
+	defer server.Close()
+
+	provider := speech.NewPiperTTS(speech.HTTPConfig{URL: server.URL, Voice: "hi_IN-pratham-medium"})
+
+	audio, err := provider.Synthesize(context.Background(), "नमस्ते", "hi")
+	assert.NoError(t, err)
+	assert.Equal(t, "audio/wav", audio.ContentType)
+	assert.Equal(t, "piper:hi_IN-pratham-medium", provider.Voice())
+}
+
+func TestTTS_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+	}{
+		{name: "error status", status: http.StatusInternalServerError, contentType: "application/json", body: `{"error":"model not loaded"}`},
+		{name: "json instead of audio", status: http.StatusOK, contentType: "application/json", body: `{"error":"unknown voice"}`},
+		{name: "empty audio", status: http.StatusOK, contentType: "audio/mpeg", body: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			provider := speech.NewOpenAITTS(speech.HTTPConfig{URL: server.URL, Model: "tts-1", Voice: "alloy"})
+			_, err := provider.Synthesize(context.Background(), "नमस्ते", "hi")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	alloy := speech.NewOpenAITTS(speech.HTTPConfig{Model: "tts-1", Voice: "alloy"})
+	nova := speech.NewOpenAITTS(speech.HTTPConfig{Model: "tts-1", Voice: "nova"})
+
+	key := speech.CacheKey(alloy, "नमस्ते", "hi")
+	assert.Len(t, key, 64)
+	assert.Equal(t, key, speech.CacheKey(alloy, " नमस्ते ", "hi"))
+	assert.NotEqual(t, key, speech.CacheKey(nova, "नमस्ते", "hi"))
+	assert.NotEqual(t, key, speech.CacheKey(alloy, "नमस्ते", "mr"))
+	assert.NotEqual(t, key, speech.CacheKey(alloy, "धन्यवाद", "hi"))
+}
@@ -0,0 +1,48 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pavittarx/lang-portal/backend/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Put(ctx, "ab/abc.mp3", strings.NewReader("audio")))
+
+	file, err := store.Open(ctx, "ab/abc.mp3")
+	assert.NoError(t, err)
+	_, err = file.Seek(2, io.SeekStart)
+	assert.NoError(t, err)
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "dio", string(content))
+	assert.NoError(t, file.Close())
+
+	assert.NoError(t, store.Delete(ctx, "ab/abc.mp3"))
+	assert.NoError(t, store.Delete(ctx, "ab/abc.mp3"))
+
+	_, err = store.Open(ctx, "ab/abc.mp3")
+	assert.ErrorIs(t, err, storage.ErrNotExist)
+}
+
+func TestLocalStorage_InvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "../escape.mp3", "/etc/passwd", "ab/../../escape.mp3", `ab\abc.mp3`} {
+		t.Run(key, func(t *testing.T) {
+			assert.ErrorIs(t, store.Put(ctx, key, strings.NewReader("audio")), storage.ErrInvalidKey)
+			_, err := store.Open(ctx, key)
+			assert.ErrorIs(t, err, storage.ErrInvalidKey)
+		})
+	}
+}
@@ -5,17 +5,57 @@ import (
 	"os"
 	"path/filepath"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
 )
 
 const schema = `
+CREATE TABLE IF NOT EXISTS languages (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    script TEXT NOT NULL,
+    romanization TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT OR IGNORE INTO languages (code, name, script, romanization) VALUES
+    ('hi', 'Hindi', 'Deva', 'hinglish'),
+    ('mr', 'Marathi', 'Deva', 'iso15919'),
+    ('pa', 'Punjabi', 'Guru', 'iso15919'),
+    ('en', 'English', 'Latn', '');
+
+CREATE TABLE IF NOT EXISTS assets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK(kind IN ('audio', 'image')),
+    mime_type TEXT NOT NULL,
+    filename TEXT NOT NULL DEFAULT '',
+    size INTEGER NOT NULL,
+    duration_ms INTEGER,
+    checksum TEXT NOT NULL UNIQUE,
+    storage_key TEXT NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
 CREATE TABLE IF NOT EXISTS words (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
-    hindi TEXT NOT NULL,
+    language TEXT NOT NULL DEFAULT 'hi',
+    native_language TEXT NOT NULL DEFAULT 'en',
+    target TEXT NOT NULL,
     scrambled TEXT,
-    hinglish TEXT,
-    english TEXT NOT NULL,
-    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+    romanized TEXT,
+    native TEXT NOT NULL,
+    ipa TEXT NOT NULL DEFAULT '',
+    ipa_override BOOLEAN NOT NULL DEFAULT 0,
+    part_of_speech TEXT NOT NULL DEFAULT '' CHECK(part_of_speech IN ('', 'noun', 'pronoun', 'verb', 'adjective', 'adverb', 'postposition', 'conjunction', 'interjection', 'numeral', 'particle')),
+    gender TEXT NOT NULL DEFAULT '' CHECK(gender IN ('', 'masculine', 'feminine')),
+    transitivity TEXT NOT NULL DEFAULT '' CHECK(transitivity IN ('', 'intransitive', 'transitive', 'ditransitive')),
+    root TEXT NOT NULL DEFAULT '',
+    plural TEXT NOT NULL DEFAULT '',
+    oblique TEXT NOT NULL DEFAULT '',
+    oblique_plural TEXT NOT NULL DEFAULT '',
+    forms_override BOOLEAN NOT NULL DEFAULT 0,
+    difficulty TEXT CHECK(difficulty IN ('easy', 'medium', 'hard')),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL
 );
 
 CREATE TABLE IF NOT EXISTS groups (
@@ -24,6 +64,216 @@ CREATE TABLE IF NOT EXISTS groups (
     description TEXT DEFAULT '',
     created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 );
+
+CREATE TABLE IF NOT EXISTS word_groups (
+    word_id INTEGER,
+    group_id INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (word_id, group_id)
+);
+
+CREATE TABLE IF NOT EXISTS sentences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    language TEXT NOT NULL DEFAULT 'hi',
+    native_language TEXT NOT NULL DEFAULT 'en',
+    target TEXT NOT NULL,
+    romanized TEXT NOT NULL DEFAULT '',
+    native TEXT NOT NULL,
+    alternatives TEXT NOT NULL DEFAULT '[]',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS word_sentences (
+    word_id INTEGER NOT NULL,
+    sentence_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (word_id, sentence_id)
+);
+
+CREATE TABLE IF NOT EXISTS study_activities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT DEFAULT '',
+    image TEXT DEFAULT '',
+    score INTEGER DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    type TEXT NOT NULL DEFAULT 'unscramble',
+    config TEXT NOT NULL DEFAULT '{}',
+    enabled INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    password_hash TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    role TEXT NOT NULL DEFAULT 'learner'
+);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_used_at DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER,
+    activity_id INTEGER NOT NULL,
+    group_id INTEGER,
+    start_time DATETIME DEFAULT CURRENT_TIMESTAMP,
+    end_time DATETIME,
+    score INTEGER DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS session_activities (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    session_id INTEGER NOT NULL,
+    activity_id INTEGER NOT NULL,
+    challenge TEXT,
+    answer TEXT,
+    input TEXT,
+    result TEXT,
+    score INTEGER DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    subject_id INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'running', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    updated_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    UNIQUE (kind, subject_id)
+);
+
+CREATE TABLE IF NOT EXISTS speech_cache (
+    cache_key TEXT PRIMARY KEY,
+    asset_id INTEGER NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS drafts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL CHECK(kind IN ('word', 'sentence', 'hint')),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'approved', 'rejected')),
+    content TEXT NOT NULL,
+    model TEXT NOT NULL DEFAULT '',
+    group_id INTEGER REFERENCES groups(id) ON DELETE SET NULL,
+    word_id INTEGER REFERENCES words(id) ON DELETE CASCADE,
+    result_id INTEGER,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    reviewed_at DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS word_hints (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS tutor_conversations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    sentence_id INTEGER REFERENCES sentences(id) ON DELETE SET NULL,
+    language TEXT NOT NULL,
+    native_language TEXT NOT NULL,
+    prompt TEXT NOT NULL,
+    answer TEXT NOT NULL DEFAULT '',
+    vocabulary TEXT NOT NULL DEFAULT '[]',
+    structure TEXT NOT NULL DEFAULT '',
+    solved BOOLEAN NOT NULL DEFAULT 0,
+    model TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+    updated_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS tutor_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    conversation_id INTEGER NOT NULL REFERENCES tutor_conversations(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK(role IN ('learner', 'tutor')),
+    content TEXT NOT NULL,
+    clues TEXT NOT NULL DEFAULT '[]',
+    session_activity_id INTEGER REFERENCES session_activities(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS listening_exercises (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    language TEXT NOT NULL DEFAULT 'hi',
+    source TEXT UNIQUE,
+    introduction TEXT NOT NULL DEFAULT '',
+    transcript TEXT NOT NULL,
+    audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL,
+    audio_url TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS listening_segments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    start_ms INTEGER,
+    end_ms INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS listening_questions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    exercise_id INTEGER NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    question TEXT NOT NULL,
+    options TEXT NOT NULL DEFAULT '[]',
+    answer TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS recordings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    session_activity_id INTEGER NOT NULL UNIQUE REFERENCES session_activities(id) ON DELETE CASCADE,
+    word_id INTEGER REFERENCES words(id) ON DELETE SET NULL,
+    mime_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    duration_ms INTEGER,
+    storage_key TEXT NOT NULL UNIQUE,
+    transcript TEXT NOT NULL DEFAULT '',
+    model TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS word_merges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kept_word_id INTEGER NOT NULL,
+    merged_word_id INTEGER NOT NULL,
+    merged_word TEXT NOT NULL,
+    group_ids TEXT NOT NULL DEFAULT '[]',
+    merged_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
+
+CREATE TABLE IF NOT EXISTS issued_challenges (
+    id TEXT PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    prompt TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '',
+    audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL,
+    answered_at DATETIME,
+    created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+);
 `
 
 // CreateTestDB creates a temporary SQLite database for testing
@@ -35,7 +285,7 @@ func CreateTestDB() (*sql.DB, func(), error) {
 	}
 
 	dbPath := filepath.Join(tmpDir, "test.db")
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := sql.Open(repository.DriverName, dbPath)
 	if err != nil {
 		os.RemoveAll(tmpDir)
 		return nil, nil, err
@@ -8,7 +8,7 @@ import (
 	"path/filepath"
 	"sync"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/pavittarx/lang-portal/backend/pkg/repository"
 )
 
 var (
@@ -28,7 +28,7 @@ func InitTestDB() *sql.DB {
 
 		// Open the database
 		var err error
-		testDB, err = sql.Open("sqlite3", dbPath+"?_foreign_keys=on")
+		testDB, err = sql.Open(repository.DriverName, dbPath+"?_foreign_keys=on")
 		if err != nil {
 			log.Fatalf("Failed to open test database: %v", err)
 		}
@@ -39,15 +39,56 @@ func InitTestDB() *sql.DB {
 
 		// Define the schema with more explicit column definitions
 		schema := `
+		-- Languages Table
+		CREATE TABLE IF NOT EXISTS languages (
+			code TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			script TEXT NOT NULL CHECK(script IN ('Deva', 'Guru', 'Latn')),
+			romanization TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
+		INSERT OR IGNORE INTO languages (code, name, script, romanization) VALUES
+			('hi', 'Hindi', 'Deva', 'hinglish'),
+			('mr', 'Marathi', 'Deva', 'iso15919'),
+			('pa', 'Punjabi', 'Guru', 'iso15919'),
+			('en', 'English', 'Latn', '');
+
+		-- Assets Table
+		CREATE TABLE IF NOT EXISTS assets (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			kind TEXT NOT NULL CHECK(kind IN ('audio', 'image')),
+			mime_type TEXT NOT NULL,
+			filename TEXT NOT NULL DEFAULT '',
+			size INTEGER NOT NULL,
+			duration_ms INTEGER,
+			checksum TEXT NOT NULL UNIQUE,
+			storage_key TEXT NOT NULL,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
 		-- Words Table
 		CREATE TABLE IF NOT EXISTS words (
 			id INTEGER PRIMARY KEY,
-			hindi TEXT NOT NULL,
+			language TEXT NOT NULL DEFAULT 'hi',
+			native_language TEXT NOT NULL DEFAULT 'en',
+			target TEXT NOT NULL,
 			scrambled TEXT NOT NULL,
-			hinglish TEXT NOT NULL,
-			english TEXT NOT NULL,
+			romanized TEXT NOT NULL,
+			native TEXT NOT NULL,
+			ipa TEXT NOT NULL DEFAULT '',
+			ipa_override BOOLEAN NOT NULL DEFAULT 0,
+			part_of_speech TEXT NOT NULL DEFAULT '' CHECK(part_of_speech IN ('', 'noun', 'pronoun', 'verb', 'adjective', 'adverb', 'postposition', 'conjunction', 'interjection', 'numeral', 'particle')),
+			gender TEXT NOT NULL DEFAULT '' CHECK(gender IN ('', 'masculine', 'feminine')),
+			transitivity TEXT NOT NULL DEFAULT '' CHECK(transitivity IN ('', 'intransitive', 'transitive', 'ditransitive')),
+			root TEXT NOT NULL DEFAULT '',
+			plural TEXT NOT NULL DEFAULT '',
+			oblique TEXT NOT NULL DEFAULT '',
+			oblique_plural TEXT NOT NULL DEFAULT '',
+			forms_override BOOLEAN NOT NULL DEFAULT 0,
 			difficulty TEXT CHECK(difficulty IN ('easy', 'medium', 'hard')) DEFAULT 'medium',
-			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+			created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+			audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL
 		);
 
 		-- Groups Table
@@ -68,6 +109,28 @@ func InitTestDB() *sql.DB {
 			FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
 		);
 
+		-- Sentences Table
+		CREATE TABLE IF NOT EXISTS sentences (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			language TEXT NOT NULL DEFAULT 'hi',
+			native_language TEXT NOT NULL DEFAULT 'en',
+			target TEXT NOT NULL,
+			romanized TEXT NOT NULL DEFAULT '',
+			native TEXT NOT NULL,
+			alternatives TEXT NOT NULL DEFAULT '[]',
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
+		-- Word Sentences Table (Many-to-Many Relationship)
+		CREATE TABLE IF NOT EXISTS word_sentences (
+			word_id INTEGER NOT NULL,
+			sentence_id INTEGER NOT NULL,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+			PRIMARY KEY (word_id, sentence_id),
+			FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+			FOREIGN KEY (sentence_id) REFERENCES sentences(id) ON DELETE CASCADE
+		);
+
 		-- Study Activities Table
 		CREATE TABLE IF NOT EXISTS study_activities (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -75,12 +138,37 @@ func InitTestDB() *sql.DB {
 			description TEXT DEFAULT '',
 			image TEXT DEFAULT '',
 			score INTEGER DEFAULT 0,
-			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+			created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+			type TEXT NOT NULL DEFAULT 'unscramble',
+			config TEXT NOT NULL DEFAULT '{}',
+			enabled INTEGER NOT NULL DEFAULT 1
+		);
+
+		-- Users Table
+		CREATE TABLE IF NOT EXISTS users (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			username TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			password_hash TEXT NOT NULL,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+			role TEXT NOT NULL DEFAULT 'learner'
+		);
+
+		-- API Keys Table
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			prefix TEXT NOT NULL,
+			key_hash TEXT NOT NULL UNIQUE,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+			last_used_at DATETIME,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 		);
 
 		-- Sessions Table
 		CREATE TABLE IF NOT EXISTS sessions (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
 			activity_id INTEGER NOT NULL,
 			group_id INTEGER,
 			start_time DATETIME DEFAULT (datetime('now', 'localtime')),
@@ -104,6 +192,150 @@ func InitTestDB() *sql.DB {
 			FOREIGN KEY (activity_id) REFERENCES study_activities(id) ON DELETE CASCADE
 		);
 
+		-- Jobs Table
+		CREATE TABLE IF NOT EXISTS jobs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			kind TEXT NOT NULL,
+			subject_id INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'running', 'succeeded', 'failed')),
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+			updated_at DATETIME DEFAULT (datetime('now', 'localtime')),
+			UNIQUE (kind, subject_id)
+		);
+
+		-- Speech Cache Table
+		CREATE TABLE IF NOT EXISTS speech_cache (
+			cache_key TEXT PRIMARY KEY,
+			asset_id INTEGER NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
+		-- Drafts Table
+		CREATE TABLE IF NOT EXISTS drafts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			kind TEXT NOT NULL CHECK(kind IN ('word', 'sentence', 'hint')),
+			status TEXT NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'approved', 'rejected')),
+			content TEXT NOT NULL,
+			model TEXT NOT NULL DEFAULT '',
+			group_id INTEGER REFERENCES groups(id) ON DELETE SET NULL,
+			word_id INTEGER REFERENCES words(id) ON DELETE CASCADE,
+			result_id INTEGER,
+			created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+			reviewed_at DATETIME
+		);
+
+		-- Word Hints Table
+		CREATE TABLE IF NOT EXISTS word_hints (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			word_id INTEGER NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+			text TEXT NOT NULL,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
+		-- Tutor Conversations Table
+		CREATE TABLE IF NOT EXISTS tutor_conversations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+			sentence_id INTEGER REFERENCES sentences(id) ON DELETE SET NULL,
+			language TEXT NOT NULL,
+			native_language TEXT NOT NULL,
+			prompt TEXT NOT NULL,
+			answer TEXT NOT NULL DEFAULT '',
+			vocabulary TEXT NOT NULL DEFAULT '[]',
+			structure TEXT NOT NULL DEFAULT '',
+			solved BOOLEAN NOT NULL DEFAULT 0,
+			model TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT (datetime('now', 'localtime')),
+			updated_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
+		-- Tutor Messages Table
+		CREATE TABLE IF NOT EXISTS tutor_messages (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			conversation_id INTEGER NOT NULL REFERENCES tutor_conversations(id) ON DELETE CASCADE,
+			role TEXT NOT NULL CHECK(role IN ('learner', 'tutor')),
+			content TEXT NOT NULL,
+			clues TEXT NOT NULL DEFAULT '[]',
+			session_activity_id INTEGER REFERENCES session_activities(id) ON DELETE SET NULL,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
+		-- Listening Exercises Table
+		CREATE TABLE IF NOT EXISTS listening_exercises (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			language TEXT NOT NULL DEFAULT 'hi',
+			source TEXT UNIQUE,
+			introduction TEXT NOT NULL DEFAULT '',
+			transcript TEXT NOT NULL,
+			audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL,
+			audio_url TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
+		-- Listening Segments Table
+		CREATE TABLE IF NOT EXISTS listening_segments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			exercise_id INTEGER NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			text TEXT NOT NULL,
+			start_ms INTEGER,
+			end_ms INTEGER
+		);
+
+		-- Listening Questions Table
+		CREATE TABLE IF NOT EXISTS listening_questions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			exercise_id INTEGER NOT NULL REFERENCES listening_exercises(id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			question TEXT NOT NULL,
+			options TEXT NOT NULL DEFAULT '[]',
+			answer TEXT NOT NULL
+		);
+
+		-- Recordings Table
+		CREATE TABLE IF NOT EXISTS recordings (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+			session_activity_id INTEGER NOT NULL UNIQUE REFERENCES session_activities(id) ON DELETE CASCADE,
+			word_id INTEGER REFERENCES words(id) ON DELETE SET NULL,
+			mime_type TEXT NOT NULL,
+			size INTEGER NOT NULL,
+			duration_ms INTEGER,
+			storage_key TEXT NOT NULL UNIQUE,
+			transcript TEXT NOT NULL DEFAULT '',
+			model TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
+		-- Word Merges Table
+		CREATE TABLE IF NOT EXISTS word_merges (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			kept_word_id INTEGER NOT NULL,
+			merged_word_id INTEGER NOT NULL,
+			merged_word TEXT NOT NULL,
+			group_ids TEXT NOT NULL DEFAULT '[]',
+			merged_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
+		-- Issued Challenges Table
+		CREATE TABLE IF NOT EXISTS issued_challenges (
+			id TEXT PRIMARY KEY,
+			session_id INTEGER NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+			type TEXT NOT NULL,
+			prompt TEXT NOT NULL,
+			payload TEXT NOT NULL DEFAULT '',
+			audio_asset_id INTEGER REFERENCES assets(id) ON DELETE SET NULL,
+			answered_at DATETIME,
+			created_at DATETIME DEFAULT (datetime('now', 'localtime'))
+		);
+
 		-- Indexes for performance
 		CREATE INDEX IF NOT EXISTS idx_groups_name ON groups(name);
 		CREATE INDEX IF NOT EXISTS idx_sessions_activity ON sessions(activity_id);
@@ -131,12 +363,27 @@ func CloseTestDB(db *sql.DB) {
 // ResetTestDB resets the test database by dropping and recreating all tables
 func ResetTestDB(db *sql.DB) error {
 	tables := []string{
+		"recordings",
+		"listening_questions",
+		"listening_segments",
+		"listening_exercises",
+		"tutor_messages",
+		"tutor_conversations",
 		"session_activities", 
 		"sessions", 
+		"api_keys",
+		"users",
 		"study_activities", 
+		"drafts",
+		"word_hints",
+		"word_sentences",
+		"sentences",
 		"word_groups", 
 		"groups", 
 		"words",
+		"speech_cache",
+		"jobs",
+		"assets",
 	}
 
 	for _, table := range tables {